@@ -8,6 +8,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
@@ -27,9 +28,107 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ListAccountsRequest_SortBy int32
+
+const (
+	ListAccountsRequest_ID    ListAccountsRequest_SortBy = 0
+	ListAccountsRequest_NAME  ListAccountsRequest_SortBy = 1
+	ListAccountsRequest_SALDO ListAccountsRequest_SortBy = 2
+)
+
+var ListAccountsRequest_SortBy_name = map[int32]string{
+	0: "ID",
+	1: "NAME",
+	2: "SALDO",
+}
+
+var ListAccountsRequest_SortBy_value = map[string]int32{
+	"ID":    0,
+	"NAME":  1,
+	"SALDO": 2,
+}
+
+func (x ListAccountsRequest_SortBy) String() string {
+	return proto.EnumName(ListAccountsRequest_SortBy_name, int32(x))
+}
+
+func (ListAccountsRequest_SortBy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e1e7723af4c007b7, []int{0, 0}
+}
+
+type Account_Status int32
+
+const (
+	Account_ACTIVE Account_Status = 0
+	// BLOCKED accounts can not pay, online or offline
+	Account_BLOCKED Account_Status = 1
+)
+
+var Account_Status_name = map[int32]string{
+	0: "ACTIVE",
+	1: "BLOCKED",
+}
+
+var Account_Status_value = map[string]int32{
+	"ACTIVE":  0,
+	"BLOCKED": 1,
+}
+
+func (x Account_Status) String() string {
+	return proto.EnumName(Account_Status_name, int32(x))
+}
+
+func (Account_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e1e7723af4c007b7, []int{2, 0}
+}
+
+type ImportAccountError_Reason int32
+
+const (
+	ImportAccountError_UNKNOWN               ImportAccountError_Reason = 0
+	ImportAccountError_INVALID_ROW           ImportAccountError_Reason = 1
+	ImportAccountError_DUPLICATE_NFC_CHIP_ID ImportAccountError_Reason = 2
+	ImportAccountError_GROUP_NOT_FOUND       ImportAccountError_Reason = 3
+)
+
+var ImportAccountError_Reason_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "INVALID_ROW",
+	2: "DUPLICATE_NFC_CHIP_ID",
+	3: "GROUP_NOT_FOUND",
+}
+
+var ImportAccountError_Reason_value = map[string]int32{
+	"UNKNOWN":               0,
+	"INVALID_ROW":           1,
+	"DUPLICATE_NFC_CHIP_ID": 2,
+	"GROUP_NOT_FOUND":       3,
+}
+
+func (x ImportAccountError_Reason) String() string {
+	return proto.EnumName(ImportAccountError_Reason_name, int32(x))
+}
+
+func (ImportAccountError_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e1e7723af4c007b7, []int{8, 0}
+}
+
 type ListAccountsRequest struct {
-	GroupId              int32    `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
-	Paging               *Paging  `protobuf:"bytes,2,opt,name=paging,proto3" json:"paging,omitempty"`
+	GroupId int32   `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Paging  *Paging `protobuf:"bytes,2,opt,name=paging,proto3" json:"paging,omitempty"`
+	// part of the name or description, case is ignored
+	Search string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	// start of the nfc chip id, case is ignored
+	NfcChipIdPrefix string `protobuf:"bytes,4,opt,name=nfc_chip_id_prefix,json=nfcChipIdPrefix,proto3" json:"nfc_chip_id_prefix,omitempty"`
+	// inclusive lower bound of the saldo
+	MinSaldo *wrappers.DoubleValue `protobuf:"bytes,5,opt,name=min_saldo,json=minSaldo,proto3" json:"min_saldo,omitempty"`
+	// inclusive upper bound of the saldo
+	MaxSaldo *wrappers.DoubleValue `protobuf:"bytes,6,opt,name=max_saldo,json=maxSaldo,proto3" json:"max_saldo,omitempty"`
+	// accounts with one of the statuses, empty matches all
+	Status []Account_Status           `protobuf:"varint,7,rep,packed,name=status,proto3,enum=api.Account_Status" json:"status,omitempty"`
+	SortBy ListAccountsRequest_SortBy `protobuf:"varint,8,opt,name=sort_by,json=sortBy,proto3,enum=api.ListAccountsRequest_SortBy" json:"sort_by,omitempty"`
+	// desc or DESC sorts descending, all other values ascending
+	Order                string   `protobuf:"bytes,9,opt,name=order,proto3" json:"order,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -74,12 +173,63 @@ func (m *ListAccountsRequest) GetPaging() *Paging {
 	return nil
 }
 
+func (m *ListAccountsRequest) GetSearch() string {
+	if m != nil {
+		return m.Search
+	}
+	return ""
+}
+
+func (m *ListAccountsRequest) GetNfcChipIdPrefix() string {
+	if m != nil {
+		return m.NfcChipIdPrefix
+	}
+	return ""
+}
+
+func (m *ListAccountsRequest) GetMinSaldo() *wrappers.DoubleValue {
+	if m != nil {
+		return m.MinSaldo
+	}
+	return nil
+}
+
+func (m *ListAccountsRequest) GetMaxSaldo() *wrappers.DoubleValue {
+	if m != nil {
+		return m.MaxSaldo
+	}
+	return nil
+}
+
+func (m *ListAccountsRequest) GetStatus() []Account_Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *ListAccountsRequest) GetSortBy() ListAccountsRequest_SortBy {
+	if m != nil {
+		return m.SortBy
+	}
+	return ListAccountsRequest_ID
+}
+
+func (m *ListAccountsRequest) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
 type ListAccountsResponse struct {
-	Accounts             []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
-	TotalCount           int32      `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Accounts   []*Account `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	TotalCount int32      `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// empty on the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListAccountsResponse) Reset()         { *m = ListAccountsResponse{} }
@@ -121,16 +271,24 @@ func (m *ListAccountsResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (m *ListAccountsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type Account struct {
-	Id                   int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Saldo                float64  `protobuf:"fixed64,4,opt,name=saldo,proto3" json:"saldo,omitempty"`
-	NfcChipId            string   `protobuf:"bytes,5,opt,name=nfc_chip_id,json=nfcChipId,proto3" json:"nfc_chip_id,omitempty"`
-	Group                *Group   `protobuf:"bytes,6,opt,name=group,proto3" json:"group,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   int32          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string         `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string         `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Saldo                float64        `protobuf:"fixed64,4,opt,name=saldo,proto3" json:"saldo,omitempty"`
+	NfcChipId            string         `protobuf:"bytes,5,opt,name=nfc_chip_id,json=nfcChipId,proto3" json:"nfc_chip_id,omitempty"`
+	Group                *Group         `protobuf:"bytes,6,opt,name=group,proto3" json:"group,omitempty"`
+	Status               Account_Status `protobuf:"varint,7,opt,name=status,proto3,enum=api.Account_Status" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *Account) Reset()         { *m = Account{} }
@@ -200,6 +358,13 @@ func (m *Account) GetGroup() *Group {
 	return nil
 }
 
+func (m *Account) GetStatus() Account_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Account_ACTIVE
+}
+
 type CreateAccountRequest struct {
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
@@ -349,72 +514,286 @@ func (m *DeleteAccountRequest) GetId() int32 {
 	return 0
 }
 
+type ImportAccountsRequest struct {
+	Accounts             []*CreateAccountRequest `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	DryRun               bool                    `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ImportAccountsRequest) Reset()         { *m = ImportAccountsRequest{} }
+func (m *ImportAccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountsRequest) ProtoMessage()    {}
+func (*ImportAccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e1e7723af4c007b7, []int{6}
+}
+
+func (m *ImportAccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountsRequest.Unmarshal(m, b)
+}
+func (m *ImportAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountsRequest.Merge(m, src)
+}
+func (m *ImportAccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountsRequest.Size(m)
+}
+func (m *ImportAccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountsRequest proto.InternalMessageInfo
+
+func (m *ImportAccountsRequest) GetAccounts() []*CreateAccountRequest {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+func (m *ImportAccountsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ImportAccountsResponse struct {
+	Accounts             []*Account            `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Errors               []*ImportAccountError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	DryRun               bool                  `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ImportAccountsResponse) Reset()         { *m = ImportAccountsResponse{} }
+func (m *ImportAccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountsResponse) ProtoMessage()    {}
+func (*ImportAccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e1e7723af4c007b7, []int{7}
+}
+
+func (m *ImportAccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountsResponse.Unmarshal(m, b)
+}
+func (m *ImportAccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountsResponse.Merge(m, src)
+}
+func (m *ImportAccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountsResponse.Size(m)
+}
+func (m *ImportAccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountsResponse proto.InternalMessageInfo
+
+func (m *ImportAccountsResponse) GetAccounts() []*Account {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+func (m *ImportAccountsResponse) GetErrors() []*ImportAccountError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func (m *ImportAccountsResponse) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type ImportAccountError struct {
+	Row                  int32                     `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	NfcChipId            string                    `protobuf:"bytes,2,opt,name=nfc_chip_id,json=nfcChipId,proto3" json:"nfc_chip_id,omitempty"`
+	Reason               ImportAccountError_Reason `protobuf:"varint,3,opt,name=reason,proto3,enum=api.ImportAccountError_Reason" json:"reason,omitempty"`
+	Message              string                    `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ImportAccountError) Reset()         { *m = ImportAccountError{} }
+func (m *ImportAccountError) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountError) ProtoMessage()    {}
+func (*ImportAccountError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e1e7723af4c007b7, []int{8}
+}
+
+func (m *ImportAccountError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountError.Unmarshal(m, b)
+}
+func (m *ImportAccountError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountError.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountError.Merge(m, src)
+}
+func (m *ImportAccountError) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountError.Size(m)
+}
+func (m *ImportAccountError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountError proto.InternalMessageInfo
+
+func (m *ImportAccountError) GetRow() int32 {
+	if m != nil {
+		return m.Row
+	}
+	return 0
+}
+
+func (m *ImportAccountError) GetNfcChipId() string {
+	if m != nil {
+		return m.NfcChipId
+	}
+	return ""
+}
+
+func (m *ImportAccountError) GetReason() ImportAccountError_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return ImportAccountError_UNKNOWN
+}
+
+func (m *ImportAccountError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("api.ListAccountsRequest_SortBy", ListAccountsRequest_SortBy_name, ListAccountsRequest_SortBy_value)
+	proto.RegisterEnum("api.Account_Status", Account_Status_name, Account_Status_value)
+	proto.RegisterEnum("api.ImportAccountError_Reason", ImportAccountError_Reason_name, ImportAccountError_Reason_value)
 	proto.RegisterType((*ListAccountsRequest)(nil), "api.ListAccountsRequest")
 	proto.RegisterType((*ListAccountsResponse)(nil), "api.ListAccountsResponse")
 	proto.RegisterType((*Account)(nil), "api.Account")
 	proto.RegisterType((*CreateAccountRequest)(nil), "api.CreateAccountRequest")
 	proto.RegisterType((*GetAccountRequest)(nil), "api.GetAccountRequest")
 	proto.RegisterType((*DeleteAccountRequest)(nil), "api.DeleteAccountRequest")
+	proto.RegisterType((*ImportAccountsRequest)(nil), "api.ImportAccountsRequest")
+	proto.RegisterType((*ImportAccountsResponse)(nil), "api.ImportAccountsResponse")
+	proto.RegisterType((*ImportAccountError)(nil), "api.ImportAccountError")
 }
 
 func init() { proto.RegisterFile("accounts.proto", fileDescriptor_e1e7723af4c007b7) }
 
 var fileDescriptor_e1e7723af4c007b7 = []byte{
-	// 845 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xcd, 0x6e, 0xe4, 0x44,
-	0x10, 0xc6, 0x93, 0x9d, 0xd9, 0xa4, 0x66, 0xc6, 0xd9, 0xe9, 0xfc, 0xec, 0xac, 0x01, 0x6d, 0xcb,
-	0x0b, 0x68, 0xd4, 0x4a, 0x66, 0x20, 0xa0, 0x3d, 0xcc, 0x09, 0x27, 0x41, 0x51, 0xa4, 0x15, 0x5a,
-	0x79, 0xc9, 0x01, 0x71, 0x88, 0x7a, 0xdc, 0x3d, 0x4e, 0x0b, 0xa7, 0x6d, 0xdc, 0x3d, 0x89, 0x56,
-	0x88, 0x0b, 0x37, 0x38, 0x7a, 0x1f, 0x80, 0x0b, 0x67, 0xee, 0xbc, 0x04, 0x1c, 0x78, 0x05, 0x1e,
-	0x04, 0xb9, 0xed, 0xce, 0xce, 0x1f, 0xcb, 0x05, 0xed, 0xc9, 0xaa, 0xaa, 0xaf, 0xbb, 0x3e, 0x7d,
-	0xf5, 0x95, 0x1b, 0x5c, 0x1a, 0x45, 0xe9, 0x4c, 0x6a, 0x35, 0xcc, 0xf2, 0x54, 0xa7, 0x68, 0x83,
-	0x66, 0xc2, 0xeb, 0xc6, 0x49, 0x3a, 0xa1, 0x49, 0x9d, 0xf3, 0x3a, 0x71, 0x9e, 0xce, 0x32, 0x1b,
-	0xbd, 0x1b, 0xa7, 0x69, 0x9c, 0xf0, 0x91, 0x89, 0x26, 0xb3, 0xe9, 0x88, 0x5f, 0x67, 0xfa, 0x65,
-	0x5d, 0x7c, 0xaf, 0x2e, 0xd2, 0x4c, 0x8c, 0xa8, 0x94, 0xa9, 0xa6, 0x5a, 0xa4, 0xd2, 0x1e, 0x3d,
-	0x30, 0x9f, 0xe8, 0x30, 0xe6, 0xf2, 0x50, 0xdd, 0xd2, 0x38, 0xe6, 0xf9, 0x28, 0xcd, 0x0c, 0x62,
-	0x15, 0xed, 0x5f, 0xc0, 0xce, 0x33, 0xa1, 0x74, 0x50, 0x13, 0x0c, 0xf9, 0x77, 0x33, 0xae, 0x34,
-	0x7a, 0x04, 0x9b, 0x86, 0xcf, 0xa5, 0x60, 0x7d, 0x07, 0x3b, 0x83, 0x66, 0x78, 0xdf, 0xc4, 0xe7,
-	0x0c, 0x3d, 0x81, 0x56, 0x46, 0x63, 0x21, 0xe3, 0x7e, 0x03, 0x3b, 0x83, 0xf6, 0x51, 0x7b, 0x48,
-	0x33, 0x31, 0x7c, 0x6e, 0x52, 0x61, 0x5d, 0xf2, 0x73, 0xd8, 0x5d, 0xbc, 0x56, 0x65, 0xa9, 0x54,
-	0x1c, 0x0d, 0x60, 0xd3, 0x6a, 0xd1, 0x77, 0xf0, 0xc6, 0xa0, 0x7d, 0xd4, 0x31, 0xc7, 0x6b, 0x60,
-	0x78, 0x57, 0x45, 0x8f, 0xa1, 0xad, 0x53, 0x4d, 0x93, 0x4b, 0x13, 0x9b, 0x5e, 0xcd, 0x10, 0x4c,
-	0xea, 0xa4, 0xcc, 0x8c, 0xb7, 0x8b, 0xa0, 0x03, 0x40, 0x36, 0x6d, 0x0f, 0xff, 0xf7, 0x06, 0xdc,
-	0xaf, 0x03, 0xf4, 0x18, 0x1a, 0x96, 0xf9, 0x71, 0x09, 0x24, 0x50, 0x57, 0xf0, 0xf9, 0x69, 0xd8,
-	0x10, 0x0c, 0x7d, 0x08, 0xf7, 0x24, 0xbd, 0xe6, 0xe6, 0xde, 0xad, 0xe3, 0x5e, 0x11, 0xb8, 0xa4,
-	0x63, 0x21, 0x65, 0x21, 0x34, 0x65, 0x34, 0x86, 0x36, 0xe3, 0x2a, 0xca, 0x85, 0x11, 0xb0, 0xbf,
-	0x61, 0xd0, 0xfd, 0x22, 0xd8, 0x23, 0x3b, 0x16, 0x3d, 0x57, 0x0f, 0xe7, 0xc1, 0x68, 0x00, 0x4d,
-	0x45, 0x13, 0x96, 0xf6, 0xef, 0x61, 0x67, 0xe0, 0x1c, 0xa3, 0x22, 0xd8, 0x26, 0x5d, 0x7b, 0xea,
-	0x45, 0x59, 0x09, 0x2b, 0x40, 0xd9, 0x45, 0x4e, 0xa3, 0xcb, 0xe8, 0x4a, 0x18, 0xc1, 0x9b, 0xa6,
-	0x8b, 0x57, 0x04, 0x0f, 0xc9, 0x9e, 0xc5, 0x7f, 0x39, 0x8d, 0xf0, 0xc9, 0x95, 0xc8, 0xf0, 0xc5,
-	0x4c, 0xb0, 0x70, 0x4b, 0x4e, 0xa3, 0x32, 0x3a, 0x67, 0xe8, 0x33, 0x68, 0x9a, 0xc9, 0xf4, 0x5b,
-	0x66, 0x1a, 0x60, 0xe4, 0x3c, 0x2b, 0x33, 0x4b, 0x1d, 0x4d, 0x2e, 0xac, 0xc0, 0x63, 0xb7, 0x08,
-	0xda, 0xb0, 0x45, 0xac, 0x5e, 0xfe, 0x6f, 0x0d, 0xd8, 0x3d, 0xc9, 0x39, 0xd5, 0xdc, 0x4e, 0xa2,
-	0x36, 0xc2, 0x5b, 0xd0, 0xe9, 0x70, 0x51, 0xa7, 0x87, 0x45, 0xb0, 0x4b, 0xd0, 0x9d, 0x4e, 0x9a,
-	0xe6, 0x5a, 0xfd, 0x5f, 0x62, 0x7d, 0x3c, 0x67, 0xeb, 0x96, 0x31, 0xc7, 0x5e, 0x11, 0x20, 0xf2,
-	0x60, 0x41, 0xa3, 0xd2, 0x22, 0xd6, 0xed, 0xe3, 0xfd, 0x22, 0xd8, 0x81, 0x1e, 0xd9, 0xae, 0x11,
-	0x46, 0x23, 0x91, 0x4a, 0xff, 0x09, 0xf4, 0xce, 0xb8, 0x5e, 0x12, 0xcb, 0x7d, 0xed, 0xba, 0xd2,
-	0x64, 0xfe, 0x47, 0xb0, 0x7b, 0xca, 0x13, 0xbe, 0x22, 0xea, 0x12, 0xee, 0xe8, 0x8f, 0x16, 0xb8,
-	0x35, 0xe4, 0x05, 0xcf, 0x6f, 0x44, 0xc4, 0xd1, 0x9f, 0x0e, 0x74, 0xe6, 0x37, 0x08, 0xf5, 0xcd,
-	0x60, 0xd7, 0xec, 0xaa, 0xf7, 0x68, 0x4d, 0xa5, 0x5a, 0x37, 0xff, 0x27, 0xa7, 0x08, 0x72, 0xef,
-	0x59, 0xc8, 0xf5, 0x2c, 0x97, 0x0a, 0xd3, 0x24, 0xc1, 0xf5, 0x82, 0x1d, 0xe0, 0x88, 0x4a, 0x3c,
-	0xe1, 0x38, 0x11, 0xd7, 0x42, 0x73, 0x86, 0x6f, 0x85, 0xbe, 0xc2, 0x19, 0x8d, 0x39, 0xc3, 0xf5,
-	0xdf, 0x02, 0x53, 0xc9, 0xf0, 0x54, 0x24, 0x9a, 0xe7, 0x9c, 0xe1, 0xc9, 0x4b, 0x6c, 0x34, 0x21,
-	0xbd, 0xb2, 0xd3, 0xfc, 0x55, 0x6a, 0xb2, 0x0d, 0x5d, 0xd8, 0xfa, 0x2a, 0xfd, 0x96, 0xcb, 0x60,
-	0xa6, 0xaf, 0xd0, 0x3b, 0x3f, 0xfe, 0xf5, 0xf7, 0xab, 0x86, 0x8b, 0x3a, 0xa3, 0x9b, 0x4f, 0x46,
-	0x77, 0x0b, 0xfd, 0xb3, 0x03, 0xdd, 0x05, 0x8b, 0xa1, 0x8a, 0xf8, 0x3a, 0xdb, 0x79, 0x0b, 0x7f,
-	0x05, 0xff, 0x79, 0x11, 0x3c, 0xf5, 0x76, 0x2a, 0xa0, 0xc2, 0x92, 0xdf, 0xda, 0xd6, 0xc4, 0xad,
-	0x92, 0x36, 0x5e, 0xcf, 0xa4, 0xe7, 0x2f, 0x30, 0x19, 0x3b, 0x04, 0xbd, 0x72, 0x00, 0x5e, 0xcf,
-	0x0f, 0xed, 0x57, 0x5b, 0xb3, 0x3c, 0xd0, 0x25, 0x1a, 0x97, 0x45, 0x70, 0xea, 0x7d, 0x60, 0xc5,
-	0x54, 0x42, 0xc6, 0xc9, 0x5d, 0xe7, 0x4a, 0xbf, 0x58, 0xdc, 0x70, 0x89, 0x05, 0x23, 0xed, 0x33,
-	0xae, 0xdf, 0x4c, 0x0a, 0xa1, 0x07, 0x73, 0xa4, 0x46, 0xdf, 0x0b, 0xf6, 0x03, 0xfa, 0xd5, 0x81,
-	0xee, 0x45, 0xc6, 0xe6, 0x24, 0x5a, 0x20, 0xb0, 0x44, 0xe7, 0xb6, 0x08, 0xbe, 0xf6, 0x9e, 0x56,
-	0x78, 0xb5, 0x9e, 0xc7, 0x81, 0x99, 0xd3, 0x54, 0xf0, 0x84, 0x29, 0x7c, 0x3d, 0x53, 0xba, 0x9c,
-	0xb8, 0xe2, 0x92, 0x11, 0xb7, 0x3a, 0xf7, 0x66, 0x8e, 0x7b, 0xde, 0x0a, 0xc7, 0x52, 0xbc, 0x5f,
-	0x1c, 0xe8, 0x2e, 0xf8, 0xba, 0x9e, 0xe4, 0x3a, 0xaf, 0x7b, 0xfb, 0xc3, 0xea, 0xb5, 0x1a, 0xda,
-	0xa7, 0x6c, 0xf8, 0x45, 0xf9, 0x94, 0xf9, 0xdf, 0x14, 0xc1, 0xe7, 0xde, 0xfb, 0xd5, 0x91, 0x7f,
-	0x61, 0x4f, 0xdc, 0xaa, 0xfc, 0x1f, 0x42, 0x92, 0x15, 0x92, 0x93, 0x96, 0x69, 0xf6, 0xe9, 0x3f,
-	0x01, 0x00, 0x00, 0xff, 0xff, 0x12, 0x78, 0x0e, 0x30, 0x79, 0x07, 0x00, 0x00,
+	// 1525 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4b, 0x6f, 0xe3, 0xc8,
+	0x11, 0x36, 0xa5, 0xb1, 0x2c, 0x95, 0x6c, 0x49, 0x6e, 0xf9, 0x21, 0x6b, 0x5e, 0x1d, 0x4e, 0x66,
+	0x20, 0x70, 0x64, 0x29, 0xe3, 0x04, 0x83, 0xc4, 0xa7, 0xa1, 0x24, 0x8f, 0x23, 0x8c, 0x23, 0x19,
+	0xf4, 0x63, 0x10, 0xe4, 0x40, 0x50, 0x62, 0x4b, 0x6a, 0x84, 0x6a, 0x32, 0x64, 0xcb, 0x0f, 0x04,
+	0xc9, 0x21, 0xc7, 0xe4, 0x32, 0xe0, 0xdc, 0x72, 0x48, 0x80, 0xc1, 0x1e, 0xf6, 0xb4, 0x87, 0xc5,
+	0xfe, 0x84, 0xdd, 0xcb, 0x5e, 0xf7, 0x2f, 0xec, 0x0f, 0x59, 0xb0, 0x49, 0xda, 0x92, 0x65, 0xcf,
+	0xec, 0x02, 0x8b, 0x3d, 0xc9, 0x5d, 0xf5, 0x55, 0xf7, 0xd7, 0x55, 0x5f, 0x55, 0xd3, 0x90, 0x33,
+	0xfa, 0x7d, 0x7b, 0xc2, 0xb8, 0x57, 0x73, 0x5c, 0x9b, 0xdb, 0x28, 0x69, 0x38, 0xb4, 0xbc, 0x32,
+	0xb4, 0xec, 0x9e, 0x61, 0x45, 0xb6, 0xf2, 0xf2, 0xd0, 0xb5, 0x27, 0x4e, 0xbc, 0xba, 0x3f, 0xb4,
+	0xed, 0xa1, 0x45, 0xea, 0x62, 0xd5, 0x9b, 0x0c, 0xea, 0x64, 0xec, 0xf0, 0xcb, 0xc8, 0xf9, 0xe8,
+	0xa6, 0xf3, 0xdc, 0x35, 0x1c, 0x87, 0xb8, 0x71, 0xf0, 0x83, 0xc8, 0x6f, 0x38, 0xb4, 0x6e, 0x30,
+	0x66, 0x73, 0x83, 0x53, 0x9b, 0xc5, 0xde, 0xaa, 0xf8, 0xe9, 0x6f, 0x0f, 0x09, 0xdb, 0xf6, 0xce,
+	0x8d, 0xe1, 0x90, 0xb8, 0x75, 0xdb, 0x11, 0x88, 0x79, 0xb4, 0xfc, 0x55, 0x12, 0x8a, 0x07, 0xd4,
+	0xe3, 0x6a, 0x74, 0x03, 0x8d, 0xfc, 0x6d, 0x42, 0x3c, 0x8e, 0xb6, 0x20, 0x2d, 0x08, 0xeb, 0xd4,
+	0x2c, 0x49, 0x58, 0xaa, 0x2c, 0x6a, 0x4b, 0x62, 0xdd, 0x36, 0xd1, 0x13, 0x48, 0x39, 0xc6, 0x90,
+	0xb2, 0x61, 0x29, 0x81, 0xa5, 0x4a, 0x76, 0x27, 0x5b, 0x33, 0x1c, 0x5a, 0x3b, 0x14, 0x26, 0x2d,
+	0x72, 0xa1, 0x0d, 0x48, 0x79, 0xc4, 0x70, 0xfb, 0xa3, 0x52, 0x12, 0x4b, 0x95, 0x8c, 0x16, 0xad,
+	0xd0, 0x73, 0x40, 0x6c, 0xd0, 0xd7, 0xfb, 0x23, 0x1a, 0x6c, 0xad, 0x3b, 0x2e, 0x19, 0xd0, 0x8b,
+	0xd2, 0x3d, 0x81, 0xc9, 0xb3, 0x41, 0xbf, 0x39, 0xa2, 0x4e, 0xdb, 0x3c, 0x14, 0x66, 0xf4, 0x07,
+	0xc8, 0x8c, 0x29, 0xd3, 0x3d, 0xc3, 0x32, 0xed, 0xd2, 0xa2, 0x38, 0xec, 0x41, 0x2d, 0xbc, 0x7c,
+	0x2d, 0x4e, 0x4e, 0xad, 0x65, 0x4f, 0x7a, 0x16, 0x39, 0x35, 0xac, 0x09, 0xd1, 0xd2, 0x63, 0xca,
+	0x8e, 0x02, 0xb4, 0x08, 0x35, 0x2e, 0xa2, 0xd0, 0xd4, 0x8f, 0x0a, 0x35, 0x2e, 0xc2, 0xd0, 0xe7,
+	0x90, 0xf2, 0xb8, 0xc1, 0x27, 0x5e, 0x69, 0x09, 0x27, 0x2b, 0xb9, 0x9d, 0xa2, 0xb8, 0x5f, 0x94,
+	0xa0, 0xda, 0x91, 0x70, 0x69, 0x11, 0x04, 0xfd, 0x1e, 0x96, 0x3c, 0xdb, 0xe5, 0x7a, 0xef, 0xb2,
+	0x94, 0xc6, 0x52, 0x25, 0xb7, 0xf3, 0x58, 0xa0, 0x6f, 0x49, 0x69, 0xed, 0xc8, 0x76, 0x79, 0xe3,
+	0x52, 0x4b, 0x79, 0xe2, 0x17, 0xad, 0xc1, 0xa2, 0xed, 0x9a, 0xc4, 0x2d, 0x65, 0xc4, 0xe5, 0xc3,
+	0x85, 0xfc, 0x14, 0x52, 0x21, 0x0e, 0xa5, 0x20, 0xd1, 0x6e, 0x15, 0x16, 0x50, 0x1a, 0xee, 0x75,
+	0xd4, 0x3f, 0xed, 0x15, 0x24, 0x94, 0x81, 0xc5, 0x23, 0xf5, 0xa0, 0xd5, 0x2d, 0x24, 0xe4, 0xff,
+	0x4a, 0xb0, 0x36, 0x7b, 0x86, 0xe7, 0xd8, 0xcc, 0x23, 0xa8, 0x02, 0xe9, 0x58, 0x8c, 0x25, 0x09,
+	0x27, 0x2b, 0xd9, 0x9d, 0xe5, 0x69, 0xfa, 0xda, 0x95, 0x17, 0x3d, 0x86, 0x2c, 0xb7, 0xb9, 0x61,
+	0xe9, 0x62, 0x2d, 0x6a, 0xb9, 0xa8, 0x81, 0x30, 0x35, 0x03, 0x0b, 0x7a, 0x06, 0x79, 0x46, 0x2e,
+	0xb8, 0xee, 0x18, 0x43, 0xa2, 0x73, 0xfb, 0xaf, 0x84, 0x45, 0xb5, 0x5c, 0x09, 0xcc, 0x87, 0xc6,
+	0x90, 0x1c, 0x07, 0xc6, 0xdd, 0xbc, 0xaf, 0x2e, 0x03, 0x28, 0xe9, 0x98, 0x8b, 0xfc, 0xbf, 0x24,
+	0x2c, 0x45, 0x0b, 0xf4, 0x18, 0x12, 0xb1, 0x82, 0x1a, 0x01, 0x50, 0x81, 0xc8, 0x83, 0xdb, 0x2d,
+	0x2d, 0x41, 0x4d, 0xf4, 0x14, 0xee, 0x31, 0x63, 0x4c, 0xc4, 0xf9, 0x99, 0xc6, 0xaa, 0xaf, 0xe6,
+	0x94, 0xe5, 0x18, 0x12, 0x38, 0x34, 0xe1, 0x46, 0xbb, 0x90, 0x35, 0x89, 0xd7, 0x77, 0xa9, 0x50,
+	0x72, 0x48, 0xa4, 0x51, 0xf2, 0xd5, 0x75, 0xa5, 0x18, 0xa3, 0xa7, 0xfc, 0xda, 0x34, 0x18, 0x55,
+	0x60, 0x31, 0xd4, 0x41, 0x20, 0x33, 0xa9, 0x81, 0x7c, 0x35, 0xaf, 0xac, 0xc4, 0x51, 0xa2, 0xe6,
+	0x5a, 0x08, 0x08, 0x4e, 0x99, 0x52, 0xa7, 0x90, 0x5c, 0xa6, 0x51, 0xf6, 0xd5, 0x4d, 0x65, 0x3d,
+	0xc6, 0x77, 0x06, 0x7d, 0x1c, 0x88, 0x14, 0x9f, 0x4c, 0xa8, 0xa9, 0x65, 0xae, 0x24, 0x8b, 0x7e,
+	0x07, 0x8b, 0xa2, 0x43, 0x22, 0xb5, 0x81, 0x48, 0xfb, 0x7e, 0x60, 0xb9, 0x71, 0xa2, 0xb0, 0x69,
+	0x21, 0x18, 0xbd, 0x9a, 0x12, 0x9b, 0x74, 0x87, 0xd8, 0x1a, 0x45, 0x5f, 0x2d, 0x28, 0xb9, 0x2b,
+	0xc6, 0x33, 0x0a, 0x94, 0x7f, 0x05, 0xa9, 0xd0, 0x82, 0x00, 0x52, 0x6a, 0xf3, 0xb8, 0x7d, 0xba,
+	0x57, 0x58, 0x40, 0x59, 0x58, 0x6a, 0x1c, 0x74, 0x9b, 0x6f, 0xf6, 0x5a, 0x05, 0x69, 0x37, 0xe7,
+	0xab, 0x59, 0xc8, 0x28, 0x71, 0x51, 0xe4, 0x2f, 0x12, 0xb0, 0xd6, 0x74, 0x89, 0xc1, 0x49, 0x2c,
+	0x8b, 0xa8, 0xeb, 0x7f, 0x81, 0x62, 0x6c, 0xcf, 0x16, 0x63, 0xd3, 0x57, 0xd7, 0x14, 0x34, 0x75,
+	0x35, 0x97, 0x7b, 0x3f, 0x57, 0x45, 0x7e, 0x33, 0x35, 0xc3, 0x52, 0x42, 0x81, 0xeb, 0xbe, 0x8a,
+	0x94, 0xc2, 0x4c, 0x21, 0x02, 0x1d, 0xc6, 0xa3, 0x6d, 0x77, 0xc3, 0x57, 0x8b, 0xb0, 0xaa, 0xe4,
+	0x23, 0x84, 0xc8, 0x11, 0xb5, 0x99, 0xfc, 0x04, 0x56, 0xf7, 0x09, 0xbf, 0x91, 0xac, 0xdc, 0xb5,
+	0xb4, 0x03, 0x25, 0xcb, 0xcf, 0x60, 0xad, 0x45, 0x2c, 0xc2, 0xc9, 0x27, 0x70, 0xdf, 0x48, 0xb0,
+	0xde, 0x1e, 0x3b, 0xb6, 0x3b, 0x37, 0x74, 0x3b, 0x73, 0xcd, 0xbb, 0x25, 0xe4, 0x70, 0x5b, 0xad,
+	0x66, 0x33, 0xe7, 0x61, 0x6e, 0xe3, 0xbe, 0x80, 0x4d, 0xb5, 0xf8, 0x6b, 0x58, 0x32, 0xdd, 0x4b,
+	0xdd, 0x9d, 0x30, 0x51, 0xd1, 0x74, 0x63, 0xdb, 0x57, 0x15, 0xa5, 0xd2, 0x65, 0xd6, 0x25, 0x3e,
+	0x33, 0x2c, 0x6a, 0x1a, 0x9c, 0xe0, 0x18, 0x5d, 0xc5, 0xcc, 0xe6, 0x23, 0xca, 0x86, 0x98, 0x7a,
+	0xd8, 0x33, 0xce, 0x88, 0xa9, 0xa5, 0x4c, 0xf7, 0x52, 0x9b, 0xb0, 0xdd, 0x35, 0x5f, 0x5d, 0x85,
+	0x6b, 0x05, 0x87, 0xe4, 0xe5, 0xcf, 0x25, 0xd8, 0xb8, 0x79, 0x8f, 0x9f, 0x3c, 0x85, 0xea, 0x90,
+	0x22, 0xae, 0x6b, 0xbb, 0x5e, 0x29, 0x21, 0x70, 0x9b, 0x02, 0x37, 0xb3, 0xed, 0x5e, 0xe0, 0xd7,
+	0x22, 0x18, 0xda, 0xbc, 0xbe, 0x53, 0xa0, 0xbb, 0xf4, 0x15, 0xc9, 0x40, 0x14, 0x70, 0xad, 0xc0,
+	0x70, 0x0b, 0x8d, 0x78, 0x13, 0x8b, 0xcb, 0x7e, 0x02, 0xd0, 0xfc, 0x9e, 0x68, 0x07, 0x92, 0xae,
+	0x7d, 0x1e, 0x4d, 0x27, 0xec, 0xab, 0x0f, 0x95, 0xfb, 0x9a, 0x7d, 0x8e, 0xd9, 0x64, 0xdc, 0x23,
+	0x6e, 0x15, 0x7b, 0x81, 0x1a, 0x83, 0x84, 0x9c, 0x53, 0x3e, 0xc2, 0x2f, 0xb4, 0x00, 0x8c, 0x1e,
+	0xcd, 0x0a, 0x52, 0x74, 0xca, 0xb4, 0xe8, 0x5e, 0x42, 0xca, 0x25, 0x86, 0x17, 0xb5, 0x45, 0x6e,
+	0xe7, 0xd1, 0x1d, 0x17, 0xaa, 0x69, 0x02, 0xa5, 0x45, 0x68, 0x54, 0x82, 0xa5, 0x31, 0xf1, 0x3c,
+	0x63, 0x48, 0xa2, 0xd7, 0x30, 0x5e, 0xca, 0xa7, 0x90, 0x0a, 0xb1, 0x41, 0x53, 0x9f, 0x74, 0xde,
+	0x74, 0xba, 0x6f, 0x3b, 0x85, 0x05, 0x94, 0x87, 0x6c, 0xbb, 0x73, 0xaa, 0x1e, 0xb4, 0x5b, 0xba,
+	0xd6, 0x7d, 0x5b, 0x90, 0xd0, 0x16, 0xac, 0xb7, 0x4e, 0x0e, 0x0f, 0xda, 0x4d, 0xf5, 0x78, 0x4f,
+	0xef, 0xbc, 0x6e, 0xea, 0xcd, 0x3f, 0xb6, 0x0f, 0xf5, 0x76, 0xab, 0x90, 0x40, 0x45, 0xc8, 0xef,
+	0x6b, 0xdd, 0x93, 0x43, 0xbd, 0xd3, 0x3d, 0xd6, 0x5f, 0x77, 0x4f, 0x3a, 0xad, 0x42, 0x72, 0xe7,
+	0x43, 0x06, 0xe2, 0x99, 0x72, 0x44, 0xdc, 0x33, 0xda, 0x27, 0xe8, 0x5d, 0x02, 0x96, 0xa7, 0x9f,
+	0x15, 0x54, 0xba, 0xeb, 0x35, 0x2b, 0x6f, 0xdd, 0xe2, 0x09, 0xab, 0x2f, 0x7f, 0x2b, 0xf9, 0xea,
+	0x97, 0x52, 0xf9, 0x3f, 0x92, 0x46, 0xf8, 0xc4, 0x65, 0x1e, 0x36, 0x2c, 0x2b, 0x96, 0x59, 0x15,
+	0xf7, 0x0d, 0x86, 0x7b, 0x04, 0x5b, 0x74, 0x4c, 0x39, 0x31, 0xc3, 0xdc, 0x06, 0x4f, 0x8c, 0x89,
+	0xa3, 0xaf, 0x94, 0x2a, 0x1e, 0x50, 0x8b, 0x13, 0x97, 0x98, 0xb8, 0x77, 0x89, 0x45, 0x6b, 0x56,
+	0xc5, 0xf4, 0xa9, 0x62, 0x36, 0xe8, 0xe3, 0x20, 0xf3, 0x55, 0x2c, 0xa6, 0x03, 0x36, 0x98, 0x89,
+	0xc3, 0x31, 0x18, 0xfe, 0x69, 0xbb, 0x3c, 0x8c, 0xa2, 0x66, 0x18, 0x82, 0x6d, 0x37, 0x84, 0x2a,
+	0xab, 0x01, 0xd5, 0x69, 0x26, 0x5e, 0x2f, 0x0f, 0x2b, 0x90, 0x11, 0x6f, 0x98, 0x3a, 0xe1, 0x23,
+	0xb4, 0xf0, 0xaf, 0xef, 0xbe, 0x7f, 0x9f, 0xc8, 0xa1, 0xe5, 0xfa, 0xd9, 0x8b, 0xfa, 0x95, 0x40,
+	0xff, 0x2d, 0xc1, 0xca, 0x4c, 0xff, 0xa1, 0xbb, 0x7b, 0xb2, 0x3c, 0xa3, 0x72, 0xf9, 0xd0, 0x57,
+	0x5f, 0x96, 0x8b, 0x21, 0xd0, 0xc3, 0x8c, 0x9c, 0xc7, 0x47, 0x2b, 0xb9, 0xd0, 0x18, 0xaf, 0x6f,
+	0x67, 0xb2, 0x2a, 0xcf, 0x30, 0xd9, 0x95, 0x14, 0xf4, 0x5e, 0x02, 0xb8, 0x1e, 0x44, 0x68, 0x23,
+	0x7c, 0x63, 0x08, 0xff, 0x28, 0x0d, 0xdd, 0x57, 0x5b, 0xe5, 0x5f, 0xc7, 0xb5, 0xf0, 0x28, 0x1b,
+	0x5a, 0x57, 0x27, 0x87, 0xe9, 0x1f, 0xd2, 0x33, 0xc2, 0x30, 0x35, 0x95, 0xec, 0x3e, 0xe1, 0x1f,
+	0x27, 0x85, 0x50, 0x61, 0x8a, 0x54, 0xfd, 0xef, 0xd4, 0xfc, 0x07, 0xfa, 0x4c, 0x82, 0x95, 0x13,
+	0xc7, 0x9c, 0x4a, 0xd1, 0x0c, 0x81, 0x1b, 0x74, 0xce, 0x7d, 0xf5, 0xcf, 0xe5, 0x97, 0x21, 0xde,
+	0xbb, 0x9d, 0x47, 0x55, 0xd4, 0x69, 0x40, 0x89, 0x65, 0x7a, 0x78, 0x3c, 0xf1, 0x78, 0x20, 0x18,
+	0x8f, 0x30, 0x53, 0xc9, 0x85, 0x71, 0x1f, 0xe7, 0xb8, 0x5e, 0x9e, 0xe3, 0x18, 0x24, 0xef, 0x5d,
+	0x02, 0x72, 0xb3, 0xf3, 0x0a, 0x95, 0xe7, 0x9b, 0xf3, 0x4a, 0xe0, 0xf7, 0x6f, 0xf5, 0x45, 0x12,
+	0xff, 0x5a, 0xf2, 0xd5, 0x0f, 0x52, 0xf9, 0x9f, 0x71, 0x71, 0x03, 0xbe, 0x21, 0xfb, 0xb8, 0x5c,
+	0x98, 0x32, 0x6c, 0x33, 0x82, 0x7b, 0x06, 0xef, 0x8f, 0xaa, 0x98, 0x50, 0x3e, 0x22, 0xee, 0x8c,
+	0xfe, 0xb0, 0xe1, 0x92, 0x68, 0x66, 0x9b, 0x81, 0x4e, 0x99, 0xcd, 0x48, 0x0d, 0xbf, 0x0d, 0x12,
+	0x11, 0x4d, 0x38, 0xcc, 0x47, 0x64, 0x16, 0x6e, 0x4f, 0x8f, 0x6e, 0x53, 0xc9, 0x87, 0xf4, 0x3e,
+	0x21, 0xe9, 0x92, 0x5c, 0x9c, 0x16, 0x52, 0x9d, 0x8a, 0xa0, 0x20, 0x25, 0xff, 0x97, 0x60, 0x65,
+	0xe6, 0xcd, 0x8a, 0xc4, 0x7d, 0xdb, 0x3b, 0x56, 0xde, 0x98, 0xfb, 0x7e, 0xde, 0x0b, 0xfe, 0x69,
+	0x91, 0xff, 0xe2, 0xab, 0xaf, 0xca, 0x0f, 0xc3, 0x90, 0x3b, 0x0a, 0xaa, 0xe4, 0x42, 0xf7, 0x27,
+	0xb4, 0xa5, 0xcc, 0xd5, 0xad, 0x97, 0x12, 0x87, 0xfd, 0xf6, 0x87, 0x01, 0x00, 0x2c, 0x44, 0x7c,
+	0x01, 0x63, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -433,6 +812,7 @@ type AccountServiceClient interface {
 	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error)
 	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error)
 	UpdateAccount(ctx context.Context, in *Account, opts ...grpc.CallOption) (*Account, error)
+	ImportAccounts(ctx context.Context, in *ImportAccountsRequest, opts ...grpc.CallOption) (*ImportAccountsResponse, error)
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
@@ -480,6 +860,15 @@ func (c *accountServiceClient) UpdateAccount(ctx context.Context, in *Account, o
 	return out, nil
 }
 
+func (c *accountServiceClient) ImportAccounts(ctx context.Context, in *ImportAccountsRequest, opts ...grpc.CallOption) (*ImportAccountsResponse, error) {
+	out := new(ImportAccountsResponse)
+	err := c.cc.Invoke(ctx, "/api.AccountService/ImportAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *accountServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/api.AccountService/DeleteAccount", in, out, opts...)
@@ -495,6 +884,7 @@ type AccountServiceServer interface {
 	CreateAccount(context.Context, *CreateAccountRequest) (*Account, error)
 	GetAccount(context.Context, *GetAccountRequest) (*Account, error)
 	UpdateAccount(context.Context, *Account) (*Account, error)
+	ImportAccounts(context.Context, *ImportAccountsRequest) (*ImportAccountsResponse, error)
 	DeleteAccount(context.Context, *DeleteAccountRequest) (*empty.Empty, error)
 }
 
@@ -514,6 +904,9 @@ func (*UnimplementedAccountServiceServer) GetAccount(ctx context.Context, req *G
 func (*UnimplementedAccountServiceServer) UpdateAccount(ctx context.Context, req *Account) (*Account, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccount not implemented")
 }
+func (*UnimplementedAccountServiceServer) ImportAccounts(ctx context.Context, req *ImportAccountsRequest) (*ImportAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAccounts not implemented")
+}
 func (*UnimplementedAccountServiceServer) DeleteAccount(ctx context.Context, req *DeleteAccountRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
 }
@@ -594,6 +987,24 @@ func _AccountService_UpdateAccount_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_ImportAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ImportAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AccountService/ImportAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ImportAccounts(ctx, req.(*ImportAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AccountService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAccountRequest)
 	if err := dec(in); err != nil {
@@ -632,6 +1043,10 @@ var _AccountService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAccount",
 			Handler:    _AccountService_UpdateAccount_Handler,
 		},
+		{
+			MethodName: "ImportAccounts",
+			Handler:    _AccountService_ImportAccounts_Handler,
+		},
 		{
 			MethodName: "DeleteAccount",
 			Handler:    _AccountService_DeleteAccount_Handler,
@@ -0,0 +1,562 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type LedgerDiscrepancy_Kind int32
+
+const (
+	// CHAIN_BREAK: old_saldo of the transaction is not the new_saldo of the previous transaction,
+	// it is only reported and never repaired, also not with fix
+	LedgerDiscrepancy_CHAIN_BREAK LedgerDiscrepancy_Kind = 0
+	// SALDO_MISMATCH: new_saldo of the last transaction is not the saldo of the account
+	LedgerDiscrepancy_SALDO_MISMATCH LedgerDiscrepancy_Kind = 1
+)
+
+var LedgerDiscrepancy_Kind_name = map[int32]string{
+	0: "CHAIN_BREAK",
+	1: "SALDO_MISMATCH",
+}
+
+var LedgerDiscrepancy_Kind_value = map[string]int32{
+	"CHAIN_BREAK":    0,
+	"SALDO_MISMATCH": 1,
+}
+
+func (x LedgerDiscrepancy_Kind) String() string {
+	return proto.EnumName(LedgerDiscrepancy_Kind_name, int32(x))
+}
+
+func (LedgerDiscrepancy_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2, 0}
+}
+
+type VerifyLedgerRequest struct {
+	// account_id limits the verification to one account, 0 verifies all accounts
+	AccountId int32 `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// fix writes an ADJUSTMENT transaction for every saldo mismatch, chain breaks are never repaired
+	Fix                  bool     `protobuf:"varint,2,opt,name=fix,proto3" json:"fix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyLedgerRequest) Reset()         { *m = VerifyLedgerRequest{} }
+func (m *VerifyLedgerRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyLedgerRequest) ProtoMessage()    {}
+func (*VerifyLedgerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+func (m *VerifyLedgerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyLedgerRequest.Unmarshal(m, b)
+}
+func (m *VerifyLedgerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyLedgerRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyLedgerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyLedgerRequest.Merge(m, src)
+}
+func (m *VerifyLedgerRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyLedgerRequest.Size(m)
+}
+func (m *VerifyLedgerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyLedgerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyLedgerRequest proto.InternalMessageInfo
+
+func (m *VerifyLedgerRequest) GetAccountId() int32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func (m *VerifyLedgerRequest) GetFix() bool {
+	if m != nil {
+		return m.Fix
+	}
+	return false
+}
+
+type LedgerReport struct {
+	CheckedAccounts      int32                `protobuf:"varint,1,opt,name=checked_accounts,json=checkedAccounts,proto3" json:"checked_accounts,omitempty"`
+	CheckedTransactions  int32                `protobuf:"varint,2,opt,name=checked_transactions,json=checkedTransactions,proto3" json:"checked_transactions,omitempty"`
+	Discrepancies        []*LedgerDiscrepancy `protobuf:"bytes,3,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LedgerReport) Reset()         { *m = LedgerReport{} }
+func (m *LedgerReport) String() string { return proto.CompactTextString(m) }
+func (*LedgerReport) ProtoMessage()    {}
+func (*LedgerReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{1}
+}
+
+func (m *LedgerReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LedgerReport.Unmarshal(m, b)
+}
+func (m *LedgerReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LedgerReport.Marshal(b, m, deterministic)
+}
+func (m *LedgerReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LedgerReport.Merge(m, src)
+}
+func (m *LedgerReport) XXX_Size() int {
+	return xxx_messageInfo_LedgerReport.Size(m)
+}
+func (m *LedgerReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_LedgerReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LedgerReport proto.InternalMessageInfo
+
+func (m *LedgerReport) GetCheckedAccounts() int32 {
+	if m != nil {
+		return m.CheckedAccounts
+	}
+	return 0
+}
+
+func (m *LedgerReport) GetCheckedTransactions() int32 {
+	if m != nil {
+		return m.CheckedTransactions
+	}
+	return 0
+}
+
+func (m *LedgerReport) GetDiscrepancies() []*LedgerDiscrepancy {
+	if m != nil {
+		return m.Discrepancies
+	}
+	return nil
+}
+
+type LedgerDiscrepancy struct {
+	Kind          LedgerDiscrepancy_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=api.LedgerDiscrepancy_Kind" json:"kind,omitempty"`
+	AccountId     int32                  `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TransactionId int32                  `protobuf:"varint,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Expected      float64                `protobuf:"fixed64,4,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual        float64                `protobuf:"fixed64,5,opt,name=actual,proto3" json:"actual,omitempty"`
+	// adjustment_id is the id of the ADJUSTMENT transaction that fixed the discrepancy
+	AdjustmentId int32 `protobuf:"varint,6,opt,name=adjustment_id,json=adjustmentId,proto3" json:"adjustment_id,omitempty"`
+	// resolved is true if the saldo mismatch was gone when it was fixed, a concurrent payment made the saldos
+	// match again and no ADJUSTMENT transaction was needed
+	Resolved             bool     `protobuf:"varint,7,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LedgerDiscrepancy) Reset()         { *m = LedgerDiscrepancy{} }
+func (m *LedgerDiscrepancy) String() string { return proto.CompactTextString(m) }
+func (*LedgerDiscrepancy) ProtoMessage()    {}
+func (*LedgerDiscrepancy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2}
+}
+
+func (m *LedgerDiscrepancy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LedgerDiscrepancy.Unmarshal(m, b)
+}
+func (m *LedgerDiscrepancy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LedgerDiscrepancy.Marshal(b, m, deterministic)
+}
+func (m *LedgerDiscrepancy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LedgerDiscrepancy.Merge(m, src)
+}
+func (m *LedgerDiscrepancy) XXX_Size() int {
+	return xxx_messageInfo_LedgerDiscrepancy.Size(m)
+}
+func (m *LedgerDiscrepancy) XXX_DiscardUnknown() {
+	xxx_messageInfo_LedgerDiscrepancy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LedgerDiscrepancy proto.InternalMessageInfo
+
+func (m *LedgerDiscrepancy) GetKind() LedgerDiscrepancy_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return LedgerDiscrepancy_CHAIN_BREAK
+}
+
+func (m *LedgerDiscrepancy) GetAccountId() int32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func (m *LedgerDiscrepancy) GetTransactionId() int32 {
+	if m != nil {
+		return m.TransactionId
+	}
+	return 0
+}
+
+func (m *LedgerDiscrepancy) GetExpected() float64 {
+	if m != nil {
+		return m.Expected
+	}
+	return 0
+}
+
+func (m *LedgerDiscrepancy) GetActual() float64 {
+	if m != nil {
+		return m.Actual
+	}
+	return 0
+}
+
+func (m *LedgerDiscrepancy) GetAdjustmentId() int32 {
+	if m != nil {
+		return m.AdjustmentId
+	}
+	return 0
+}
+
+func (m *LedgerDiscrepancy) GetResolved() bool {
+	if m != nil {
+		return m.Resolved
+	}
+	return false
+}
+
+type RegisterTerminalRequest struct {
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// public_key is the 32 byte ed25519 public key of the terminal
+	PublicKey            []byte   `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterTerminalRequest) Reset()         { *m = RegisterTerminalRequest{} }
+func (m *RegisterTerminalRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterTerminalRequest) ProtoMessage()    {}
+func (*RegisterTerminalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3}
+}
+
+func (m *RegisterTerminalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterTerminalRequest.Unmarshal(m, b)
+}
+func (m *RegisterTerminalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterTerminalRequest.Marshal(b, m, deterministic)
+}
+func (m *RegisterTerminalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterTerminalRequest.Merge(m, src)
+}
+func (m *RegisterTerminalRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterTerminalRequest.Size(m)
+}
+func (m *RegisterTerminalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterTerminalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterTerminalRequest proto.InternalMessageInfo
+
+func (m *RegisterTerminalRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RegisterTerminalRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RegisterTerminalRequest) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type Terminal struct {
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey []byte `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// last_sequence is the sequence of the last offline transaction processed for the terminal
+	LastSequence         uint64               `protobuf:"varint,4,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Terminal) Reset()         { *m = Terminal{} }
+func (m *Terminal) String() string { return proto.CompactTextString(m) }
+func (*Terminal) ProtoMessage()    {}
+func (*Terminal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{4}
+}
+
+func (m *Terminal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Terminal.Unmarshal(m, b)
+}
+func (m *Terminal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Terminal.Marshal(b, m, deterministic)
+}
+func (m *Terminal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Terminal.Merge(m, src)
+}
+func (m *Terminal) XXX_Size() int {
+	return xxx_messageInfo_Terminal.Size(m)
+}
+func (m *Terminal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Terminal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Terminal proto.InternalMessageInfo
+
+func (m *Terminal) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Terminal) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Terminal) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *Terminal) GetLastSequence() uint64 {
+	if m != nil {
+		return m.LastSequence
+	}
+	return 0
+}
+
+func (m *Terminal) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.LedgerDiscrepancy_Kind", LedgerDiscrepancy_Kind_name, LedgerDiscrepancy_Kind_value)
+	proto.RegisterType((*VerifyLedgerRequest)(nil), "api.VerifyLedgerRequest")
+	proto.RegisterType((*LedgerReport)(nil), "api.LedgerReport")
+	proto.RegisterType((*LedgerDiscrepancy)(nil), "api.LedgerDiscrepancy")
+	proto.RegisterType((*RegisterTerminalRequest)(nil), "api.RegisterTerminalRequest")
+	proto.RegisterType((*Terminal)(nil), "api.Terminal")
+}
+
+func init() { proto.RegisterFile("admin.proto", fileDescriptor_73a7fc70dcc2027c) }
+
+var fileDescriptor_73a7fc70dcc2027c = []byte{
+	// 836 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x41, 0x6f, 0x23, 0x35,
+	0x14, 0xde, 0x49, 0xd2, 0x6e, 0xe3, 0x26, 0x69, 0xea, 0xa2, 0xdd, 0x28, 0x14, 0x61, 0x05, 0x21,
+	0x95, 0xd0, 0x26, 0xda, 0xc2, 0xa9, 0xe2, 0x32, 0x74, 0x81, 0xad, 0xba, 0x0b, 0xd2, 0x34, 0xe2,
+	0x84, 0x14, 0x39, 0xf6, 0x4b, 0x62, 0x32, 0x63, 0x0f, 0xb6, 0x27, 0xdb, 0x5c, 0xf9, 0x01, 0x1c,
+	0xca, 0x6f, 0xe0, 0xc6, 0x1f, 0xe0, 0xc4, 0x91, 0x2b, 0x12, 0x57, 0x6e, 0xf0, 0x3f, 0x40, 0xf6,
+	0xcc, 0xa4, 0x69, 0xb7, 0xdc, 0x38, 0x65, 0xfc, 0xbd, 0xcf, 0xdf, 0xbc, 0xf7, 0xcd, 0xf7, 0x82,
+	0x76, 0x29, 0x4f, 0x84, 0x1c, 0xa4, 0x5a, 0x59, 0x85, 0xab, 0x34, 0x15, 0xdd, 0x77, 0x67, 0x4a,
+	0xcd, 0x62, 0x18, 0x7a, 0x68, 0x92, 0x4d, 0x87, 0x56, 0x24, 0x60, 0x2c, 0x4d, 0xd2, 0x9c, 0xd5,
+	0x3d, 0x2c, 0x08, 0x34, 0x15, 0x43, 0x2a, 0xa5, 0xb2, 0xd4, 0x0a, 0x25, 0x4d, 0x51, 0x3d, 0xf6,
+	0x3f, 0xec, 0x64, 0x06, 0xf2, 0xc4, 0xbc, 0xa6, 0xb3, 0x19, 0xe8, 0xa1, 0x4a, 0x3d, 0xe3, 0x4d,
+	0x76, 0xef, 0x73, 0x74, 0xf0, 0x35, 0x68, 0x31, 0x5d, 0xbd, 0x04, 0x3e, 0x03, 0x1d, 0xc1, 0x77,
+	0x19, 0x18, 0x8b, 0xdf, 0x41, 0x88, 0x32, 0xa6, 0x32, 0x69, 0xc7, 0x82, 0x77, 0x02, 0x12, 0x1c,
+	0x6d, 0x45, 0xf5, 0x02, 0xb9, 0xe0, 0xb8, 0x8d, 0xaa, 0x53, 0x71, 0xdd, 0xa9, 0x90, 0xe0, 0x68,
+	0x27, 0x72, 0x8f, 0xbd, 0x5f, 0x03, 0xd4, 0x28, 0x25, 0x52, 0xa5, 0x2d, 0xfe, 0x00, 0xb5, 0xd9,
+	0x1c, 0xd8, 0x02, 0xf8, 0xb8, 0xb8, 0x67, 0x0a, 0x9d, 0xbd, 0x02, 0x0f, 0x0b, 0x18, 0x3f, 0x43,
+	0x6f, 0x95, 0x54, 0xab, 0xa9, 0x34, 0x94, 0xf9, 0x0e, 0xbd, 0xfc, 0x56, 0x74, 0x50, 0xd4, 0x46,
+	0x1b, 0x25, 0xfc, 0x09, 0x6a, 0x72, 0x61, 0x98, 0x86, 0x94, 0x4a, 0x26, 0xc0, 0x74, 0xaa, 0xa4,
+	0x7a, 0xb4, 0x7b, 0xfa, 0x64, 0x40, 0x53, 0x31, 0xc8, 0xfb, 0x78, 0xbe, 0xae, 0xaf, 0xa2, 0xbb,
+	0xe4, 0xb3, 0x83, 0x9b, 0xb0, 0x8d, 0x5a, 0xfd, 0x3b, 0x0d, 0xf7, 0x7e, 0xae, 0xa0, 0xfd, 0x37,
+	0x6e, 0xe2, 0x21, 0xaa, 0x2d, 0x84, 0xcc, 0x2d, 0x68, 0x9d, 0xbe, 0xfd, 0xb0, 0xfe, 0xe0, 0x52,
+	0x48, 0x1e, 0x79, 0xe2, 0x3d, 0xe7, 0x2a, 0xf7, 0x9d, 0x7b, 0x1f, 0xb5, 0x36, 0x66, 0x74, 0x94,
+	0xaa, 0xa7, 0x34, 0x37, 0xd0, 0x0b, 0x8e, 0xbb, 0x68, 0x07, 0xae, 0x53, 0x60, 0x16, 0x78, 0xa7,
+	0x46, 0x82, 0xa3, 0x20, 0x5a, 0x9f, 0xf1, 0x13, 0xb4, 0x4d, 0x99, 0xcd, 0x68, 0xdc, 0xd9, 0xf2,
+	0x95, 0xe2, 0x84, 0xdf, 0x43, 0x4d, 0xca, 0xbf, 0xcd, 0x8c, 0x4d, 0x20, 0x7f, 0xf9, 0xb6, 0x57,
+	0x6e, 0xdc, 0x82, 0xb9, 0xb0, 0x06, 0xa3, 0xe2, 0x25, 0xf0, 0xce, 0x63, 0xff, 0xf9, 0xd6, 0xe7,
+	0xde, 0x87, 0xa8, 0xe6, 0x06, 0xc1, 0x7b, 0x68, 0xf7, 0xfc, 0x45, 0x78, 0xf1, 0xe5, 0xf8, 0xd3,
+	0xe8, 0xb3, 0xf0, 0xb2, 0xfd, 0x08, 0x63, 0xd4, 0xba, 0x0a, 0x5f, 0x3e, 0xff, 0x6a, 0xfc, 0xea,
+	0xe2, 0xea, 0x55, 0x38, 0x3a, 0x7f, 0xd1, 0x0e, 0x7a, 0xdf, 0xa0, 0xa7, 0x11, 0xcc, 0x84, 0xb1,
+	0xa0, 0x47, 0xa0, 0x13, 0x21, 0x69, 0x5c, 0x86, 0xa7, 0x85, 0x2a, 0x45, 0x68, 0xea, 0x51, 0x45,
+	0x70, 0x8c, 0x51, 0x4d, 0xd2, 0x04, 0xbc, 0x19, 0xf5, 0xc8, 0x3f, 0x3b, 0x9b, 0xd2, 0x6c, 0x12,
+	0x0b, 0x36, 0x5e, 0xc0, 0xca, 0x7b, 0xd0, 0x88, 0xea, 0x39, 0x72, 0x09, 0xab, 0xde, 0x2f, 0x01,
+	0xda, 0x29, 0x65, 0xff, 0x07, 0x3d, 0xe7, 0x4d, 0x4c, 0x8d, 0x1d, 0x1b, 0xd7, 0xa2, 0x64, 0xe0,
+	0x4d, 0xad, 0x45, 0x0d, 0x07, 0x5e, 0x15, 0x18, 0xfe, 0x18, 0x3d, 0x66, 0x1a, 0xa8, 0xf3, 0xdc,
+	0x39, 0xbb, 0x7b, 0xda, 0x1d, 0xe4, 0x9b, 0x36, 0x28, 0x57, 0x71, 0x30, 0x2a, 0x57, 0x31, 0x2a,
+	0xa9, 0x67, 0x7b, 0x37, 0x61, 0x03, 0xa1, 0xfe, 0xba, 0xdd, 0xd3, 0xdf, 0x6a, 0xa8, 0x11, 0xba,
+	0xa5, 0xbe, 0x02, 0xbd, 0x14, 0x0c, 0xf0, 0x5f, 0x15, 0xd4, 0xd8, 0x5c, 0x32, 0xdc, 0xf1, 0x31,
+	0x7a, 0x60, 0xef, 0xba, 0xfb, 0x1b, 0x01, 0x2b, 0x72, 0xf9, 0x53, 0xe5, 0x26, 0xfc, 0x27, 0xe8,
+	0xfe, 0x19, 0x9c, 0xbb, 0x3d, 0x30, 0xc4, 0xce, 0xa9, 0x25, 0x76, 0x0e, 0x64, 0x23, 0x31, 0x84,
+	0xcd, 0xa9, 0x90, 0x44, 0x4d, 0x09, 0x2c, 0x41, 0xaf, 0x48, 0x91, 0x37, 0x22, 0x0c, 0x61, 0x4a,
+	0x5a, 0x21, 0x33, 0x95, 0x19, 0x42, 0x25, 0x27, 0x20, 0xb9, 0x21, 0xaf, 0x85, 0x9d, 0x7b, 0x09,
+	0x43, 0x63, 0xae, 0xdc, 0x35, 0x77, 0x28, 0x2e, 0x1d, 0x17, 0x68, 0x22, 0x4c, 0x42, 0x2d, 0x9b,
+	0x83, 0x21, 0x8c, 0x4a, 0x32, 0x01, 0x32, 0x15, 0xd7, 0xc0, 0xf3, 0xcb, 0xb7, 0x99, 0xda, 0x6c,
+	0xc3, 0x0c, 0xc8, 0xb9, 0x6f, 0x64, 0xa2, 0x81, 0x2e, 0x0c, 0xa1, 0x1a, 0x88, 0x92, 0xf1, 0x8a,
+	0x68, 0x3f, 0x0a, 0xf0, 0x63, 0xf7, 0xa2, 0x95, 0xc7, 0xa5, 0xeb, 0xd4, 0x15, 0xa8, 0xd0, 0xc0,
+	0xfb, 0xcd, 0xdc, 0x0d, 0x12, 0xfb, 0xd1, 0x27, 0x7b, 0xa8, 0x89, 0xea, 0x23, 0xb5, 0x00, 0x19,
+	0x66, 0x76, 0x8e, 0x1f, 0x7d, 0xff, 0xc7, 0xdf, 0x3f, 0x56, 0x0e, 0x7b, 0x4f, 0x87, 0xcb, 0x67,
+	0x43, 0xff, 0x87, 0x39, 0x5c, 0x7a, 0xfe, 0x49, 0xce, 0x3f, 0x0b, 0xfa, 0xf8, 0xf7, 0x00, 0xb5,
+	0xef, 0x27, 0x12, 0x1f, 0x7a, 0x3f, 0xff, 0x23, 0xa8, 0xdd, 0xa6, 0xaf, 0x96, 0x68, 0xef, 0x87,
+	0xe0, 0x26, 0x4c, 0xba, 0x5f, 0x94, 0x6c, 0xe3, 0x5d, 0xc9, 0x33, 0x44, 0x16, 0xae, 0x6d, 0x62,
+	0x0b, 0x2a, 0x31, 0x62, 0x26, 0x0d, 0x11, 0xd6, 0x10, 0x35, 0x9d, 0xc6, 0x42, 0xde, 0xf9, 0x1a,
+	0xb9, 0xc1, 0xfd, 0xfd, 0x52, 0x68, 0x7d, 0xed, 0xe1, 0xb1, 0x3a, 0xbd, 0x83, 0xdb, 0xb1, 0x4a,
+	0xaa, 0x39, 0x0b, 0xfa, 0x93, 0x6d, 0x9f, 0xbb, 0x8f, 0xfe, 0x1d, 0x00, 0xe8, 0x7f, 0x51, 0xf6,
+	0x24, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	VerifyLedger(ctx context.Context, in *VerifyLedgerRequest, opts ...grpc.CallOption) (*LedgerReport, error)
+	RegisterTerminal(ctx context.Context, in *RegisterTerminalRequest, opts ...grpc.CallOption) (*Terminal, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) VerifyLedger(ctx context.Context, in *VerifyLedgerRequest, opts ...grpc.CallOption) (*LedgerReport, error) {
+	out := new(LedgerReport)
+	err := c.cc.Invoke(ctx, "/api.AdminService/VerifyLedger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RegisterTerminal(ctx context.Context, in *RegisterTerminalRequest, opts ...grpc.CallOption) (*Terminal, error) {
+	out := new(Terminal)
+	err := c.cc.Invoke(ctx, "/api.AdminService/RegisterTerminal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	VerifyLedger(context.Context, *VerifyLedgerRequest) (*LedgerReport, error)
+	RegisterTerminal(context.Context, *RegisterTerminalRequest) (*Terminal, error)
+}
+
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (*UnimplementedAdminServiceServer) VerifyLedger(ctx context.Context, req *VerifyLedgerRequest) (*LedgerReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyLedger not implemented")
+}
+func (*UnimplementedAdminServiceServer) RegisterTerminal(ctx context.Context, req *RegisterTerminalRequest) (*Terminal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterTerminal not implemented")
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_VerifyLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).VerifyLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/VerifyLedger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).VerifyLedger(ctx, req.(*VerifyLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RegisterTerminal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterTerminalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RegisterTerminal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/RegisterTerminal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RegisterTerminal(ctx, req.(*RegisterTerminalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "VerifyLedger",
+			Handler:    _AdminService_VerifyLedger_Handler,
+		},
+		{
+			MethodName: "RegisterTerminal",
+			Handler:    _AdminService_RegisterTerminal_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
@@ -77,8 +77,15 @@ func (m *Status) GetMessage() string {
 }
 
 type Paging struct {
-	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset               int32    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is the number of entries of a page, at most 500. A missing or larger limit returns pages of 500 entries,
+	// so lists with more entries are only complete if the client follows next_page_token
+	Limit  int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// next_page_token of the previous response, offset is ignored if set.
+	// The token is only valid for a request with the same filters, sort order and direction
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// total_count of the response is left at 0 if set, counting is slow on large lists
+	SkipTotalCount       bool     `protobuf:"varint,4,opt,name=skip_total_count,json=skipTotalCount,proto3" json:"skip_total_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -123,6 +130,20 @@ func (m *Paging) GetOffset() int32 {
 	return 0
 }
 
+func (m *Paging) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *Paging) GetSkipTotalCount() bool {
+	if m != nil {
+		return m.SkipTotalCount
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Status)(nil), "api.Status")
 	proto.RegisterType((*Paging)(nil), "api.Paging")
@@ -131,34 +152,40 @@ func init() {
 func init() { proto.RegisterFile("globals.proto", fileDescriptor_b61bc6eb45b21f34) }
 
 var fileDescriptor_b61bc6eb45b21f34 = []byte{
-	// 454 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0x41, 0x6f, 0x13, 0x31,
-	0x10, 0x85, 0x95, 0x2c, 0x09, 0xc9, 0xa0, 0x28, 0xad, 0xa9, 0x50, 0x14, 0x71, 0xb0, 0x72, 0xaa,
-	0xa2, 0x66, 0xb7, 0x34, 0x3d, 0x45, 0x42, 0x22, 0x2d, 0x02, 0x84, 0x10, 0xa0, 0x2d, 0xa7, 0x72,
-	0x40, 0x8e, 0x77, 0xb2, 0x76, 0xc8, 0xda, 0x2b, 0x8f, 0xb7, 0x01, 0x7e, 0x05, 0x8a, 0xf8, 0xc1,
-	0x28, 0xde, 0x44, 0x3d, 0xe4, 0xf6, 0xde, 0x78, 0xde, 0x37, 0xf6, 0xc8, 0xd0, 0xcb, 0xd7, 0x76,
-	0x21, 0xd6, 0x14, 0x97, 0xce, 0x7a, 0xcb, 0x22, 0x51, 0xea, 0xe1, 0x45, 0xd0, 0x72, 0x92, 0xa3,
-	0x99, 0xd0, 0x46, 0xe4, 0x39, 0xba, 0xc4, 0x96, 0x5e, 0x5b, 0x43, 0x89, 0x30, 0xc6, 0x7a, 0x11,
-	0x74, 0x1d, 0x19, 0x7d, 0x87, 0xf6, 0x9d, 0x17, 0xbe, 0x22, 0x76, 0x06, 0x2d, 0x74, 0xce, 0xba,
-	0x41, 0x83, 0x37, 0xce, 0xbb, 0x69, 0x6d, 0x18, 0x83, 0x27, 0xd2, 0x66, 0x38, 0x68, 0xf2, 0xc6,
-	0x79, 0x2b, 0x0d, 0x9a, 0x0d, 0xe0, 0x69, 0x81, 0x44, 0x22, 0xc7, 0x41, 0x14, 0x7a, 0x0f, 0x76,
-	0xd6, 0xdb, 0xce, 0x01, 0x3a, 0xe3, 0x3d, 0x72, 0xf4, 0x09, 0xda, 0x5f, 0x45, 0xae, 0x4d, 0xbe,
-	0x83, 0xaf, 0x75, 0xa1, 0x7d, 0x80, 0xb7, 0xd2, 0xda, 0xb0, 0x17, 0xd0, 0xb6, 0xcb, 0x25, 0xa1,
-	0xdf, 0xe3, 0xf7, 0x6e, 0x76, 0xb6, 0x9d, 0x9f, 0x42, 0x7f, 0xdc, 0xab, 0xc3, 0x5f, 0xea, 0xcb,
-	0xdf, 0xfc, 0x8b, 0xb6, 0xf3, 0xbf, 0x11, 0xdb, 0x40, 0xff, 0xf3, 0xbb, 0x5b, 0x7e, 0x2b, 0x48,
-	0xf1, 0xbb, 0xdf, 0xe4, 0xb1, 0x18, 0xfd, 0x80, 0xd3, 0x8f, 0x56, 0x09, 0x63, 0x90, 0xf8, 0x07,
-	0xd4, 0xc5, 0x42, 0x48, 0xc5, 0x2e, 0x94, 0xf7, 0x25, 0xcd, 0x92, 0x24, 0xd7, 0x5e, 0x55, 0x8b,
-	0x58, 0xda, 0x22, 0x59, 0xa9, 0xfd, 0x69, 0x62, 0x96, 0x72, 0x22, 0x05, 0xa9, 0x09, 0x05, 0xc4,
-	0xf0, 0xa5, 0x59, 0xca, 0x9d, 0xaf, 0xed, 0x9b, 0x02, 0x33, 0x8d, 0x86, 0xbc, 0x58, 0xfb, 0x38,
-	0xc3, 0xab, 0xe8, 0x55, 0x7c, 0x39, 0x3c, 0x39, 0x64, 0x62, 0x7c, 0x58, 0x95, 0x71, 0x86, 0xe3,
-	0x46, 0xf3, 0xea, 0x44, 0x94, 0xe5, 0x5a, 0xcb, 0xb0, 0xd3, 0x64, 0x45, 0xd6, 0xcc, 0x8e, 0x2a,
-	0xa9, 0x80, 0xe8, 0xfa, 0x72, 0xca, 0xee, 0xe1, 0x7d, 0x8a, 0xbe, 0x72, 0x06, 0x33, 0xbe, 0x51,
-	0x68, 0xb8, 0x57, 0xc8, 0x2b, 0x42, 0xc7, 0x33, 0x8b, 0xc4, 0x8d, 0xf5, 0x5c, 0x89, 0x07, 0xe4,
-	0x25, 0xba, 0x42, 0x13, 0x69, 0x6b, 0xb8, 0xb7, 0x5c, 0x48, 0x89, 0x44, 0xa1, 0xd7, 0x21, 0xd9,
-	0xca, 0x49, 0x8c, 0x59, 0x1f, 0x7a, 0xc3, 0x67, 0xb1, 0x28, 0x75, 0x5c, 0x6f, 0x3a, 0x7d, 0xbb,
-	0x1b, 0x71, 0xcd, 0x5e, 0xc3, 0xf8, 0x78, 0xc4, 0x21, 0xf6, 0x38, 0x06, 0x7f, 0x69, 0xf2, 0xc7,
-	0x94, 0xfb, 0x29, 0xf4, 0xa1, 0x7b, 0x23, 0x48, 0xcb, 0x79, 0xe5, 0x15, 0x6b, 0x76, 0x1a, 0xc0,
-	0xa1, 0xfb, 0xcd, 0xfe, 0x44, 0x13, 0x0a, 0xcf, 0x3b, 0xcd, 0x61, 0x6f, 0xa7, 0xac, 0xd3, 0x7f,
-	0xc2, 0x03, 0x79, 0x73, 0xd1, 0x0e, 0x1f, 0x69, 0xfa, 0x3f, 0x00, 0x00, 0xff, 0xff, 0x6f, 0x4b,
-	0xaa, 0x78, 0x8c, 0x02, 0x00, 0x00,
+	// 548 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x52, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0x95, 0x93, 0x36, 0x5f, 0x72, 0x3f, 0x85, 0xb4, 0x43, 0x85, 0xa2, 0x08, 0xa4, 0x51, 0x57,
+	0x51, 0xd4, 0x38, 0xa5, 0xed, 0x2a, 0x02, 0x44, 0x5a, 0x44, 0x11, 0x48, 0x80, 0xdc, 0xae, 0xca,
+	0x22, 0x9a, 0x8c, 0x6f, 0xec, 0x69, 0xec, 0x19, 0x6b, 0xee, 0x38, 0xa5, 0xbc, 0x01, 0xdb, 0x4a,
+	0x3c, 0x23, 0x0b, 0x5e, 0x02, 0x79, 0x9c, 0x8a, 0x45, 0x56, 0xbe, 0xe7, 0xf8, 0xfc, 0x8c, 0x66,
+	0x2e, 0x74, 0x93, 0xcc, 0x2c, 0x44, 0x46, 0x61, 0x61, 0x8d, 0x33, 0xac, 0x29, 0x0a, 0x35, 0x38,
+	0xf2, 0xb3, 0x1c, 0x27, 0xa8, 0xc7, 0x74, 0x27, 0x92, 0x04, 0xed, 0xc4, 0x14, 0x4e, 0x19, 0x4d,
+	0x13, 0xa1, 0xb5, 0x71, 0xc2, 0xcf, 0xb5, 0xe5, 0xf0, 0x1b, 0xb4, 0xae, 0x9c, 0x70, 0x25, 0xb1,
+	0x03, 0xd8, 0x45, 0x6b, 0x8d, 0xed, 0x07, 0x3c, 0x18, 0x76, 0xa2, 0x1a, 0x30, 0x06, 0x3b, 0xd2,
+	0xc4, 0xd8, 0x6f, 0xf0, 0x60, 0xb8, 0x1b, 0xf9, 0x99, 0xf5, 0xe1, 0xbf, 0x1c, 0x89, 0x44, 0x82,
+	0xfd, 0xa6, 0xd7, 0x3e, 0xc2, 0x69, 0xf7, 0x61, 0x06, 0xd0, 0x1e, 0x6d, 0x22, 0x0f, 0x7f, 0x05,
+	0xd0, 0xfa, 0x2a, 0x12, 0xa5, 0x93, 0x2a, 0x3d, 0x53, 0xb9, 0x72, 0x3e, 0x7d, 0x37, 0xaa, 0x01,
+	0x7b, 0x06, 0x2d, 0xb3, 0x5c, 0x12, 0xba, 0x4d, 0xfe, 0x06, 0xb1, 0x17, 0x00, 0x85, 0x48, 0x70,
+	0xee, 0xcc, 0x0a, 0xf5, 0xa6, 0xa4, 0x53, 0x31, 0xd7, 0x15, 0xc1, 0x86, 0xb0, 0x47, 0x2b, 0x55,
+	0xcc, 0x9d, 0x71, 0x22, 0x9b, 0x4b, 0x53, 0x6a, 0xd7, 0xdf, 0xe1, 0xc1, 0xb0, 0x1d, 0x3d, 0xa9,
+	0xf8, 0xeb, 0x8a, 0xbe, 0xa8, 0xd8, 0xe9, 0xc1, 0xc3, 0x6c, 0x1f, 0x7a, 0xa3, 0x6e, 0x7d, 0x8a,
+	0x2f, 0xf5, 0x35, 0x9c, 0xff, 0x69, 0x3e, 0xcc, 0x7e, 0x37, 0xd9, 0x1d, 0xf4, 0x3e, 0xbf, 0xbf,
+	0xe0, 0x17, 0x82, 0x52, 0x7e, 0x75, 0x4f, 0x0e, 0xf3, 0xc3, 0x39, 0xec, 0x7f, 0x34, 0xa9, 0xd0,
+	0x1a, 0x89, 0x7f, 0x40, 0x95, 0x2f, 0x84, 0x4c, 0xd9, 0x51, 0xea, 0x5c, 0x41, 0xd3, 0xc9, 0x24,
+	0x51, 0x2e, 0x2d, 0x17, 0xa1, 0x34, 0xf9, 0xe4, 0x36, 0xdd, 0xfc, 0x9d, 0xe8, 0xa5, 0x1c, 0x4b,
+	0x41, 0xe9, 0x98, 0x7c, 0xc4, 0xe0, 0xb9, 0x5e, 0xca, 0x0a, 0xd7, 0xf0, 0x6d, 0x8e, 0xb1, 0x42,
+	0x4d, 0x4e, 0x64, 0x2e, 0x8c, 0xf1, 0xa4, 0xf9, 0x32, 0x3c, 0x1e, 0xec, 0x3d, 0x7a, 0x42, 0x5c,
+	0xdf, 0x16, 0x61, 0x8c, 0xa3, 0xa0, 0x71, 0xb2, 0x27, 0x8a, 0x22, 0x53, 0xd2, 0xbf, 0xce, 0xe4,
+	0x96, 0x8c, 0x9e, 0x6e, 0x31, 0x91, 0x80, 0xe6, 0xd9, 0xf1, 0x29, 0xbb, 0x81, 0xcb, 0x08, 0x5d,
+	0x69, 0x35, 0xc6, 0xfc, 0x2e, 0x45, 0xcd, 0x5d, 0x8a, 0xbc, 0x24, 0xb4, 0x3c, 0x36, 0x48, 0x5c,
+	0x1b, 0xc7, 0x53, 0xb1, 0x46, 0x5e, 0xa0, 0xcd, 0x15, 0x91, 0x32, 0x9a, 0x3b, 0xc3, 0x85, 0x94,
+	0x48, 0xe4, 0xb5, 0x16, 0xc9, 0x94, 0x56, 0x62, 0xc8, 0x7a, 0xd0, 0x1d, 0xfc, 0x1f, 0x8a, 0x42,
+	0x85, 0xf5, 0x9b, 0x45, 0xef, 0xaa, 0x8a, 0x33, 0xf6, 0x1a, 0x46, 0xdb, 0x15, 0x8f, 0xb6, 0x7f,
+	0x35, 0xf8, 0x5d, 0x91, 0xdb, 0x4e, 0xb9, 0xf9, 0x19, 0x40, 0x0f, 0x3a, 0xe7, 0x82, 0x94, 0x9c,
+	0x95, 0x2e, 0x65, 0x8d, 0x76, 0x00, 0xd7, 0xd0, 0xf9, 0xa4, 0x0c, 0xad, 0x3c, 0x71, 0xd9, 0x6e,
+	0xb0, 0x13, 0x0f, 0xf9, 0xab, 0x55, 0xf5, 0x99, 0xaf, 0xf0, 0xfe, 0xcd, 0x11, 0x37, 0x3a, 0xbb,
+	0xe7, 0x6b, 0x91, 0xa9, 0x98, 0x2f, 0x8d, 0xf5, 0xad, 0x5e, 0x75, 0x85, 0x76, 0xad, 0x24, 0x0e,
+	0xba, 0x95, 0xdb, 0x58, 0xf5, 0xc3, 0xdf, 0x0a, 0x6f, 0x00, 0x87, 0x8e, 0x5f, 0x0a, 0x9f, 0xfa,
+	0xb4, 0xdd, 0xd8, 0x52, 0x2c, 0x5a, 0x7e, 0xd3, 0x4f, 0xff, 0x0e, 0x00, 0x48, 0xf5, 0xc6, 0xda,
+	0x2d, 0x03, 0x00, 0x00,
 }
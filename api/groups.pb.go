@@ -200,8 +200,10 @@ func (m *DeleteGroupRequest) GetId() int32 {
 }
 
 type ListGroupsResponse struct {
-	Groups               []*Group `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
-	TotalCount           int32    `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Groups     []*Group `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	TotalCount int32    `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// empty on the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -246,6 +248,13 @@ func (m *ListGroupsResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (m *ListGroupsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type Group struct {
 	Id                   int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -321,49 +330,51 @@ func init() {
 func init() { proto.RegisterFile("groups.proto", fileDescriptor_6616980d7c5e2870) }
 
 var fileDescriptor_6616980d7c5e2870 = []byte{
-	// 666 bytes of a gzipped FileDescriptorProto
+	// 689 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xcd, 0x6e, 0xd3, 0x4a,
-	0x14, 0xbe, 0x4e, 0x9a, 0xdc, 0xe6, 0x38, 0x69, 0x6e, 0xe7, 0x56, 0xbd, 0x91, 0x7b, 0x25, 0xa6,
-	0x06, 0xa1, 0xca, 0x6a, 0x13, 0x51, 0x58, 0xa0, 0x8a, 0x05, 0x56, 0x81, 0x6c, 0x90, 0x40, 0xa6,
-	0x5d, 0xb0, 0xaa, 0x26, 0xf6, 0xd4, 0x1d, 0xe1, 0xcc, 0x18, 0xcf, 0x38, 0x15, 0x20, 0x58, 0xc0,
-	0x02, 0x89, 0xa5, 0x11, 0xef, 0xc0, 0xfb, 0xf0, 0x0a, 0xf0, 0x1e, 0x28, 0x33, 0x4e, 0x71, 0x92,
-	0x52, 0x56, 0xf6, 0x9c, 0x1f, 0x7f, 0xdf, 0xf9, 0xce, 0xe7, 0x81, 0x76, 0x9c, 0x89, 0x3c, 0x95,
-	0xfd, 0x34, 0x13, 0x4a, 0xa0, 0x3a, 0x49, 0x99, 0xd3, 0x89, 0x13, 0x31, 0x22, 0x49, 0x19, 0x73,
-	0xb6, 0x62, 0x21, 0xe2, 0x84, 0x0e, 0xf4, 0x69, 0x94, 0x9f, 0x0e, 0xe8, 0x38, 0x55, 0xaf, 0xca,
-	0xe4, 0xff, 0x65, 0x92, 0xa4, 0x6c, 0x40, 0x38, 0x17, 0x8a, 0x28, 0x26, 0xf8, 0xac, 0x75, 0x57,
-	0x3f, 0xc2, 0xbd, 0x98, 0xf2, 0x3d, 0x79, 0x4e, 0xe2, 0x98, 0x66, 0x03, 0x91, 0xea, 0x8a, 0xe5,
-	0x6a, 0xf7, 0x2e, 0xac, 0x3f, 0x66, 0x52, 0x0d, 0x35, 0xa1, 0x80, 0xbe, 0xcc, 0xa9, 0x54, 0xe8,
-	0x3a, 0x34, 0x53, 0x12, 0x33, 0x1e, 0xf7, 0x2c, 0x6c, 0xed, 0xd8, 0xfb, 0x76, 0x9f, 0xa4, 0xac,
-	0xff, 0x54, 0x87, 0x82, 0x32, 0xe5, 0x7e, 0xb0, 0x00, 0x1d, 0x66, 0x94, 0x28, 0xaa, 0x9b, 0x67,
-	0xbd, 0x08, 0x56, 0x38, 0x19, 0x53, 0xdd, 0xd9, 0x0a, 0xf4, 0x3b, 0xc2, 0x60, 0x47, 0x54, 0x86,
-	0x19, 0xd3, 0x34, 0x7a, 0x35, 0x9d, 0xaa, 0x86, 0xd0, 0x36, 0xb4, 0x43, 0xc2, 0x4f, 0xc4, 0x84,
-	0x66, 0x51, 0x46, 0xce, 0x7b, 0x75, 0x6c, 0xed, 0xac, 0x06, 0x76, 0x48, 0xf8, 0x93, 0x32, 0x74,
-	0xb0, 0x51, 0xf8, 0xeb, 0xd0, 0xf5, 0x3a, 0x1a, 0x4d, 0x03, 0x33, 0xc1, 0xdd, 0x6d, 0xe8, 0x0e,
-	0xa9, 0x9a, 0x63, 0xb0, 0x06, 0x35, 0x16, 0x69, 0xfc, 0x46, 0x50, 0x63, 0x91, 0x7b, 0x03, 0xd0,
-	0x03, 0x9a, 0xd0, 0x05, 0x9e, 0x8b, 0x55, 0x67, 0x80, 0xaa, 0x42, 0xc8, 0x54, 0x70, 0x49, 0x91,
-	0x0b, 0x4d, 0xb3, 0xab, 0x9e, 0x85, 0xeb, 0x3b, 0xf6, 0x3e, 0x68, 0x25, 0xcc, 0x87, 0xca, 0x0c,
-	0xba, 0x06, 0xb6, 0x12, 0x8a, 0x24, 0x27, 0xa1, 0xc8, 0xb9, 0xd2, 0xd3, 0x35, 0x02, 0xd0, 0xa1,
-	0xc3, 0x69, 0xe4, 0xa0, 0x53, 0xf8, 0x00, 0xab, 0x5e, 0xd3, 0x7c, 0xdb, 0x7d, 0x07, 0x0d, 0xfd,
-	0xb6, 0x48, 0xe1, 0x42, 0xba, 0xda, 0xef, 0xa5, 0xab, 0xff, 0x59, 0xba, 0x95, 0x65, 0xe9, 0xda,
-	0x85, 0xdf, 0x82, 0xbf, 0x3d, 0x03, 0xbb, 0xff, 0xa3, 0x01, 0x46, 0x44, 0xf9, 0x8c, 0x66, 0x13,
-	0x16, 0x52, 0xf4, 0xd5, 0x02, 0xf8, 0x35, 0x3c, 0xda, 0xd4, 0x43, 0x2e, 0xd9, 0xc2, 0xf9, 0x6f,
-	0x29, 0x6e, 0x54, 0x72, 0x59, 0xe1, 0x1f, 0x39, 0x77, 0xa6, 0x09, 0x89, 0x49, 0x92, 0x60, 0x23,
-	0xcc, 0x2e, 0x0e, 0x09, 0xc7, 0x23, 0x8a, 0x13, 0x36, 0x66, 0x8a, 0x46, 0xf8, 0x9c, 0xa9, 0x33,
-	0x6c, 0xdc, 0x83, 0x4b, 0x47, 0x7a, 0xf6, 0xb4, 0xab, 0x6c, 0x18, 0x75, 0xa1, 0x03, 0xad, 0x23,
-	0xf1, 0x82, 0x72, 0x3f, 0x57, 0x67, 0xe8, 0xaf, 0xf7, 0xdf, 0xbe, 0x7f, 0xae, 0xb5, 0x11, 0x0c,
-	0x26, 0xb7, 0x06, 0xa5, 0xd8, 0xaf, 0xc1, 0xae, 0x98, 0x0e, 0x19, 0x4a, 0xcb, 0x36, 0x74, 0x2a,
-	0x8b, 0x72, 0x1f, 0x15, 0x7e, 0xdf, 0xe9, 0x98, 0x22, 0x69, 0xb0, 0xbc, 0xb6, 0x39, 0x9a, 0xd3,
-	0xe5, 0xc0, 0x5d, 0xb7, 0x02, 0x7c, 0x60, 0x79, 0xe8, 0xa3, 0x05, 0xab, 0x33, 0xb3, 0xa1, 0x0d,
-	0x03, 0x30, 0xef, 0xbd, 0x39, 0xd8, 0xe7, 0x85, 0x7f, 0xdf, 0x71, 0x03, 0xaa, 0xf2, 0x8c, 0x4b,
-	0x2c, 0x19, 0x8f, 0x93, 0x12, 0xcf, 0x28, 0x11, 0xb3, 0x09, 0xe5, 0x98, 0x45, 0x5e, 0x6b, 0x48,
-	0xd5, 0x55, 0x44, 0xfe, 0x41, 0x6b, 0x17, 0x44, 0x06, 0x6f, 0x58, 0xf4, 0x16, 0x7d, 0xb2, 0xc0,
-	0x3e, 0x4e, 0xa3, 0x0b, 0x19, 0x2a, 0xb0, 0x73, 0x14, 0xc2, 0xc2, 0x1f, 0x3a, 0x37, 0x4d, 0x65,
-	0x39, 0xf9, 0xae, 0xde, 0xd0, 0x29, 0xa3, 0x49, 0x24, 0xf1, 0x38, 0x97, 0x6a, 0xba, 0x21, 0x49,
-	0x79, 0xe4, 0xb5, 0x4d, 0xdd, 0x55, 0x4c, 0xfe, 0x75, 0x16, 0x98, 0x4c, 0x65, 0xf9, 0x62, 0x81,
-	0x5d, 0xf9, 0xc1, 0xca, 0x9d, 0x2c, 0xff, 0x72, 0xce, 0x66, 0xdf, 0x5c, 0x5c, 0xfd, 0xd9, 0xad,
-	0xd6, 0x7f, 0x38, 0xbd, 0xd5, 0xdc, 0xe3, 0xc2, 0xbf, 0xe7, 0x6c, 0x99, 0x06, 0x79, 0xa9, 0x42,
-	0x6d, 0x93, 0xbc, 0x52, 0x24, 0x6f, 0x81, 0xda, 0xa8, 0xa9, 0x61, 0x6e, 0xff, 0x0c, 0x00, 0x00,
-	0xff, 0xff, 0x23, 0x40, 0x41, 0xdd, 0x6e, 0x05, 0x00, 0x00,
+	0x14, 0xbe, 0x4e, 0x9a, 0xdc, 0xe6, 0x38, 0x69, 0x6e, 0xe7, 0x56, 0xbd, 0x91, 0x7b, 0xa5, 0xeb,
+	0xfa, 0xa2, 0xaa, 0xb2, 0xda, 0x44, 0x14, 0x16, 0xa8, 0x62, 0x81, 0x55, 0x20, 0x1b, 0x24, 0x2a,
+	0xd3, 0x2e, 0x58, 0x45, 0x13, 0xfb, 0xd4, 0x1d, 0xe1, 0x8c, 0x8d, 0x67, 0x92, 0xf2, 0x23, 0x58,
+	0xc0, 0x02, 0x89, 0x15, 0x32, 0xe2, 0x1d, 0x78, 0x1f, 0x5e, 0x01, 0xde, 0x03, 0x79, 0xc6, 0x29,
+	0x69, 0xd3, 0x86, 0x95, 0x67, 0xbe, 0x73, 0x8e, 0xbf, 0x6f, 0xbe, 0x73, 0x66, 0xa0, 0x19, 0x65,
+	0xc9, 0x38, 0x15, 0xdd, 0x34, 0x4b, 0x64, 0x42, 0xaa, 0x34, 0x65, 0x56, 0x2b, 0x8a, 0x93, 0x21,
+	0x8d, 0x4b, 0xcc, 0xda, 0x88, 0x92, 0x24, 0x8a, 0xb1, 0xa7, 0x76, 0xc3, 0xf1, 0x49, 0x0f, 0x47,
+	0xa9, 0x7c, 0x59, 0x06, 0xff, 0x2d, 0x83, 0x34, 0x65, 0x3d, 0xca, 0x79, 0x22, 0xa9, 0x64, 0x09,
+	0x9f, 0x96, 0xee, 0xa8, 0x4f, 0xb0, 0x1b, 0x21, 0xdf, 0x15, 0x67, 0x34, 0x8a, 0x30, 0xeb, 0x25,
+	0xa9, 0xca, 0x98, 0xcf, 0x76, 0xee, 0xc0, 0xea, 0x23, 0x26, 0x64, 0x5f, 0x09, 0xf2, 0xf1, 0xf9,
+	0x18, 0x85, 0x24, 0xff, 0x43, 0x3d, 0xa5, 0x11, 0xe3, 0x51, 0xc7, 0xb0, 0x8d, 0x6d, 0x73, 0xcf,
+	0xec, 0xd2, 0x94, 0x75, 0x0f, 0x15, 0xe4, 0x97, 0x21, 0xe7, 0xbd, 0x01, 0xe4, 0x20, 0x43, 0x2a,
+	0x51, 0x15, 0x4f, 0x6b, 0x09, 0x2c, 0x71, 0x3a, 0x42, 0x55, 0xd9, 0xf0, 0xd5, 0x9a, 0xd8, 0x60,
+	0x86, 0x28, 0x82, 0x8c, 0x29, 0x19, 0x9d, 0x8a, 0x0a, 0xcd, 0x42, 0x64, 0x13, 0x9a, 0x01, 0xe5,
+	0x83, 0x64, 0x82, 0x59, 0x98, 0xd1, 0xb3, 0x4e, 0xd5, 0x36, 0xb6, 0x97, 0x7d, 0x33, 0xa0, 0xfc,
+	0x71, 0x09, 0xed, 0xaf, 0xe5, 0xde, 0x2a, 0xb4, 0xdd, 0x96, 0x62, 0x53, 0xc4, 0x2c, 0xe1, 0xce,
+	0x26, 0xb4, 0xfb, 0x28, 0x2f, 0x28, 0x58, 0x81, 0x0a, 0x0b, 0x15, 0x7f, 0xcd, 0xaf, 0xb0, 0xd0,
+	0xb9, 0x01, 0xe4, 0x3e, 0xc6, 0x28, 0x71, 0x61, 0xd6, 0x27, 0x03, 0xc8, 0xac, 0x13, 0x22, 0x4d,
+	0xb8, 0x40, 0xe2, 0x40, 0x5d, 0x37, 0xab, 0x63, 0xd8, 0xd5, 0x6d, 0x73, 0x0f, 0x94, 0x15, 0xfa,
+	0x4f, 0x65, 0x84, 0xfc, 0x07, 0xa6, 0x4c, 0x24, 0x8d, 0x07, 0x41, 0x32, 0xe6, 0x52, 0x1d, 0xaf,
+	0xe6, 0x83, 0x82, 0x0e, 0x0a, 0x84, 0x6c, 0x41, 0x9b, 0xe3, 0x0b, 0x39, 0x48, 0x69, 0x84, 0x03,
+	0x99, 0x3c, 0x43, 0xae, 0x0e, 0xd8, 0xf0, 0x5b, 0x05, 0x7c, 0x48, 0x23, 0x3c, 0x2a, 0xc0, 0xfd,
+	0x56, 0xee, 0x01, 0x2c, 0xbb, 0x75, 0xad, 0xc1, 0x79, 0x0b, 0x35, 0xb5, 0xba, 0xac, 0xf5, 0xdc,
+	0xe3, 0xca, 0xf5, 0x1e, 0x57, 0x7f, 0xef, 0xf1, 0xd2, 0xbc, 0xc7, 0xcd, 0xdc, 0x6b, 0xc0, 0x9f,
+	0xae, 0xa6, 0xdd, 0xfb, 0x51, 0x03, 0xed, 0xb6, 0x78, 0x82, 0xd9, 0x84, 0x05, 0x48, 0xbe, 0x1a,
+	0x00, 0xbf, 0x4c, 0x22, 0xeb, 0xca, 0x8c, 0xb9, 0xf9, 0xb1, 0xfe, 0x99, 0xc3, 0xb5, 0x9b, 0x0e,
+	0xcb, 0xbd, 0x23, 0xeb, 0x76, 0x11, 0x10, 0x36, 0x8d, 0x63, 0x5b, 0x1b, 0xb8, 0x63, 0x07, 0x94,
+	0xdb, 0x43, 0xb4, 0x63, 0x36, 0x62, 0x12, 0x43, 0xfb, 0x8c, 0xc9, 0x53, 0x5b, 0x8f, 0x99, 0x5d,
+	0x8e, 0xae, 0x6b, 0x16, 0x55, 0x65, 0xc1, 0xb0, 0x0d, 0x2d, 0x68, 0x28, 0xcf, 0xbc, 0xb1, 0x3c,
+	0x25, 0x7f, 0xbc, 0xfb, 0xf6, 0xfd, 0x73, 0xa5, 0x49, 0xa0, 0x37, 0xb9, 0xd9, 0x2b, 0x9b, 0xf2,
+	0x0a, 0xcc, 0x99, 0xe9, 0x24, 0x5a, 0xd2, 0xfc, 0xbc, 0x5a, 0x33, 0x0d, 0x75, 0x1e, 0xe6, 0x5e,
+	0xd7, 0x6a, 0xe9, 0x24, 0xa1, 0xb9, 0xdc, 0xa6, 0xde, 0xea, 0xdd, 0xd5, 0xc4, 0x6d, 0x67, 0x86,
+	0x78, 0xdf, 0x70, 0xc9, 0x07, 0x03, 0x96, 0xa7, 0x53, 0x49, 0xd6, 0x34, 0x01, 0xca, 0x6b, 0x69,
+	0x9f, 0xe6, 0xde, 0x3d, 0xcb, 0xf1, 0x51, 0x8e, 0x33, 0x2e, 0x6c, 0xc1, 0x78, 0x14, 0x97, 0x7c,
+	0xda, 0x89, 0x88, 0x4d, 0x90, 0xdb, 0x2c, 0x74, 0x1b, 0x7d, 0x94, 0x8b, 0x84, 0xfc, 0x45, 0x56,
+	0xce, 0x85, 0xf4, 0x5e, 0xb3, 0xf0, 0x0d, 0xf9, 0x68, 0x80, 0x79, 0x9c, 0x86, 0xe7, 0x36, 0xcc,
+	0xd0, 0x5e, 0x90, 0x10, 0xe4, 0x5e, 0xdf, 0xda, 0xd2, 0x99, 0xe5, 0xc9, 0x77, 0x54, 0x87, 0x4e,
+	0x18, 0xc6, 0xa1, 0xb0, 0x47, 0x63, 0x21, 0x8b, 0x0e, 0x09, 0xe4, 0xa1, 0xdb, 0xd4, 0x79, 0x8b,
+	0x94, 0xfc, 0x6d, 0x5d, 0x52, 0x52, 0xd8, 0xf2, 0xc5, 0x00, 0x73, 0xe6, 0x26, 0x96, 0x3d, 0x99,
+	0xbf, 0x9b, 0xd6, 0x7a, 0x57, 0xbf, 0x70, 0xdd, 0xe9, 0xf3, 0xd7, 0x7d, 0x50, 0x3c, 0x7f, 0xce,
+	0x71, 0xee, 0xdd, 0xb5, 0x36, 0x74, 0x81, 0xb8, 0xd2, 0xa1, 0xa6, 0x0e, 0x2e, 0x34, 0xc9, 0xbd,
+	0x24, 0x6d, 0x58, 0x57, 0x34, 0xb7, 0x7e, 0x0e, 0x00, 0xcd, 0x8c, 0xbb, 0x1a, 0x97, 0x05, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
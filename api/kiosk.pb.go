@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: kiosk.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetKioskBalanceRequest struct {
+	NfcChipId string `protobuf:"bytes,1,opt,name=nfc_chip_id,json=nfcChipId,proto3" json:"nfc_chip_id,omitempty"`
+	// transaction_limit is the number of transactions that are returned, defaults to 5 and is at most 20
+	TransactionLimit     int32    `protobuf:"varint,2,opt,name=transaction_limit,json=transactionLimit,proto3" json:"transaction_limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetKioskBalanceRequest) Reset()         { *m = GetKioskBalanceRequest{} }
+func (m *GetKioskBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*GetKioskBalanceRequest) ProtoMessage()    {}
+func (*GetKioskBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8298087f9dc3fed8, []int{0}
+}
+
+func (m *GetKioskBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetKioskBalanceRequest.Unmarshal(m, b)
+}
+func (m *GetKioskBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetKioskBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *GetKioskBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetKioskBalanceRequest.Merge(m, src)
+}
+func (m *GetKioskBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_GetKioskBalanceRequest.Size(m)
+}
+func (m *GetKioskBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetKioskBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetKioskBalanceRequest proto.InternalMessageInfo
+
+func (m *GetKioskBalanceRequest) GetNfcChipId() string {
+	if m != nil {
+		return m.NfcChipId
+	}
+	return ""
+}
+
+func (m *GetKioskBalanceRequest) GetTransactionLimit() int32 {
+	if m != nil {
+		return m.TransactionLimit
+	}
+	return 0
+}
+
+type KioskBalance struct {
+	AccountName string         `protobuf:"bytes,1,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Saldo       float64        `protobuf:"fixed64,2,opt,name=saldo,proto3" json:"saldo,omitempty"`
+	Status      Account_Status `protobuf:"varint,3,opt,name=status,proto3,enum=api.Account_Status" json:"status,omitempty"`
+	// transactions are the last transactions of the account, newest first
+	Transactions         []*KioskTransaction `protobuf:"bytes,4,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *KioskBalance) Reset()         { *m = KioskBalance{} }
+func (m *KioskBalance) String() string { return proto.CompactTextString(m) }
+func (*KioskBalance) ProtoMessage()    {}
+func (*KioskBalance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8298087f9dc3fed8, []int{1}
+}
+
+func (m *KioskBalance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KioskBalance.Unmarshal(m, b)
+}
+func (m *KioskBalance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KioskBalance.Marshal(b, m, deterministic)
+}
+func (m *KioskBalance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KioskBalance.Merge(m, src)
+}
+func (m *KioskBalance) XXX_Size() int {
+	return xxx_messageInfo_KioskBalance.Size(m)
+}
+func (m *KioskBalance) XXX_DiscardUnknown() {
+	xxx_messageInfo_KioskBalance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KioskBalance proto.InternalMessageInfo
+
+func (m *KioskBalance) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *KioskBalance) GetSaldo() float64 {
+	if m != nil {
+		return m.Saldo
+	}
+	return 0
+}
+
+func (m *KioskBalance) GetStatus() Account_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Account_ACTIVE
+}
+
+func (m *KioskBalance) GetTransactions() []*KioskTransaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type KioskTransaction struct {
+	Amount               float64              `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	NewSaldo             float64              `protobuf:"fixed64,2,opt,name=new_saldo,json=newSaldo,proto3" json:"new_saldo,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KioskTransaction) Reset()         { *m = KioskTransaction{} }
+func (m *KioskTransaction) String() string { return proto.CompactTextString(m) }
+func (*KioskTransaction) ProtoMessage()    {}
+func (*KioskTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8298087f9dc3fed8, []int{2}
+}
+
+func (m *KioskTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KioskTransaction.Unmarshal(m, b)
+}
+func (m *KioskTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KioskTransaction.Marshal(b, m, deterministic)
+}
+func (m *KioskTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KioskTransaction.Merge(m, src)
+}
+func (m *KioskTransaction) XXX_Size() int {
+	return xxx_messageInfo_KioskTransaction.Size(m)
+}
+func (m *KioskTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_KioskTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KioskTransaction proto.InternalMessageInfo
+
+func (m *KioskTransaction) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *KioskTransaction) GetNewSaldo() float64 {
+	if m != nil {
+		return m.NewSaldo
+	}
+	return 0
+}
+
+func (m *KioskTransaction) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetKioskBalanceRequest)(nil), "api.GetKioskBalanceRequest")
+	proto.RegisterType((*KioskBalance)(nil), "api.KioskBalance")
+	proto.RegisterType((*KioskTransaction)(nil), "api.KioskTransaction")
+}
+
+func init() { proto.RegisterFile("kiosk.proto", fileDescriptor_8298087f9dc3fed8) }
+
+var fileDescriptor_8298087f9dc3fed8 = []byte{
+	// 509 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x4d, 0x92, 0xd2, 0x40,
+	0x14, 0x36, 0xe0, 0xa0, 0x34, 0x88, 0x4c, 0xa3, 0x23, 0x32, 0x96, 0xb6, 0xac, 0xa8, 0xf9, 0x49,
+	0x4a, 0x74, 0xa3, 0x3b, 0x74, 0x41, 0x59, 0x5a, 0x2e, 0x02, 0xae, 0xa9, 0xa6, 0x79, 0x84, 0x2e,
+	0x92, 0xee, 0x98, 0x7e, 0x19, 0x16, 0xd6, 0x2c, 0xf4, 0x00, 0x2e, 0xc6, 0x93, 0x78, 0x07, 0x6f,
+	0xe0, 0x15, 0x3c, 0x80, 0x47, 0xb0, 0xd2, 0x49, 0x6a, 0x32, 0x3a, 0xab, 0x54, 0x7f, 0xef, 0x7b,
+	0xfd, 0xbe, 0xef, 0x7d, 0x1d, 0xd2, 0xda, 0x4a, 0x6d, 0xb6, 0x6e, 0x9c, 0x68, 0xd4, 0xb4, 0xce,
+	0x63, 0x39, 0xe8, 0x70, 0x21, 0x74, 0xaa, 0xd0, 0xe4, 0xe0, 0xe0, 0x49, 0xa0, 0x75, 0x10, 0x82,
+	0x67, 0x4f, 0xcb, 0x74, 0xed, 0xa1, 0x8c, 0xc0, 0x20, 0x8f, 0xe2, 0x82, 0xf0, 0xa8, 0x20, 0xf0,
+	0x58, 0x7a, 0x5c, 0x29, 0x8d, 0x1c, 0xa5, 0x56, 0x65, 0xfb, 0x89, 0xfd, 0x88, 0xd3, 0x00, 0xd4,
+	0xa9, 0xd9, 0xf1, 0x20, 0x80, 0xc4, 0xd3, 0xb1, 0x65, 0xfc, 0xcf, 0x1e, 0x02, 0x39, 0x98, 0x02,
+	0xbe, 0xcb, 0x34, 0xbd, 0xe6, 0x21, 0x57, 0x02, 0x7c, 0xf8, 0x94, 0x82, 0x41, 0xfa, 0x98, 0xb4,
+	0xd4, 0x5a, 0x2c, 0xc4, 0x46, 0xc6, 0x0b, 0xb9, 0xea, 0x3b, 0xcc, 0x19, 0x35, 0xfd, 0xa6, 0x5a,
+	0x8b, 0x37, 0x1b, 0x19, 0xbf, 0x5d, 0xd1, 0x63, 0xb2, 0x8f, 0x09, 0x57, 0x86, 0x8b, 0xec, 0xbe,
+	0x45, 0x28, 0x23, 0x89, 0xfd, 0x1a, 0x73, 0x46, 0x7b, 0x7e, 0xb7, 0x52, 0x78, 0x9f, 0xe1, 0xc3,
+	0x9f, 0x0e, 0x69, 0x57, 0x87, 0xd0, 0xa7, 0xa4, 0x5d, 0xd8, 0x5e, 0x28, 0x1e, 0x41, 0x71, 0x7d,
+	0xab, 0xc0, 0x3e, 0xf0, 0x08, 0xe8, 0x3d, 0xb2, 0x67, 0x78, 0xb8, 0xd2, 0xf6, 0x52, 0xc7, 0xcf,
+	0x0f, 0xf4, 0x98, 0x34, 0x0c, 0x72, 0x4c, 0x4d, 0xbf, 0xce, 0x9c, 0x51, 0x67, 0xdc, 0x73, 0x79,
+	0x2c, 0xdd, 0x49, 0xde, 0xe7, 0xce, 0x6c, 0xc9, 0x2f, 0x28, 0xf4, 0x25, 0x69, 0x57, 0xa4, 0x98,
+	0xfe, 0x4d, 0x56, 0x1f, 0xb5, 0xc6, 0xf7, 0x6d, 0x8b, 0x95, 0x33, 0xbf, 0xac, 0xfa, 0x57, 0xa8,
+	0xaf, 0x7a, 0x17, 0x93, 0x2e, 0xe9, 0x1c, 0x5d, 0x51, 0x3d, 0x3c, 0x27, 0xdd, 0x7f, 0xdb, 0xe8,
+	0x01, 0x69, 0xf0, 0x28, 0x1b, 0x6e, 0x3d, 0x38, 0x7e, 0x71, 0xa2, 0x87, 0xa4, 0xa9, 0x60, 0xb7,
+	0xa8, 0x5a, 0xb8, 0xad, 0x60, 0x37, 0xb3, 0x2e, 0x5e, 0x90, 0x5b, 0x22, 0x01, 0x8e, 0xb0, 0xb2,
+	0x36, 0x5a, 0xe3, 0x81, 0x9b, 0x87, 0xea, 0x96, 0xa9, 0xbb, 0xf3, 0x32, 0x75, 0xbf, 0xa4, 0x8e,
+	0xbf, 0xd4, 0x8a, 0x2d, 0xce, 0x20, 0x39, 0x93, 0x02, 0xe8, 0x1f, 0x87, 0x90, 0x29, 0x60, 0xb9,
+	0xd4, 0x43, 0x6b, 0xec, 0xfa, 0x3c, 0x07, 0xfb, 0x97, 0xae, 0x4b, 0x3b, 0x3f, 0x9c, 0x8b, 0xc9,
+	0x37, 0x67, 0xf0, 0xd1, 0x07, 0x4c, 0x13, 0x65, 0x58, 0x96, 0xc4, 0x09, 0xb3, 0x8a, 0x19, 0x57,
+	0x2b, 0x86, 0x1b, 0x60, 0x21, 0x37, 0xc8, 0xaa, 0x9b, 0x61, 0x7a, 0x6d, 0x0b, 0x45, 0x54, 0x0c,
+	0x37, 0x1c, 0xd9, 0x12, 0x42, 0xad, 0x02, 0xc3, 0x50, 0xdb, 0x5a, 0xf6, 0x68, 0x8e, 0x7a, 0x53,
+	0x40, 0xb6, 0xcc, 0x47, 0x65, 0x4d, 0x19, 0xb8, 0xbc, 0x4b, 0xee, 0x90, 0xa6, 0x15, 0x31, 0x49,
+	0x71, 0x43, 0x6f, 0xe4, 0xc0, 0x5c, 0x6f, 0x41, 0xe5, 0xc0, 0xd7, 0x5f, 0xbf, 0xbf, 0xd7, 0x1e,
+	0xd2, 0x07, 0xde, 0xd9, 0x33, 0xcf, 0xfe, 0x2a, 0xde, 0xe7, 0xca, 0x33, 0x3c, 0x5f, 0x36, 0xec,
+	0x82, 0x9e, 0xff, 0x1d, 0x00, 0xb1, 0x73, 0x5a, 0xb9, 0x48, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// KioskServiceClient is the client API for KioskService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type KioskServiceClient interface {
+	GetBalance(ctx context.Context, in *GetKioskBalanceRequest, opts ...grpc.CallOption) (*KioskBalance, error)
+}
+
+type kioskServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewKioskServiceClient(cc *grpc.ClientConn) KioskServiceClient {
+	return &kioskServiceClient{cc}
+}
+
+func (c *kioskServiceClient) GetBalance(ctx context.Context, in *GetKioskBalanceRequest, opts ...grpc.CallOption) (*KioskBalance, error) {
+	out := new(KioskBalance)
+	err := c.cc.Invoke(ctx, "/api.KioskService/GetBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KioskServiceServer is the server API for KioskService service.
+type KioskServiceServer interface {
+	GetBalance(context.Context, *GetKioskBalanceRequest) (*KioskBalance, error)
+}
+
+// UnimplementedKioskServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedKioskServiceServer struct {
+}
+
+func (*UnimplementedKioskServiceServer) GetBalance(ctx context.Context, req *GetKioskBalanceRequest) (*KioskBalance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+
+func RegisterKioskServiceServer(s *grpc.Server, srv KioskServiceServer) {
+	s.RegisterService(&_KioskService_serviceDesc, srv)
+}
+
+func _KioskService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKioskBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KioskServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.KioskService/GetBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KioskServiceServer).GetBalance(ctx, req.(*GetKioskBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _KioskService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.KioskService",
+	HandlerType: (*KioskServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBalance",
+			Handler:    _KioskService_GetBalance_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "kiosk.proto",
+}
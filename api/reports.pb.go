@@ -0,0 +1,728 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: reports.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type RevenueRequest_Grouping int32
+
+const (
+	RevenueRequest_DAY      RevenueRequest_Grouping = 0
+	RevenueRequest_HOUR     RevenueRequest_Grouping = 1
+	RevenueRequest_GROUP    RevenueRequest_Grouping = 2
+	RevenueRequest_TERMINAL RevenueRequest_Grouping = 3
+)
+
+var RevenueRequest_Grouping_name = map[int32]string{
+	0: "DAY",
+	1: "HOUR",
+	2: "GROUP",
+	3: "TERMINAL",
+}
+
+var RevenueRequest_Grouping_value = map[string]int32{
+	"DAY":      0,
+	"HOUR":     1,
+	"GROUP":    2,
+	"TERMINAL": 3,
+}
+
+func (x RevenueRequest_Grouping) String() string {
+	return proto.EnumName(RevenueRequest_Grouping_name, int32(x))
+}
+
+func (RevenueRequest_Grouping) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{1, 0}
+}
+
+// TimeRange limits a report to transactions created in [from, to), unset from or to is unbounded
+type TimeRange struct {
+	From                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TimeRange) Reset()         { *m = TimeRange{} }
+func (m *TimeRange) String() string { return proto.CompactTextString(m) }
+func (*TimeRange) ProtoMessage()    {}
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{0}
+}
+
+func (m *TimeRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeRange.Unmarshal(m, b)
+}
+func (m *TimeRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeRange.Marshal(b, m, deterministic)
+}
+func (m *TimeRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeRange.Merge(m, src)
+}
+func (m *TimeRange) XXX_Size() int {
+	return xxx_messageInfo_TimeRange.Size(m)
+}
+func (m *TimeRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeRange proto.InternalMessageInfo
+
+func (m *TimeRange) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *TimeRange) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+type RevenueRequest struct {
+	Range                *TimeRange              `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	GroupBy              RevenueRequest_Grouping `protobuf:"varint,2,opt,name=group_by,json=groupBy,proto3,enum=api.RevenueRequest_Grouping" json:"group_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *RevenueRequest) Reset()         { *m = RevenueRequest{} }
+func (m *RevenueRequest) String() string { return proto.CompactTextString(m) }
+func (*RevenueRequest) ProtoMessage()    {}
+func (*RevenueRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{1}
+}
+
+func (m *RevenueRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevenueRequest.Unmarshal(m, b)
+}
+func (m *RevenueRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevenueRequest.Marshal(b, m, deterministic)
+}
+func (m *RevenueRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevenueRequest.Merge(m, src)
+}
+func (m *RevenueRequest) XXX_Size() int {
+	return xxx_messageInfo_RevenueRequest.Size(m)
+}
+func (m *RevenueRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevenueRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevenueRequest proto.InternalMessageInfo
+
+func (m *RevenueRequest) GetRange() *TimeRange {
+	if m != nil {
+		return m.Range
+	}
+	return nil
+}
+
+func (m *RevenueRequest) GetGroupBy() RevenueRequest_Grouping {
+	if m != nil {
+		return m.GroupBy
+	}
+	return RevenueRequest_DAY
+}
+
+type RevenueReport struct {
+	Buckets              []*RevenueBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Total                float64          `protobuf:"fixed64,2,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RevenueReport) Reset()         { *m = RevenueReport{} }
+func (m *RevenueReport) String() string { return proto.CompactTextString(m) }
+func (*RevenueReport) ProtoMessage()    {}
+func (*RevenueReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{2}
+}
+
+func (m *RevenueReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevenueReport.Unmarshal(m, b)
+}
+func (m *RevenueReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevenueReport.Marshal(b, m, deterministic)
+}
+func (m *RevenueReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevenueReport.Merge(m, src)
+}
+func (m *RevenueReport) XXX_Size() int {
+	return xxx_messageInfo_RevenueReport.Size(m)
+}
+func (m *RevenueReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevenueReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevenueReport proto.InternalMessageInfo
+
+func (m *RevenueReport) GetBuckets() []*RevenueBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *RevenueReport) GetTotal() float64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+// RevenueBucket is one row of the revenue report, depending on the grouping either start, group or terminal_id is set
+type RevenueBucket struct {
+	Start                *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	GroupId              int32                `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupName            string               `protobuf:"bytes,3,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	TerminalId           string               `protobuf:"bytes,4,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	Revenue              float64              `protobuf:"fixed64,5,opt,name=revenue,proto3" json:"revenue,omitempty"`
+	TransactionCount     int32                `protobuf:"varint,6,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RevenueBucket) Reset()         { *m = RevenueBucket{} }
+func (m *RevenueBucket) String() string { return proto.CompactTextString(m) }
+func (*RevenueBucket) ProtoMessage()    {}
+func (*RevenueBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{3}
+}
+
+func (m *RevenueBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevenueBucket.Unmarshal(m, b)
+}
+func (m *RevenueBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevenueBucket.Marshal(b, m, deterministic)
+}
+func (m *RevenueBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevenueBucket.Merge(m, src)
+}
+func (m *RevenueBucket) XXX_Size() int {
+	return xxx_messageInfo_RevenueBucket.Size(m)
+}
+func (m *RevenueBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevenueBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevenueBucket proto.InternalMessageInfo
+
+func (m *RevenueBucket) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *RevenueBucket) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *RevenueBucket) GetGroupName() string {
+	if m != nil {
+		return m.GroupName
+	}
+	return ""
+}
+
+func (m *RevenueBucket) GetTerminalId() string {
+	if m != nil {
+		return m.TerminalId
+	}
+	return ""
+}
+
+func (m *RevenueBucket) GetRevenue() float64 {
+	if m != nil {
+		return m.Revenue
+	}
+	return 0
+}
+
+func (m *RevenueBucket) GetTransactionCount() int32 {
+	if m != nil {
+		return m.TransactionCount
+	}
+	return 0
+}
+
+type CashFlowReport struct {
+	TopUps               float64  `protobuf:"fixed64,1,opt,name=top_ups,json=topUps,proto3" json:"top_ups,omitempty"`
+	TopUpCount           int32    `protobuf:"varint,2,opt,name=top_up_count,json=topUpCount,proto3" json:"top_up_count,omitempty"`
+	Purchases            float64  `protobuf:"fixed64,3,opt,name=purchases,proto3" json:"purchases,omitempty"`
+	PurchaseCount        int32    `protobuf:"varint,4,opt,name=purchase_count,json=purchaseCount,proto3" json:"purchase_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CashFlowReport) Reset()         { *m = CashFlowReport{} }
+func (m *CashFlowReport) String() string { return proto.CompactTextString(m) }
+func (*CashFlowReport) ProtoMessage()    {}
+func (*CashFlowReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{4}
+}
+
+func (m *CashFlowReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CashFlowReport.Unmarshal(m, b)
+}
+func (m *CashFlowReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CashFlowReport.Marshal(b, m, deterministic)
+}
+func (m *CashFlowReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CashFlowReport.Merge(m, src)
+}
+func (m *CashFlowReport) XXX_Size() int {
+	return xxx_messageInfo_CashFlowReport.Size(m)
+}
+func (m *CashFlowReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CashFlowReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CashFlowReport proto.InternalMessageInfo
+
+func (m *CashFlowReport) GetTopUps() float64 {
+	if m != nil {
+		return m.TopUps
+	}
+	return 0
+}
+
+func (m *CashFlowReport) GetTopUpCount() int32 {
+	if m != nil {
+		return m.TopUpCount
+	}
+	return 0
+}
+
+func (m *CashFlowReport) GetPurchases() float64 {
+	if m != nil {
+		return m.Purchases
+	}
+	return 0
+}
+
+func (m *CashFlowReport) GetPurchaseCount() int32 {
+	if m != nil {
+		return m.PurchaseCount
+	}
+	return 0
+}
+
+type BalanceReport struct {
+	Total                float64  `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
+	AccountCount         int32    `protobuf:"varint,2,opt,name=account_count,json=accountCount,proto3" json:"account_count,omitempty"`
+	OverdrawnTotal       float64  `protobuf:"fixed64,3,opt,name=overdrawn_total,json=overdrawnTotal,proto3" json:"overdrawn_total,omitempty"`
+	OverdrawnCount       int32    `protobuf:"varint,4,opt,name=overdrawn_count,json=overdrawnCount,proto3" json:"overdrawn_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BalanceReport) Reset()         { *m = BalanceReport{} }
+func (m *BalanceReport) String() string { return proto.CompactTextString(m) }
+func (*BalanceReport) ProtoMessage()    {}
+func (*BalanceReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{5}
+}
+
+func (m *BalanceReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BalanceReport.Unmarshal(m, b)
+}
+func (m *BalanceReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BalanceReport.Marshal(b, m, deterministic)
+}
+func (m *BalanceReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceReport.Merge(m, src)
+}
+func (m *BalanceReport) XXX_Size() int {
+	return xxx_messageInfo_BalanceReport.Size(m)
+}
+func (m *BalanceReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceReport proto.InternalMessageInfo
+
+func (m *BalanceReport) GetTotal() float64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *BalanceReport) GetAccountCount() int32 {
+	if m != nil {
+		return m.AccountCount
+	}
+	return 0
+}
+
+func (m *BalanceReport) GetOverdrawnTotal() float64 {
+	if m != nil {
+		return m.OverdrawnTotal
+	}
+	return 0
+}
+
+func (m *BalanceReport) GetOverdrawnCount() int32 {
+	if m != nil {
+		return m.OverdrawnCount
+	}
+	return 0
+}
+
+type AverageSpendReport struct {
+	Average              float64  `protobuf:"fixed64,1,opt,name=average,proto3" json:"average,omitempty"`
+	Total                float64  `protobuf:"fixed64,2,opt,name=total,proto3" json:"total,omitempty"`
+	AccountCount         int32    `protobuf:"varint,3,opt,name=account_count,json=accountCount,proto3" json:"account_count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AverageSpendReport) Reset()         { *m = AverageSpendReport{} }
+func (m *AverageSpendReport) String() string { return proto.CompactTextString(m) }
+func (*AverageSpendReport) ProtoMessage()    {}
+func (*AverageSpendReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b94ab03de429a8, []int{6}
+}
+
+func (m *AverageSpendReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AverageSpendReport.Unmarshal(m, b)
+}
+func (m *AverageSpendReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AverageSpendReport.Marshal(b, m, deterministic)
+}
+func (m *AverageSpendReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AverageSpendReport.Merge(m, src)
+}
+func (m *AverageSpendReport) XXX_Size() int {
+	return xxx_messageInfo_AverageSpendReport.Size(m)
+}
+func (m *AverageSpendReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_AverageSpendReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AverageSpendReport proto.InternalMessageInfo
+
+func (m *AverageSpendReport) GetAverage() float64 {
+	if m != nil {
+		return m.Average
+	}
+	return 0
+}
+
+func (m *AverageSpendReport) GetTotal() float64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *AverageSpendReport) GetAccountCount() int32 {
+	if m != nil {
+		return m.AccountCount
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("api.RevenueRequest_Grouping", RevenueRequest_Grouping_name, RevenueRequest_Grouping_value)
+	proto.RegisterType((*TimeRange)(nil), "api.TimeRange")
+	proto.RegisterType((*RevenueRequest)(nil), "api.RevenueRequest")
+	proto.RegisterType((*RevenueReport)(nil), "api.RevenueReport")
+	proto.RegisterType((*RevenueBucket)(nil), "api.RevenueBucket")
+	proto.RegisterType((*CashFlowReport)(nil), "api.CashFlowReport")
+	proto.RegisterType((*BalanceReport)(nil), "api.BalanceReport")
+	proto.RegisterType((*AverageSpendReport)(nil), "api.AverageSpendReport")
+}
+
+func init() { proto.RegisterFile("reports.proto", fileDescriptor_66b94ab03de429a8) }
+
+var fileDescriptor_66b94ab03de429a8 = []byte{
+	// 966 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
+	0x14, 0xde, 0x69, 0x9a, 0xa6, 0x79, 0xdd, 0xb4, 0xd9, 0xd9, 0x96, 0xa6, 0xa6, 0x68, 0x47, 0x01,
+	0x44, 0x15, 0xf2, 0x63, 0x29, 0x12, 0x48, 0xbd, 0xb9, 0x05, 0xb2, 0x95, 0x96, 0x2d, 0x72, 0xd3,
+	0x03, 0xa7, 0x6a, 0x62, 0x4f, 0x1d, 0xab, 0xf1, 0x8c, 0x99, 0x19, 0xa7, 0xea, 0x85, 0x03, 0xe2,
+	0xc2, 0x35, 0x9c, 0x90, 0xb8, 0x23, 0xae, 0x48, 0xfc, 0x01, 0xfc, 0x0d, 0x48, 0x1c, 0xb8, 0xc2,
+	0x1f, 0x82, 0x3c, 0x63, 0x67, 0x93, 0xa6, 0x2a, 0x70, 0x6a, 0xe7, 0xbd, 0xcf, 0xdf, 0xfb, 0x3e,
+	0xbf, 0xcf, 0x13, 0xa8, 0x49, 0x96, 0x08, 0xa9, 0x55, 0x37, 0x91, 0x42, 0x0b, 0x5c, 0xa2, 0x49,
+	0xe4, 0x3c, 0x0b, 0x85, 0x08, 0xc7, 0xac, 0x67, 0x4a, 0xc3, 0xf4, 0xaa, 0xa7, 0xa3, 0x98, 0x29,
+	0x4d, 0xe3, 0xc4, 0xa2, 0x9c, 0xfd, 0x1c, 0x40, 0x93, 0xa8, 0x47, 0x39, 0x17, 0x9a, 0xea, 0x48,
+	0xf0, 0x9c, 0xc3, 0x69, 0x9b, 0x3f, 0x7e, 0x27, 0x64, 0xbc, 0xa3, 0x6e, 0x68, 0x18, 0x32, 0xd9,
+	0x13, 0x89, 0x41, 0x2c, 0xa3, 0x9b, 0x21, 0x54, 0x07, 0x51, 0xcc, 0x3c, 0xca, 0x43, 0x86, 0xbb,
+	0xb0, 0x7a, 0x25, 0x45, 0xdc, 0x40, 0x04, 0x1d, 0x6c, 0x1c, 0x3a, 0x5d, 0x3b, 0xa7, 0x5b, 0x08,
+	0xe9, 0x0e, 0x0a, 0x21, 0x9e, 0xc1, 0xe1, 0x16, 0xac, 0x68, 0xd1, 0x58, 0xf9, 0x57, 0xf4, 0x8a,
+	0x16, 0xcd, 0x9f, 0x10, 0x6c, 0x7a, 0x6c, 0xc2, 0x78, 0xca, 0x3c, 0xf6, 0x55, 0xca, 0x94, 0xc6,
+	0xef, 0x40, 0x59, 0x66, 0x73, 0xf3, 0x79, 0x9b, 0x5d, 0x9a, 0x44, 0xdd, 0x99, 0x1a, 0xcf, 0x36,
+	0xf1, 0xc7, 0xb0, 0x1e, 0x4a, 0x91, 0x26, 0x97, 0xc3, 0x5b, 0x33, 0x6a, 0xf3, 0x70, 0xdf, 0x00,
+	0x17, 0xc9, 0xba, 0xfd, 0x0c, 0x13, 0xf1, 0xd0, 0xab, 0x18, 0xf4, 0xf1, 0x6d, 0xf3, 0x23, 0x58,
+	0x2f, 0x8a, 0xb8, 0x02, 0xa5, 0x4f, 0xdc, 0x2f, 0xeb, 0x8f, 0xf0, 0x3a, 0xac, 0xbe, 0x38, 0xbb,
+	0xf0, 0xea, 0x08, 0x57, 0xa1, 0xdc, 0xf7, 0xce, 0x2e, 0xbe, 0xa8, 0xaf, 0xe0, 0xc7, 0xb0, 0x3e,
+	0xf8, 0xd4, 0xfb, 0xfc, 0xf4, 0x95, 0xfb, 0xb2, 0x5e, 0x6a, 0x46, 0x50, 0x9b, 0x71, 0x67, 0xcb,
+	0xc1, 0x6d, 0xa8, 0x0c, 0x53, 0xff, 0x9a, 0x69, 0xd5, 0x40, 0xa4, 0x74, 0xb0, 0x71, 0x88, 0xe7,
+	0x05, 0x1c, 0x9b, 0x96, 0x57, 0x40, 0xf0, 0x36, 0x94, 0xb5, 0xd0, 0x74, 0x6c, 0xc4, 0x22, 0xcf,
+	0x1e, 0x8e, 0xb6, 0xa7, 0xee, 0x13, 0xd8, 0x6a, 0x2d, 0x32, 0x37, 0xff, 0x42, 0x50, 0x5b, 0xa0,
+	0xc1, 0xcf, 0xa1, 0xac, 0x34, 0x95, 0xfa, 0x3f, 0xec, 0xc0, 0x02, 0xf1, 0x5e, 0xf1, 0x7e, 0xa2,
+	0xc0, 0x8c, 0x2c, 0xe7, 0x6f, 0xe0, 0x34, 0xc0, 0x6f, 0x01, 0xd8, 0x16, 0xa7, 0x31, 0x6b, 0x94,
+	0x08, 0x3a, 0xa8, 0x7a, 0x55, 0x53, 0x79, 0x45, 0x63, 0x86, 0x9f, 0xc1, 0x86, 0x66, 0x32, 0x8e,
+	0x38, 0x1d, 0x67, 0x0f, 0xaf, 0x9a, 0x3e, 0x14, 0xa5, 0xd3, 0x00, 0x37, 0xa0, 0x22, 0xad, 0xba,
+	0x46, 0xd9, 0x98, 0x29, 0x8e, 0xf8, 0x7d, 0x78, 0xa2, 0x25, 0xe5, 0x8a, 0xfa, 0x59, 0x98, 0x2e,
+	0x7d, 0x91, 0x72, 0xdd, 0x58, 0x33, 0xd3, 0xeb, 0x73, 0x8d, 0x93, 0xac, 0x6e, 0x56, 0x7f, 0x42,
+	0xd5, 0xe8, 0xb3, 0xb1, 0xb8, 0xc9, 0x5f, 0xe9, 0x2e, 0x54, 0xb4, 0x48, 0x2e, 0xd3, 0x44, 0x19,
+	0xa3, 0xc8, 0x5b, 0xd3, 0x22, 0xb9, 0x48, 0x14, 0x26, 0xf0, 0xd8, 0x36, 0x72, 0x4e, 0xeb, 0x08,
+	0x4c, 0xd7, 0xb0, 0xe1, 0x7d, 0xa8, 0x26, 0xa9, 0xf4, 0x47, 0x54, 0x31, 0x65, 0x3c, 0x21, 0xef,
+	0x75, 0x01, 0xbf, 0x0b, 0x9b, 0xc5, 0x21, 0x67, 0x58, 0x35, 0x0c, 0xb5, 0xa2, 0x6a, 0x48, 0x8e,
+	0x76, 0xa6, 0x2e, 0x86, 0x7a, 0xeb, 0x8e, 0xac, 0xe6, 0x2f, 0x08, 0x6a, 0xc7, 0x74, 0x4c, 0xb9,
+	0x5f, 0xec, 0x7e, 0xb6, 0x4d, 0x34, 0xb7, 0x4d, 0xfc, 0x36, 0xd4, 0xa8, 0x6f, 0xe8, 0x17, 0x64,
+	0x3e, 0xce, 0x8b, 0x56, 0xe8, 0x7b, 0xb0, 0x25, 0x26, 0x4c, 0x06, 0x92, 0xde, 0xf0, 0x4b, 0x4b,
+	0x62, 0xe5, 0x6e, 0xce, 0xca, 0x03, 0xc3, 0xb6, 0x00, 0x9c, 0x17, 0xfd, 0x1a, 0x68, 0x55, 0x17,
+	0x21, 0x5a, 0x90, 0xd8, 0xfc, 0x16, 0x01, 0x76, 0x27, 0x4c, 0xd2, 0x90, 0x9d, 0x27, 0x8c, 0x07,
+	0xb9, 0xf2, 0x06, 0x54, 0xa8, 0xad, 0xe6, 0xda, 0x8b, 0xe3, 0xfd, 0x09, 0x5d, 0xf6, 0x54, 0x5a,
+	0xf6, 0x74, 0xb4, 0x37, 0x75, 0xdf, 0x80, 0xed, 0xd6, 0x3d, 0xf3, 0x0e, 0x7f, 0x5d, 0x83, 0x9a,
+	0xfd, 0xf7, 0x9c, 0xc9, 0x49, 0xe4, 0x33, 0xfc, 0x1b, 0x02, 0xe8, 0x33, 0x9d, 0x07, 0x1c, 0x3f,
+	0xbd, 0xe7, 0xb3, 0x75, 0xf0, 0x62, 0xd1, 0x18, 0xfa, 0x0e, 0x4d, 0xdd, 0x6b, 0xe7, 0xc5, 0x79,
+	0x1a, 0x2b, 0x92, 0x26, 0x84, 0x8e, 0xc7, 0x64, 0xb6, 0x60, 0x12, 0x71, 0xa2, 0x47, 0x8c, 0x64,
+	0x77, 0x22, 0x31, 0x17, 0x04, 0x49, 0x98, 0x24, 0x23, 0x91, 0xca, 0x36, 0x09, 0xe8, 0x6d, 0x9b,
+	0x98, 0x7c, 0x13, 0x21, 0x49, 0x11, 0xe4, 0x56, 0x71, 0xef, 0x10, 0x7b, 0xd9, 0x0e, 0xb7, 0xa0,
+	0x06, 0xd5, 0x81, 0xb8, 0x66, 0xdc, 0x4d, 0xf5, 0x08, 0x3f, 0xfa, 0xe6, 0xf7, 0xbf, 0xbf, 0x5f,
+	0xd9, 0xc1, 0x4f, 0x7b, 0x93, 0x0f, 0x7a, 0x16, 0xa2, 0x7a, 0x45, 0xd0, 0x7f, 0x46, 0xb0, 0xd1,
+	0x67, 0xba, 0xc8, 0x09, 0xbe, 0x73, 0x49, 0x39, 0xd6, 0xd4, 0x9d, 0x18, 0xa5, 0x53, 0xf7, 0xc2,
+	0x79, 0x7e, 0x22, 0xe2, 0x84, 0x4a, 0xa6, 0x88, 0x16, 0x49, 0x27, 0x4d, 0x14, 0xa1, 0x3c, 0x78,
+	0xc8, 0x48, 0xab, 0x9e, 0xd1, 0x90, 0xab, 0xb1, 0xb8, 0x79, 0x50, 0xe9, 0x2e, 0xde, 0x99, 0x57,
+	0xea, 0x53, 0x35, 0xea, 0x64, 0x0f, 0xe1, 0x3f, 0x11, 0xec, 0xf4, 0x99, 0x3e, 0x4b, 0xb5, 0xd2,
+	0x94, 0x07, 0x11, 0x0f, 0xf3, 0xa0, 0x2c, 0xa9, 0xb6, 0x6f, 0x7d, 0x31, 0x46, 0x3f, 0xa2, 0xa9,
+	0xfb, 0xb5, 0x73, 0x66, 0x22, 0x49, 0x86, 0xb6, 0x45, 0xa8, 0x2f, 0x85, 0x52, 0x66, 0x05, 0x79,
+	0x0e, 0x14, 0xa1, 0xda, 0x08, 0x67, 0x3c, 0x20, 0xe2, 0xea, 0x8e, 0x87, 0x36, 0xc9, 0x7e, 0x18,
+	0x48, 0xa4, 0x48, 0x14, 0x72, 0x21, 0x59, 0xd0, 0x72, 0xe6, 0xe4, 0xcc, 0x68, 0xff, 0xc7, 0x22,
+	0xf2, 0x47, 0xf0, 0x1f, 0x08, 0xb6, 0xfa, 0x4c, 0xcf, 0x07, 0x6f, 0xc9, 0xd6, 0xae, 0x39, 0x2f,
+	0x67, 0xb3, 0xf9, 0x03, 0x9a, 0xba, 0xa9, 0xf3, 0x32, 0xef, 0xcc, 0x96, 0x40, 0x54, 0x1a, 0x67,
+	0x1e, 0x16, 0xec, 0xe9, 0x11, 0xd5, 0x64, 0x28, 0xd2, 0x70, 0xa4, 0x89, 0x12, 0x31, 0xd3, 0xa3,
+	0x4c, 0xf9, 0xf2, 0xb6, 0xb6, 0x0b, 0x36, 0x95, 0x0d, 0x7a, 0xd0, 0xd2, 0x9b, 0x78, 0x6f, 0xde,
+	0x52, 0xfe, 0x1d, 0x76, 0xcc, 0x83, 0xc3, 0x35, 0x73, 0xb5, 0x7f, 0xf8, 0xcf, 0x00, 0x41, 0xfc,
+	0x8e, 0xb2, 0x0b, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ReportServiceClient is the client API for ReportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReportServiceClient interface {
+	GetRevenue(ctx context.Context, in *RevenueRequest, opts ...grpc.CallOption) (*RevenueReport, error)
+	GetCashFlow(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*CashFlowReport, error)
+	GetOutstandingBalance(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*BalanceReport, error)
+	GetAverageSpend(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*AverageSpendReport, error)
+}
+
+type reportServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewReportServiceClient(cc *grpc.ClientConn) ReportServiceClient {
+	return &reportServiceClient{cc}
+}
+
+func (c *reportServiceClient) GetRevenue(ctx context.Context, in *RevenueRequest, opts ...grpc.CallOption) (*RevenueReport, error) {
+	out := new(RevenueReport)
+	err := c.cc.Invoke(ctx, "/api.ReportService/GetRevenue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetCashFlow(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*CashFlowReport, error) {
+	out := new(CashFlowReport)
+	err := c.cc.Invoke(ctx, "/api.ReportService/GetCashFlow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetOutstandingBalance(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*BalanceReport, error) {
+	out := new(BalanceReport)
+	err := c.cc.Invoke(ctx, "/api.ReportService/GetOutstandingBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) GetAverageSpend(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*AverageSpendReport, error) {
+	out := new(AverageSpendReport)
+	err := c.cc.Invoke(ctx, "/api.ReportService/GetAverageSpend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportServiceServer is the server API for ReportService service.
+type ReportServiceServer interface {
+	GetRevenue(context.Context, *RevenueRequest) (*RevenueReport, error)
+	GetCashFlow(context.Context, *TimeRange) (*CashFlowReport, error)
+	GetOutstandingBalance(context.Context, *TimeRange) (*BalanceReport, error)
+	GetAverageSpend(context.Context, *TimeRange) (*AverageSpendReport, error)
+}
+
+// UnimplementedReportServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedReportServiceServer struct {
+}
+
+func (*UnimplementedReportServiceServer) GetRevenue(ctx context.Context, req *RevenueRequest) (*RevenueReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevenue not implemented")
+}
+func (*UnimplementedReportServiceServer) GetCashFlow(ctx context.Context, req *TimeRange) (*CashFlowReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCashFlow not implemented")
+}
+func (*UnimplementedReportServiceServer) GetOutstandingBalance(ctx context.Context, req *TimeRange) (*BalanceReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutstandingBalance not implemented")
+}
+func (*UnimplementedReportServiceServer) GetAverageSpend(ctx context.Context, req *TimeRange) (*AverageSpendReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAverageSpend not implemented")
+}
+
+func RegisterReportServiceServer(s *grpc.Server, srv ReportServiceServer) {
+	s.RegisterService(&_ReportService_serviceDesc, srv)
+}
+
+func _ReportService_GetRevenue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevenueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetRevenue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ReportService/GetRevenue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetRevenue(ctx, req.(*RevenueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetCashFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeRange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetCashFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ReportService/GetCashFlow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetCashFlow(ctx, req.(*TimeRange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetOutstandingBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeRange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetOutstandingBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ReportService/GetOutstandingBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetOutstandingBalance(ctx, req.(*TimeRange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_GetAverageSpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeRange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetAverageSpend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ReportService/GetAverageSpend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetAverageSpend(ctx, req.(*TimeRange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ReportService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.ReportService",
+	HandlerType: (*ReportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRevenue",
+			Handler:    _ReportService_GetRevenue_Handler,
+		},
+		{
+			MethodName: "GetCashFlow",
+			Handler:    _ReportService_GetCashFlow_Handler,
+		},
+		{
+			MethodName: "GetOutstandingBalance",
+			Handler:    _ReportService_GetOutstandingBalance_Handler,
+		},
+		{
+			MethodName: "GetAverageSpend",
+			Handler:    _ReportService_GetAverageSpend_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reports.proto",
+}
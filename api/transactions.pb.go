@@ -8,6 +8,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
@@ -27,12 +28,88 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Transaction_Type int32
+
+const (
+	Transaction_PAYMENT Transaction_Type = 0
+	// ADJUSTMENT corrects the ledger, it does not change the saldo of the account
+	Transaction_ADJUSTMENT Transaction_Type = 1
+)
+
+var Transaction_Type_name = map[int32]string{
+	0: "PAYMENT",
+	1: "ADJUSTMENT",
+}
+
+var Transaction_Type_value = map[string]int32{
+	"PAYMENT":    0,
+	"ADJUSTMENT": 1,
+}
+
+func (x Transaction_Type) String() string {
+	return proto.EnumName(Transaction_Type_name, int32(x))
+}
+
+func (Transaction_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{6, 0}
+}
+
+type OfflineConflict_Reason int32
+
+const (
+	OfflineConflict_UNKNOWN OfflineConflict_Reason = 0
+	// INVALID_SIGNATURE charges are not processed, but their sequence is lost once a later sequence of the terminal
+	// is applied, a re-signed charge with the same sequence is then a DUPLICATE_SEQUENCE
+	OfflineConflict_INVALID_SIGNATURE OfflineConflict_Reason = 1
+	// DUPLICATE_SEQUENCE charges were already processed by an earlier sync
+	OfflineConflict_DUPLICATE_SEQUENCE   OfflineConflict_Reason = 2
+	OfflineConflict_ACCOUNT_NOT_FOUND    OfflineConflict_Reason = 3
+	OfflineConflict_ACCOUNT_BLOCKED      OfflineConflict_Reason = 4
+	OfflineConflict_INSUFFICIENT_BALANCE OfflineConflict_Reason = 5
+)
+
+var OfflineConflict_Reason_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "INVALID_SIGNATURE",
+	2: "DUPLICATE_SEQUENCE",
+	3: "ACCOUNT_NOT_FOUND",
+	4: "ACCOUNT_BLOCKED",
+	5: "INSUFFICIENT_BALANCE",
+}
+
+var OfflineConflict_Reason_value = map[string]int32{
+	"UNKNOWN":              0,
+	"INVALID_SIGNATURE":    1,
+	"DUPLICATE_SEQUENCE":   2,
+	"ACCOUNT_NOT_FOUND":    3,
+	"ACCOUNT_BLOCKED":      4,
+	"INSUFFICIENT_BALANCE": 5,
+}
+
+func (x OfflineConflict_Reason) String() string {
+	return proto.EnumName(OfflineConflict_Reason_name, int32(x))
+}
+
+func (OfflineConflict_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{11, 0}
+}
+
 type ListTransactionRequest struct {
-	Paging               *Paging  `protobuf:"bytes,1,opt,name=paging,proto3" json:"paging,omitempty"`
-	Order                string   `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Paging *Paging `protobuf:"bytes,1,opt,name=paging,proto3" json:"paging,omitempty"`
+	Order  string  `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	// inclusive start of the time range
+	CreatedFrom *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	// exclusive end of the time range
+	CreatedTo *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// inclusive lower bound of the amount, top-ups have negative amounts
+	MinAmount *wrappers.DoubleValue `protobuf:"bytes,5,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	// inclusive upper bound of the amount
+	MaxAmount            *wrappers.DoubleValue `protobuf:"bytes,6,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	GroupId              int32                 `protobuf:"varint,7,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	TerminalId           string                `protobuf:"bytes,8,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ListTransactionRequest) Reset()         { *m = ListTransactionRequest{} }
@@ -74,13 +151,65 @@ func (m *ListTransactionRequest) GetOrder() string {
 	return ""
 }
 
+func (m *ListTransactionRequest) GetCreatedFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedFrom
+	}
+	return nil
+}
+
+func (m *ListTransactionRequest) GetCreatedTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedTo
+	}
+	return nil
+}
+
+func (m *ListTransactionRequest) GetMinAmount() *wrappers.DoubleValue {
+	if m != nil {
+		return m.MinAmount
+	}
+	return nil
+}
+
+func (m *ListTransactionRequest) GetMaxAmount() *wrappers.DoubleValue {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return nil
+}
+
+func (m *ListTransactionRequest) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *ListTransactionRequest) GetTerminalId() string {
+	if m != nil {
+		return m.TerminalId
+	}
+	return ""
+}
+
 type ListTransactionsByAccountRequest struct {
-	AccountId            int32    `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Paging               *Paging  `protobuf:"bytes,2,opt,name=paging,proto3" json:"paging,omitempty"`
-	Order                string   `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	AccountId int32   `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Paging    *Paging `protobuf:"bytes,2,opt,name=paging,proto3" json:"paging,omitempty"`
+	Order     string  `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	// inclusive start of the time range
+	CreatedFrom *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	// exclusive end of the time range
+	CreatedTo *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	// inclusive lower bound of the amount, top-ups have negative amounts
+	MinAmount *wrappers.DoubleValue `protobuf:"bytes,6,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	// inclusive upper bound of the amount
+	MaxAmount            *wrappers.DoubleValue `protobuf:"bytes,7,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	GroupId              int32                 `protobuf:"varint,8,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	TerminalId           string                `protobuf:"bytes,9,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ListTransactionsByAccountRequest) Reset()         { *m = ListTransactionsByAccountRequest{} }
@@ -129,6 +258,174 @@ func (m *ListTransactionsByAccountRequest) GetOrder() string {
 	return ""
 }
 
+func (m *ListTransactionsByAccountRequest) GetCreatedFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedFrom
+	}
+	return nil
+}
+
+func (m *ListTransactionsByAccountRequest) GetCreatedTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedTo
+	}
+	return nil
+}
+
+func (m *ListTransactionsByAccountRequest) GetMinAmount() *wrappers.DoubleValue {
+	if m != nil {
+		return m.MinAmount
+	}
+	return nil
+}
+
+func (m *ListTransactionsByAccountRequest) GetMaxAmount() *wrappers.DoubleValue {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return nil
+}
+
+func (m *ListTransactionsByAccountRequest) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *ListTransactionsByAccountRequest) GetTerminalId() string {
+	if m != nil {
+		return m.TerminalId
+	}
+	return ""
+}
+
+type ExportTransactionsRequest struct {
+	From                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	GroupId              int32                `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	AccountId            int32                `protobuf:"varint,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Order                string               `protobuf:"bytes,5,opt,name=order,proto3" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ExportTransactionsRequest) Reset()         { *m = ExportTransactionsRequest{} }
+func (m *ExportTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportTransactionsRequest) ProtoMessage()    {}
+func (*ExportTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{2}
+}
+
+func (m *ExportTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportTransactionsRequest.Unmarshal(m, b)
+}
+func (m *ExportTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTransactionsRequest.Merge(m, src)
+}
+func (m *ExportTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportTransactionsRequest.Size(m)
+}
+func (m *ExportTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTransactionsRequest proto.InternalMessageInfo
+
+func (m *ExportTransactionsRequest) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *ExportTransactionsRequest) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *ExportTransactionsRequest) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *ExportTransactionsRequest) GetAccountId() int32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func (m *ExportTransactionsRequest) GetOrder() string {
+	if m != nil {
+		return m.Order
+	}
+	return ""
+}
+
+type WatchTransactionsRequest struct {
+	AccountId            int32    `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	GroupId              int32    `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	TerminalId           string   `protobuf:"bytes,3,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchTransactionsRequest) Reset()         { *m = WatchTransactionsRequest{} }
+func (m *WatchTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchTransactionsRequest) ProtoMessage()    {}
+func (*WatchTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{3}
+}
+
+func (m *WatchTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchTransactionsRequest.Unmarshal(m, b)
+}
+func (m *WatchTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchTransactionsRequest.Merge(m, src)
+}
+func (m *WatchTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchTransactionsRequest.Size(m)
+}
+func (m *WatchTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchTransactionsRequest proto.InternalMessageInfo
+
+func (m *WatchTransactionsRequest) GetAccountId() int32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func (m *WatchTransactionsRequest) GetGroupId() int32 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *WatchTransactionsRequest) GetTerminalId() string {
+	if m != nil {
+		return m.TerminalId
+	}
+	return ""
+}
+
 type GetTransactionRequest struct {
 	Id                   int32    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	AccountId            int32    `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
@@ -141,7 +438,7 @@ func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
 func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTransactionRequest) ProtoMessage()    {}
 func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0b72849cf10e9c77, []int{2}
+	return fileDescriptor_0b72849cf10e9c77, []int{4}
 }
 
 func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
@@ -177,18 +474,20 @@ func (m *GetTransactionRequest) GetAccountId() int32 {
 }
 
 type ListTransactionsResponse struct {
-	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	TotalCount           int32          `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	TotalCount   int32          `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// empty on the last page
+	NextPageToken        string   `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListTransactionsResponse) Reset()         { *m = ListTransactionsResponse{} }
 func (m *ListTransactionsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListTransactionsResponse) ProtoMessage()    {}
 func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0b72849cf10e9c77, []int{3}
+	return fileDescriptor_0b72849cf10e9c77, []int{5}
 }
 
 func (m *ListTransactionsResponse) XXX_Unmarshal(b []byte) error {
@@ -223,23 +522,35 @@ func (m *ListTransactionsResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (m *ListTransactionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type Transaction struct {
-	Id                   int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	OldSaldo             float64              `protobuf:"fixed64,2,opt,name=old_saldo,json=oldSaldo,proto3" json:"old_saldo,omitempty"`
-	NewSaldo             float64              `protobuf:"fixed64,3,opt,name=new_saldo,json=newSaldo,proto3" json:"new_saldo,omitempty"`
-	Amount               float64              `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Created              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
-	Account              *Account             `protobuf:"bytes,6,opt,name=account,proto3" json:"account,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Id         int32                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OldSaldo   float64              `protobuf:"fixed64,2,opt,name=old_saldo,json=oldSaldo,proto3" json:"old_saldo,omitempty"`
+	NewSaldo   float64              `protobuf:"fixed64,3,opt,name=new_saldo,json=newSaldo,proto3" json:"new_saldo,omitempty"`
+	Amount     float64              `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Created    *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	Account    *Account             `protobuf:"bytes,6,opt,name=account,proto3" json:"account,omitempty"`
+	TerminalId string               `protobuf:"bytes,7,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	Type       Transaction_Type     `protobuf:"varint,8,opt,name=type,proto3,enum=api.Transaction_Type" json:"type,omitempty"`
+	// chip_balance is the signed balance blob the terminal writes to the chip, only set by CreateTransaction
+	// if the request contained a chip balance
+	ChipBalance          []byte   `protobuf:"bytes,9,opt,name=chip_balance,json=chipBalance,proto3" json:"chip_balance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0b72849cf10e9c77, []int{4}
+	return fileDescriptor_0b72849cf10e9c77, []int{6}
 }
 
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
@@ -302,9 +613,33 @@ func (m *Transaction) GetAccount() *Account {
 	return nil
 }
 
+func (m *Transaction) GetTerminalId() string {
+	if m != nil {
+		return m.TerminalId
+	}
+	return ""
+}
+
+func (m *Transaction) GetType() Transaction_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Transaction_PAYMENT
+}
+
+func (m *Transaction) GetChipBalance() []byte {
+	if m != nil {
+		return m.ChipBalance
+	}
+	return nil
+}
+
 type CreateTransactionRequest struct {
-	Amount               float64  `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	AccountId            int32    `protobuf:"varint,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount     float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	AccountId  int32   `protobuf:"varint,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TerminalId string  `protobuf:"bytes,5,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	// chip_balance is the balance blob read from the chip, it is verified before the account is charged
+	ChipBalance          []byte   `protobuf:"bytes,6,opt,name=chip_balance,json=chipBalance,proto3" json:"chip_balance,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -314,7 +649,7 @@ func (m *CreateTransactionRequest) Reset()         { *m = CreateTransactionReque
 func (m *CreateTransactionRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateTransactionRequest) ProtoMessage()    {}
 func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0b72849cf10e9c77, []int{5}
+	return fileDescriptor_0b72849cf10e9c77, []int{7}
 }
 
 func (m *CreateTransactionRequest) XXX_Unmarshal(b []byte) error {
@@ -349,65 +684,381 @@ func (m *CreateTransactionRequest) GetAccountId() int32 {
 	return 0
 }
 
+func (m *CreateTransactionRequest) GetTerminalId() string {
+	if m != nil {
+		return m.TerminalId
+	}
+	return ""
+}
+
+func (m *CreateTransactionRequest) GetChipBalance() []byte {
+	if m != nil {
+		return m.ChipBalance
+	}
+	return nil
+}
+
+// OfflineTransaction is a charge a terminal made while it could not reach the server.
+// The signature is the ed25519 signature of the terminal over the payload built by pkg/offline
+type OfflineTransaction struct {
+	// sequence is increased by one for every charge of the terminal, charges are applied in this order
+	Sequence  uint64  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	AccountId int32   `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount    float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// created is the time of the charge on the terminal, the saved transaction is created at sync time
+	// so the saldo chain of the account stays in order
+	Created              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	Signature            []byte               `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *OfflineTransaction) Reset()         { *m = OfflineTransaction{} }
+func (m *OfflineTransaction) String() string { return proto.CompactTextString(m) }
+func (*OfflineTransaction) ProtoMessage()    {}
+func (*OfflineTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{8}
+}
+
+func (m *OfflineTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OfflineTransaction.Unmarshal(m, b)
+}
+func (m *OfflineTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OfflineTransaction.Marshal(b, m, deterministic)
+}
+func (m *OfflineTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OfflineTransaction.Merge(m, src)
+}
+func (m *OfflineTransaction) XXX_Size() int {
+	return xxx_messageInfo_OfflineTransaction.Size(m)
+}
+func (m *OfflineTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_OfflineTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OfflineTransaction proto.InternalMessageInfo
+
+func (m *OfflineTransaction) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *OfflineTransaction) GetAccountId() int32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func (m *OfflineTransaction) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *OfflineTransaction) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *OfflineTransaction) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type SyncOfflineTransactionsRequest struct {
+	TerminalId           string                `protobuf:"bytes,1,opt,name=terminal_id,json=terminalId,proto3" json:"terminal_id,omitempty"`
+	Transactions         []*OfflineTransaction `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *SyncOfflineTransactionsRequest) Reset()         { *m = SyncOfflineTransactionsRequest{} }
+func (m *SyncOfflineTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncOfflineTransactionsRequest) ProtoMessage()    {}
+func (*SyncOfflineTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{9}
+}
+
+func (m *SyncOfflineTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncOfflineTransactionsRequest.Unmarshal(m, b)
+}
+func (m *SyncOfflineTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncOfflineTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *SyncOfflineTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncOfflineTransactionsRequest.Merge(m, src)
+}
+func (m *SyncOfflineTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncOfflineTransactionsRequest.Size(m)
+}
+func (m *SyncOfflineTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncOfflineTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncOfflineTransactionsRequest proto.InternalMessageInfo
+
+func (m *SyncOfflineTransactionsRequest) GetTerminalId() string {
+	if m != nil {
+		return m.TerminalId
+	}
+	return ""
+}
+
+func (m *SyncOfflineTransactionsRequest) GetTransactions() []*OfflineTransaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type SyncOfflineTransactionsResponse struct {
+	Applied   []*Transaction     `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"`
+	Conflicts []*OfflineConflict `protobuf:"bytes,2,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// last_sequence is the highest sequence the server has processed for the terminal,
+	// the terminal can drop every queued charge up to it
+	LastSequence         uint64   `protobuf:"varint,3,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncOfflineTransactionsResponse) Reset()         { *m = SyncOfflineTransactionsResponse{} }
+func (m *SyncOfflineTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncOfflineTransactionsResponse) ProtoMessage()    {}
+func (*SyncOfflineTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{10}
+}
+
+func (m *SyncOfflineTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncOfflineTransactionsResponse.Unmarshal(m, b)
+}
+func (m *SyncOfflineTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncOfflineTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *SyncOfflineTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncOfflineTransactionsResponse.Merge(m, src)
+}
+func (m *SyncOfflineTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_SyncOfflineTransactionsResponse.Size(m)
+}
+func (m *SyncOfflineTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncOfflineTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncOfflineTransactionsResponse proto.InternalMessageInfo
+
+func (m *SyncOfflineTransactionsResponse) GetApplied() []*Transaction {
+	if m != nil {
+		return m.Applied
+	}
+	return nil
+}
+
+func (m *SyncOfflineTransactionsResponse) GetConflicts() []*OfflineConflict {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
+func (m *SyncOfflineTransactionsResponse) GetLastSequence() uint64 {
+	if m != nil {
+		return m.LastSequence
+	}
+	return 0
+}
+
+// OfflineConflict is an offline charge that was not applied
+type OfflineConflict struct {
+	Sequence             uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	AccountId            int32                  `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount               float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason               OfflineConflict_Reason `protobuf:"varint,4,opt,name=reason,proto3,enum=api.OfflineConflict_Reason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *OfflineConflict) Reset()         { *m = OfflineConflict{} }
+func (m *OfflineConflict) String() string { return proto.CompactTextString(m) }
+func (*OfflineConflict) ProtoMessage()    {}
+func (*OfflineConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0b72849cf10e9c77, []int{11}
+}
+
+func (m *OfflineConflict) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OfflineConflict.Unmarshal(m, b)
+}
+func (m *OfflineConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OfflineConflict.Marshal(b, m, deterministic)
+}
+func (m *OfflineConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OfflineConflict.Merge(m, src)
+}
+func (m *OfflineConflict) XXX_Size() int {
+	return xxx_messageInfo_OfflineConflict.Size(m)
+}
+func (m *OfflineConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_OfflineConflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OfflineConflict proto.InternalMessageInfo
+
+func (m *OfflineConflict) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *OfflineConflict) GetAccountId() int32 {
+	if m != nil {
+		return m.AccountId
+	}
+	return 0
+}
+
+func (m *OfflineConflict) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *OfflineConflict) GetReason() OfflineConflict_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return OfflineConflict_UNKNOWN
+}
+
 func init() {
+	proto.RegisterEnum("api.Transaction_Type", Transaction_Type_name, Transaction_Type_value)
+	proto.RegisterEnum("api.OfflineConflict_Reason", OfflineConflict_Reason_name, OfflineConflict_Reason_value)
 	proto.RegisterType((*ListTransactionRequest)(nil), "api.ListTransactionRequest")
 	proto.RegisterType((*ListTransactionsByAccountRequest)(nil), "api.ListTransactionsByAccountRequest")
+	proto.RegisterType((*ExportTransactionsRequest)(nil), "api.ExportTransactionsRequest")
+	proto.RegisterType((*WatchTransactionsRequest)(nil), "api.WatchTransactionsRequest")
 	proto.RegisterType((*GetTransactionRequest)(nil), "api.GetTransactionRequest")
 	proto.RegisterType((*ListTransactionsResponse)(nil), "api.ListTransactionsResponse")
 	proto.RegisterType((*Transaction)(nil), "api.Transaction")
 	proto.RegisterType((*CreateTransactionRequest)(nil), "api.CreateTransactionRequest")
+	proto.RegisterType((*OfflineTransaction)(nil), "api.OfflineTransaction")
+	proto.RegisterType((*SyncOfflineTransactionsRequest)(nil), "api.SyncOfflineTransactionsRequest")
+	proto.RegisterType((*SyncOfflineTransactionsResponse)(nil), "api.SyncOfflineTransactionsResponse")
+	proto.RegisterType((*OfflineConflict)(nil), "api.OfflineConflict")
 }
 
 func init() { proto.RegisterFile("transactions.proto", fileDescriptor_0b72849cf10e9c77) }
 
 var fileDescriptor_0b72849cf10e9c77 = []byte{
-	// 721 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xc1, 0x4e, 0xdb, 0x4a,
-	0x14, 0x7d, 0x4e, 0x20, 0xbc, 0xdc, 0x40, 0x08, 0xc3, 0x03, 0xf2, 0x4c, 0x11, 0x23, 0x57, 0xb4,
-	0xc8, 0x82, 0x58, 0xa5, 0xac, 0xb2, 0xa8, 0xe4, 0x22, 0x15, 0x55, 0x62, 0x51, 0x19, 0xf6, 0x68,
-	0x12, 0x0f, 0x66, 0x54, 0x67, 0xc6, 0x78, 0x26, 0x44, 0x15, 0x45, 0x95, 0xba, 0xe0, 0x03, 0xd2,
-	0x3f, 0xe8, 0x2f, 0x74, 0xd1, 0xcf, 0xe8, 0xa2, 0x3f, 0xd0, 0x4a, 0xfd, 0x90, 0x2a, 0x63, 0x87,
-	0x38, 0x89, 0x11, 0x59, 0x59, 0x73, 0xef, 0x9d, 0x7b, 0xce, 0x99, 0x7b, 0xae, 0x01, 0xa9, 0x98,
-	0x70, 0x49, 0xda, 0x8a, 0x09, 0x2e, 0x1b, 0x51, 0x2c, 0x94, 0x40, 0x45, 0x12, 0x31, 0x73, 0x29,
-	0x08, 0x45, 0x8b, 0x84, 0x69, 0xcc, 0xac, 0x92, 0x76, 0x5b, 0x74, 0xb9, 0x1a, 0x9e, 0xb7, 0x03,
-	0x21, 0x82, 0x90, 0x3a, 0xfa, 0xd4, 0xea, 0x5e, 0x38, 0x8a, 0x75, 0xa8, 0x54, 0xa4, 0x13, 0xa5,
-	0x05, 0x4f, 0xd2, 0x02, 0x12, 0x31, 0x87, 0x70, 0x2e, 0x14, 0xc9, 0x40, 0x98, 0x7b, 0xfa, 0xd3,
-	0xde, 0x0f, 0x28, 0xdf, 0x97, 0x3d, 0x12, 0x04, 0x34, 0x76, 0x44, 0xa4, 0x2b, 0xa6, 0xab, 0xad,
-	0x53, 0x58, 0x3f, 0x61, 0x52, 0x9d, 0x8d, 0xa8, 0x7a, 0xf4, 0xaa, 0x4b, 0xa5, 0x42, 0x4f, 0xa1,
-	0x14, 0x91, 0x80, 0xf1, 0xa0, 0x6e, 0x60, 0x63, 0xb7, 0x72, 0x50, 0x69, 0x90, 0x88, 0x35, 0xde,
-	0xe9, 0x90, 0x97, 0xa6, 0xd0, 0x7f, 0x30, 0x2f, 0x62, 0x9f, 0xc6, 0xf5, 0x02, 0x36, 0x76, 0xcb,
-	0x5e, 0x72, 0xb0, 0x3e, 0x02, 0x9e, 0x68, 0x2a, 0x5f, 0x7f, 0x70, 0x13, 0x95, 0xc3, 0xf6, 0x5b,
-	0x00, 0xa9, 0xee, 0x73, 0xe6, 0x6b, 0x88, 0x79, 0xaf, 0x9c, 0x46, 0xde, 0xfa, 0x19, 0xf4, 0xc2,
-	0x0c, 0xe8, 0xc5, 0x2c, 0xfa, 0x1b, 0x58, 0x3b, 0xa6, 0x79, 0x8a, 0xaa, 0x50, 0xb8, 0x87, 0x2a,
-	0x30, 0x7f, 0x82, 0x42, 0x61, 0x82, 0x82, 0x75, 0x67, 0x40, 0x7d, 0x52, 0x86, 0x47, 0x65, 0x24,
-	0xb8, 0xa4, 0xe8, 0x10, 0x16, 0xb3, 0xe3, 0xad, 0x1b, 0xb8, 0xb8, 0x5b, 0x39, 0xa8, 0x69, 0x96,
-	0x59, 0xe8, 0xb1, 0x2a, 0xb4, 0x0d, 0x15, 0x25, 0x14, 0x09, 0xcf, 0x35, 0x46, 0x0a, 0x09, 0x3a,
-	0x74, 0x34, 0x88, 0x34, 0x57, 0xfb, 0x6e, 0x0d, 0xaa, 0xf6, 0x62, 0x16, 0xd3, 0xfa, 0x6d, 0x40,
-	0x25, 0x13, 0x98, 0xd2, 0xb1, 0x09, 0x65, 0x11, 0xfa, 0xe7, 0x92, 0x84, 0xbe, 0xd0, 0x3d, 0x0d,
-	0xef, 0x5f, 0x11, 0xfa, 0xa7, 0x83, 0xf3, 0x20, 0xc9, 0x69, 0x2f, 0x4d, 0x16, 0x93, 0x24, 0xa7,
-	0xbd, 0x24, 0xb9, 0x0e, 0x25, 0xd2, 0xd1, 0x54, 0xe6, 0x74, 0x26, 0x3d, 0xa1, 0x43, 0x58, 0x68,
-	0xc7, 0x94, 0x28, 0xea, 0xd7, 0xe7, 0xf5, 0xf3, 0x9b, 0x8d, 0xc4, 0x73, 0x8d, 0xa1, 0x29, 0x1b,
-	0x67, 0x43, 0x53, 0x7a, 0xc3, 0x52, 0xf4, 0x0c, 0x16, 0xd2, 0xd7, 0xab, 0x97, 0xf4, 0xad, 0x45,
-	0xfd, 0x1c, 0xc3, 0xc1, 0x0f, 0x93, 0x4d, 0xd4, 0x77, 0x97, 0x61, 0xc9, 0xce, 0x6a, 0xb2, 0x3a,
-	0x50, 0x3f, 0xd2, 0x6d, 0x72, 0xe6, 0x36, 0x62, 0x59, 0x1c, 0x63, 0x39, 0x3e, 0xbf, 0xb9, 0x89,
-	0xf9, 0x35, 0xcd, 0xbe, 0xbb, 0x01, 0x6b, 0xf6, 0x6a, 0xa6, 0xa3, 0x86, 0x60, 0x82, 0x1f, 0xfc,
-	0x2a, 0x41, 0x36, 0x2e, 0x4f, 0x69, 0x7c, 0xcd, 0xda, 0x14, 0xfd, 0x30, 0xa0, 0x36, 0x39, 0x73,
-	0xb4, 0xa9, 0x65, 0xe4, 0xaf, 0x89, 0xb9, 0x95, 0x97, 0xbc, 0xf7, 0x89, 0xf5, 0xa9, 0xef, 0xfa,
-	0x66, 0x73, 0x90, 0x96, 0x98, 0x84, 0x21, 0xce, 0xda, 0x61, 0x0f, 0xb7, 0x09, 0xc7, 0x2d, 0x8a,
-	0x43, 0xd6, 0x61, 0x8a, 0xfa, 0xb8, 0xc7, 0xd4, 0x25, 0x4e, 0xbc, 0x8d, 0xd3, 0x95, 0xb5, 0xd7,
-	0x06, 0x77, 0xa7, 0xae, 0xb6, 0x96, 0x61, 0x09, 0xca, 0x67, 0xe2, 0x3d, 0xe5, 0x6e, 0x57, 0x5d,
-	0xa2, 0x7f, 0x3e, 0xff, 0xfc, 0xf3, 0xa5, 0x80, 0x50, 0xcd, 0xb9, 0x7e, 0xe1, 0x8c, 0x59, 0xee,
-	0xae, 0x00, 0xff, 0x3f, 0xb8, 0x8c, 0x68, 0x27, 0x97, 0xfd, 0xe4, 0xb2, 0x3e, 0x26, 0xf2, 0xab,
-	0xd1, 0x77, 0x63, 0xf3, 0x64, 0xa4, 0x32, 0x5b, 0x85, 0x2f, 0x44, 0x8c, 0x03, 0x76, 0x4d, 0x39,
-	0x4e, 0x67, 0x33, 0x93, 0xee, 0x15, 0xad, 0xfb, 0x71, 0xcd, 0xcf, 0xd1, 0xce, 0x40, 0x73, 0xda,
-	0xda, 0xb9, 0x19, 0x39, 0xe2, 0x76, 0xfc, 0x21, 0xbe, 0x1b, 0xb0, 0x32, 0x65, 0x31, 0x94, 0x28,
-	0x7b, 0xc8, 0x7a, 0xe6, 0xd4, 0x42, 0x5b, 0x57, 0x7d, 0xf7, 0x95, 0xb9, 0x91, 0x5c, 0x90, 0x98,
-	0xd3, 0x5e, 0x96, 0xa3, 0x8d, 0x92, 0x44, 0x36, 0x96, 0x4f, 0xdb, 0xb6, 0x66, 0xa3, 0xdd, 0x34,
-	0x6c, 0xf4, 0xcd, 0x80, 0xea, 0xf8, 0x1f, 0x0d, 0x99, 0x9a, 0x57, 0xee, 0x6f, 0x2e, 0x87, 0xb3,
-	0x1c, 0x70, 0x36, 0x3d, 0xaa, 0xba, 0x31, 0x97, 0x58, 0x32, 0x1e, 0x84, 0x63, 0x14, 0xed, 0xe5,
-	0x63, 0xaa, 0x1e, 0xe7, 0xbc, 0x87, 0xec, 0x99, 0x38, 0x3b, 0x37, 0xcc, 0xbf, 0x6d, 0x95, 0xf4,
-	0xaf, 0xe2, 0xe5, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x87, 0xb8, 0x11, 0x86, 0x07, 0x07, 0x00,
-	0x00,
+	// 1527 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcf, 0x6f, 0x1b, 0x45,
+	0x14, 0xee, 0xae, 0x1d, 0x3b, 0x7e, 0x76, 0x12, 0x67, 0xda, 0x34, 0x8e, 0xfb, 0x6b, 0x70, 0x68,
+	0x09, 0x56, 0x6a, 0x43, 0xda, 0x0b, 0xa9, 0x40, 0xda, 0x3a, 0x4e, 0x31, 0x0d, 0x4e, 0x58, 0xdb,
+	0xad, 0x38, 0x59, 0x63, 0x7b, 0xec, 0xac, 0x58, 0xef, 0x6e, 0x76, 0xc7, 0x4d, 0xa2, 0xaa, 0x1c,
+	0xe8, 0x05, 0x21, 0x21, 0xa1, 0x70, 0xe4, 0xde, 0x03, 0x42, 0x48, 0x88, 0x43, 0x25, 0x38, 0xf4,
+	0xc8, 0x1f, 0xc0, 0x91, 0x2b, 0xff, 0x02, 0x77, 0xb4, 0xb3, 0xbb, 0xce, 0x7a, 0xd7, 0x8e, 0xa3,
+	0x56, 0x3d, 0xad, 0xe6, 0xbd, 0x37, 0x33, 0xdf, 0x7b, 0xdf, 0xf7, 0x66, 0x66, 0x01, 0x31, 0x93,
+	0x68, 0x16, 0x69, 0x33, 0x45, 0xd7, 0xac, 0x82, 0x61, 0xea, 0x4c, 0x47, 0x11, 0x62, 0x28, 0xd9,
+	0xb9, 0x9e, 0xaa, 0xb7, 0x88, 0xea, 0xda, 0xb2, 0xf3, 0xa4, 0xdd, 0xd6, 0x07, 0x1a, 0xf3, 0xc6,
+	0x37, 0x7a, 0xba, 0xde, 0x53, 0x69, 0x91, 0x8f, 0x5a, 0x83, 0x6e, 0x91, 0x29, 0x7d, 0x6a, 0x31,
+	0xd2, 0x37, 0xdc, 0x80, 0xeb, 0xc1, 0x80, 0x43, 0x93, 0x18, 0x06, 0x35, 0xbd, 0x05, 0xae, 0xba,
+	0x7e, 0x62, 0x28, 0x45, 0xa2, 0x69, 0x3a, 0x23, 0x3e, 0x08, 0xd9, 0x75, 0xfe, 0x69, 0xdf, 0xee,
+	0x51, 0xed, 0xb6, 0x75, 0x48, 0x7a, 0x3d, 0x6a, 0x16, 0x75, 0x83, 0x47, 0x84, 0xa3, 0x73, 0xcf,
+	0x23, 0x70, 0x79, 0x47, 0xb1, 0x58, 0xfd, 0x34, 0x17, 0x99, 0x1e, 0x0c, 0xa8, 0xc5, 0xd0, 0x2a,
+	0xc4, 0x0c, 0xd2, 0x53, 0xb4, 0x5e, 0x46, 0xc0, 0xc2, 0x5a, 0x72, 0x23, 0x59, 0x20, 0x86, 0x52,
+	0xd8, 0xe3, 0x26, 0xd9, 0x75, 0xa1, 0x4b, 0x30, 0xa3, 0x9b, 0x1d, 0x6a, 0x66, 0x44, 0x2c, 0xac,
+	0x25, 0x64, 0x67, 0x80, 0x3e, 0x86, 0x54, 0xdb, 0xa4, 0x84, 0xd1, 0x4e, 0xb3, 0x6b, 0xea, 0xfd,
+	0x4c, 0x84, 0x2f, 0x90, 0x2d, 0x38, 0xc0, 0x0b, 0x5e, 0x62, 0x85, 0xba, 0x97, 0xb9, 0x9c, 0x74,
+	0xe3, 0xb7, 0x4d, 0xbd, 0x8f, 0x3e, 0x02, 0xf0, 0xa6, 0x33, 0x3d, 0x13, 0x9d, 0x3a, 0x39, 0xe1,
+	0x46, 0xd7, 0x75, 0x74, 0x0f, 0xa0, 0xaf, 0x68, 0x4d, 0xd2, 0xb7, 0x2b, 0x9e, 0x99, 0xe1, 0x53,
+	0xaf, 0x86, 0xa6, 0x6e, 0xe9, 0x83, 0x96, 0x4a, 0x1f, 0x11, 0x75, 0x40, 0xe5, 0x44, 0x5f, 0xd1,
+	0x24, 0x1e, 0xce, 0x27, 0x93, 0x23, 0x6f, 0x72, 0xec, 0x5c, 0x93, 0xc9, 0x91, 0x3b, 0x79, 0x05,
+	0x66, 0x7b, 0xa6, 0x3e, 0x30, 0x9a, 0x4a, 0x27, 0x13, 0xc7, 0xc2, 0xda, 0x8c, 0x1c, 0xe7, 0xe3,
+	0x4a, 0x07, 0xdd, 0x80, 0x24, 0xa3, 0x66, 0x5f, 0xd1, 0x88, 0x6a, 0x7b, 0x67, 0x79, 0xa9, 0xc0,
+	0x33, 0x55, 0x3a, 0xb9, 0x5f, 0x22, 0x80, 0x03, 0x2c, 0x58, 0xf7, 0x8f, 0x25, 0x47, 0x37, 0x1e,
+	0x1f, 0xd7, 0x00, 0x5c, 0x25, 0xd9, 0x8b, 0x08, 0x7c, 0x8b, 0x84, 0x6b, 0xa9, 0x74, 0x7c, 0x74,
+	0x89, 0xe7, 0xa0, 0x2b, 0x72, 0x16, 0x5d, 0xd1, 0x37, 0xa1, 0x6b, 0xe6, 0xf5, 0xe9, 0x8a, 0xbd,
+	0x09, 0x5d, 0xf1, 0xd7, 0xa7, 0x6b, 0xf6, 0x4c, 0xba, 0x12, 0x21, 0xba, 0xfe, 0x12, 0x60, 0xa5,
+	0x7c, 0x64, 0xe8, 0xe6, 0x08, 0x61, 0x1e, 0x4f, 0x05, 0x88, 0xf2, 0x2a, 0x0a, 0x53, 0x0b, 0xc1,
+	0xe3, 0x50, 0x1e, 0x44, 0xa6, 0x67, 0xc4, 0xa9, 0xd1, 0x22, 0xd3, 0x47, 0x50, 0x47, 0x46, 0x51,
+	0x8f, 0xca, 0x23, 0x1a, 0x94, 0xc7, 0x90, 0xf9, 0x19, 0x1f, 0xf3, 0xb9, 0x01, 0x64, 0x1e, 0x13,
+	0xd6, 0xde, 0x1f, 0x97, 0xc7, 0x14, 0xbd, 0xf9, 0xa1, 0x88, 0x67, 0x16, 0x30, 0x12, 0x2a, 0xe0,
+	0x36, 0x2c, 0x3d, 0xa0, 0xe3, 0xce, 0x9c, 0x79, 0x10, 0x87, 0x7b, 0x89, 0x4a, 0x30, 0x29, 0x31,
+	0x80, 0x21, 0xf7, 0xab, 0x00, 0x99, 0x60, 0xdf, 0xc8, 0xd4, 0x32, 0x74, 0xcd, 0xa2, 0xe8, 0x2e,
+	0xa4, 0xfc, 0x27, 0x74, 0x46, 0xc0, 0x91, 0xb5, 0xe4, 0x46, 0x9a, 0xb7, 0x85, 0x7f, 0xeb, 0x91,
+	0x28, 0x8e, 0x5d, 0x67, 0x44, 0x6d, 0xf2, 0x3d, 0xdc, 0x2d, 0x81, 0x9b, 0x4a, 0x5c, 0x38, 0xb7,
+	0x60, 0x41, 0xa3, 0x47, 0xac, 0x69, 0x90, 0x1e, 0x6d, 0x32, 0xfd, 0x2b, 0xaa, 0xb9, 0x09, 0xce,
+	0xd9, 0xe6, 0x3d, 0xd2, 0xa3, 0x75, 0xdb, 0xb8, 0x79, 0xf1, 0x44, 0x4a, 0xc3, 0x7c, 0x3e, 0xe5,
+	0xc7, 0x96, 0xfb, 0x4f, 0x84, 0xa4, 0xcf, 0x10, 0xca, 0xf7, 0x0a, 0x24, 0x74, 0xb5, 0xd3, 0xb4,
+	0x88, 0xda, 0x71, 0x24, 0x21, 0xc8, 0xb3, 0xba, 0xda, 0xa9, 0xd9, 0x63, 0xdb, 0xa9, 0xd1, 0x43,
+	0xd7, 0x19, 0x71, 0x9c, 0x1a, 0x3d, 0x74, 0x9c, 0x97, 0x21, 0xe6, 0x36, 0x42, 0x94, 0x7b, 0xdc,
+	0x11, 0xba, 0x0b, 0x71, 0xb7, 0xdd, 0xce, 0xd1, 0x99, 0x5e, 0x28, 0xba, 0x05, 0x71, 0xb7, 0xca,
+	0x6e, 0x53, 0xa6, 0x78, 0xd9, 0xbc, 0x13, 0xc9, 0x73, 0x06, 0x99, 0x8e, 0x07, 0x99, 0x46, 0xef,
+	0x43, 0x94, 0x1d, 0x1b, 0x94, 0xb7, 0xd8, 0xfc, 0xc6, 0x52, 0xb0, 0xf8, 0x85, 0xfa, 0xb1, 0x41,
+	0x65, 0x1e, 0x82, 0xde, 0x81, 0x54, 0x7b, 0x5f, 0x31, 0x9a, 0x2d, 0xa2, 0x12, 0xad, 0x4d, 0x79,
+	0xdf, 0xa5, 0xe4, 0xa4, 0x6d, 0xbb, 0xef, 0x98, 0x72, 0xab, 0x10, 0xb5, 0x27, 0xa0, 0x24, 0xc4,
+	0xf7, 0xa4, 0x2f, 0x3f, 0x2f, 0x57, 0xeb, 0xe9, 0x0b, 0x68, 0x1e, 0x40, 0xda, 0xfa, 0xac, 0x51,
+	0xab, 0xf3, 0xb1, 0xb0, 0x89, 0x4e, 0xa4, 0x05, 0x98, 0xcb, 0xfb, 0xeb, 0x9c, 0xfb, 0x4d, 0x80,
+	0x4c, 0x89, 0xe7, 0x36, 0x46, 0x74, 0xa7, 0xa5, 0x8b, 0x8c, 0x94, 0x6e, 0x4a, 0x47, 0x05, 0x72,
+	0x9f, 0x09, 0xe5, 0x1e, 0x4c, 0x28, 0x16, 0x4a, 0x68, 0x33, 0x7b, 0x22, 0x2d, 0xc3, 0x52, 0xfe,
+	0xa2, 0x0f, 0x15, 0x87, 0x69, 0x63, 0xfe, 0x53, 0x00, 0xb4, 0xdb, 0xed, 0xaa, 0x8a, 0xe6, 0x07,
+	0x8d, 0xb2, 0x30, 0x6b, 0xd9, 0xc0, 0xed, 0x15, 0x6d, 0xe1, 0x44, 0xe5, 0xe1, 0x78, 0x4a, 0xbb,
+	0x4c, 0x4c, 0xd4, 0xa7, 0x91, 0xe8, 0xf9, 0x35, 0x72, 0x15, 0x12, 0x96, 0xd2, 0xd3, 0x08, 0x1b,
+	0x98, 0x94, 0x67, 0x9f, 0x92, 0x4f, 0x0d, 0xb9, 0xaf, 0xe1, 0x7a, 0xed, 0x58, 0x6b, 0x87, 0x13,
+	0x18, 0x9e, 0x2f, 0x81, 0xfa, 0x09, 0xa1, 0xfa, 0xdd, 0x0b, 0x34, 0xb0, 0xc8, 0x1b, 0x78, 0x99,
+	0x6b, 0x28, 0xbc, 0xee, 0x68, 0x1f, 0xe7, 0xfe, 0x10, 0xe0, 0xc6, 0x44, 0x00, 0xee, 0x09, 0x91,
+	0x87, 0x38, 0x31, 0x0c, 0x55, 0xa1, 0x9d, 0x89, 0x87, 0x83, 0x17, 0x80, 0x36, 0x20, 0xd1, 0xd6,
+	0xb5, 0xae, 0xaa, 0xb4, 0x99, 0x87, 0xe4, 0x92, 0x1f, 0x49, 0xc9, 0x75, 0xca, 0xa7, 0x61, 0x68,
+	0x15, 0xe6, 0x54, 0x62, 0xb1, 0xe6, 0x90, 0xaf, 0x08, 0xe7, 0x2b, 0x65, 0x1b, 0x6b, 0xae, 0x6d,
+	0x28, 0x57, 0x77, 0x1d, 0x1b, 0x73, 0xee, 0x85, 0x08, 0x0b, 0x81, 0x75, 0xdf, 0x06, 0xef, 0x77,
+	0x20, 0x66, 0x52, 0x62, 0xe9, 0x1a, 0xa7, 0x7d, 0x7e, 0xe3, 0xca, 0xb8, 0x84, 0x0a, 0x32, 0x0f,
+	0x91, 0xdd, 0xd0, 0xdc, 0x77, 0x02, 0xc4, 0x1c, 0x93, 0xdd, 0x86, 0x8d, 0xea, 0xc3, 0xea, 0xee,
+	0xe3, 0x6a, 0xfa, 0x02, 0x5a, 0x82, 0xc5, 0x4a, 0xf5, 0x91, 0xb4, 0x53, 0xd9, 0x6a, 0xd6, 0x2a,
+	0x0f, 0xaa, 0x52, 0xbd, 0x21, 0x97, 0xd3, 0x02, 0xba, 0x0c, 0x68, 0xab, 0xb1, 0xb7, 0x53, 0x29,
+	0x49, 0xf5, 0x72, 0xb3, 0x56, 0xfe, 0xa2, 0x51, 0xae, 0x96, 0xca, 0x69, 0xd1, 0x0e, 0x97, 0x4a,
+	0xa5, 0xdd, 0x46, 0xb5, 0xde, 0xac, 0xee, 0xd6, 0x9b, 0xdb, 0xbb, 0x8d, 0xea, 0x56, 0x3a, 0x82,
+	0x2e, 0xc2, 0x82, 0x67, 0xbe, 0xbf, 0xb3, 0x5b, 0x7a, 0x58, 0xde, 0x4a, 0x47, 0x51, 0x06, 0x2e,
+	0x55, 0xaa, 0xb5, 0xc6, 0xf6, 0x76, 0xa5, 0x54, 0x29, 0xdb, 0x1e, 0x69, 0x47, 0xb2, 0x57, 0x99,
+	0xd9, 0xf8, 0x3e, 0x09, 0xfe, 0xde, 0xb1, 0x6a, 0xd4, 0x7c, 0xa2, 0xb4, 0x29, 0xfa, 0x56, 0x84,
+	0x74, 0xf0, 0x62, 0x40, 0x4e, 0x7a, 0xe3, 0x5f, 0xbb, 0xd9, 0x6b, 0xe3, 0x9c, 0x43, 0xa9, 0xe4,
+	0x5e, 0x0a, 0x27, 0xd2, 0x4f, 0x42, 0xf6, 0xc0, 0x0e, 0xb0, 0x30, 0x51, 0x55, 0xec, 0x57, 0xdb,
+	0x3a, 0x6e, 0x13, 0x0d, 0xb7, 0x28, 0x56, 0x95, 0xbe, 0xc2, 0x68, 0x07, 0x1f, 0x2a, 0x6c, 0x1f,
+	0x3b, 0x6f, 0x2e, 0xec, 0x3e, 0xbe, 0x31, 0xd1, 0x3a, 0xb8, 0xab, 0xa8, 0x8c, 0x9a, 0xb4, 0x83,
+	0x5b, 0xc7, 0xd8, 0x7e, 0xf6, 0x63, 0x93, 0x68, 0x3d, 0xba, 0x8e, 0x1d, 0x42, 0xd6, 0x31, 0xbf,
+	0x41, 0x79, 0xa4, 0xd7, 0x07, 0xf9, 0x25, 0x7b, 0xcb, 0xd0, 0x8e, 0xad, 0x05, 0x98, 0x83, 0x04,
+	0xbf, 0x69, 0xa4, 0x01, 0xdb, 0x47, 0x17, 0xbe, 0xf9, 0xfb, 0xdf, 0x1f, 0x45, 0x84, 0xd2, 0xc5,
+	0x27, 0x1f, 0x16, 0x47, 0x2e, 0xb4, 0x57, 0x22, 0xac, 0x4c, 0x7c, 0x5b, 0xa2, 0x9b, 0x63, 0xd3,
+	0x0e, 0xbe, 0x3d, 0xa7, 0x55, 0xe7, 0x1f, 0xe1, 0x44, 0xfa, 0x59, 0xc8, 0x3e, 0x17, 0x4e, 0xcb,
+	0xe3, 0x8f, 0xc3, 0x5d, 0xdd, 0xc4, 0x3d, 0xe5, 0x09, 0xd5, 0xb0, 0x2b, 0xca, 0xb7, 0x59, 0xb0,
+	0x45, 0x5e, 0xb0, 0xe9, 0xc5, 0x7a, 0x0f, 0xdd, 0xb4, 0x8b, 0xe5, 0x22, 0x2a, 0x3e, 0x3d, 0xed,
+	0xa0, 0x67, 0xa3, 0x15, 0x7c, 0x29, 0xc0, 0x62, 0xe8, 0xf2, 0x40, 0x4e, 0x49, 0x26, 0x5d, 0x2a,
+	0xd9, 0xd0, 0x51, 0x92, 0x3b, 0x38, 0x91, 0x3e, 0xc9, 0x2e, 0x3b, 0x13, 0x2c, 0xac, 0xd1, 0x43,
+	0x3f, 0xc6, 0x3c, 0x72, 0x1c, 0x7e, 0xdb, 0x78, 0xd8, 0xf9, 0xdc, 0xf9, 0x60, 0x6f, 0x0a, 0x79,
+	0xf4, 0xbb, 0x00, 0xf3, 0xa3, 0x0f, 0x2d, 0x94, 0xe5, 0xb8, 0xc6, 0xbe, 0xbe, 0xc6, 0x60, 0xb6,
+	0x6c, 0xcc, 0x59, 0x99, 0xb2, 0x81, 0xa9, 0x59, 0xd8, 0x52, 0xb4, 0x9e, 0x3a, 0x02, 0x31, 0xbf,
+	0xf0, 0x80, 0xb2, 0xe9, 0x98, 0xd7, 0x51, 0xfe, 0x5c, 0x98, 0x8b, 0x4f, 0x95, 0xce, 0x33, 0xf4,
+	0x42, 0x84, 0xe5, 0x09, 0x47, 0x37, 0x5a, 0xe5, 0x10, 0xcf, 0xbe, 0x59, 0xb2, 0xef, 0x9e, 0x1d,
+	0xe4, 0x8a, 0xf6, 0x95, 0x70, 0x22, 0xfd, 0x20, 0x64, 0x5b, 0x32, 0x35, 0x54, 0x72, 0x6c, 0x61,
+	0xb6, 0x4f, 0xb1, 0x7d, 0x7f, 0xd1, 0xce, 0x88, 0x78, 0x30, 0x19, 0x4a, 0x0b, 0x1f, 0x0c, 0xe8,
+	0xc0, 0x16, 0xeb, 0xbe, 0xa2, 0x52, 0xac, 0x30, 0x7c, 0x48, 0x2c, 0xac, 0x3b, 0x5b, 0xac, 0x63,
+	0x45, 0xc3, 0xfc, 0x51, 0x8d, 0xf5, 0xae, 0xbd, 0x96, 0x62, 0x62, 0xef, 0x7c, 0xce, 0xaf, 0xd8,
+	0x58, 0xbc, 0xc8, 0x73, 0x48, 0xf3, 0x66, 0x0e, 0xf3, 0x3e, 0x76, 0xf7, 0xb5, 0x8a, 0x4f, 0x7d,
+	0x37, 0xe5, 0xb3, 0xa2, 0x75, 0xac, 0xb5, 0x6d, 0x7a, 0x3f, 0x05, 0x14, 0xfe, 0x0d, 0x41, 0xd7,
+	0x79, 0xf6, 0x13, 0xff, 0x4f, 0xc2, 0x2c, 0x7f, 0x20, 0xa0, 0x6d, 0x58, 0x0c, 0xfd, 0x07, 0xb8,
+	0x0a, 0x9f, 0xf4, 0x7f, 0x30, 0x6e, 0x9d, 0x56, 0x8c, 0x3f, 0x18, 0xee, 0xfc, 0x3f, 0x00, 0x4d,
+	0xc0, 0x94, 0x54, 0x1c, 0x11, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -426,6 +1077,13 @@ type TransactionsServiceClient interface {
 	ListTransactionsByAccount(ctx context.Context, in *ListTransactionsByAccountRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
 	CreateTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
 	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	SyncOfflineTransactions(ctx context.Context, in *SyncOfflineTransactionsRequest, opts ...grpc.CallOption) (*SyncOfflineTransactionsResponse, error)
+	// ExportTransactions streams all transactions that match the filter,
+	// the gateway serves it as csv or json lines on /v1/transactions/export
+	ExportTransactions(ctx context.Context, in *ExportTransactionsRequest, opts ...grpc.CallOption) (TransactionsService_ExportTransactionsClient, error)
+	// WatchTransactions streams every new transaction that matches the filter as soon as it is saved,
+	// the gateway serves it as server-sent events on /v1/transactions/watch
+	WatchTransactions(ctx context.Context, in *WatchTransactionsRequest, opts ...grpc.CallOption) (TransactionsService_WatchTransactionsClient, error)
 }
 
 type transactionsServiceClient struct {
@@ -472,12 +1130,92 @@ func (c *transactionsServiceClient) GetTransaction(ctx context.Context, in *GetT
 	return out, nil
 }
 
+func (c *transactionsServiceClient) SyncOfflineTransactions(ctx context.Context, in *SyncOfflineTransactionsRequest, opts ...grpc.CallOption) (*SyncOfflineTransactionsResponse, error) {
+	out := new(SyncOfflineTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/api.TransactionsService/SyncOfflineTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionsServiceClient) ExportTransactions(ctx context.Context, in *ExportTransactionsRequest, opts ...grpc.CallOption) (TransactionsService_ExportTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TransactionsService_serviceDesc.Streams[0], "/api.TransactionsService/ExportTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &transactionsServiceExportTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TransactionsService_ExportTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type transactionsServiceExportTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *transactionsServiceExportTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *transactionsServiceClient) WatchTransactions(ctx context.Context, in *WatchTransactionsRequest, opts ...grpc.CallOption) (TransactionsService_WatchTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TransactionsService_serviceDesc.Streams[1], "/api.TransactionsService/WatchTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &transactionsServiceWatchTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TransactionsService_WatchTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type transactionsServiceWatchTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *transactionsServiceWatchTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TransactionsServiceServer is the server API for TransactionsService service.
 type TransactionsServiceServer interface {
 	ListTransactions(context.Context, *ListTransactionRequest) (*ListTransactionsResponse, error)
 	ListTransactionsByAccount(context.Context, *ListTransactionsByAccountRequest) (*ListTransactionsResponse, error)
 	CreateTransaction(context.Context, *CreateTransactionRequest) (*Transaction, error)
 	GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error)
+	SyncOfflineTransactions(context.Context, *SyncOfflineTransactionsRequest) (*SyncOfflineTransactionsResponse, error)
+	// ExportTransactions streams all transactions that match the filter,
+	// the gateway serves it as csv or json lines on /v1/transactions/export
+	ExportTransactions(*ExportTransactionsRequest, TransactionsService_ExportTransactionsServer) error
+	// WatchTransactions streams every new transaction that matches the filter as soon as it is saved,
+	// the gateway serves it as server-sent events on /v1/transactions/watch
+	WatchTransactions(*WatchTransactionsRequest, TransactionsService_WatchTransactionsServer) error
 }
 
 // UnimplementedTransactionsServiceServer can be embedded to have forward compatible implementations.
@@ -496,6 +1234,15 @@ func (*UnimplementedTransactionsServiceServer) CreateTransaction(ctx context.Con
 func (*UnimplementedTransactionsServiceServer) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*Transaction, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
 }
+func (*UnimplementedTransactionsServiceServer) SyncOfflineTransactions(ctx context.Context, req *SyncOfflineTransactionsRequest) (*SyncOfflineTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncOfflineTransactions not implemented")
+}
+func (*UnimplementedTransactionsServiceServer) ExportTransactions(req *ExportTransactionsRequest, srv TransactionsService_ExportTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTransactions not implemented")
+}
+func (*UnimplementedTransactionsServiceServer) WatchTransactions(req *WatchTransactionsRequest, srv TransactionsService_WatchTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTransactions not implemented")
+}
 
 func RegisterTransactionsServiceServer(s *grpc.Server, srv TransactionsServiceServer) {
 	s.RegisterService(&_TransactionsService_serviceDesc, srv)
@@ -573,6 +1320,66 @@ func _TransactionsService_GetTransaction_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionsService_SyncOfflineTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncOfflineTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionsServiceServer).SyncOfflineTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.TransactionsService/SyncOfflineTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionsServiceServer).SyncOfflineTransactions(ctx, req.(*SyncOfflineTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionsService_ExportTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransactionsServiceServer).ExportTransactions(m, &transactionsServiceExportTransactionsServer{stream})
+}
+
+type TransactionsService_ExportTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type transactionsServiceExportTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *transactionsServiceExportTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TransactionsService_WatchTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TransactionsServiceServer).WatchTransactions(m, &transactionsServiceWatchTransactionsServer{stream})
+}
+
+type TransactionsService_WatchTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type transactionsServiceWatchTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *transactionsServiceWatchTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _TransactionsService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.TransactionsService",
 	HandlerType: (*TransactionsServiceServer)(nil),
@@ -593,7 +1400,22 @@ var _TransactionsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransaction",
 			Handler:    _TransactionsService_GetTransaction_Handler,
 		},
+		{
+			MethodName: "SyncOfflineTransactions",
+			Handler:    _TransactionsService_SyncOfflineTransactions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportTransactions",
+			Handler:       _TransactionsService_ExportTransactions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchTransactions",
+			Handler:       _TransactionsService_WatchTransactions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "transactions.proto",
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authenticateMethod = "/api.UserService/AuthenticateUser"
+
+// session logs in to the server and adds the access token to every call
+type session struct {
+	users    api.UserServiceClient
+	username string
+	password string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// dialServer connects to the configured grpc server, authorize adds the credentials to every call
+func dialServer(ctx context.Context, authorize grpc.UnaryClientInterceptor) (*grpc.ClientConn, error) {
+	certFile := viper.GetString("server.tls_cert")
+	creds, err := credentials.NewClientTLSFromFile(certFile, "nfc-cash-system.local")
+	if err != nil {
+		return nil, fmt.Errorf("could not create credentials from %q: %v", certFile, err)
+	}
+
+	address := viper.GetString("server.address")
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(creds), grpc.WithUnaryInterceptor(authorize))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to server %q: %v", address, err)
+	}
+	return conn, nil
+}
+
+// dialAsUser connects to the server and logs in with the configured user
+func dialAsUser(ctx context.Context) (*grpc.ClientConn, error) {
+	s := &session{
+		username: viper.GetString("server.username"),
+		password: viper.GetString("server.password"),
+	}
+	if s.username == "" || s.password == "" {
+		return nil, fmt.Errorf("required settings server.username and server.password are missing")
+	}
+
+	conn, err := dialServer(ctx, s.authorize)
+	if err != nil {
+		return nil, err
+	}
+	s.users = api.NewUserServiceClient(conn)
+
+	if _, err := s.accessToken(ctx); err != nil {
+		//noinspection GoUnhandledErrorResult
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// dialAsKiosk connects to the server with the configured kiosk key, the connection can only be used for the KioskService
+func dialAsKiosk(ctx context.Context) (*grpc.ClientConn, error) {
+	key := viper.GetString("server.kiosk_key")
+	if key == "" {
+		return nil, fmt.Errorf("required setting server.kiosk_key is missing")
+	}
+
+	return dialServer(ctx, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Kiosk "+key)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
+
+// authorize adds the access token to all calls except the login
+func (s *session) authorize(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if method != authenticateMethod {
+		token, err := s.accessToken(ctx)
+		if err != nil {
+			return err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// accessToken returns the access token, the user is logged in again shortly before the token expires
+func (s *session) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(time.Minute).Before(s.expires) {
+		return s.token, nil
+	}
+
+	basic := base64.StdEncoding.EncodeToString([]byte(s.username + ":" + s.password))
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Basic "+basic)
+	res, err := s.users.AuthenticateUser(ctx, &empty.Empty{})
+	if err != nil {
+		// the code is kept, so callers can tell an unavailable server from wrong credentials
+		return "", status.Errorf(status.Code(err), "could not log in as %q: %s", s.username, status.Convert(err).Message())
+	}
+
+	s.token = res.AccessToken
+	s.expires = time.Unix(res.ExpiresIn, 0)
+	return s.token, nil
+}
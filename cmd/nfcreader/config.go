@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	minPollingTimeOut = 10 * time.Millisecond
+	maxPollingTimeOut = 10 * time.Second
+)
+
+// flagKeys maps the flags to the config keys
+var flagKeys = map[string]string{
+	"device":         "device",
+	"cards":          "cards",
+	"polling":        "polling",
+	"remove-after":   "remove_after",
+	"cooldown":       "cooldown",
+	"server":         "server.address",
+	"tls-cert":       "server.tls_cert",
+	"username":       "server.username",
+	"password":       "server.password",
+	"kiosk-key":      "server.kiosk_key",
+	"transactions":   "kiosk.transactions",
+	"bridge-address": "bridge.address",
+	"origins":        "bridge.origins",
+	"token":          "bridge.token",
+	"terminal-id":    "terminal.id",
+	"chip-key":       "terminal.chip_key",
+	"terminal-key":   "terminal.key",
+	"queue":          "terminal.queue",
+}
+
+func initConfig() error {
+	// device is a libnfc connection string, auto, the index of a libnfc device, sim:<script> or tcp:<address>
+	viper.SetDefault("device", nfcreader.AutoDevice)
+	viper.SetDefault("cards", []string{nfcreader.ISO14443A.String()})
+	viper.SetDefault("polling", "100ms")
+	viper.SetDefault("remove_after", nfcreader.DefaultRemoveAfter.String())
+	viper.SetDefault("cooldown", nfcreader.DefaultCooldown.String())
+	viper.SetDefault("server.address", "localhost:50051")
+	viper.SetDefault("server.tls_cert", "./cert.pem")
+	viper.SetDefault("server.username", "")
+	viper.SetDefault("server.password", "")
+	// server.kiosk_key is the kiosk credential of the server, kiosk mode does not log in as user
+	viper.SetDefault("server.kiosk_key", "")
+	viper.SetDefault("kiosk.transactions", 5)
+	viper.SetDefault("bridge.address", "127.0.0.1:4433")
+	viper.SetDefault("bridge.origins", []string{"http://localhost:8080"})
+	// bridge.token is the pairing token of the bridge, a random token is created if it is empty
+	viper.SetDefault("bridge.token", "")
+	// terminal.chip_key is the chip_balance_key of the server, terminal.key the ed25519 key the offline charges are signed with
+	viper.SetDefault("terminal.id", "")
+	viper.SetDefault("terminal.chip_key", "")
+	viper.SetDefault("terminal.key", "./terminal.key")
+	viper.SetDefault("terminal.queue", "./offline-queue.json")
+
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("$HOME/.nfc-cash-system/nfcreader")
+	viper.AddConfigPath(".")
+
+	viper.SetEnvPrefix("nfcreader")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return fmt.Errorf("could not load config: %w", err)
+		}
+	}
+
+	if err := viper.SafeWriteConfigAs("./config.yml"); err != nil {
+		if _, ok := err.(viper.ConfigFileAlreadyExistsError); !ok {
+			return fmt.Errorf("could not save config: %w", err)
+		}
+	}
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.String("device", viper.GetString("device"), "reader: libnfc connection string, auto, device index, sim:<script> or tcp:<host:port>")
+	flags.StringSlice("cards", viper.GetStringSlice("cards"), "card types to poll: iso14443a, iso14443b, felica, jewel")
+	flags.Duration("polling", viper.GetDuration("polling"), "time between two polling cycles of a libnfc device")
+	flags.Duration("remove-after", viper.GetDuration("remove_after"), "time after the last read until a card counts as removed")
+	flags.Duration("cooldown", viper.GetDuration("cooldown"), "time after a tap in which the same card is ignored")
+	flags.String("server", viper.GetString("server.address"), "address of the grpc server")
+	flags.String("tls-cert", viper.GetString("server.tls_cert"), "TLS certificate of the grpc server")
+	flags.String("username", viper.GetString("server.username"), "register, charge: username to log in to the server")
+	flags.String("password", viper.GetString("server.password"), "register, charge: password to log in to the server")
+	flags.String("kiosk-key", viper.GetString("server.kiosk_key"), "kiosk: kiosk key of the server")
+	flags.Int32("transactions", viper.GetInt32("kiosk.transactions"), "kiosk: number of transactions that are shown")
+	flags.String("bridge-address", viper.GetString("bridge.address"), "serve: address of the bridge, it should only listen on localhost")
+	flags.StringSlice("origins", viper.GetStringSlice("bridge.origins"), "serve: origins that are allowed to connect, * allows all")
+	flags.String("token", viper.GetString("bridge.token"), "serve: pairing token, a random token is created if it is empty")
+	flags.String("terminal-id", viper.GetString("terminal.id"), "charge: id of the terminal on the server")
+	flags.String("chip-key", viper.GetString("terminal.chip_key"), "charge: key the chip balances are signed with")
+	flags.String("terminal-key", viper.GetString("terminal.key"), "charge: file of the key the offline charges are signed with, it is created if it does not exist")
+	flags.String("queue", viper.GetString("terminal.queue"), "charge: file the offline charges are queued in")
+	if len(os.Args) > 2 {
+		_ = flags.Parse(os.Args[2:])
+	}
+
+	for name, key := range flagKeys {
+		if err := viper.BindPFlag(key, flags.Lookup(name)); err != nil {
+			return fmt.Errorf("could not bind flag values: %v", err)
+		}
+	}
+	// the device can also be passed as argument
+	if device := flags.Arg(0); device != "" {
+		viper.Set("device", device)
+	}
+
+	return checkConfig()
+}
+
+// checkConfig validates the reader settings
+func checkConfig() error {
+	if viper.GetString("device") == "" {
+		return fmt.Errorf("required setting device is missing")
+	}
+
+	if _, err := parseCardTypes(viper.GetStringSlice("cards")); err != nil {
+		return err
+	}
+
+	polling := viper.GetDuration("polling")
+	if polling < minPollingTimeOut || polling > maxPollingTimeOut {
+		return fmt.Errorf("polling %v has to be between %v and %v", polling, minPollingTimeOut, maxPollingTimeOut)
+	}
+	if removeAfter := viper.GetDuration("remove_after"); removeAfter <= polling {
+		return fmt.Errorf("remove_after %v has to be longer than polling %v", removeAfter, polling)
+	}
+	if cooldown := viper.GetDuration("cooldown"); cooldown < 0 {
+		return fmt.Errorf("cooldown %v must not be negative", cooldown)
+	}
+	if transactions := viper.GetInt32("kiosk.transactions"); transactions < 0 {
+		return fmt.Errorf("kiosk.transactions %d must not be negative", transactions)
+	}
+	return nil
+}
+
+// parseCardTypes parses the names of card types
+func parseCardTypes(names []string) ([]nfcreader.CardType, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("required setting cards is missing")
+	}
+
+	types := make([]nfcreader.CardType, 0, len(names))
+	for _, name := range names {
+		cardType, err := nfcreader.ParseCardType(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, cardType)
+	}
+	return types, nil
+}
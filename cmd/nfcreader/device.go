@@ -0,0 +1,29 @@
+//go:build cgo
+// +build cgo
+
+package main
+
+import (
+	"log"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+	"github.com/spf13/viper"
+)
+
+// the terminal writes the chip balances with libnfc devices
+var _ tagSelector = (*nfcreader.Device)(nil)
+
+// configureDevice applies the polling config to dev if it is a libnfc device
+func configureDevice(deviceName string, dev nfcreader.Reader, cardTypes []nfcreader.CardType, debouncer *nfcreader.Debouncer) {
+	r, ok := dev.(*nfcreader.Device)
+	if !ok {
+		return
+	}
+
+	r.PollingTimeOut = viper.GetDuration("polling")
+	r.Modulations = nfcreader.Modulations(cardTypes...)
+	r.Debouncer = debouncer
+	r.OnRetry = func(err error, attempt int) {
+		log.Printf("device %q: retry %d after error: %v", deviceName, attempt, err)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !cgo
+// +build !cgo
+
+package main
+
+import "github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+
+// configureDevice does nothing, without cgo nfcreader.Open returns no libnfc devices
+func configureDevice(deviceName string, dev nfcreader.Reader, cardTypes []nfcreader.CardType, debouncer *nfcreader.Debouncer) {
+}
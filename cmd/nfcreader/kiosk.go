@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// kioskTimeout is the time a balance request may take before the kiosk waits for the next chip
+const kioskTimeout = 5 * time.Second
+
+// runKiosk shows the balance of every chip that is put on the reader, it only uses the kiosk credential
+func runKiosk() error {
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	conn, err := dialAsKiosk(ctx)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer conn.Close()
+
+	deviceName, dev, err := openReader()
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer dev.Close()
+
+	kiosk := api.NewKioskServiceClient(conn)
+	limit := viper.GetInt32("kiosk.transactions")
+
+	events, errs := dev.Listen(ctx)
+	fmt.Printf("device %q ready, put a chip on the reader to see the balance...\n", deviceName)
+	for event := range events {
+		if event.Type != nfcreader.CardPresent {
+			continue
+		}
+		showBalance(ctx, kiosk, event.Card.Uid, limit)
+	}
+	fmt.Printf("device %q: polling stopped \n", deviceName)
+
+	return <-errs
+}
+
+// showBalance loads and prints the balance of the chip
+func showBalance(ctx context.Context, kiosk api.KioskServiceClient, chipId string, limit int32) {
+	ctx, cancel := context.WithTimeout(ctx, kioskTimeout)
+	defer cancel()
+
+	balance, err := kiosk.GetBalance(ctx, &api.GetKioskBalanceRequest{NfcChipId: chipId, TransactionLimit: limit})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			fmt.Println("this chip is not registered")
+			return
+		}
+		log.Printf("chip %s: could not load balance: %v", chipId, err)
+		return
+	}
+	printBalance(os.Stdout, balance)
+}
+
+// printBalance prints the balance as the guest sees it, payments are negative and top ups positive
+func printBalance(w io.Writer, balance *api.KioskBalance) {
+	fmt.Fprintf(w, "\n%s\nsaldo: %.2f\n", balance.AccountName, balance.Saldo)
+	if balance.Status == api.Account_BLOCKED {
+		fmt.Fprintln(w, "this chip is blocked, please contact the top-up desk")
+	}
+	if len(balance.Transactions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "last transactions:")
+	for _, t := range balance.Transactions {
+		created, err := ptypes.Timestamp(t.Created)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "  %s %+8.2f %8.2f\n", created.Local().Format("02.01. 15:04"), -t.Amount, t.NewSaldo)
+	}
+}
@@ -1,13 +1,15 @@
 package main
 
 import (
-	"encoding/hex"
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/fuzxxl/nfc/2.0/nfc"
 	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+	"github.com/spf13/viper"
 )
 
 func main() {
@@ -17,16 +19,35 @@ func main() {
 }
 
 func run() error {
-	//var reciever = flag.String("receiver", "0.0.0.0:4433", "send cardId to this address")
 	if !isArgsLongEnough(1) {
 		return fmt.Errorf("no command arg found")
 	}
+	if err := initConfig(); err != nil {
+		return err
+	}
+
 	switch os.Args[1] {
 	case "poll":
 		err := pollingDevice()
 		if err != nil {
 			return err
 		}
+	case "serve":
+		if err := serveBridge(); err != nil {
+			return err
+		}
+	case "register":
+		if err := registerCards(); err != nil {
+			return err
+		}
+	case "kiosk":
+		if err := runKiosk(); err != nil {
+			return err
+		}
+	case "charge":
+		if err := runTerminal(); err != nil {
+			return err
+		}
 	case "list":
 		if err := listDevices(); err != nil {
 			return err
@@ -38,43 +59,65 @@ func run() error {
 }
 
 func pollingDevice() error {
-	deviceName, err := selectDevice()
-	if err != nil {
-		return err
-	}
-	dev, err := nfcreader.OpenDevice(deviceName)
+	deviceName, dev, err := openReader()
 	if err != nil {
 		return err
 	}
 	//noinspection GoUnhandledErrorResult
 	defer dev.Close()
 
-	// open channel to send uids to
-	listenChan := make(chan []byte)
+	ctx, cancel := interruptContext()
+	defer cancel()
 
-	// listen for targets in goroutine
-	go func(chan []byte) {
-		dev.ListenForCardUids(listenChan)
-	}(listenChan)
+	events, errs := dev.Listen(ctx)
 
 	fmt.Printf("device %q ready, start polling...\n", deviceName)
-	for {
-		uidBytes, open := <-listenChan
-		if !open {
-			fmt.Printf("device %q: polling stopped \n", deviceName)
-			break
-		}
-
-		uidStr := hex.EncodeToString(uidBytes)
+	for event := range events {
 		// todo do something with uid
-		fmt.Printf("%014s\n", uidStr)
+		fmt.Printf("%s %s %s\n", event.Card.Uid, event.Card.Type, event.Type)
 	}
+	fmt.Printf("device %q: polling stopped \n", deviceName)
 
-	if dev.HasError() {
-		return dev.LastErr
+	return <-errs
+}
+
+// openReader opens the configured reader
+func openReader() (string, nfcreader.Reader, error) {
+	cardTypes, err := parseCardTypes(viper.GetStringSlice("cards"))
+	if err != nil {
+		return "", nil, err
 	}
 
-	return nil
+	deviceName := viper.GetString("device")
+	dev, err := nfcreader.Open(deviceName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	debouncer := &nfcreader.Debouncer{
+		RemoveAfter: viper.GetDuration("remove_after"),
+		Cooldown:    viper.GetDuration("cooldown"),
+	}
+	switch r := dev.(type) {
+	case *nfcreader.Simulator:
+		r.Debouncer = debouncer
+	default:
+		configureDevice(deviceName, dev, cardTypes, debouncer)
+	}
+
+	return deviceName, dev, nil
+}
+
+// interruptContext returns a context that is cancelled on interrupt
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		<-stop
+		cancel()
+	}()
+	return ctx, cancel
 }
 
 func isArgsLongEnough(minLength int) bool {
@@ -82,44 +125,20 @@ func isArgsLongEnough(minLength int) bool {
 }
 
 func listDevices() error {
-	devices, err := nfc.ListDevices()
+	devices, err := nfcreader.ListDevices()
 	if err != nil {
 		return err
 	}
 
 	if len(devices) == 0 {
 		fmt.Println("no devices found")
-		return nil
 	}
 
 	for key, dev := range devices {
 		fmt.Printf("[%d] %s \n", key, dev)
 	}
 
+	fmt.Printf("use the index or %q to select a device, %q to simulate cards from a script or stdin and %q for a remote reader\n",
+		nfcreader.AutoDevice, nfcreader.SimulatorPrefix+"<script>", nfcreader.TCPPrefix+"<host:port>")
 	return nil
 }
-
-func selectDevice() (string, error) {
-	if isArgsLongEnough(2) {
-		return os.Args[2], nil
-	}
-	fmt.Printf("no device in arguments found, please select one from this list:\n")
-	err := listDevices()
-	if err != nil {
-		return "", err
-	}
-
-	var selection = 0
-	fmt.Printf("device num:")
-	_, err = fmt.Scanf("%d", &selection)
-	if err != nil {
-		return "", err
-	}
-
-	devices, err := nfc.ListDevices()
-	if err != nil {
-		return "", err
-	}
-
-	return devices[selection], nil
-}
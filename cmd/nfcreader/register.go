@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+	"github.com/spf13/viper"
+)
+
+// accountsPageSize is the number of accounts that are loaded at once while searching a chip,
+// only accounts whose chip id starts with the chip id are loaded
+const accountsPageSize = 100
+
+// registerCards creates an account for every new chip that is put on the reader,
+// chips that are already registered can be topped up
+func registerCards() error {
+	device := viper.GetString("device")
+	if device == nfcreader.SimulatorPrefix || device == nfcreader.SimulatorPrefix+"-" {
+		return fmt.Errorf("register reads the account details from stdin, the simulator needs a script")
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	conn, err := dialAsUser(ctx)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer conn.Close()
+
+	deviceName, dev, err := openReader()
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer dev.Close()
+
+	d := &desk{
+		accounts:     api.NewAccountServiceClient(conn),
+		groups:       api.NewGroupsServiceClient(conn),
+		transactions: api.NewTransactionsServiceClient(conn),
+		prompt:       prompt{in: bufio.NewReader(os.Stdin), out: os.Stdout},
+	}
+
+	events, errs := dev.Listen(ctx)
+	fmt.Printf("device %q ready, put a chip on the reader to register it...\n", deviceName)
+	for event := range events {
+		if event.Type != nfcreader.CardPresent {
+			continue
+		}
+		if err := d.register(ctx, event.Card.Uid); err != nil {
+			if err == io.EOF {
+				cancel()
+				continue
+			}
+			log.Printf("chip %s: %v", event.Card.Uid, err)
+		}
+	}
+	fmt.Printf("device %q: polling stopped \n", deviceName)
+
+	return <-errs
+}
+
+// desk registers chips and tops up accounts, the details are asked on in
+type desk struct {
+	accounts     api.AccountServiceClient
+	groups       api.GroupsServiceClient
+	transactions api.TransactionsServiceClient
+	prompt
+}
+
+// register creates an account for chipId or offers a top-up if the chip is already registered
+func (d *desk) register(ctx context.Context, chipId string) error {
+	account, err := findAccount(ctx, d.accounts, chipId)
+	if err != nil {
+		return err
+	}
+	if account != nil {
+		return d.topUp(ctx, account)
+	}
+	return d.createAccount(ctx, chipId)
+}
+
+// findAccount returns the account of chipId or nil if the chip is not registered.
+// The prefix filter also matches longer chip ids, so the chip id has to match exactly
+func findAccount(ctx context.Context, accounts api.AccountServiceClient, chipId string) (*api.Account, error) {
+	var pageToken string
+	for {
+		res, err := accounts.ListAccounts(ctx, &api.ListAccountsRequest{
+			NfcChipIdPrefix: chipId,
+			Paging:          &api.Paging{Limit: accountsPageSize, PageToken: pageToken, SkipTotalCount: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not load accounts: %v", err)
+		}
+
+		for _, account := range res.Accounts {
+			if strings.EqualFold(account.NfcChipId, chipId) {
+				return account, nil
+			}
+		}
+
+		if res.NextPageToken == "" {
+			return nil, nil
+		}
+		pageToken = res.NextPageToken
+	}
+}
+
+func (d *desk) createAccount(ctx context.Context, chipId string) error {
+	fmt.Fprintf(d.out, "new chip %s\n", chipId)
+	name, err := d.ask("name (empty to skip): ")
+	if err != nil || name == "" {
+		return err
+	}
+
+	groupId, err := d.askGroup(ctx)
+	if err != nil {
+		return err
+	}
+
+	saldo, err := d.askAmount("start saldo [0]: ")
+	if err != nil {
+		return err
+	}
+
+	account, err := d.accounts.CreateAccount(ctx, &api.CreateAccountRequest{
+		Name:      name,
+		Saldo:     saldo,
+		NfcChipId: chipId,
+		GroupId:   groupId,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create account: %v", err)
+	}
+	fmt.Fprintf(d.out, "created account %d %q with saldo %.2f\n", account.Id, account.Name, account.Saldo)
+	return nil
+}
+
+func (d *desk) topUp(ctx context.Context, account *api.Account) error {
+	fmt.Fprintf(d.out, "chip %s belongs to account %d %q", account.NfcChipId, account.Id, account.Name)
+	if account.Group != nil {
+		fmt.Fprintf(d.out, " in group %q", account.Group.Name)
+	}
+	fmt.Fprintf(d.out, ", saldo %.2f\n", account.Saldo)
+	if account.Status == api.Account_BLOCKED {
+		fmt.Fprintln(d.out, "account is blocked")
+		return nil
+	}
+
+	amount, err := d.askAmount("top up amount (empty to skip): ")
+	if err != nil || amount == 0 {
+		return err
+	}
+
+	// the amount of a transaction is subtracted from the saldo, a top up is a negative amount
+	transaction, err := d.transactions.CreateTransaction(ctx, &api.CreateTransactionRequest{
+		Amount:    -amount,
+		AccountId: account.Id,
+	})
+	if err != nil {
+		return fmt.Errorf("could not top up account: %v", err)
+	}
+	fmt.Fprintf(d.out, "topped up account %d, new saldo %.2f\n", account.Id, transaction.NewSaldo)
+	return nil
+}
+
+// askGroup lists the groups and asks for the group of the account until a valid group is given
+func (d *desk) askGroup(ctx context.Context) (int32, error) {
+	res, err := d.groups.ListGroups(ctx, &api.ListGroupsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("could not load groups: %v", err)
+	}
+	if len(res.Groups) == 0 {
+		return 0, fmt.Errorf("no groups found, create a group first")
+	}
+
+	for _, group := range res.Groups {
+		fmt.Fprintf(d.out, "[%d] %s\n", group.Id, group.Name)
+	}
+	for {
+		answer, err := d.ask("group: ")
+		if err != nil {
+			return 0, err
+		}
+		id, err := strconv.ParseInt(answer, 10, 32)
+		if err == nil {
+			for _, group := range res.Groups {
+				if group.Id == int32(id) {
+					return group.Id, nil
+				}
+			}
+		}
+		fmt.Fprintf(d.out, "group %q not found\n", answer)
+	}
+}
+
+// prompt asks the details of chips and accounts on in
+type prompt struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// askAmount asks for a positive amount until a valid amount is given, an empty answer is 0
+func (p *prompt) askAmount(question string) (float64, error) {
+	for {
+		answer, err := p.ask(question)
+		if err != nil || answer == "" {
+			return 0, err
+		}
+		amount, err := strconv.ParseFloat(strings.Replace(answer, ",", ".", 1), 64)
+		if err == nil && amount >= 0 {
+			return amount, nil
+		}
+		fmt.Fprintf(p.out, "%q is not a valid amount\n", answer)
+	}
+}
+
+// ask prints question and returns the trimmed answer
+func (p *prompt) ask(question string) (string, error) {
+	fmt.Fprint(p.out, question)
+	answer, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", err
+	}
+	return strings.TrimSpace(answer), nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	isPkg "github.com/matryer/is"
+	"google.golang.org/grpc"
+)
+
+type accountClientMock struct {
+	api.AccountServiceClient
+	listFunc   func(req *api.ListAccountsRequest) (*api.ListAccountsResponse, error)
+	createFunc func(req *api.CreateAccountRequest) (*api.Account, error)
+}
+
+func (a *accountClientMock) ListAccounts(_ context.Context, in *api.ListAccountsRequest, _ ...grpc.CallOption) (*api.ListAccountsResponse, error) {
+	return a.listFunc(in)
+}
+
+func (a *accountClientMock) CreateAccount(_ context.Context, in *api.CreateAccountRequest, _ ...grpc.CallOption) (*api.Account, error) {
+	return a.createFunc(in)
+}
+
+type groupClientMock struct {
+	api.GroupsServiceClient
+	groups []*api.Group
+}
+
+func (g *groupClientMock) ListGroups(context.Context, *api.ListGroupsRequest, ...grpc.CallOption) (*api.ListGroupsResponse, error) {
+	return &api.ListGroupsResponse{Groups: g.groups}, nil
+}
+
+type transactionClientMock struct {
+	api.TransactionsServiceClient
+	createFunc func(req *api.CreateTransactionRequest) (*api.Transaction, error)
+	syncFunc   func(req *api.SyncOfflineTransactionsRequest) (*api.SyncOfflineTransactionsResponse, error)
+}
+
+func (t *transactionClientMock) CreateTransaction(_ context.Context, in *api.CreateTransactionRequest, _ ...grpc.CallOption) (*api.Transaction, error) {
+	return t.createFunc(in)
+}
+
+func (t *transactionClientMock) SyncOfflineTransactions(_ context.Context, in *api.SyncOfflineTransactionsRequest, _ ...grpc.CallOption) (*api.SyncOfflineTransactionsResponse, error) {
+	return t.syncFunc(in)
+}
+
+func TestDesk_Register(t *testing.T) {
+	const chipId = "04a1b2c3"
+
+	tests := []struct {
+		name            string
+		input           string
+		accounts        []*api.Account
+		listErr         error
+		wantAccount     *api.CreateAccountRequest
+		wantTopUp       float64
+		wantErr         bool
+		wantOutContains string
+	}{
+		{
+			name:            "tops up registered chip",
+			input:           "5\n",
+			accounts:        []*api.Account{{Id: 3, Name: "tim", NfcChipId: "04A1B2C3", Saldo: 10}},
+			wantTopUp:       -5,
+			wantOutContains: "topped up account 3, new saldo 15.00",
+		},
+		{
+			name:            "does not top up blocked account",
+			accounts:        []*api.Account{{Id: 3, Name: "tim", NfcChipId: chipId, Status: api.Account_BLOCKED}},
+			wantOutContains: "account is blocked",
+		},
+		{
+			name:            "creates account for new chip",
+			input:           "tom\n2\n7,5\n",
+			wantAccount:     &api.CreateAccountRequest{Name: "tom", Saldo: 7.5, NfcChipId: chipId, GroupId: 2},
+			wantOutContains: `created account 4 "tom" with saldo 7.50`,
+		},
+		{
+			name:            "chip id that only starts with the chip is a new chip",
+			input:           "tom\n1\n\n",
+			accounts:        []*api.Account{{Id: 3, Name: "tim", NfcChipId: chipId + "d4e5f6"}},
+			wantAccount:     &api.CreateAccountRequest{Name: "tom", NfcChipId: chipId, GroupId: 1},
+			wantOutContains: "new chip " + chipId,
+		},
+		{
+			name:    "accounts can not be loaded",
+			listErr: errors.New("unavailable"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			var createdAccount *api.CreateAccountRequest
+			var topUp float64
+			out := &bytes.Buffer{}
+			d := &desk{
+				accounts: &accountClientMock{
+					listFunc: func(req *api.ListAccountsRequest) (*api.ListAccountsResponse, error) {
+						is.Equal(req.NfcChipIdPrefix, chipId) // accounts are not filtered by chip
+						return &api.ListAccountsResponse{Accounts: tt.accounts}, tt.listErr
+					},
+					createFunc: func(req *api.CreateAccountRequest) (*api.Account, error) {
+						createdAccount = req
+						return &api.Account{Id: 4, Name: req.Name, Saldo: req.Saldo, NfcChipId: req.NfcChipId}, nil
+					},
+				},
+				groups: &groupClientMock{groups: []*api.Group{{Id: 1, Name: "guests"}, {Id: 2, Name: "staff"}}},
+				transactions: &transactionClientMock{
+					createFunc: func(req *api.CreateTransactionRequest) (*api.Transaction, error) {
+						topUp = req.Amount
+						return &api.Transaction{NewSaldo: tt.accounts[0].Saldo - req.Amount}, nil
+					},
+				},
+				prompt: prompt{in: bufio.NewReader(strings.NewReader(tt.input)), out: out},
+			}
+
+			err := d.register(context.Background(), chipId)
+			if tt.wantErr {
+				is.True(err != nil) // expected error
+				return
+			}
+			is.NoErr(err)
+
+			is.Equal(createdAccount, tt.wantAccount)
+			is.Equal(topUp, tt.wantTopUp)
+			if !strings.Contains(out.String(), tt.wantOutContains) {
+				t.Errorf("got output %q, expected it to contain %q", out.String(), tt.wantOutContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader/bridge"
+	"github.com/spf13/viper"
+)
+
+// serveBridge serves the card events of the reader to the web ui
+func serveBridge() error {
+	token := viper.GetString("bridge.token")
+	if token == "" {
+		var err error
+		if token, err = bridge.NewToken(); err != nil {
+			return err
+		}
+	}
+	addr := viper.GetString("bridge.address")
+
+	deviceName, dev, err := openReader()
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer dev.Close()
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	b := bridge.New(token, viper.GetStringSlice("bridge.origins"))
+	server := &http.Server{Addr: addr, Handler: b.Handler()}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	events, errs := dev.Listen(ctx)
+	fmt.Printf("device %q ready, serving card events on http://%s/events and ws://%s/ws\n", deviceName, addr, addr)
+	fmt.Printf("pairing token: %s\n", token)
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				fmt.Printf("device %q: polling stopped \n", deviceName)
+				shutdown(b, server)
+				return <-errs
+			}
+			b.Publish(bridgeEvent(event))
+		case err := <-serveErr:
+			cancel()
+			for range events {
+			}
+			return fmt.Errorf("could not serve bridge: %v", err)
+		}
+	}
+}
+
+func bridgeEvent(e nfcreader.CardEvent) bridge.Event {
+	return bridge.Event{
+		Type:     e.Type.String(),
+		Uid:      e.Card.Uid,
+		CardType: e.Card.Type.String(),
+		Time:     e.Time,
+	}
+}
+
+func shutdown(b *bridge.Bridge, server *http.Server) {
+	b.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("could not shutdown bridge: %v", err)
+	}
+}
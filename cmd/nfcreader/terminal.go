@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/chip"
+	"github.com/jheimbach/nfc-cash-system/pkg/nfcreader"
+	"github.com/jheimbach/nfc-cash-system/pkg/offline"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chargeTimeout is the time the server may take for a charge, the reader does not poll while the chip is charged.
+// If it takes longer, the chip is charged offline
+const chargeTimeout = 3 * time.Second
+
+// tagSelector is a reader that can read and write the memory of a card, see nfcreader.Device
+type tagSelector interface {
+	SelectTag(uid []byte, fn func(tag chip.Tag) error) error
+}
+
+// runTerminal charges every chip that is put on the reader, the amount is asked on stdin.
+// The balance on the chip is charged online and written back with the blob the server issued,
+// while the server is unavailable the chip is charged directly and the charge is queued until the next sync
+func runTerminal() error {
+	terminalId := viper.GetString("terminal.id")
+	chipKey := viper.GetString("terminal.chip_key")
+	if terminalId == "" || chipKey == "" {
+		return fmt.Errorf("required settings terminal.id and terminal.chip_key are missing")
+	}
+
+	key, err := offline.LoadOrCreateKey(viper.GetString("terminal.key"))
+	if err != nil {
+		return fmt.Errorf("could not load terminal key: %v", err)
+	}
+	queue, err := offline.OpenQueue(viper.GetString("terminal.queue"), terminalId, key)
+	if err != nil {
+		return fmt.Errorf("could not open offline queue: %v", err)
+	}
+
+	ctx, cancel := interruptContext()
+	defer cancel()
+
+	conn, err := dialAsUser(ctx)
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer conn.Close()
+
+	deviceName, dev, err := openReader()
+	if err != nil {
+		return err
+	}
+	//noinspection GoUnhandledErrorResult
+	defer dev.Close()
+
+	tags, ok := dev.(tagSelector)
+	if !ok {
+		return fmt.Errorf("device %q can not write to chips", deviceName)
+	}
+
+	t := &terminal{
+		id:           terminalId,
+		chipKey:      []byte(chipKey),
+		accounts:     api.NewAccountServiceClient(conn),
+		transactions: api.NewTransactionsServiceClient(conn),
+		queue:        queue,
+		tags:         tags,
+		prompt:       prompt{in: bufio.NewReader(os.Stdin), out: os.Stdout},
+	}
+	t.sync(ctx)
+
+	events, errs := dev.Listen(ctx)
+	fmt.Printf("terminal %q ready on device %q, put a chip on the reader to charge it...\n", terminalId, deviceName)
+	for event := range events {
+		if event.Type != nfcreader.CardPresent {
+			continue
+		}
+		if err := t.charge(ctx, event.Card.Uid); err != nil {
+			if err == io.EOF {
+				cancel()
+				continue
+			}
+			log.Printf("chip %s: %v", event.Card.Uid, err)
+		}
+	}
+	fmt.Printf("device %q: polling stopped \n", deviceName)
+
+	return <-errs
+}
+
+// terminal charges the balance on the chips, see runTerminal
+type terminal struct {
+	id           string
+	chipKey      []byte
+	accounts     api.AccountServiceClient
+	transactions api.TransactionsServiceClient
+	queue        *offline.Queue
+	tags         tagSelector
+	prompt
+}
+
+// charge reads the balance of the chip with uid, asks for the amount and charges the chip.
+// The chip is read and charged in two sessions, so the reader keeps polling while the amount is asked
+func (t *terminal) charge(ctx context.Context, uid string) error {
+	rawUid, err := hex.DecodeString(uid)
+	if err != nil {
+		return fmt.Errorf("invalid chip uid: %v", err)
+	}
+
+	var blob []byte
+	err = t.tags.SelectTag(rawUid, func(tag chip.Tag) error {
+		var err error
+		blob, err = chip.ReadBlob(tag)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not read chip: %v", err)
+	}
+
+	balance, err := chip.Decode(t.chipKey, uid, blob)
+	if err == chip.ErrInvalidBlob {
+		balance, blob, err = t.newBalance(ctx, uid)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(t.out, "chip %s belongs to account %d, saldo %.2f\n", uid, balance.AccountId, balance.Saldo)
+
+	amount, err := t.askAmount("amount (empty to skip): ")
+	if err != nil || amount == 0 {
+		return err
+	}
+
+	t.sync(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, chargeTimeout)
+	defer cancel()
+	return t.tags.SelectTag(rawUid, func(tag chip.Tag) error {
+		return t.chargeTag(ctx, tag, uid, balance.AccountId, blob, amount)
+	})
+}
+
+// chargeTag charges amount online with blob and writes the issued blob to tag.
+// If the server is unavailable the balance on tag is charged and the charge is queued
+func (t *terminal) chargeTag(ctx context.Context, tag chip.Tag, uid string, accountId int32, blob []byte, amount float64) error {
+	transaction, err := t.transactions.CreateTransaction(ctx, &api.CreateTransactionRequest{
+		Amount:      amount,
+		AccountId:   accountId,
+		TerminalId:  t.id,
+		ChipBalance: blob,
+	})
+	if err == nil {
+		if len(transaction.ChipBalance) == 0 {
+			return fmt.Errorf("charged account %d, but the server issued no chip balance", accountId)
+		}
+		if err := chip.WriteBlob(tag, transaction.ChipBalance); err != nil {
+			return fmt.Errorf("charged account %d, but could not write the chip balance: %v", accountId, err)
+		}
+		fmt.Fprintf(t.out, "charged %.2f, new saldo %.2f\n", amount, transaction.NewSaldo)
+		return nil
+	}
+	if !isUnavailable(err) {
+		return fmt.Errorf("could not charge account %d: %v", accountId, err)
+	}
+
+	balance, err := chip.Charge(tag, t.chipKey, uid, amount, false)
+	if err != nil {
+		return fmt.Errorf("could not charge chip offline: %v", err)
+	}
+	if _, err := t.queue.Add(balance.AccountId, amount); err != nil {
+		return fmt.Errorf("charged chip offline, but could not queue the charge: %v", err)
+	}
+	fmt.Fprintf(t.out, "server unavailable, charged %.2f from the chip, new saldo %.2f\n", amount, balance.Saldo)
+	return nil
+}
+
+// newBalance returns the balance for a chip without balance blob, it is signed with the saldo of the account on the server.
+// The server verifies it like a blob read from the chip and issues the first blob with the charge
+func (t *terminal) newBalance(ctx context.Context, uid string) (chip.Balance, []byte, error) {
+	account, err := findAccount(ctx, t.accounts, uid)
+	if err != nil {
+		return chip.Balance{}, nil, err
+	}
+	if account == nil {
+		return chip.Balance{}, nil, fmt.Errorf("chip is not registered")
+	}
+
+	balance := chip.Balance{AccountId: account.Id, Saldo: account.Saldo}
+	return balance, chip.Encode(t.chipKey, uid, balance), nil
+}
+
+// sync sends the queued offline charges to the server, the charges stay queued if the server is unavailable
+func (t *terminal) sync(ctx context.Context) {
+	pending, err := t.queue.Pending()
+	if err != nil || len(pending) == 0 {
+		return
+	}
+
+	res, err := t.queue.Sync(ctx, t.transactions)
+	if err != nil {
+		if !isUnavailable(err) {
+			log.Printf("could not sync offline charges: %v", err)
+		}
+		return
+	}
+	fmt.Fprintf(t.out, "synced %d offline charges, %d conflicts\n", len(res.Applied), len(res.Conflicts))
+	for _, conflict := range res.Conflicts {
+		log.Printf("offline charge %d of %.2f for account %d was not applied: %s",
+			conflict.Sequence, conflict.Amount, conflict.AccountId, conflict.Reason)
+	}
+}
+
+// isUnavailable reports if err is returned because the server can not be reached
+func isUnavailable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/chip"
+	"github.com/jheimbach/nfc-cash-system/pkg/offline"
+	isPkg "github.com/matryer/is"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryTags is a reader with the tags in memory by their hex uid
+type memoryTags map[string]*chip.MemoryTag
+
+func (m memoryTags) SelectTag(uid []byte, fn func(tag chip.Tag) error) error {
+	tag, ok := m[hex.EncodeToString(uid)]
+	if !ok {
+		return errors.New("card is not on the reader")
+	}
+	return fn(tag)
+}
+
+var terminalChipKey = []byte("test chip key")
+
+const terminalUid = "04a1b2c3d4e5f6"
+
+func TestTerminal_Charge(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	tests := []struct {
+		name            string
+		input           string
+		chipBalance     *chip.Balance
+		chipKey         []byte
+		removed         bool
+		accounts        []*api.Account
+		createErr       error
+		wantRequest     *chip.Balance
+		wantChip        *chip.Balance
+		wantQueued      []float64
+		wantErr         bool
+		wantOutContains string
+	}{
+		{
+			name:            "charges online and writes the issued balance",
+			input:           "2.5\n",
+			chipBalance:     &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			wantRequest:     &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			wantChip:        &chip.Balance{AccountId: 1, Saldo: 7.5, Counter: 4},
+			wantOutContains: "charged 2.50, new saldo 7.50",
+		},
+		{
+			name:            "charges chip while the server is unavailable",
+			input:           "2.5\n",
+			chipBalance:     &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			createErr:       unavailable,
+			wantRequest:     &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			wantChip:        &chip.Balance{AccountId: 1, Saldo: 7.5, Counter: 4},
+			wantQueued:      []float64{2.5},
+			wantOutContains: "server unavailable, charged 2.50 from the chip, new saldo 7.50",
+		},
+		{
+			name:        "offline charge needs enough balance on the chip",
+			input:       "2.5\n",
+			chipBalance: &chip.Balance{AccountId: 1, Saldo: 2, Counter: 3},
+			createErr:   unavailable,
+			wantRequest: &chip.Balance{AccountId: 1, Saldo: 2, Counter: 3},
+			wantChip:    &chip.Balance{AccountId: 1, Saldo: 2, Counter: 3},
+			wantErr:     true,
+		},
+		{
+			name:        "rejected charge does not change the chip",
+			input:       "2.5\n",
+			chipBalance: &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			createErr:   status.Error(codes.FailedPrecondition, "account is blocked"),
+			wantRequest: &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			wantChip:    &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			wantErr:     true,
+		},
+		{
+			name:            "chip without balance is charged with the saldo of the account",
+			input:           "2.5\n",
+			accounts:        []*api.Account{{Id: 1, NfcChipId: strings.ToUpper(terminalUid), Saldo: 20}},
+			wantRequest:     &chip.Balance{AccountId: 1, Saldo: 20},
+			wantChip:        &chip.Balance{AccountId: 1, Saldo: 17.5, Counter: 1},
+			wantOutContains: "chip 04a1b2c3d4e5f6 belongs to account 1, saldo 20.00",
+		},
+		{
+			name:    "chip without balance has to be registered",
+			input:   "2.5\n",
+			wantErr: true,
+		},
+		{
+			name:        "balance signed with another key is rejected",
+			input:       "2.5\n",
+			chipBalance: &chip.Balance{AccountId: 1, Saldo: 1000, Counter: 3},
+			chipKey:     []byte("forged key"),
+			wantErr:     true,
+		},
+		{
+			name:    "chip was removed",
+			removed: true,
+			wantErr: true,
+		},
+		{
+			name:        "empty amount skips the chip",
+			input:       "\n",
+			chipBalance: &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+			wantChip:    &chip.Balance{AccountId: 1, Saldo: 10, Counter: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			tag := chip.NewMemoryTag(45)
+			if tt.chipBalance != nil {
+				key := terminalChipKey
+				if tt.chipKey != nil {
+					key = tt.chipKey
+				}
+				is.NoErr(chip.WriteBalance(tag, key, terminalUid, *tt.chipBalance))
+			}
+			tags := memoryTags{terminalUid: tag}
+			if tt.removed {
+				tags = memoryTags{}
+			}
+
+			queue, cleanup := openTestQueue(t)
+			defer cleanup()
+
+			var request *chip.Balance
+			out := &bytes.Buffer{}
+			term := &terminal{
+				id:      "bar-1",
+				chipKey: terminalChipKey,
+				accounts: &accountClientMock{
+					listFunc: func(req *api.ListAccountsRequest) (*api.ListAccountsResponse, error) {
+						return &api.ListAccountsResponse{Accounts: tt.accounts}, nil
+					},
+				},
+				transactions: &transactionClientMock{
+					createFunc: func(req *api.CreateTransactionRequest) (*api.Transaction, error) {
+						is.Equal(req.TerminalId, "bar-1")
+						balance, err := chip.Decode(terminalChipKey, terminalUid, req.ChipBalance)
+						is.NoErr(err) // request contains a valid chip balance
+						request = &balance
+						if tt.createErr != nil {
+							return nil, tt.createErr
+						}
+
+						issued := chip.Balance{AccountId: req.AccountId, Saldo: balance.Saldo - req.Amount, Counter: balance.Counter + 1}
+						return &api.Transaction{
+							NewSaldo:    issued.Saldo,
+							ChipBalance: chip.Encode(terminalChipKey, terminalUid, issued),
+						}, nil
+					},
+				},
+				queue:  queue,
+				tags:   tags,
+				prompt: prompt{in: bufio.NewReader(strings.NewReader(tt.input)), out: out},
+			}
+
+			err := term.charge(context.Background(), terminalUid)
+			if tt.wantErr {
+				is.True(err != nil) // expected error
+			} else {
+				is.NoErr(err)
+			}
+
+			is.Equal(request, tt.wantRequest)
+			if tt.wantChip != nil {
+				got, err := chip.ReadBalance(tag, terminalChipKey, terminalUid)
+				is.NoErr(err)
+				is.Equal(got, *tt.wantChip)
+			}
+
+			pending, err := queue.Pending()
+			is.NoErr(err)
+			var queued []float64
+			for _, p := range pending {
+				is.Equal(p.AccountId, int32(1))
+				queued = append(queued, p.Amount)
+			}
+			is.Equal(queued, tt.wantQueued)
+
+			if !strings.Contains(out.String(), tt.wantOutContains) {
+				t.Errorf("got output %q, expected it to contain %q", out.String(), tt.wantOutContains)
+			}
+		})
+	}
+}
+
+func TestTerminal_ChargeSyncsQueue(t *testing.T) {
+	is := isPkg.New(t)
+
+	tag := chip.NewMemoryTag(45)
+	is.NoErr(chip.WriteBalance(tag, terminalChipKey, terminalUid, chip.Balance{AccountId: 1, Saldo: 7.5, Counter: 4}))
+
+	queue, cleanup := openTestQueue(t)
+	defer cleanup()
+	_, err := queue.Add(1, 2.5)
+	is.NoErr(err)
+
+	var synced []*api.OfflineTransaction
+	term := &terminal{
+		id:      "bar-1",
+		chipKey: terminalChipKey,
+		transactions: &transactionClientMock{
+			createFunc: func(req *api.CreateTransactionRequest) (*api.Transaction, error) {
+				is.Equal(len(synced), 1) // offline charges are synced before the next charge
+				return &api.Transaction{
+					NewSaldo:    12.5,
+					ChipBalance: chip.Encode(terminalChipKey, terminalUid, chip.Balance{AccountId: 1, Saldo: 5, Counter: 5}),
+				}, nil
+			},
+			syncFunc: func(req *api.SyncOfflineTransactionsRequest) (*api.SyncOfflineTransactionsResponse, error) {
+				is.Equal(req.TerminalId, "bar-1")
+				synced = req.Transactions
+				return &api.SyncOfflineTransactionsResponse{LastSequence: 1}, nil
+			},
+		},
+		queue:  queue,
+		tags:   memoryTags{terminalUid: tag},
+		prompt: prompt{in: bufio.NewReader(strings.NewReader("2.5\n")), out: ioutil.Discard},
+	}
+
+	is.NoErr(term.charge(context.Background(), terminalUid))
+
+	is.Equal(len(synced), 1)
+	is.Equal(synced[0].Amount, 2.5)
+	pending, err := queue.Pending()
+	is.NoErr(err)
+	is.Equal(len(pending), 0) // synced charges are removed from the queue
+}
+
+// openTestQueue opens an empty offline queue in a temporary directory, cleanup removes the directory
+func openTestQueue(t *testing.T) (*offline.Queue, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "terminal")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queue, err := offline.OpenQueue(filepath.Join(dir, "queue.json"), "bar-1", ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return queue, func() {
+		_ = os.RemoveAll(dir)
+	}
+}
@@ -3,17 +3,31 @@ package main
 import (
 	"fmt"
 
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/database"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
 var requiredFields = map[string]string{
+	"database.driver":   "string",
 	"database.user":     "string",
 	"database.password": "string",
 	"database.host":     "string",
 	"database.name":     "string",
 }
 
+// sqliteFields are the database settings a sqlite database file needs, the others are not required
+var sqliteFields = map[string]bool{
+	"database.driver": true,
+	"database.name":   true,
+}
+
+// storage settings, with storageMemory nothing is persisted and no database is needed
+const (
+	storageSQL    = "sql"
+	storageMemory = "memory"
+)
+
 func initConfig() error {
 	viper.SetDefault("host", "")
 	viper.SetDefault("port", "50051")
@@ -21,10 +35,31 @@ func initConfig() error {
 	viper.SetDefault("tls_key", "./cert-key.pem")
 	viper.SetDefault("access_token_key", "7QC/y4Dkke2izCGyArkfH074ETD9Hyf6PxIV")
 	viper.SetDefault("refresh_token_key", "tA2ZFqRCgYBEX4Y9/Q4Au9U0qrbW2oBcqJ8uRPavj9g=")
+	// chip_balance_key signs the balances on the chips, it has to be shared with the offline terminals. Empty disables chip balances
+	viper.SetDefault("chip_balance_key", "")
+	// kiosk_key is the credential of the balance kiosks, it only grants access to the KioskService. Empty disables kiosks
+	viper.SetDefault("kiosk_key", "")
+	// database.driver is one of mysql, postgres or sqlite, for sqlite database.name is the path of the database file
+	viper.SetDefault("database.driver", database.MySQL)
 	viper.SetDefault("database.user", "")
 	viper.SetDefault("database.password", "")
 	viper.SetDefault("database.host", "")
 	viper.SetDefault("database.name", "")
+	// database.migrations overrides the migrations embedded into the binary with a directory that contains the migrations
+	// of every driver, like ./migrations during development. migrate new creates migrations in ./migrations if it is empty
+	viper.SetDefault("database.migrations", "")
+	// database.migration_lock_timeout is how long migrations wait for another server that migrates the database
+	viper.SetDefault("database.migration_lock_timeout", "5m")
+	// storage is sql or memory, memory keeps all data in memory and seeds a user with memory.email and memory.password
+	viper.SetDefault("storage", storageSQL)
+	viper.SetDefault("memory.email", "demo@example.com")
+	viper.SetDefault("memory.password", "demo")
+	// cache.size is the number of entries of the group and account caches of sql storage, 0 disables the caches.
+	// Changes made by other server processes are seen after cache.ttl
+	viper.SetDefault("cache.size", 1000)
+	viper.SetDefault("cache.ttl", "30s")
+	// metrics_address serves the expvar metrics like the cache hits and misses at /debug/vars, empty disables it
+	viper.SetDefault("metrics_address", "")
 
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
@@ -48,6 +83,10 @@ func initConfig() error {
 
 	flag.String("address", "", "Host address for server")
 	flag.String("port", viper.GetString("port"), "Host port for server")
+	flag.String("storage", viper.GetString("storage"), "Storage of the server, sql or memory (nothing is persisted)")
+	flag.Bool("fix", false, "verify-ledger: fix saldo mismatches with adjustment transactions, chain breaks are only reported")
+	flag.Int32("account", 0, "verify-ledger: only verify the account with this id")
+	flag.Bool("migrate-on-start", false, "Apply all pending migrations before the server starts")
 	flag.Parse()
 
 	err := viper.BindPFlags(flag.CommandLine)
@@ -55,6 +94,10 @@ func initConfig() error {
 		return fmt.Errorf("could not bind flag values: %v\n", err)
 	}
 
+	if isNewMigration(flag.Args()) {
+		return nil
+	}
+
 	err = checkRequired()
 	if err != nil {
 		return err
@@ -64,7 +107,19 @@ func initConfig() error {
 }
 
 func checkRequired() error {
+	switch storage := viper.GetString("storage"); storage {
+	case storageSQL:
+	case storageMemory:
+		return nil
+	default:
+		return fmt.Errorf("unknown storage %q, available storages: %s, %s", storage, storageSQL, storageMemory)
+	}
+
+	sqlite := viper.GetString("database.driver") == database.SQLite
 	for name, t := range requiredFields {
+		if sqlite && !sqliteFields[name] {
+			continue
+		}
 		switch t {
 		case "string":
 			val := viper.GetString(name)
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"context"
+	"expvar"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/jheimbach/nfc-cash-system/pkg/server"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/broker"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/database"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/cache"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/memory"
+	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -17,29 +24,105 @@ func main() {
 	if err != nil {
 		log.Fatalf("could not load config: %v\n", err)
 	}
-	log.Println("connecting to database...")
-	db, err := database.OpenDatabase(
-		database.CreateDsn(
-			viper.GetString("database.user"),
-			viper.GetString("database.password"),
-			viper.GetString("database.host"),
-			viper.GetString("database.name"),
-		),
+
+	if isNewMigration(flag.Args()) {
+		os.Exit(newMigration(flag.Args()[2:]))
+	}
+
+	if viper.GetString("storage") == storageMemory {
+		serveMemory()
+		return
+	}
+
+	driver := viper.GetString("database.driver")
+	dsn, err := database.CreateDsn(
+		driver,
+		viper.GetString("database.user"),
+		viper.GetString("database.password"),
+		viper.GetString("database.host"),
+		viper.GetString("database.name"),
 	)
+	if err != nil {
+		log.Fatalf("invalid database settings: %v", err)
+	}
+
+	log.Printf("connecting to %s database...", driver)
+	db, err := database.OpenDatabase(driver, dsn)
 	if err != nil {
 		log.Fatalf("could not connect to database, %v", err)
 	}
-	defer db.Close()
 	log.Println("connected to database")
 
+	switch command := flag.Arg(0); command {
+	case "":
+		defer db.Close()
+
+		if viper.GetBool("migrate-on-start") {
+			if err := migrateOnStart(driver, db); err != nil {
+				log.Fatalf("could not migrate database: %v", err)
+			}
+		}
+
+		feed := server.NewTransactionFeed()
+		storage, err := server.NewSQLStorage(driver, db, feed)
+		if err != nil {
+			log.Fatalf("could not create storage: %v", err)
+		}
+		if size := viper.GetInt("cache.size"); size > 0 {
+			ttl := viper.GetDuration("cache.ttl")
+			storage = storage.Cached(cache.Config{Size: size, TTL: ttl})
+			log.Printf("caching up to %d groups and accounts for %s", size, ttl)
+		}
+		serve(storage, feed)
+	case "verify-ledger":
+		code := verifyLedger(driver, db)
+		db.Close()
+		os.Exit(code)
+	case "migrate":
+		code := migrateCommand(driver, db, flag.Args()[1:])
+		db.Close()
+		os.Exit(code)
+	default:
+		db.Close()
+		log.Fatalf("unknown command %q, available commands: verify-ledger, migrate", command)
+	}
+}
+
+// serveMemory serves a demo server that keeps all data in memory,
+// the only data is a user to login with memory.email and memory.password
+func serveMemory() {
+	if command := flag.Arg(0); command != "" {
+		log.Fatalf("command %q is not available with %s storage", command, storageMemory)
+	}
+
+	feed := server.NewTransactionFeed()
+	storage := server.NewMemoryStorage(feed)
+
+	email, password := viper.GetString("memory.email"), viper.GetString("memory.password")
+	err := storage.Users.(*memory.UserRepository).Create(context.Background(), "Demo", email, password)
+	if err != nil {
+		log.Fatalf("could not create demo user: %v", err)
+	}
+	log.Printf("using memory storage, nothing is persisted. login with %s / %s", email, password)
+
+	serve(storage, feed)
+}
+
+// serve starts the grpc server and blocks until it is stopped
+func serve(storage *server.Storage, feed *broker.TransactionBroker) {
+	serveMetrics(storage)
+
 	log.Println("start grpc server...")
 	// start grpc server
 	grpcSrv, err := server.NewGrpcServer(
-		db,
+		storage,
+		feed,
 		viper.GetString("tls_cert"),
 		viper.GetString("tls_key"),
 		viper.GetString("access_token_key"),
 		viper.GetString("refresh_token_key"),
+		viper.GetString("chip_balance_key"),
+		viper.GetString("kiosk_key"),
 	)
 	if err != nil {
 		log.Fatalf("could not create grpc server: %v", err)
@@ -62,3 +145,24 @@ func main() {
 		log.Fatalf("could not start grpc server at %s: %v", endpointAddr, err)
 	}
 }
+
+// serveMetrics publishes the cache stats of storage and serves the expvar metrics at metrics_address in the background
+func serveMetrics(storage *server.Storage) {
+	for name, stats := range storage.CacheStats {
+		expvar.Publish("cache_"+name, stats)
+	}
+
+	addr := viper.GetString("metrics_address")
+	if addr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	go func() {
+		log.Printf("serving metrics at %s/debug/vars", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("could not serve metrics at %s: %v", addr, err)
+		}
+	}()
+}
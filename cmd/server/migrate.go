@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/database"
+	"github.com/spf13/viper"
+)
+
+const migrateUsage = `usage: migrate up | down N | status | force V | new NAME
+  up        apply all migrations that are not applied yet
+  down N    roll back the last N applied migrations
+  status    list the migrations and the version of the database
+  force V   mark version V as applied and not dirty without running migrations, 0 marks no migration as applied
+  new NAME  create empty up and down files of a new migration for every driver`
+
+// defaultMigrationDir is the directory of the migrations in the repository, migrate new creates migrations there
+const defaultMigrationDir = "./migrations"
+
+// isNewMigration reports if args is the migrate new command, which does not need a database
+func isNewMigration(args []string) bool {
+	return len(args) > 1 && args[0] == "migrate" && args[1] == "new"
+}
+
+// newMigration runs the migrate new command and returns the exit code
+func newMigration(args []string) int {
+	if len(args) != 1 {
+		log.Println(migrateUsage)
+		return 1
+	}
+
+	dir := viper.GetString("database.migrations")
+	if dir == "" {
+		dir = defaultMigrationDir
+	}
+
+	paths, err := database.CreateMigration(dir, args[0], time.Now())
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	if err != nil {
+		log.Printf("could not create migration: %v", err)
+		return 1
+	}
+	log.Println("run go generate ./migrations to embed the migration once it is written")
+	return 0
+}
+
+// migrateCommand runs the migrate command with args on db and returns the exit code,
+// up, down and force hold the migration lock while they change the database
+func migrateCommand(driver string, db *sql.DB, args []string) int {
+	if len(args) == 0 {
+		log.Println(migrateUsage)
+		return 1
+	}
+
+	migrator, err := newMigrator(driver, db)
+	if err != nil {
+		log.Printf("could not read migrations: %v", err)
+		return 1
+	}
+
+	switch command := args[0]; {
+	case command == "status" && len(args) == 1:
+		err = printMigrationStatus(migrator)
+	case command == "up" && len(args) == 1:
+		err = withMigrationLock(driver, db, migrator.Up)
+	case command == "down" && len(args) == 2:
+		steps, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			log.Printf("invalid number of migrations %q", args[1])
+			return 1
+		}
+		err = withMigrationLock(driver, db, func() error {
+			return migrator.Down(steps)
+		})
+	case command == "force" && len(args) == 2:
+		version, convErr := strconv.ParseUint(args[1], 10, 64)
+		if convErr != nil {
+			log.Printf("invalid version %q", args[1])
+			return 1
+		}
+		err = withMigrationLock(driver, db, func() error {
+			return migrator.Force(uint(version))
+		})
+	default:
+		log.Println(migrateUsage)
+		return 1
+	}
+
+	if err != nil {
+		log.Printf("could not migrate database: %v", err)
+		return 1
+	}
+	return 0
+}
+
+// migrateOnStart applies all migrations before the server starts,
+// replicas that start at the same time wait for the one that holds the migration lock
+func migrateOnStart(driver string, db *sql.DB) error {
+	migrator, err := newMigrator(driver, db)
+	if err != nil {
+		return err
+	}
+
+	log.Println("migrating database...")
+	return withMigrationLock(driver, db, migrator.Up)
+}
+
+// newMigrator returns a migrator with the embedded migrations, or the migrations of database.migrations if it is set
+func newMigrator(driver string, db *sql.DB) (*database.Migrator, error) {
+	dir := viper.GetString("database.migrations")
+	if dir != "" {
+		dir = filepath.Join(dir, driver)
+	}
+	return database.NewMigrator(db, driver, viper.GetString("database.name"), dir)
+}
+
+// withMigrationLock runs migrate while it holds the migration lock of the database,
+// it waits database.migration_lock_timeout for the lock
+func withMigrationLock(driver string, db *sql.DB, migrate func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("database.migration_lock_timeout"))
+	defer cancel()
+
+	unlock, err := database.LockMigrations(ctx, db, driver, viper.GetString("database.name"))
+	if err != nil {
+		return err
+	}
+
+	err = migrate()
+	if unlockErr := unlock(); unlockErr != nil {
+		log.Printf("could not release migration lock: %v", unlockErr)
+	}
+	return err
+}
+
+// printMigrationStatus prints the version of the database and the migrations that are applied or pending
+func printMigrationStatus(migrator *database.Migrator) error {
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, migration := range status.Migrations {
+		state := "applied"
+		switch {
+		case migration.Version == status.Version && status.Dirty:
+			state = "dirty"
+		case !migration.Applied:
+			state = "pending"
+			pending++
+		}
+		fmt.Printf("%d %-40s %s\n", migration.Version, migration.Name, state)
+	}
+
+	fmt.Printf("database version %d", status.Version)
+	if status.Dirty {
+		fmt.Print(" is dirty, repair the failed migration by hand and run migrate force with the repaired version")
+	}
+	fmt.Printf(", %d migrations pending\n", pending)
+	return nil
+}
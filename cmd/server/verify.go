@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server"
+	"github.com/spf13/viper"
+)
+
+// verifyLedger runs the verify-ledger command and returns the exit code,
+// 1 if the ledger could not be verified or has discrepancies that are not fixed.
+// Mismatches that were gone when they were fixed do not fail the command, chain breaks are never fixed
+func verifyLedger(driver string, db *sql.DB) int {
+	storage, err := server.NewSQLStorage(driver, db, nil)
+	if err != nil {
+		log.Printf("could not create storage: %v", err)
+		return 1
+	}
+	transactions := storage.Transactions
+
+	log.Println("verifying ledger...")
+	report, err := transactions.VerifyLedger(context.Background(), viper.GetInt32("account"), viper.GetBool("fix"))
+	if err != nil {
+		log.Printf("could not verify ledger: %v", err)
+		return 1
+	}
+
+	fixed, resolved, unfixed := 0, 0, 0
+	for _, d := range report.Discrepancies {
+		switch d.Kind {
+		case api.LedgerDiscrepancy_CHAIN_BREAK:
+			fmt.Printf("account %d: chain break at transaction %d, old_saldo is %.2f, previous new_saldo is %.2f\n",
+				d.AccountId, d.TransactionId, d.Actual, d.Expected)
+		case api.LedgerDiscrepancy_SALDO_MISMATCH:
+			fmt.Printf("account %d: last transaction %d ends with %.2f, account saldo is %.2f",
+				d.AccountId, d.TransactionId, d.Actual, d.Expected)
+			if d.AdjustmentId > 0 {
+				fmt.Printf(", fixed with adjustment %d", d.AdjustmentId)
+			}
+			if d.Resolved {
+				fmt.Print(", saldos match again, no adjustment needed")
+			}
+			fmt.Println()
+		}
+
+		switch {
+		case d.AdjustmentId > 0:
+			fixed++
+		case d.Resolved:
+			resolved++
+		default:
+			unfixed++
+		}
+	}
+
+	fmt.Printf("checked %d transactions of %d accounts, found %d discrepancies, %d fixed, %d no longer needed a fix, %d not fixed\n",
+		report.CheckedTransactions, report.CheckedAccounts, len(report.Discrepancies), fixed, resolved, unfixed)
+	if unfixed > 0 && viper.GetBool("fix") {
+		fmt.Println("chain breaks are never repaired, they have to be checked by hand")
+	}
+
+	if unfixed > 0 {
+		return 1
+	}
+	return 0
+}
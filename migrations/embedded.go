@@ -0,0 +1,32 @@
+// Code generated by go run gen.go. DO NOT EDIT.
+
+package migrations
+
+var files = map[string]map[string]string{
+	"mysql": {
+		"20191028204458_users.down.sql":                 "DROP TABLE `users`;",
+		"20191028204458_users.up.sql":                   "CREATE TABLE `users`\n(\n    `id`              integer PRIMARY KEY NOT NULL AUTO_INCREMENT,\n    `name`            varchar(255)        NOT NULL,\n    `email`           varchar(255) UNIQUE NOT NULL,\n    `hashed_password` char(60)            NOT NULL,\n    `created`         datetime            NOT NULL\n);",
+		"20191030182101_groups.down.sql":                "DROP TABLE `account_groups`",
+		"20191030182101_groups.up.sql":                  "CREATE TABLE `account_groups`\n(\n    `id` integer PRIMARY KEY NOT NULL AUTO_INCREMENT,\n    `name` varchar(255) NOT NULL,\n    `description` TEXT,\n    `can_overdraw` BOOLEAN NOT NULL DEFAULT false\n)",
+		"20191030182105_accounts.down.sql":              "DROP TABLE `accounts`",
+		"20191030182105_accounts.up.sql":                "CREATE TABLE `accounts`\n(\n    `id`          INTEGER PRIMARY KEY NOT NULL AUTO_INCREMENT,\n    `name`        VARCHAR(255)        NOT NULL,\n    `description` TEXT,\n    `saldo`       DECIMAL(15,2)               NOT NULL DEFAULT 0,\n    `group_id`    INTEGER,\n    # according to ISO 14443-3A for nfc tags, uids are 4-10 bytes long (hex 2 chars per byte are 20 max).\n    # source: https://www.nxp.com/docs/en/application-note/AN10927.pdf\n    `nfc_chip_uid`     char(20) UNIQUE  NOT NULL\n);\n\nALTER TABLE `accounts`\n    ADD FOREIGN KEY (`group_id`) REFERENCES `account_groups` (`id`);\n\nCREATE INDEX idx_id ON accounts(`id`);\nCREATE INDEX idx_nfc_chip_uid ON accounts(`nfc_chip_uid`)",
+		"20191106112856_transactions.down.sql":          "DROP TABLE `transactions`",
+		"20191106112856_transactions.up.sql":            "CREATE TABLE transactions\n(\n    `id`         integer PRIMARY KEY NOT NULL AUTO_INCREMENT,\n    `new_saldo`  decimal(15, 2)      NOT NULL,\n    `old_saldo`  decimal(15, 2)      NOT NULL,\n    `amount`     decimal(15, 2)      NOT NULL,\n    `account_id` integer             NOT NULL,\n    `created`    datetime            NOT NULL\n);\n\nALTER TABLE `transactions`\n    ADD FOREIGN KEY (`account_id`) REFERENCES `accounts` (`id`);\n\n\nCREATE INDEX idx_created ON transactions (`created`);\nCREATE INDEX idx_id ON transactions (`id`)",
+		"20200301101500_transactions_terminal.down.sql": "DROP INDEX idx_terminal_id ON transactions;\n\nALTER TABLE `transactions`\n    DROP COLUMN `terminal_id`;",
+		"20200301101500_transactions_terminal.up.sql":   "ALTER TABLE `transactions`\n    ADD COLUMN `terminal_id` varchar(64) NULL;\n\nCREATE INDEX idx_terminal_id ON transactions (`terminal_id`);",
+		"20200308180000_transactions_type.down.sql":     "ALTER TABLE `transactions`\n    DROP COLUMN `type`;",
+		"20200308180000_transactions_type.up.sql":       "ALTER TABLE `transactions`\n    ADD COLUMN `type` varchar(16) NOT NULL DEFAULT 'PAYMENT';",
+		"20200315120000_offline_terminals.down.sql":     "DROP TABLE `terminals`;\n\nALTER TABLE `accounts`\n    DROP COLUMN `status`;",
+		"20200315120000_offline_terminals.up.sql":       "ALTER TABLE `accounts`\n    ADD COLUMN `status` varchar(16) NOT NULL DEFAULT 'ACTIVE';\n\nCREATE TABLE `terminals`\n(\n    `id`            varchar(64) PRIMARY KEY NOT NULL,\n    `name`          varchar(255)            NOT NULL,\n    # ed25519 public key the terminal signs its offline transactions with\n    `public_key`    varbinary(32)           NOT NULL,\n    `last_sequence` bigint unsigned         NOT NULL DEFAULT 0,\n    `created`       datetime                NOT NULL\n);",
+		"20200322100000_accounts_chip_counter.down.sql": "ALTER TABLE `accounts`\n    DROP COLUMN `chip_counter`;",
+		"20200322100000_accounts_chip_counter.up.sql":   "ALTER TABLE `accounts`\n    ADD COLUMN `chip_counter` int unsigned NOT NULL DEFAULT 0;",
+	},
+	"postgres": {
+		"20200322100000_schema.down.sql": "DROP TABLE terminals;\nDROP TABLE transactions;\nDROP TABLE accounts;\nDROP TABLE account_groups;\nDROP TABLE users;\n",
+		"20200322100000_schema.up.sql":   "-- the postgres schema starts at the schema version of mysql migration 20200322100000\nCREATE TABLE users\n(\n    id              SERIAL PRIMARY KEY,\n    name            VARCHAR(255) NOT NULL,\n    email           VARCHAR(255) NOT NULL,\n    hashed_password CHAR(60)     NOT NULL,\n    created         TIMESTAMPTZ  NOT NULL\n);\n\n-- emails are not case sensitive, as with the collation of mysql\nCREATE UNIQUE INDEX idx_users_email ON users (lower(email));\n\nCREATE TABLE account_groups\n(\n    id           SERIAL PRIMARY KEY,\n    name         VARCHAR(255) NOT NULL,\n    description  TEXT,\n    can_overdraw BOOLEAN      NOT NULL DEFAULT false\n);\n\nCREATE TABLE accounts\n(\n    id           SERIAL PRIMARY KEY,\n    name         VARCHAR(255)   NOT NULL,\n    description  TEXT,\n    saldo        NUMERIC(15, 2) NOT NULL DEFAULT 0,\n    group_id     INTEGER REFERENCES account_groups (id),\n    -- according to ISO 14443-3A for nfc tags, uids are 4-10 bytes long (hex 2 chars per byte are 20 max).\n    nfc_chip_uid VARCHAR(20)    NOT NULL,\n    status       VARCHAR(16)    NOT NULL DEFAULT 'ACTIVE',\n    chip_counter BIGINT         NOT NULL DEFAULT 0\n);\n\n-- chip uids are not case sensitive, as with the collation of mysql\nCREATE UNIQUE INDEX idx_accounts_nfc_chip_uid ON accounts (lower(nfc_chip_uid));\n\nCREATE TABLE transactions\n(\n    id          SERIAL PRIMARY KEY,\n    new_saldo   NUMERIC(15, 2) NOT NULL,\n    old_saldo   NUMERIC(15, 2) NOT NULL,\n    amount      NUMERIC(15, 2) NOT NULL,\n    account_id  INTEGER        NOT NULL REFERENCES accounts (id),\n    created     TIMESTAMPTZ    NOT NULL,\n    terminal_id VARCHAR(64)    NULL,\n    type        VARCHAR(16)    NOT NULL DEFAULT 'PAYMENT'\n);\n\nCREATE INDEX idx_created ON transactions (created);\nCREATE INDEX idx_account_id ON transactions (account_id);\nCREATE INDEX idx_terminal_id ON transactions (terminal_id);\n\nCREATE TABLE terminals\n(\n    id            VARCHAR(64) PRIMARY KEY,\n    name          VARCHAR(255) NOT NULL,\n    -- ed25519 public key the terminal signs its offline transactions with\n    public_key    BYTEA        NOT NULL,\n    last_sequence BIGINT       NOT NULL DEFAULT 0,\n    created       TIMESTAMPTZ  NOT NULL\n);\n",
+	},
+	"sqlite": {
+		"20200322100000_schema.down.sql": "DROP TABLE terminals;\nDROP TABLE transactions;\nDROP TABLE accounts;\nDROP TABLE account_groups;\nDROP TABLE users;\n",
+		"20200322100000_schema.up.sql":   "-- the sqlite schema starts at the schema version of mysql migration 20200322100000\nCREATE TABLE users\n(\n    id              INTEGER PRIMARY KEY AUTOINCREMENT,\n    name            VARCHAR(255)                       NOT NULL,\n    email           VARCHAR(255) UNIQUE COLLATE NOCASE NOT NULL,\n    hashed_password CHAR(60)                           NOT NULL,\n    created         DATETIME                           NOT NULL\n);\n\nCREATE TABLE account_groups\n(\n    id           INTEGER PRIMARY KEY AUTOINCREMENT,\n    name         VARCHAR(255) NOT NULL,\n    description  TEXT,\n    can_overdraw BOOLEAN      NOT NULL DEFAULT 0\n);\n\nCREATE TABLE accounts\n(\n    id           INTEGER PRIMARY KEY AUTOINCREMENT,\n    name         VARCHAR(255)                   NOT NULL,\n    description  TEXT,\n    saldo        DECIMAL(15, 2)                 NOT NULL DEFAULT 0,\n    group_id     INTEGER REFERENCES account_groups (id),\n    -- according to ISO 14443-3A for nfc tags, uids are 4-10 bytes long (hex 2 chars per byte are 20 max).\n    nfc_chip_uid CHAR(20) UNIQUE COLLATE NOCASE NOT NULL,\n    status       VARCHAR(16)                    NOT NULL DEFAULT 'ACTIVE',\n    chip_counter INTEGER                        NOT NULL DEFAULT 0\n);\n\nCREATE TABLE transactions\n(\n    id          INTEGER PRIMARY KEY AUTOINCREMENT,\n    new_saldo   DECIMAL(15, 2) NOT NULL,\n    old_saldo   DECIMAL(15, 2) NOT NULL,\n    amount      DECIMAL(15, 2) NOT NULL,\n    account_id  INTEGER        NOT NULL REFERENCES accounts (id),\n    created     DATETIME       NOT NULL,\n    terminal_id VARCHAR(64)    NULL,\n    type        VARCHAR(16)    NOT NULL DEFAULT 'PAYMENT'\n);\n\nCREATE INDEX idx_created ON transactions (created);\nCREATE INDEX idx_account_id ON transactions (account_id);\nCREATE INDEX idx_terminal_id ON transactions (terminal_id);\n\nCREATE TABLE terminals\n(\n    id            VARCHAR(64) PRIMARY KEY NOT NULL,\n    name          VARCHAR(255)            NOT NULL,\n    -- ed25519 public key the terminal signs its offline transactions with\n    public_key    BLOB                    NOT NULL,\n    last_sequence INTEGER                 NOT NULL DEFAULT 0,\n    created       DATETIME                NOT NULL\n);\n",
+	},
+}
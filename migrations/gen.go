@@ -0,0 +1,52 @@
+//go:build ignore
+// +build ignore
+
+// gen writes the migrations of every driver directory into embedded.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+var drivers = []string{"mysql", "postgres", "sqlite"}
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by go run gen.go. DO NOT EDIT.\n\n")
+	buf.WriteString("package migrations\n\n")
+	buf.WriteString("var files = map[string]map[string]string{\n")
+
+	for _, driver := range drivers {
+		paths, err := filepath.Glob(filepath.Join(driver, "*.sql"))
+		if err != nil {
+			log.Fatalf("could not list migrations of %s: %v", driver, err)
+		}
+		sort.Strings(paths)
+
+		fmt.Fprintf(&buf, "%q: {\n", driver)
+		for _, path := range paths {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				log.Fatalf("could not read migration: %v", err)
+			}
+			fmt.Fprintf(&buf, "%q: %s,\n", filepath.Base(path), strconv.Quote(string(content)))
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("could not format migrations: %v", err)
+	}
+	if err := ioutil.WriteFile("embedded.go", src, 0644); err != nil {
+		log.Fatalf("could not write migrations: %v", err)
+	}
+}
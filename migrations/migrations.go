@@ -0,0 +1,30 @@
+// Package migrations embeds the sql migrations of every database driver into the binary.
+// The migrations are read from the driver directories by go generate, it has to run after a migration is added or changed
+package migrations
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Names returns the file names of the migrations of driver, sorted by version
+func Names(driver string) []string {
+	names := make([]string, 0, len(files[driver]))
+	for name := range files[driver] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Asset returns the content of the migration file name of driver
+func Asset(driver, name string) ([]byte, error) {
+	content, ok := files[driver][name]
+	if !ok {
+		return nil, &os.PathError{Op: "read", Path: fmt.Sprintf("%s/%s", driver, name), Err: os.ErrNotExist}
+	}
+	return []byte(content), nil
+}
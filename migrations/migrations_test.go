@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	for driver := range files {
+		t.Run(driver, func(t *testing.T) {
+			paths, err := filepath.Glob(filepath.Join(driver, "*.sql"))
+			if err != nil {
+				t.Fatalf("could not list migrations: %v", err)
+			}
+			if got := len(Names(driver)); got != len(paths) {
+				t.Fatalf("got %d embedded migrations, expected %d, run go generate ./migrations", got, len(paths))
+			}
+
+			for _, path := range paths {
+				want, err := ioutil.ReadFile(path)
+				if err != nil {
+					t.Fatalf("could not read migration: %v", err)
+				}
+				got, err := Asset(driver, filepath.Base(path))
+				if err != nil {
+					t.Fatalf("migration %s is not embedded, run go generate ./migrations", path)
+				}
+				if string(got) != string(want) {
+					t.Errorf("embedded migration %s is outdated, run go generate ./migrations", path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+DROP INDEX idx_terminal_id ON transactions;
+
+ALTER TABLE `transactions`
+    DROP COLUMN `terminal_id`;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE `transactions`
+    ADD COLUMN `terminal_id` varchar(64) NULL;
+
+CREATE INDEX idx_terminal_id ON transactions (`terminal_id`);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE `transactions`
+    DROP COLUMN `type`;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE `transactions`
+    ADD COLUMN `type` varchar(16) NOT NULL DEFAULT 'PAYMENT';
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE `terminals`;
+
+ALTER TABLE `accounts`
+    DROP COLUMN `status`;
\ No newline at end of file
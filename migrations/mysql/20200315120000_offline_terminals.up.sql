@@ -0,0 +1,12 @@
+ALTER TABLE `accounts`
+    ADD COLUMN `status` varchar(16) NOT NULL DEFAULT 'ACTIVE';
+
+CREATE TABLE `terminals`
+(
+    `id`            varchar(64) PRIMARY KEY NOT NULL,
+    `name`          varchar(255)            NOT NULL,
+    # ed25519 public key the terminal signs its offline transactions with
+    `public_key`    varbinary(32)           NOT NULL,
+    `last_sequence` bigint unsigned         NOT NULL DEFAULT 0,
+    `created`       datetime                NOT NULL
+);
\ No newline at end of file
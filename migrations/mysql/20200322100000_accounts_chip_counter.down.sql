@@ -0,0 +1,2 @@
+ALTER TABLE `accounts`
+    DROP COLUMN `chip_counter`;
\ No newline at end of file
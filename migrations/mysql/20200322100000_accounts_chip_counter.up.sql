@@ -0,0 +1,2 @@
+ALTER TABLE `accounts`
+    ADD COLUMN `chip_counter` int unsigned NOT NULL DEFAULT 0;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE terminals;
+DROP TABLE transactions;
+DROP TABLE accounts;
+DROP TABLE account_groups;
+DROP TABLE users;
@@ -0,0 +1,62 @@
+-- the postgres schema starts at the schema version of mysql migration 20200322100000
+CREATE TABLE users
+(
+    id              SERIAL PRIMARY KEY,
+    name            VARCHAR(255) NOT NULL,
+    email           VARCHAR(255) NOT NULL,
+    hashed_password CHAR(60)     NOT NULL,
+    created         TIMESTAMPTZ  NOT NULL
+);
+
+-- emails are not case sensitive, as with the collation of mysql
+CREATE UNIQUE INDEX idx_users_email ON users (lower(email));
+
+CREATE TABLE account_groups
+(
+    id           SERIAL PRIMARY KEY,
+    name         VARCHAR(255) NOT NULL,
+    description  TEXT,
+    can_overdraw BOOLEAN      NOT NULL DEFAULT false
+);
+
+CREATE TABLE accounts
+(
+    id           SERIAL PRIMARY KEY,
+    name         VARCHAR(255)   NOT NULL,
+    description  TEXT,
+    saldo        NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    group_id     INTEGER REFERENCES account_groups (id),
+    -- according to ISO 14443-3A for nfc tags, uids are 4-10 bytes long (hex 2 chars per byte are 20 max).
+    nfc_chip_uid VARCHAR(20)    NOT NULL,
+    status       VARCHAR(16)    NOT NULL DEFAULT 'ACTIVE',
+    chip_counter BIGINT         NOT NULL DEFAULT 0
+);
+
+-- chip uids are not case sensitive, as with the collation of mysql
+CREATE UNIQUE INDEX idx_accounts_nfc_chip_uid ON accounts (lower(nfc_chip_uid));
+
+CREATE TABLE transactions
+(
+    id          SERIAL PRIMARY KEY,
+    new_saldo   NUMERIC(15, 2) NOT NULL,
+    old_saldo   NUMERIC(15, 2) NOT NULL,
+    amount      NUMERIC(15, 2) NOT NULL,
+    account_id  INTEGER        NOT NULL REFERENCES accounts (id),
+    created     TIMESTAMPTZ    NOT NULL,
+    terminal_id VARCHAR(64)    NULL,
+    type        VARCHAR(16)    NOT NULL DEFAULT 'PAYMENT'
+);
+
+CREATE INDEX idx_created ON transactions (created);
+CREATE INDEX idx_account_id ON transactions (account_id);
+CREATE INDEX idx_terminal_id ON transactions (terminal_id);
+
+CREATE TABLE terminals
+(
+    id            VARCHAR(64) PRIMARY KEY,
+    name          VARCHAR(255) NOT NULL,
+    -- ed25519 public key the terminal signs its offline transactions with
+    public_key    BYTEA        NOT NULL,
+    last_sequence BIGINT       NOT NULL DEFAULT 0,
+    created       TIMESTAMPTZ  NOT NULL
+);
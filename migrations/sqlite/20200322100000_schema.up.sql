@@ -0,0 +1,56 @@
+-- the sqlite schema starts at the schema version of mysql migration 20200322100000
+CREATE TABLE users
+(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    name            VARCHAR(255)                       NOT NULL,
+    email           VARCHAR(255) UNIQUE COLLATE NOCASE NOT NULL,
+    hashed_password CHAR(60)                           NOT NULL,
+    created         DATETIME                           NOT NULL
+);
+
+CREATE TABLE account_groups
+(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    name         VARCHAR(255) NOT NULL,
+    description  TEXT,
+    can_overdraw BOOLEAN      NOT NULL DEFAULT 0
+);
+
+CREATE TABLE accounts
+(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    name         VARCHAR(255)                   NOT NULL,
+    description  TEXT,
+    saldo        DECIMAL(15, 2)                 NOT NULL DEFAULT 0,
+    group_id     INTEGER REFERENCES account_groups (id),
+    -- according to ISO 14443-3A for nfc tags, uids are 4-10 bytes long (hex 2 chars per byte are 20 max).
+    nfc_chip_uid CHAR(20) UNIQUE COLLATE NOCASE NOT NULL,
+    status       VARCHAR(16)                    NOT NULL DEFAULT 'ACTIVE',
+    chip_counter INTEGER                        NOT NULL DEFAULT 0
+);
+
+CREATE TABLE transactions
+(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    new_saldo   DECIMAL(15, 2) NOT NULL,
+    old_saldo   DECIMAL(15, 2) NOT NULL,
+    amount      DECIMAL(15, 2) NOT NULL,
+    account_id  INTEGER        NOT NULL REFERENCES accounts (id),
+    created     DATETIME       NOT NULL,
+    terminal_id VARCHAR(64)    NULL,
+    type        VARCHAR(16)    NOT NULL DEFAULT 'PAYMENT'
+);
+
+CREATE INDEX idx_created ON transactions (created);
+CREATE INDEX idx_account_id ON transactions (account_id);
+CREATE INDEX idx_terminal_id ON transactions (terminal_id);
+
+CREATE TABLE terminals
+(
+    id            VARCHAR(64) PRIMARY KEY NOT NULL,
+    name          VARCHAR(255)            NOT NULL,
+    -- ed25519 public key the terminal signs its offline transactions with
+    public_key    BLOB                    NOT NULL,
+    last_sequence INTEGER                 NOT NULL DEFAULT 0,
+    created       DATETIME                NOT NULL
+);
@@ -0,0 +1,75 @@
+// Package chip encodes the balance that is stored on the chip of an account, so terminals can check and charge it
+// while they are offline. The balance is signed with an hmac key that the server shares with the terminals,
+// the signature covers the uid of the chip, so a balance can not be copied to another chip.
+package chip
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	// BlobSize is the size of an encoded balance, it fits into 8 pages of a NTAG or MIFARE Ultralight tag
+	BlobSize = dataSize + macSize
+
+	dataSize = 20
+	macSize  = 12
+	version  = 1
+)
+
+var magic = [2]byte{'N', 'C'}
+
+var (
+	ErrInvalidBlob         = errors.New("chip balance is not valid")
+	ErrInvalidSignature    = errors.New("chip balance signature is not valid")
+	ErrInsufficientBalance = errors.New("chip balance is insufficient")
+)
+
+// Balance is the content of the balance blob.
+// Counter is increased with every write, the server uses it to detect old blobs that were written back to the chip
+type Balance struct {
+	AccountId int32
+	Saldo     float64
+	Counter   uint32
+}
+
+// Encode returns the signed blob of balance for the chip with uid, uid is the hex encoded chip uid
+func Encode(key []byte, uid string, balance Balance) []byte {
+	blob := make([]byte, dataSize, BlobSize)
+	copy(blob, magic[:])
+	blob[2] = version
+	binary.BigEndian.PutUint32(blob[4:], uint32(balance.AccountId))
+	binary.BigEndian.PutUint64(blob[8:], uint64(int64(math.Round(balance.Saldo*100))))
+	binary.BigEndian.PutUint32(blob[16:], balance.Counter)
+
+	return append(blob, signature(key, uid, blob)...)
+}
+
+// Decode verifies and decodes the balance blob of the chip with uid.
+// It returns ErrInvalidBlob if blob is no balance and ErrInvalidSignature if it was not signed with key for this chip
+func Decode(key []byte, uid string, blob []byte) (Balance, error) {
+	if len(blob) < BlobSize || blob[0] != magic[0] || blob[1] != magic[1] || blob[2] != version {
+		return Balance{}, ErrInvalidBlob
+	}
+	data, mac := blob[:dataSize], blob[dataSize:BlobSize]
+	if !hmac.Equal(mac, signature(key, uid, data)) {
+		return Balance{}, ErrInvalidSignature
+	}
+
+	return Balance{
+		AccountId: int32(binary.BigEndian.Uint32(data[4:])),
+		Saldo:     float64(int64(binary.BigEndian.Uint64(data[8:]))) / 100,
+		Counter:   binary.BigEndian.Uint32(data[16:]),
+	}, nil
+}
+
+func signature(key []byte, uid string, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.ToLower(uid)))
+	mac.Write(data)
+	return mac.Sum(nil)[:macSize]
+}
@@ -0,0 +1,50 @@
+package chip
+
+import (
+	"testing"
+
+	isPkg "github.com/matryer/is"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncodeDecode(t *testing.T) {
+	balance := Balance{AccountId: 12, Saldo: -3.7, Counter: 42}
+	blob := Encode(testKey, "04a1b2c3d4e5f6", balance)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		uid     string
+		modify  func([]byte)
+		want    Balance
+		wantErr error
+	}{
+		{name: "valid balance", key: testKey, uid: "04a1b2c3d4e5f6", want: balance},
+		{name: "uid case is ignored", key: testKey, uid: "04A1B2C3D4E5F6", want: balance},
+		{name: "other chip", key: testKey, uid: "04a1b2c3d4e5f7", wantErr: ErrInvalidSignature},
+		{name: "other key", key: []byte("other key"), uid: "04a1b2c3d4e5f6", wantErr: ErrInvalidSignature},
+		{name: "changed saldo", key: testKey, uid: "04a1b2c3d4e5f6", modify: func(b []byte) { b[15]++ }, wantErr: ErrInvalidSignature},
+		{name: "changed counter", key: testKey, uid: "04a1b2c3d4e5f6", modify: func(b []byte) { b[19]-- }, wantErr: ErrInvalidSignature},
+		{name: "empty tag", key: testKey, uid: "04a1b2c3d4e5f6", modify: func(b []byte) { copy(b, make([]byte, BlobSize)) }, wantErr: ErrInvalidBlob},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			data := append([]byte(nil), blob...)
+			if tt.modify != nil {
+				tt.modify(data)
+			}
+
+			got, err := Decode(tt.key, tt.uid, data)
+			is.Equal(err, tt.wantErr)
+			is.Equal(got, tt.want)
+		})
+	}
+
+	t.Run("short blob", func(t *testing.T) {
+		is := isPkg.New(t)
+		_, err := Decode(testKey, "04a1b2c3d4e5f6", blob[:BlobSize-1])
+		is.Equal(err, ErrInvalidBlob)
+	})
+}
@@ -0,0 +1,131 @@
+package chip
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	// PageSize is the number of bytes in one page of a NTAG or MIFARE Ultralight tag
+	PageSize = 4
+	// FirstPage is the first page the balance is written to, pages before it hold the uid and lock bits
+	FirstPage = 4
+
+	// readPages is the number of pages returned by one read command
+	readPages = 4
+)
+
+// Tag is the memory of a NTAG or MIFARE Ultralight tag
+type Tag interface {
+	// ReadPages returns the 4 pages starting at page
+	ReadPages(page byte) ([]byte, error)
+	// WritePage writes 4 bytes of data to page
+	WritePage(page byte, data []byte) error
+}
+
+// ReadBalance reads and verifies the balance of the tag
+func ReadBalance(tag Tag, key []byte, uid string) (Balance, error) {
+	blob, err := ReadBlob(tag)
+	if err != nil {
+		return Balance{}, err
+	}
+	return Decode(key, uid, blob)
+}
+
+// ReadBlob reads the balance blob of the tag without verifying it, online terminals send it to the server
+func ReadBlob(tag Tag) ([]byte, error) {
+	blob := make([]byte, 0, BlobSize)
+	for page := byte(FirstPage); len(blob) < BlobSize; page += readPages {
+		data, err := tag.ReadPages(page)
+		if err != nil {
+			return nil, err
+		}
+		blob = append(blob, data...)
+	}
+	return blob[:BlobSize], nil
+}
+
+// WriteBalance signs balance and writes it to the tag
+func WriteBalance(tag Tag, key []byte, uid string, balance Balance) error {
+	return writeBlob(tag, Encode(key, uid, balance))
+}
+
+// WriteBlob writes a blob that was signed by the server to the tag
+func WriteBlob(tag Tag, blob []byte) error {
+	if len(blob) != BlobSize {
+		return ErrInvalidBlob
+	}
+	return writeBlob(tag, blob)
+}
+
+func writeBlob(tag Tag, blob []byte) error {
+	for i := 0; i < len(blob); i += PageSize {
+		if err := tag.WritePage(byte(FirstPage+i/PageSize), blob[i:i+PageSize]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Charge subtracts amount from the balance of the tag and increases its counter, it is used by offline terminals.
+// It returns ErrInsufficientBalance if a purchase would result in a negative balance and overdraw is false
+func Charge(tag Tag, key []byte, uid string, amount float64, overdraw bool) (Balance, error) {
+	balance, err := ReadBalance(tag, key, uid)
+	if err != nil {
+		return Balance{}, err
+	}
+
+	newSaldo := balance.Saldo - amount
+	if amount > 0 && newSaldo < 0 && !overdraw {
+		return balance, ErrInsufficientBalance
+	}
+	balance.Saldo = newSaldo
+	balance.Counter++
+
+	if err := WriteBalance(tag, key, uid, balance); err != nil {
+		return Balance{}, err
+	}
+	return balance, nil
+}
+
+// MemoryTag is a tag that is kept in memory, it is used to simulate tags
+type MemoryTag struct {
+	mu    sync.Mutex
+	pages [][PageSize]byte
+}
+
+// NewMemoryTag returns an empty tag with the given number of pages, a NTAG213 has 45 pages
+func NewMemoryTag(pages int) *MemoryTag {
+	return &MemoryTag{pages: make([][PageSize]byte, pages)}
+}
+
+func (m *MemoryTag) ReadPages(page byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if int(page) >= len(m.pages) {
+		return nil, fmt.Errorf("page %d out of range", page)
+	}
+	// like real tags, reads wrap around to the first page
+	data := make([]byte, 0, readPages*PageSize)
+	for i := 0; i < readPages; i++ {
+		p := m.pages[(int(page)+i)%len(m.pages)]
+		data = append(data, p[:]...)
+	}
+	return data, nil
+}
+
+func (m *MemoryTag) WritePage(page byte, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if int(page) >= len(m.pages) {
+		return fmt.Errorf("page %d out of range", page)
+	}
+	if len(data) != PageSize {
+		return errors.New("page data must be 4 bytes")
+	}
+	copy(m.pages[page][:], data)
+	return nil
+}
@@ -0,0 +1,79 @@
+package chip
+
+import (
+	"testing"
+
+	isPkg "github.com/matryer/is"
+)
+
+const testUid = "04a1b2c3d4e5f6"
+
+func TestWriteAndReadBalance(t *testing.T) {
+	is := isPkg.New(t)
+	tag := NewMemoryTag(45)
+
+	_, err := ReadBalance(tag, testKey, testUid)
+	is.Equal(err, ErrInvalidBlob) // new tag has no balance
+
+	balance := Balance{AccountId: 1, Saldo: 20, Counter: 3}
+	is.NoErr(WriteBalance(tag, testKey, testUid, balance))
+
+	got, err := ReadBalance(tag, testKey, testUid)
+	is.NoErr(err)
+	is.Equal(got, balance)
+
+	// a half written balance is detected
+	is.NoErr(tag.WritePage(FirstPage+3, []byte{0, 0, 0, 1}))
+	_, err = ReadBalance(tag, testKey, testUid)
+	is.Equal(err, ErrInvalidSignature)
+}
+
+func TestWriteBlob(t *testing.T) {
+	is := isPkg.New(t)
+	tag := NewMemoryTag(16)
+
+	balance := Balance{AccountId: 1, Saldo: 7.5, Counter: 9}
+	blob := Encode(testKey, testUid, balance)
+	is.NoErr(WriteBlob(tag, blob))
+
+	got, err := ReadBalance(tag, testKey, testUid)
+	is.NoErr(err)
+	is.Equal(got, balance)
+
+	read, err := ReadBlob(tag)
+	is.NoErr(err)
+	is.Equal(read, blob) // blob is read unchanged
+
+	is.Equal(WriteBlob(tag, []byte("short")), ErrInvalidBlob)
+}
+
+func TestCharge(t *testing.T) {
+	tests := []struct {
+		name     string
+		saldo    float64
+		amount   float64
+		overdraw bool
+		want     Balance
+		wantErr  error
+	}{
+		{name: "purchase", saldo: 10, amount: 2.5, want: Balance{AccountId: 1, Saldo: 7.5, Counter: 2}},
+		{name: "top up", saldo: 10, amount: -5, want: Balance{AccountId: 1, Saldo: 15, Counter: 2}},
+		{name: "insufficient balance", saldo: 2, amount: 2.5, want: Balance{AccountId: 1, Saldo: 2, Counter: 1}, wantErr: ErrInsufficientBalance},
+		{name: "overdraw", saldo: 2, amount: 2.5, overdraw: true, want: Balance{AccountId: 1, Saldo: -0.5, Counter: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			tag := NewMemoryTag(45)
+			is.NoErr(WriteBalance(tag, testKey, testUid, Balance{AccountId: 1, Saldo: tt.saldo, Counter: 1}))
+
+			got, err := Charge(tag, testKey, testUid, tt.amount, tt.overdraw)
+			is.Equal(err, tt.wantErr)
+			is.Equal(got, tt.want)
+
+			stored, err := ReadBalance(tag, testKey, testUid)
+			is.NoErr(err)
+			is.Equal(stored, tt.want) // balance on the tag
+		})
+	}
+}
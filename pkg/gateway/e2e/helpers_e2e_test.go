@@ -96,7 +96,7 @@ func startServers() (*sql.DB, string, func(), error) {
 		return nil, "", nil, fmt.Errorf("could not start db container: %w", err)
 	}
 	log.Println("db container started")
-	db, err := test.OpenAndMigrateDatabase(laddr, "../../../migrations")
+	db, err := test.OpenAndMigrateDatabase(laddr, "../../../migrations/mysql")
 	if err != nil {
 		return nil, "", nil, fmt.Errorf("could not migrate db container: %w", err)
 	}
@@ -0,0 +1,203 @@
+package gateway
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// csvExportColumns is the header row of the transaction export csv
+var csvExportColumns = []string{"id", "created", "account_id", "account_name", "group_id", "amount", "old_saldo", "new_saldo"}
+
+// exportFlushInterval is the number of rows after which the response is flushed to the client
+const exportFlushInterval = 100
+
+// transactionExportHandler streams the transactions from the grpc server as csv or json lines.
+// The query parameter format selects the output (csv or jsonl, default csv),
+// from and to (RFC 3339 or YYYY-MM-DD), group_id, account_id and order filter the transactions
+func transactionExportHandler(mux *runtime.ServeMux, client api.TransactionsServiceClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		ctx := r.Context()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" && format != "jsonl" {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, status.Errorf(codes.InvalidArgument, "format %q is not supported, use csv or jsonl", format))
+			return
+		}
+
+		req, err := parseExportQuery(r.URL.Query())
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		ctx, err = runtime.AnnotateContext(ctx, mux, r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		stream, err := client.ExportTransactions(ctx, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		// the first message is received before the headers are written, so errors like missing authorization
+		// can still be sent with the right status code
+		first, err := stream.Recv()
+		if err != nil && err != io.EOF {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		var writeRow func(*api.Transaction) error
+		switch format {
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="transactions.csv"`)
+			csvWriter := csv.NewWriter(w)
+			if err := csvWriter.Write(csvExportColumns); err != nil {
+				return
+			}
+			writeRow = func(transaction *api.Transaction) error {
+				if err := csvWriter.Write(transactionRecord(transaction)); err != nil {
+					return err
+				}
+				csvWriter.Flush()
+				return csvWriter.Error()
+			}
+		case "jsonl":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Header().Set("Content-Disposition", `attachment; filename="transactions.jsonl"`)
+			writeRow = func(transaction *api.Transaction) error {
+				buf, err := outboundMarshaler.Marshal(transaction)
+				if err != nil {
+					return err
+				}
+				_, err = w.Write(append(buf, '\n'))
+				return err
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		transaction := first
+		for rows := 1; err == nil; rows++ {
+			if err := writeRow(transaction); err != nil {
+				log.Printf("could not write transaction export: %v", err)
+				return
+			}
+			if flusher != nil && rows%exportFlushInterval == 0 {
+				flusher.Flush()
+			}
+			transaction, err = stream.Recv()
+		}
+		if err != io.EOF {
+			// the status code is already sent, the client sees a truncated export
+			log.Printf("transaction export aborted: %v", err)
+		}
+	})
+}
+
+// parseExportQuery builds the ExportTransactionsRequest from the query parameters of the export url
+func parseExportQuery(query url.Values) (*api.ExportTransactionsRequest, error) {
+	req := &api.ExportTransactionsRequest{Order: query.Get("order")}
+
+	if err := int32Param(query, "group_id", &req.GroupId); err != nil {
+		return nil, err
+	}
+	if err := int32Param(query, "account_id", &req.AccountId); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if v := query.Get("from"); v != "" {
+		if req.From, err = parseExportTime(v); err != nil {
+			return nil, fmt.Errorf("from %q is not a valid time", v)
+		}
+	}
+	if v := query.Get("to"); v != "" {
+		if req.To, err = parseExportTime(v); err != nil {
+			return nil, fmt.Errorf("to %q is not a valid time", v)
+		}
+	}
+
+	return req, nil
+}
+
+// int32Param sets value to the query parameter name, if it is set
+func int32Param(query url.Values, name string, value *int32) error {
+	v := query.Get(name)
+	if v == "" {
+		return nil
+	}
+	i, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a number", name, v)
+	}
+	*value = int32(i)
+	return nil
+}
+
+// parseExportTime parses v as RFC 3339 time or as date in the form YYYY-MM-DD
+func parseExportTime(v string) (*timestamp.Timestamp, error) {
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		t, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ptypes.TimestampProto(t)
+}
+
+// transactionRecord returns the csv row for transaction in the order of csvExportColumns
+func transactionRecord(transaction *api.Transaction) []string {
+	var created string
+	if t, err := ptypes.Timestamp(transaction.Created); err == nil {
+		created = t.Format(time.RFC3339)
+	}
+
+	var accountId, accountName, groupId string
+	if account := transaction.Account; account != nil {
+		accountId = strconv.Itoa(int(account.Id))
+		accountName = account.Name
+		if account.Group != nil {
+			groupId = strconv.Itoa(int(account.Group.Id))
+		}
+	}
+
+	return []string{
+		strconv.Itoa(int(transaction.Id)),
+		created,
+		accountId,
+		accountName,
+		groupId,
+		strconv.FormatFloat(transaction.Amount, 'f', -1, 64),
+		strconv.FormatFloat(transaction.OldSaldo, 'f', -1, 64),
+		strconv.FormatFloat(transaction.NewSaldo, 'f', -1, 64),
+	}
+}
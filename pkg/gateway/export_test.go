@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/jheimbach/nfc-cash-system/api"
+	isPkg "github.com/matryer/is"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type transactionClientMock struct {
+	api.TransactionsServiceClient
+	exportFunc func(ctx context.Context, req *api.ExportTransactionsRequest) (api.TransactionsService_ExportTransactionsClient, error)
+}
+
+func (t *transactionClientMock) ExportTransactions(ctx context.Context, in *api.ExportTransactionsRequest, _ ...grpc.CallOption) (api.TransactionsService_ExportTransactionsClient, error) {
+	return t.exportFunc(ctx, in)
+}
+
+type exportClientStreamMock struct {
+	grpc.ClientStream
+	transactions []*api.Transaction
+	err          error
+}
+
+func (e *exportClientStreamMock) Recv() (*api.Transaction, error) {
+	if len(e.transactions) == 0 {
+		if e.err != nil {
+			return nil, e.err
+		}
+		return nil, io.EOF
+	}
+	transaction := e.transactions[0]
+	e.transactions = e.transactions[1:]
+	return transaction, nil
+}
+
+func TestTransactionExportHandler(t *testing.T) {
+	created, _ := ptypes.TimestampProto(time.Date(2019, 01, 17, 16, 15, 14, 0, time.UTC))
+	account := &api.Account{Id: 1, Name: "tim, the tester", Group: &api.Group{Id: 2}}
+	transactions := []*api.Transaction{
+		{Id: 1, OldSaldo: 20, NewSaldo: 17.5, Amount: 2.5, Created: created, Account: account},
+		{Id: 2, OldSaldo: 17.5, NewSaldo: 27.5, Amount: -10, Created: created, Account: account},
+	}
+
+	tests := []struct {
+		name            string
+		url             string
+		method          string
+		wantReq         *api.ExportTransactionsRequest
+		streamErr       error
+		callErr         error
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "export csv",
+			url:             "/v1/transactions/export?format=csv",
+			wantReq:         &api.ExportTransactionsRequest{},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "id,created,account_id,account_name,group_id,amount,old_saldo,new_saldo\n" +
+				"1,2019-01-17T16:15:14Z,1,\"tim, the tester\",2,2.5,20,17.5\n" +
+				"2,2019-01-17T16:15:14Z,1,\"tim, the tester\",2,-10,17.5,27.5\n",
+		},
+		{
+			name:            "export jsonl",
+			url:             "/v1/transactions/export?format=jsonl",
+			wantReq:         &api.ExportTransactionsRequest{},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantBody: `{"id":1,"old_saldo":20,"new_saldo":17.5,"amount":2.5,"created":"2019-01-17T16:15:14Z","account":{"id":1,"name":"tim, the tester","group":{"id":2}}}` + "\n" +
+				`{"id":2,"old_saldo":17.5,"new_saldo":27.5,"amount":-10,"created":"2019-01-17T16:15:14Z","account":{"id":1,"name":"tim, the tester","group":{"id":2}}}` + "\n",
+		},
+		{
+			name: "export with filter",
+			url:  "/v1/transactions/export?from=2019-01-01&to=2019-02-01T00:00:00Z&group_id=2&account_id=1&order=asc",
+			wantReq: func() *api.ExportTransactionsRequest {
+				from, _ := ptypes.TimestampProto(time.Date(2019, 01, 01, 0, 0, 0, 0, time.UTC))
+				to, _ := ptypes.TimestampProto(time.Date(2019, 02, 01, 0, 0, 0, 0, time.UTC))
+				return &api.ExportTransactionsRequest{From: from, To: to, GroupId: 2, AccountId: 1, Order: "asc"}
+			}(),
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+		},
+		{
+			name:       "unknown format",
+			url:        "/v1/transactions/export?format=xml",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid filter",
+			url:        "/v1/transactions/export?group_id=two",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "only get is allowed",
+			url:        "/v1/transactions/export",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "server error before first row",
+			url:        "/v1/transactions/export",
+			wantReq:    &api.ExportTransactionsRequest{},
+			streamErr:  status.Error(codes.Unauthenticated, "authorization header required"),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "call fails",
+			url:        "/v1/transactions/export",
+			wantReq:    &api.ExportTransactionsRequest{},
+			callErr:    status.Error(codes.Unavailable, "server not reachable"),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			client := &transactionClientMock{
+				exportFunc: func(ctx context.Context, req *api.ExportTransactionsRequest) (api.TransactionsService_ExportTransactionsClient, error) {
+					if !proto.Equal(req, tt.wantReq) {
+						t.Errorf("got request %v, expected %v", req, tt.wantReq)
+					}
+					if tt.callErr != nil {
+						return nil, tt.callErr
+					}
+					stream := &exportClientStreamMock{err: tt.streamErr}
+					if tt.streamErr == nil {
+						stream.transactions = transactions
+					}
+					return stream, nil
+				},
+			}
+			h := transactionExportHandler(runtime.NewServeMux(), client)
+
+			method := http.MethodGet
+			if tt.method != "" {
+				method = tt.method
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(method, tt.url, nil))
+
+			is.Equal(rec.Code, tt.wantStatus) // unexpected status code
+			if tt.wantContentType != "" {
+				is.Equal(rec.Header().Get("Content-Type"), tt.wantContentType) // wrong content type
+			}
+			if tt.wantBody != "" {
+				is.Equal(rec.Body.String(), tt.wantBody) // unexpected export
+			}
+		})
+	}
+}
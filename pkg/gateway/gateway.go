@@ -11,7 +11,7 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
-func handler(ctx context.Context, grpcEndpoint, certFile string) (*runtime.ServeMux, error) {
+func handler(ctx context.Context, grpcEndpoint, certFile string) (http.Handler, error) {
 	creds, err := credentials.NewClientTLSFromFile(certFile, "nfc-cash-system.local")
 	if err != nil {
 		return nil, fmt.Errorf("could not create credentials from %q: %v", certFile, err)
@@ -51,7 +51,39 @@ func handler(ctx context.Context, grpcEndpoint, certFile string) (*runtime.Serve
 
 	}
 
-	return mux, nil
+	err = api.RegisterReportServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts)
+	if err != nil {
+		return nil, errCouldNotRegisterService("report", err)
+	}
+
+	err = api.RegisterAdminServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts)
+	if err != nil {
+		return nil, errCouldNotRegisterService("admin", err)
+	}
+
+	err = api.RegisterKioskServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts)
+	if err != nil {
+		return nil, errCouldNotRegisterService("kiosk", err)
+	}
+
+	// connection for the handlers that are not generated by grpc-gateway
+	conn, err := grpc.DialContext(ctx, grpcEndpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not dial grpc endpoint %q: %v", grpcEndpoint, err)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	router := http.NewServeMux()
+	router.Handle("/", mux)
+	router.Handle("/v1/accounts/import", accountImportHandler(mux, api.NewAccountServiceClient(conn), mux))
+	transactionClient := api.NewTransactionsServiceClient(conn)
+	router.Handle("/v1/transactions/export", transactionExportHandler(mux, transactionClient))
+	router.Handle("/v1/transactions/watch", transactionWatchHandler(mux, transactionClient))
+
+	return router, nil
 }
 
 func NewGatewayServer(ctx context.Context, restEndpoint, grpcEndpoint, certFile string) (*http.Server, error) {
@@ -0,0 +1,201 @@
+package gateway
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// csvImportColumns is the order of the columns in an account import csv file
+var csvImportColumns = []string{"name", "description", "saldo", "group_id", "nfc_chip_id"}
+
+// accountImportHandler accepts csv uploads (text/csv or multipart/form-data with the field "file") for the account import
+// and sends them to the grpc server. All other requests are passed to next.
+// Set query parameter dry_run to true to only validate the accounts.
+func accountImportHandler(mux *runtime.ServeMux, client api.AccountServiceClient, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if r.Method != http.MethodPost || (mediaType != "text/csv" && mediaType != "multipart/form-data") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+
+		body, err := csvBody(r, mediaType)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		defer body.Close()
+
+		dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+		req, rows, rowErrs, err := parseAccountCsv(body)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		req.DryRun = dryRun
+
+		ctx, err = runtime.AnnotateContext(ctx, mux, r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		// the import is all or nothing, if rows could not be parsed the other rows are only validated by the server,
+		// so the errors of all rows are reported at once
+		if len(rowErrs) > 0 {
+			resp := &api.ImportAccountsResponse{Errors: rowErrs, DryRun: dryRun}
+			if len(req.Accounts) > 0 {
+				req.DryRun = true
+				validated, err := client.ImportAccounts(ctx, req)
+				if err != nil {
+					runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+					return
+				}
+				resp.Errors = mergeRowErrors(rowErrs, validated.Errors, rows)
+			}
+			runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, r, resp, mux.GetForwardResponseOptions()...)
+			return
+		}
+
+		resp, err := client.ImportAccounts(ctx, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		runtime.ForwardResponseMessage(ctx, mux, outboundMarshaler, w, r, resp, mux.GetForwardResponseOptions()...)
+	})
+}
+
+// mergeRowErrors adds the errors the server found to the errors of the csv rows that could not be parsed, ordered by row.
+// The server numbers the accounts it got, rows is the csv row of every account
+func mergeRowErrors(rowErrs, serverErrs []*api.ImportAccountError, rows []int32) []*api.ImportAccountError {
+	errs := append([]*api.ImportAccountError(nil), rowErrs...)
+	for _, serverErr := range serverErrs {
+		if serverErr.Row > 0 && int(serverErr.Row) <= len(rows) {
+			serverErr.Row = rows[serverErr.Row-1]
+		}
+		errs = append(errs, serverErr)
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Row < errs[j].Row
+	})
+	return errs
+}
+
+// csvBody returns the csv content of the request, for multipart/form-data it is the file in field "file"
+func csvBody(r *http.Request, mediaType string) (io.ReadCloser, error) {
+	if mediaType == "text/csv" {
+		return r.Body, nil
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("could not read csv file from form field \"file\": %v", err)
+	}
+	return file, nil
+}
+
+// parseAccountCsv reads accounts from csv with the columns name, description, saldo, group_id and nfc_chip_id.
+// A first row that matches the column names is skipped as header. rows is the csv row of every account, starting with 1
+// after the header. Rows that could not be parsed are returned as import errors,
+// an error is only returned if the csv itself is malformed.
+func parseAccountCsv(r io.Reader) (*api.ImportAccountsRequest, []int32, []*api.ImportAccountError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	req := &api.ImportAccountsRequest{}
+	var rows []int32
+	var rowErrs []*api.ImportAccountError
+
+	row := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not parse csv: %v", err)
+		}
+
+		if row == 0 && isCsvHeader(record) {
+			continue
+		}
+		row++
+
+		account, err := parseAccountRecord(record)
+		if err != nil {
+			var nfcChipId string
+			if len(record) == len(csvImportColumns) {
+				nfcChipId = record[4]
+			}
+			rowErrs = append(rowErrs, &api.ImportAccountError{
+				Row:       int32(row),
+				NfcChipId: nfcChipId,
+				Reason:    api.ImportAccountError_INVALID_ROW,
+				Message:   err.Error(),
+			})
+			continue
+		}
+		req.Accounts = append(req.Accounts, account)
+		rows = append(rows, int32(row))
+	}
+
+	return req, rows, rowErrs, nil
+}
+
+// isCsvHeader reports if record are the column names of the import csv
+func isCsvHeader(record []string) bool {
+	if len(record) != len(csvImportColumns) {
+		return false
+	}
+	for i, column := range csvImportColumns {
+		if !strings.EqualFold(strings.TrimSpace(record[i]), column) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseAccountRecord(record []string) (*api.CreateAccountRequest, error) {
+	if len(record) != len(csvImportColumns) {
+		return nil, fmt.Errorf("got %d columns, expected %d (%s)", len(record), len(csvImportColumns), strings.Join(csvImportColumns, ", "))
+	}
+
+	var saldo float64
+	if s := strings.TrimSpace(record[2]); s != "" {
+		var err error
+		saldo, err = strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("saldo %q is not a number", record[2])
+		}
+	}
+
+	groupId, err := strconv.ParseInt(strings.TrimSpace(record[3]), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("group_id %q is not a number", record[3])
+	}
+
+	return &api.CreateAccountRequest{
+		Name:        strings.TrimSpace(record[0]),
+		Description: strings.TrimSpace(record[1]),
+		Saldo:       saldo,
+		GroupId:     int32(groupId),
+		NfcChipId:   strings.TrimSpace(record[4]),
+	}, nil
+}
@@ -0,0 +1,256 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/jheimbach/nfc-cash-system/api"
+	isPkg "github.com/matryer/is"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type accountClientMock struct {
+	api.AccountServiceClient
+	importFunc func(ctx context.Context, req *api.ImportAccountsRequest) (*api.ImportAccountsResponse, error)
+}
+
+func (a *accountClientMock) ImportAccounts(ctx context.Context, in *api.ImportAccountsRequest, _ ...grpc.CallOption) (*api.ImportAccountsResponse, error) {
+	return a.importFunc(ctx, in)
+}
+
+func TestParseAccountCsv(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        []*api.CreateAccountRequest
+		wantRows    []int32
+		wantRowErrs []*api.ImportAccountError
+		wantErr     bool
+	}{
+		{
+			name:  "csv with header",
+			input: "name,description,saldo,group_id,nfc_chip_id\ntim,,12.5,1,chip1\ntom,desc,,2,chip2\n",
+			want: []*api.CreateAccountRequest{
+				{Name: "tim", Saldo: 12.5, GroupId: 1, NfcChipId: "chip1"},
+				{Name: "tom", Description: "desc", GroupId: 2, NfcChipId: "chip2"},
+			},
+			wantRows: []int32{1, 2},
+		},
+		{
+			name:  "csv without header",
+			input: "tim, ,12,1, chip1",
+			want: []*api.CreateAccountRequest{
+				{Name: "tim", Saldo: 12, GroupId: 1, NfcChipId: "chip1"},
+			},
+			wantRows: []int32{1},
+		},
+		{
+			name:  "account named like the name column is no header",
+			input: "name,,12,1,chip1",
+			want: []*api.CreateAccountRequest{
+				{Name: "name", Saldo: 12, GroupId: 1, NfcChipId: "chip1"},
+			},
+			wantRows: []int32{1},
+		},
+		{
+			name:  "csv with invalid rows",
+			input: "tim,,12,1,chip1\ntom,,twelve,1,chip2\ntam,,12,one,chip3\ntum,12,1,chip4",
+			want: []*api.CreateAccountRequest{
+				{Name: "tim", Saldo: 12, GroupId: 1, NfcChipId: "chip1"},
+			},
+			wantRows: []int32{1},
+			wantRowErrs: []*api.ImportAccountError{
+				{Row: 2, NfcChipId: "chip2", Reason: api.ImportAccountError_INVALID_ROW, Message: `saldo "twelve" is not a number`},
+				{Row: 3, NfcChipId: "chip3", Reason: api.ImportAccountError_INVALID_ROW, Message: `group_id "one" is not a number`},
+				{Row: 4, Reason: api.ImportAccountError_INVALID_ROW, Message: "got 4 columns, expected 5 (name, description, saldo, group_id, nfc_chip_id)"},
+			},
+		},
+		{
+			name:    "malformed csv",
+			input:   "tim,\"unclosed,12,1,chip1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			got, rows, rowErrs, err := parseAccountCsv(strings.NewReader(tt.input))
+			if tt.wantErr {
+				is.True(err != nil) // expected error for malformed csv
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got.Accounts, tt.want)   // parsed accounts don't match
+			is.Equal(rows, tt.wantRows)       // rows of the accounts don't match
+			is.Equal(rowErrs, tt.wantRowErrs) // row errors don't match
+		})
+	}
+}
+
+func TestAccountImportHandler(t *testing.T) {
+	mux := runtime.NewServeMux()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	importResponse := &api.ImportAccountsResponse{
+		Accounts: []*api.Account{{Id: 1, Name: "tim", Saldo: 12, NfcChipId: "chip1", Group: &api.Group{Id: 1}}},
+	}
+
+	multipartBody := func() (*bytes.Buffer, string) {
+		body := &bytes.Buffer{}
+		w := multipart.NewWriter(body)
+		f, _ := w.CreateFormFile("file", "accounts.csv")
+		_, _ = f.Write([]byte("name,description,saldo,group_id,nfc_chip_id\ntim,,12,1,chip1"))
+		_ = w.Close()
+		return body, w.FormDataContentType()
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		contentType string
+		body        func() (*bytes.Buffer, string)
+		wantStatus  int
+		serverErrs  []*api.ImportAccountError
+		wantDryRun  bool
+		wantErrRows []int32
+	}{
+		{
+			name:        "json requests are passed to next handler",
+			method:      http.MethodPost,
+			url:         "/v1/accounts/import",
+			contentType: "application/json",
+			wantStatus:  http.StatusTeapot,
+		},
+		{
+			name:        "get requests are passed to next handler",
+			method:      http.MethodGet,
+			url:         "/v1/accounts/import",
+			contentType: "text/csv",
+			wantStatus:  http.StatusTeapot,
+		},
+		{
+			name:        "import csv body",
+			method:      http.MethodPost,
+			url:         "/v1/accounts/import",
+			contentType: "text/csv",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "import csv body with dry run",
+			method:      http.MethodPost,
+			url:         "/v1/accounts/import?dry_run=true",
+			contentType: "text/csv; charset=utf-8",
+			wantStatus:  http.StatusOK,
+			wantDryRun:  true,
+		},
+		{
+			name:       "import multipart csv file",
+			method:     http.MethodPost,
+			url:        "/v1/accounts/import",
+			body:       multipartBody,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "without valid rows the server is not asked",
+			method:      http.MethodPost,
+			url:         "/v1/accounts/import",
+			contentType: "text/csv",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString("tim,,12,one,chip0"), "text/csv"
+			},
+			wantStatus:  http.StatusOK,
+			wantErrRows: []int32{1},
+		},
+		{
+			name:        "valid rows are validated when other rows are invalid",
+			method:      http.MethodPost,
+			url:         "/v1/accounts/import",
+			contentType: "text/csv",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString("tim,,12,one,chip0\ntim,,12,1,chip1"), "text/csv"
+			},
+			serverErrs:  []*api.ImportAccountError{{Row: 1, NfcChipId: "chip1", Reason: api.ImportAccountError_DUPLICATE_NFC_CHIP_ID}},
+			wantStatus:  http.StatusOK,
+			wantErrRows: []int32{1, 2},
+		},
+		{
+			name:        "malformed csv",
+			method:      http.MethodPost,
+			url:         "/v1/accounts/import",
+			contentType: "text/csv",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString("tim,\"unclosed"), "text/csv"
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			body, contentType := bytes.NewBufferString("tim,,12,1,chip1"), tt.contentType
+			if tt.body != nil {
+				body, contentType = tt.body()
+			}
+			req := httptest.NewRequest(tt.method, tt.url, body)
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set("Authorization", "Bearer token")
+			rec := httptest.NewRecorder()
+
+			client := &accountClientMock{
+				importFunc: func(ctx context.Context, req *api.ImportAccountsRequest) (*api.ImportAccountsResponse, error) {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+						t.Errorf("got authorization %v, expected it to be forwarded", got)
+					}
+					if len(req.Accounts) != 1 || req.Accounts[0].NfcChipId != "chip1" {
+						t.Errorf("got accounts %v, expected account with chip1", req.Accounts)
+					}
+					if len(tt.wantErrRows) > 0 && !req.DryRun {
+						t.Errorf("accounts of a csv with invalid rows were imported")
+					}
+					if tt.serverErrs != nil {
+						return &api.ImportAccountsResponse{Errors: tt.serverErrs, DryRun: req.DryRun}, nil
+					}
+					resp := *importResponse
+					resp.DryRun = req.DryRun
+					return &resp, nil
+				},
+			}
+			h := accountImportHandler(mux, client, next)
+			h.ServeHTTP(rec, req)
+
+			is.Equal(rec.Code, tt.wantStatus) // unexpected status code
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var got api.ImportAccountsResponse
+			is.NoErr(jsonpb.Unmarshal(rec.Body, &got))
+			is.Equal(got.DryRun, tt.wantDryRun) // dry run does not match
+			var errRows []int32
+			for _, rowErr := range got.Errors {
+				errRows = append(errRows, rowErr.Row)
+			}
+			is.Equal(errRows, tt.wantErrRows) // unexpected rows with errors
+			if len(tt.wantErrRows) == 0 {
+				is.Equal(len(got.Accounts), 1) // expected imported account
+			}
+		})
+	}
+}
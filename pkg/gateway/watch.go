@@ -0,0 +1,149 @@
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// transactionWatchHandler serves the transaction feed as server-sent events.
+// Every transaction is sent as event "transaction" with the json encoded transaction as data, if the feed ends
+// with an error an "error" event is sent. The query parameters account_id, group_id and terminal_id filter the feed.
+// Browsers can not set the authorization header for an EventSource, so an EventSource can pass the access token
+// in the query parameter access_token instead, all other clients need the authorization header
+func transactionWatchHandler(mux *runtime.ServeMux, client api.TransactionsServiceClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		queryAccessToken(r)
+
+		ctx := r.Context()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, status.Error(codes.Internal, "streaming is not supported"))
+			return
+		}
+
+		req, err := parseWatchQuery(r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		ctx, err = runtime.AnnotateContext(ctx, mux, r)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		stream, err := client.WatchTransactions(ctx, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		// the server sends headers as soon as it is subscribed, without headers the first message tells
+		// if the call failed, a transaction is kept and sent as the first event
+		md, err := stream.Header()
+		var first *api.Transaction
+		if err == nil && md == nil {
+			first, err = stream.Recv()
+		}
+		if err != nil && err != io.EOF {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		if first != nil {
+			if err := writeEvent(w, outboundMarshaler, "transaction", first); err != nil {
+				log.Printf("could not write transaction event: %v", err)
+				return
+			}
+			flusher.Flush()
+		}
+		if err == io.EOF {
+			return
+		}
+
+		for {
+			transaction, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if status.Code(err) != codes.Canceled {
+					writeEvent(w, outboundMarshaler, "error", status.Convert(err).Proto())
+					flusher.Flush()
+				}
+				return
+			}
+
+			if err := writeEvent(w, outboundMarshaler, "transaction", transaction); err != nil {
+				log.Printf("could not write transaction event: %v", err)
+				return
+			}
+			flusher.Flush()
+		}
+	})
+}
+
+// writeEvent writes v as server-sent event with the given event name
+func writeEvent(w io.Writer, marshaler runtime.Marshaler, event string, v interface{}) error {
+	buf, err := marshaler.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, buf)
+	return err
+}
+
+// queryAccessToken removes the query parameter access_token from the url of r, so it does not end up in logs.
+// The token is used as bearer token if r is an EventSource request without authorization header, otherwise it is dropped
+func queryAccessToken(r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["access_token"]; !ok {
+		return
+	}
+
+	token := query.Get("access_token")
+	query.Del("access_token")
+	r.URL.RawQuery = query.Encode()
+	r.RequestURI = r.URL.RequestURI()
+
+	if token != "" && r.Header.Get("Authorization") == "" && strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// parseWatchQuery builds the WatchTransactionsRequest from the query parameters of the watch url
+func parseWatchQuery(r *http.Request) (*api.WatchTransactionsRequest, error) {
+	query := r.URL.Query()
+	req := &api.WatchTransactionsRequest{TerminalId: query.Get("terminal_id")}
+
+	if err := int32Param(query, "group_id", &req.GroupId); err != nil {
+		return nil, err
+	}
+	if err := int32Param(query, "account_id", &req.AccountId); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
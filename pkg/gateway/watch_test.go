@@ -0,0 +1,191 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/jheimbach/nfc-cash-system/api"
+	isPkg "github.com/matryer/is"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type watchClientMock struct {
+	api.TransactionsServiceClient
+	watchFunc func(ctx context.Context, req *api.WatchTransactionsRequest) (api.TransactionsService_WatchTransactionsClient, error)
+}
+
+func (w *watchClientMock) WatchTransactions(ctx context.Context, in *api.WatchTransactionsRequest, _ ...grpc.CallOption) (api.TransactionsService_WatchTransactionsClient, error) {
+	return w.watchFunc(ctx, in)
+}
+
+type watchClientStreamMock struct {
+	grpc.ClientStream
+	header       metadata.MD
+	transactions []*api.Transaction
+	err          error
+}
+
+func (w *watchClientStreamMock) Header() (metadata.MD, error) {
+	return w.header, nil
+}
+
+func (w *watchClientStreamMock) Recv() (*api.Transaction, error) {
+	if len(w.transactions) == 0 {
+		if w.err != nil {
+			return nil, w.err
+		}
+		return nil, io.EOF
+	}
+	transaction := w.transactions[0]
+	w.transactions = w.transactions[1:]
+	return transaction, nil
+}
+
+func TestTransactionWatchHandler(t *testing.T) {
+	transactions := []*api.Transaction{
+		{Id: 1, Amount: 2.5, TerminalId: "kiosk-1"},
+		{Id: 2, Amount: -10, TerminalId: "kiosk-1"},
+	}
+
+	tests := []struct {
+		name       string
+		url        string
+		header     map[string]string
+		wantReq    *api.WatchTransactionsRequest
+		wantAuth   string
+		wantURL    string
+		subscribed bool
+		noHeader   bool
+		streamErr  error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "stream transactions as events",
+			url:        "/v1/transactions/watch?terminal_id=kiosk-1&group_id=2&account_id=3",
+			header:     map[string]string{"Authorization": "Bearer header-token"},
+			wantReq:    &api.WatchTransactionsRequest{TerminalId: "kiosk-1", GroupId: 2, AccountId: 3},
+			wantAuth:   "Bearer header-token",
+			subscribed: true,
+			wantStatus: http.StatusOK,
+			wantBody: "event: transaction\ndata: {\"id\":1,\"amount\":2.5,\"terminal_id\":\"kiosk-1\"}\n\n" +
+				"event: transaction\ndata: {\"id\":2,\"amount\":-10,\"terminal_id\":\"kiosk-1\"}\n\n",
+		},
+		{
+			name:       "access token from query of an event source",
+			url:        "/v1/transactions/watch?access_token=query-token&group_id=2",
+			header:     map[string]string{"Accept": "text/event-stream"},
+			wantReq:    &api.WatchTransactionsRequest{GroupId: 2},
+			wantAuth:   "Bearer query-token",
+			wantURL:    "/v1/transactions/watch?group_id=2",
+			subscribed: true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "authorization header wins over access token from query",
+			url:        "/v1/transactions/watch?access_token=query-token",
+			header:     map[string]string{"Accept": "text/event-stream", "Authorization": "Bearer header-token"},
+			wantReq:    &api.WatchTransactionsRequest{},
+			wantAuth:   "Bearer header-token",
+			wantURL:    "/v1/transactions/watch",
+			subscribed: true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "access token from query is dropped for other clients",
+			url:        "/v1/transactions/watch?access_token=query-token",
+			wantReq:    &api.WatchTransactionsRequest{},
+			wantURL:    "/v1/transactions/watch",
+			streamErr:  status.Error(codes.Unauthenticated, "authorization header required"),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "feed ends with error event",
+			url:        "/v1/transactions/watch",
+			wantReq:    &api.WatchTransactionsRequest{},
+			subscribed: true,
+			streamErr:  status.Error(codes.ResourceExhausted, "lagged"),
+			wantStatus: http.StatusOK,
+			wantBody: "event: transaction\ndata: {\"id\":1,\"amount\":2.5,\"terminal_id\":\"kiosk-1\"}\n\n" +
+				"event: transaction\ndata: {\"id\":2,\"amount\":-10,\"terminal_id\":\"kiosk-1\"}\n\n" +
+				"event: error\ndata: {\"code\":8,\"message\":\"lagged\"}\n\n",
+		},
+		{
+			name:       "first transaction arrives without headers",
+			url:        "/v1/transactions/watch",
+			wantReq:    &api.WatchTransactionsRequest{},
+			subscribed: true,
+			noHeader:   true,
+			wantStatus: http.StatusOK,
+			wantBody: "event: transaction\ndata: {\"id\":1,\"amount\":2.5,\"terminal_id\":\"kiosk-1\"}\n\n" +
+				"event: transaction\ndata: {\"id\":2,\"amount\":-10,\"terminal_id\":\"kiosk-1\"}\n\n",
+		},
+		{
+			name:       "call fails before subscription",
+			url:        "/v1/transactions/watch",
+			wantReq:    &api.WatchTransactionsRequest{},
+			streamErr:  status.Error(codes.Unauthenticated, "authorization header required"),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid filter",
+			url:        "/v1/transactions/watch?account_id=one",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			client := &watchClientMock{
+				watchFunc: func(ctx context.Context, req *api.WatchTransactionsRequest) (api.TransactionsService_WatchTransactionsClient, error) {
+					if !proto.Equal(req, tt.wantReq) {
+						t.Errorf("got request %v, expected %v", req, tt.wantReq)
+					}
+					md, _ := metadata.FromOutgoingContext(ctx)
+					if auth := strings.Join(md.Get("authorization"), ","); auth != tt.wantAuth {
+						t.Errorf("got authorization %q, expected %q", auth, tt.wantAuth)
+					}
+
+					stream := &watchClientStreamMock{err: tt.streamErr}
+					if tt.subscribed {
+						if !tt.noHeader {
+							stream.header = metadata.MD{}
+						}
+						stream.transactions = append([]*api.Transaction(nil), transactions...)
+					}
+					return stream, nil
+				},
+			}
+			h := transactionWatchHandler(runtime.NewServeMux(), client)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			is.Equal(rec.Code, tt.wantStatus) // unexpected status code
+			if tt.wantURL != "" {
+				is.Equal(req.URL.String(), tt.wantURL) // access token is still in the url
+				is.Equal(req.RequestURI, tt.wantURL)   // access token is still in the request uri
+			}
+			if tt.wantStatus == http.StatusOK {
+				is.Equal(rec.Header().Get("Content-Type"), "text/event-stream") // wrong content type
+			}
+			if tt.wantBody != "" {
+				is.Equal(rec.Body.String(), tt.wantBody) // unexpected events
+			}
+		})
+	}
+}
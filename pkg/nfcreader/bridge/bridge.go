@@ -0,0 +1,234 @@
+// Package bridge serves the card events of a local reader to the web ui over server-sent events and WebSocket.
+// Browsers can not access usb readers, the ui connects to the bridge on localhost instead.
+//
+// Only pages from an allowed origin can connect and every client has to send the pairing token,
+// either as bearer token in the authorization header or, because browsers can not set headers for
+// EventSource and WebSocket connections, in the query parameter token.
+package bridge
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// clientBuffer is the number of events that are buffered for a slow client before events are dropped
+const clientBuffer = 16
+
+// Event is a card that was put on or removed from the reader
+type Event struct {
+	// Type is present or removed
+	Type     string    `json:"type"`
+	Uid      string    `json:"uid"`
+	CardType string    `json:"cardType"`
+	Time     time.Time `json:"time"`
+}
+
+// Bridge sends the published events to all connected clients
+type Bridge struct {
+	token   string
+	origins []string
+
+	mu      sync.Mutex
+	clients map[chan Event]struct{}
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// New returns a bridge that accepts clients with token from the allowed origins, the origin "*" allows all origins
+func New(token string, allowedOrigins []string) *Bridge {
+	return &Bridge{
+		token:   token,
+		origins: allowedOrigins,
+		clients: make(map[chan Event]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Close ends the streams of all clients, so the http server can shut down
+func (b *Bridge) Close() {
+	b.closeOnce.Do(func() {
+		close(b.done)
+	})
+}
+
+// NewToken returns a random pairing token
+func NewToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("could not create pairing token: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Publish sends e to all clients, clients that do not keep up miss the event
+func (b *Bridge) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for client := range b.clients {
+		select {
+		case client <- e:
+		default:
+			log.Printf("bridge: client is too slow, dropped %s event of card %s", e.Type, e.Uid)
+		}
+	}
+}
+
+func (b *Bridge) subscribe() chan Event {
+	client := make(chan Event, clientBuffer)
+	b.mu.Lock()
+	b.clients[client] = struct{}{}
+	b.mu.Unlock()
+	return client
+}
+
+func (b *Bridge) unsubscribe(client chan Event) {
+	b.mu.Lock()
+	delete(b.clients, client)
+	b.mu.Unlock()
+}
+
+// Handler returns the http handler of the bridge, it serves server-sent events on /events and WebSocket on /ws
+func (b *Bridge) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/events", b.authorize(http.HandlerFunc(b.serveEvents)))
+	mux.Handle("/ws", b.authorize(websocket.Server{
+		// the origin is checked by authorize
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler:   b.serveWebSocket,
+	}))
+	return mux
+}
+
+// authorize checks the origin and the pairing token of the request
+func (b *Bridge) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if !b.allowedOrigin(origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		if origin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+		}
+
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if !b.validToken(requestToken(r)) {
+			http.Error(w, "pairing token is not valid", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (b *Bridge) allowedOrigin(origin string) bool {
+	if origin == "" {
+		// requests without origin do not come from a browser
+		return true
+	}
+	for _, allowed := range b.origins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bridge) validToken(token string) bool {
+	return b.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(b.token)) == 1
+}
+
+// requestToken returns the bearer token of the request or the query parameter token
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return r.URL.Query().Get("token")
+}
+
+// serveEvents sends every event as server-sent event, the event name is the type of the event
+func (b *Bridge) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	client := b.subscribe()
+	defer b.unsubscribe(client)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case e := <-client:
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// writeEvent writes e as server-sent event
+func writeEvent(w http.ResponseWriter, e Event) error {
+	buf, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, buf)
+	return err
+}
+
+// serveWebSocket sends every event as json message until the client closes the connection
+func (b *Bridge) serveWebSocket(conn *websocket.Conn) {
+	client := b.subscribe()
+	defer b.unsubscribe(client)
+
+	// the client does not send messages, reads only fail when the connection is closed
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var msg []byte
+		for websocket.Message.Receive(conn, &msg) == nil {
+		}
+	}()
+
+	for {
+		select {
+		case e := <-client:
+			if err := websocket.JSON.Send(conn, e); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-b.done:
+			return
+		}
+	}
+}
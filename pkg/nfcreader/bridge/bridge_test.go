@@ -0,0 +1,190 @@
+package bridge
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	isPkg "github.com/matryer/is"
+	"golang.org/x/net/websocket"
+)
+
+const testToken = "pairing-token"
+
+var testEvent = Event{
+	Type:     "present",
+	Uid:      "04a1b2c3d4e5f6",
+	CardType: "iso14443a",
+	Time:     time.Date(2020, 3, 1, 20, 15, 0, 0, time.UTC),
+}
+
+func TestBridge_Authorize(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     map[string]string
+		wantStatus int
+	}{
+		{
+			name:       "valid bearer token",
+			path:       "/events",
+			header:     map[string]string{"Authorization": "Bearer " + testToken, "Origin": "http://localhost:8080"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "valid query token",
+			path:       "/events?token=" + testToken,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing token",
+			path:       "/events",
+			header:     map[string]string{"Origin": "http://localhost:8080"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "wrong token",
+			path:       "/events?token=guessed",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "origin not allowed",
+			path:       "/events?token=" + testToken,
+			header:     map[string]string{"Origin": "https://evil.example.com"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "websocket from origin that is not allowed",
+			path:       "/ws?token=" + testToken,
+			header:     map[string]string{"Origin": "https://evil.example.com"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "method not allowed",
+			method:     http.MethodPost,
+			path:       "/events?token=" + testToken,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			server := httptest.NewServer(New(testToken, []string{"http://localhost:8080/"}).Handler())
+			defer server.Close()
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequest(method, server.URL+tt.path, nil)
+			is.NoErr(err)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			is.NoErr(err)
+			defer res.Body.Close()
+			is.Equal(res.StatusCode, tt.wantStatus)
+			if origin := tt.header["Origin"]; origin != "" && tt.wantStatus == http.StatusOK {
+				is.Equal(res.Header.Get("Access-Control-Allow-Origin"), origin)
+			}
+		})
+	}
+}
+
+func TestBridge_Events(t *testing.T) {
+	is := isPkg.New(t)
+	bridge := New(testToken, []string{"*"})
+	server := httptest.NewServer(bridge.Handler())
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/events?token=" + testToken)
+	is.NoErr(err)
+	defer res.Body.Close()
+	is.Equal(res.Header.Get("Content-Type"), "text/event-stream")
+
+	waitForClients(t, bridge, 1)
+	bridge.Publish(testEvent)
+
+	reader := bufio.NewReader(res.Body)
+	event, err := reader.ReadString('\n')
+	is.NoErr(err)
+	data, err := reader.ReadString('\n')
+	is.NoErr(err)
+	is.Equal(event, "event: present\n")
+	is.Equal(data, `data: {"type":"present","uid":"04a1b2c3d4e5f6","cardType":"iso14443a","time":"2020-03-01T20:15:00Z"}`+"\n")
+}
+
+func TestBridge_WebSocket(t *testing.T) {
+	is := isPkg.New(t)
+	bridge := New(testToken, []string{"http://localhost:8080"})
+	server := httptest.NewServer(bridge.Handler())
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?token=" + testToken
+	conn, err := websocket.Dial(url, "", "http://localhost:8080")
+	is.NoErr(err)
+
+	waitForClients(t, bridge, 1)
+	bridge.Publish(testEvent)
+
+	var got Event
+	is.NoErr(websocket.JSON.Receive(conn, &got))
+	is.Equal(got, testEvent)
+
+	// the client is removed when it closes the connection
+	is.NoErr(conn.Close())
+	waitForClients(t, bridge, 0)
+
+	_, err = websocket.Dial(strings.TrimSuffix(url, testToken), "", "http://localhost:8080")
+	is.True(err != nil) // connection without token
+}
+
+func TestBridge_PublishSlowClient(t *testing.T) {
+	is := isPkg.New(t)
+	bridge := New(testToken, nil)
+	client := bridge.subscribe()
+
+	for i := 0; i < clientBuffer+5; i++ {
+		bridge.Publish(testEvent) // does not block
+	}
+	is.Equal(len(client), clientBuffer)
+}
+
+func TestBridge_Close(t *testing.T) {
+	is := isPkg.New(t)
+	bridge := New(testToken, nil)
+	server := httptest.NewServer(bridge.Handler())
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/events?token=" + testToken)
+	is.NoErr(err)
+	defer res.Body.Close()
+	waitForClients(t, bridge, 1)
+
+	bridge.Close()
+	_, err = ioutil.ReadAll(res.Body)
+	is.NoErr(err) // stream ended
+	waitForClients(t, bridge, 0)
+}
+
+func waitForClients(t *testing.T, bridge *Bridge, num int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		bridge.mu.Lock()
+		clients := len(bridge.clients)
+		bridge.mu.Unlock()
+		if clients == num {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("bridge has not %d clients", num)
+}
@@ -0,0 +1,62 @@
+package nfcreader
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// MaxUidLength is the length in bytes of the longest uid, its hex form fits into the nfc_chip_uid column of accounts
+const MaxUidLength = 10
+
+// CardType is the type of a card, every type is polled with its own modulation
+type CardType int
+
+const (
+	ISO14443A CardType = iota + 1
+	ISO14443B
+	FeliCa
+	Jewel
+)
+
+// CardTypes are all supported card types
+var CardTypes = []CardType{ISO14443A, ISO14443B, FeliCa, Jewel}
+
+func (c CardType) String() string {
+	switch c {
+	case ISO14443A:
+		return "iso14443a"
+	case ISO14443B:
+		return "iso14443b"
+	case FeliCa:
+		return "felica"
+	case Jewel:
+		return "jewel"
+	}
+	return "unknown"
+}
+
+// ParseCardType returns the card type with name, names are not case sensitive
+func ParseCardType(name string) (CardType, error) {
+	for _, c := range CardTypes {
+		if strings.EqualFold(name, c.String()) {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("card type %q not supported", name)
+}
+
+// Card is a card that was read by a reader
+type Card struct {
+	// Uid is the canonical form of the uid, see FormatUid
+	Uid  string
+	Type CardType
+}
+
+// FormatUid returns the canonical form of uid, the lower case hex string that is stored as nfc_chip_uid of an account
+func FormatUid(uid []byte) (string, error) {
+	if len(uid) == 0 || len(uid) > MaxUidLength {
+		return "", fmt.Errorf("uid must have 1 to %d bytes, got %d", MaxUidLength, len(uid))
+	}
+	return hex.EncodeToString(uid), nil
+}
@@ -0,0 +1,46 @@
+package nfcreader
+
+import (
+	"testing"
+
+	isPkg "github.com/matryer/is"
+)
+
+func TestFormatUid(t *testing.T) {
+	tests := []struct {
+		name    string
+		uid     []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "4 byte uid", uid: []byte{0xDE, 0xAD, 0xBE, 0xEF}, want: "deadbeef"},
+		{name: "7 byte uid", uid: []byte{4, 161, 178, 195, 212, 229, 246}, want: "04a1b2c3d4e5f6"},
+		{name: "10 byte uid", uid: make([]byte, 10), want: "00000000000000000000"},
+		{name: "empty uid", uid: []byte{}, wantErr: true},
+		{name: "uid too long", uid: make([]byte, 11), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			got, err := FormatUid(tt.uid)
+			is.Equal(err != nil, tt.wantErr)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestParseCardType(t *testing.T) {
+	is := isPkg.New(t)
+	for _, c := range CardTypes {
+		got, err := ParseCardType(c.String())
+		is.NoErr(err)
+		is.Equal(got, c)
+	}
+
+	got, err := ParseCardType("FeliCa")
+	is.NoErr(err)
+	is.Equal(got, FeliCa) // names are not case sensitive
+
+	_, err = ParseCardType("mifare")
+	is.True(err != nil) // unknown card type
+}
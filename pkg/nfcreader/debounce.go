@@ -0,0 +1,193 @@
+package nfcreader
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultRemoveAfter is the time after the last read of a card until it is removed, it has to be longer than the polling timeout
+	DefaultRemoveAfter = 500 * time.Millisecond
+	// DefaultCooldown is the time after a tap in which the same card is not reported again
+	DefaultCooldown = 2 * time.Second
+)
+
+// EventType is the type of a card event
+type EventType int
+
+const (
+	// CardPresent is sent once when a card is put on the reader
+	CardPresent EventType = iota + 1
+	// CardRemoved is sent when the card left the reader
+	CardRemoved
+)
+
+func (e EventType) String() string {
+	switch e {
+	case CardPresent:
+		return "present"
+	case CardRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// CardEvent is a card that was put on or removed from the reader
+type CardEvent struct {
+	Type EventType
+	Card Card
+	Time time.Time
+}
+
+// Debouncer turns the cards that a reader reads in every polling cycle into one CardPresent event per tap
+// and a CardRemoved event when the card leaves the reader
+type Debouncer struct {
+	// RemoveAfter is the time after the last read of a card until it counts as removed
+	RemoveAfter time.Duration
+	// Cooldown is the time after a CardPresent event in which a new tap of the same card is ignored
+	Cooldown time.Duration
+
+	// present holds the last read of the cards on the reader and if their tap was reported
+	present map[string]*presentCard
+	// reported holds the time of the last CardPresent event of every card
+	reported map[string]time.Time
+}
+
+type presentCard struct {
+	card     Card
+	lastSeen time.Time
+	reported bool
+}
+
+// NewDebouncer returns a debouncer with DefaultRemoveAfter and DefaultCooldown
+func NewDebouncer() *Debouncer {
+	return &Debouncer{
+		RemoveAfter: DefaultRemoveAfter,
+		Cooldown:    DefaultCooldown,
+	}
+}
+
+// Run receives the reads until the channel is closed and sends the events to events.
+// Cards that are still present are removed before events is closed
+func (d *Debouncer) Run(reads <-chan Card, events chan<- CardEvent) {
+	defer close(events)
+
+	ticker := time.NewTicker(d.checkInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case card, ok := <-reads:
+			if !ok {
+				sendEvents(events, d.removeAll(time.Now()))
+				return
+			}
+			sendEvents(events, d.read(card, time.Now()))
+		case now := <-ticker.C:
+			sendEvents(events, d.expire(now))
+		}
+	}
+}
+
+// read registers a read of card and returns the CardPresent event if it is a new tap
+func (d *Debouncer) read(card Card, now time.Time) []CardEvent {
+	d.init()
+
+	key := card.Uid
+	if present, ok := d.present[key]; ok {
+		present.lastSeen = now
+		return nil
+	}
+
+	present := &presentCard{card: card, lastSeen: now}
+	d.present[key] = present
+
+	if last, ok := d.reported[key]; ok && now.Sub(last) < d.Cooldown {
+		return nil
+	}
+	present.reported = true
+	d.reported[key] = now
+	return []CardEvent{{Type: CardPresent, Card: card, Time: now}}
+}
+
+// expire removes the cards that were not read for RemoveAfter
+func (d *Debouncer) expire(now time.Time) []CardEvent {
+	d.init()
+
+	var events []CardEvent
+	for key, card := range d.present {
+		if now.Sub(card.lastSeen) < d.RemoveAfter {
+			continue
+		}
+		delete(d.present, key)
+		if card.reported {
+			events = append(events, CardEvent{Type: CardRemoved, Card: card.card, Time: now})
+		}
+	}
+
+	for key, last := range d.reported {
+		if _, ok := d.present[key]; !ok && now.Sub(last) >= d.Cooldown {
+			delete(d.reported, key)
+		}
+	}
+	return events
+}
+
+// removeAll removes all cards that are present
+func (d *Debouncer) removeAll(now time.Time) []CardEvent {
+	d.init()
+
+	var events []CardEvent
+	for key, card := range d.present {
+		delete(d.present, key)
+		if card.reported {
+			events = append(events, CardEvent{Type: CardRemoved, Card: card.card, Time: now})
+		}
+	}
+	return events
+}
+
+func (d *Debouncer) init() {
+	if d.present == nil {
+		d.present = make(map[string]*presentCard)
+		d.reported = make(map[string]time.Time)
+	}
+}
+
+// checkInterval is the interval in which removed cards are detected
+func (d *Debouncer) checkInterval() time.Duration {
+	if interval := d.RemoveAfter / 4; interval > time.Millisecond {
+		return interval
+	}
+	return time.Millisecond
+}
+
+func sendEvents(send chan<- CardEvent, events []CardEvent) {
+	for _, e := range events {
+		send <- e
+	}
+}
+
+// listen runs poll and debounces the cards it reads, poll sends reads until ctx is cancelled or it fails.
+// Both channels are closed when poll returned, the error channel receives the error of poll
+func listen(ctx context.Context, debouncer *Debouncer, poll func(ctx context.Context, reads chan<- Card) error) (<-chan CardEvent, <-chan error) {
+	if debouncer == nil {
+		debouncer = NewDebouncer()
+	}
+
+	reads := make(chan Card)
+	events := make(chan CardEvent)
+	errs := make(chan error, 1)
+
+	go debouncer.Run(reads, events)
+	go func() {
+		defer close(errs)
+		err := poll(ctx, reads)
+		close(reads)
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return events, errs
+}
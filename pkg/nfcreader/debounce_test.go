@@ -0,0 +1,125 @@
+package nfcreader
+
+import (
+	"testing"
+	"time"
+
+	isPkg "github.com/matryer/is"
+)
+
+func TestDebouncer(t *testing.T) {
+	card := Card{Uid: "01020304", Type: ISO14443A}
+	other := Card{Uid: "05060708", Type: FeliCa}
+	start := time.Date(2020, 3, 1, 20, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	type step struct {
+		read   Card // read is empty for an expire check
+		at     int
+		events []EventType
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "card stays on the reader",
+			steps: []step{
+				{read: card, at: 0, events: []EventType{CardPresent}},
+				{read: card, at: 100},
+				{read: card, at: 200},
+				{at: 600},
+				{read: card, at: 1000},
+				{at: 1600, events: []EventType{CardRemoved}},
+				{at: 1700},
+			},
+		},
+		{
+			name: "tap within cooldown is ignored",
+			steps: []step{
+				{read: card, at: 0, events: []EventType{CardPresent}},
+				{at: 500, events: []EventType{CardRemoved}},
+				{read: card, at: 1000},
+				{at: 1500},
+				{read: card, at: 2100, events: []EventType{CardPresent}},
+				{at: 2600, events: []EventType{CardRemoved}},
+			},
+		},
+		{
+			name: "card stays on the reader longer than the cooldown",
+			steps: []step{
+				{read: card, at: 0, events: []EventType{CardPresent}},
+				{read: card, at: 1000},
+				{read: card, at: 2000},
+				{read: card, at: 3000},
+				{at: 3500, events: []EventType{CardRemoved}},
+				{read: card, at: 3600, events: []EventType{CardPresent}},
+			},
+		},
+		{
+			name: "cooldown is per card",
+			steps: []step{
+				{read: card, at: 0, events: []EventType{CardPresent}},
+				{read: other, at: 100, events: []EventType{CardPresent}},
+				{at: 550, events: []EventType{CardRemoved}},
+				{read: other, at: 560},
+				{at: 1100, events: []EventType{CardRemoved}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Debouncer{RemoveAfter: 500 * time.Millisecond, Cooldown: 2 * time.Second}
+
+			for _, s := range tt.steps {
+				var events []CardEvent
+				if s.read != (Card{}) {
+					events = d.read(s.read, at(s.at))
+				} else {
+					events = d.expire(at(s.at))
+				}
+
+				if len(events) != len(s.events) {
+					t.Fatalf("at %dms: got events %v, expected %v", s.at, events, s.events)
+				}
+				for i, e := range events {
+					if e.Type != s.events[i] {
+						t.Errorf("at %dms: got event %v, expected %v", s.at, e.Type, s.events[i])
+					}
+					if e.Type == CardPresent && e.Card != s.read {
+						t.Errorf("at %dms: got card %v, expected %v", s.at, e.Card, s.read)
+					}
+					if !e.Time.Equal(at(s.at)) {
+						t.Errorf("at %dms: got event time %v", s.at, e.Time)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDebouncer_Run(t *testing.T) {
+	is := isPkg.New(t)
+	card := Card{Uid: "01020304", Type: ISO14443A}
+
+	uids := make(chan Card)
+	events := make(chan CardEvent)
+	d := &Debouncer{RemoveAfter: 20 * time.Millisecond, Cooldown: time.Hour}
+	go d.Run(uids, events)
+
+	uids <- card
+	e := <-events
+	is.Equal(e.Type, CardPresent)
+	is.Equal(e.Card, card)
+	for i := 0; i < 4; i++ {
+		uids <- card // card stays on the reader
+	}
+
+	e = <-events
+	is.Equal(e.Type, CardRemoved) // card not read anymore
+
+	uids <- card // within cooldown
+	close(uids)
+	_, ok := <-events
+	is.True(!ok) // events closed without further events
+}
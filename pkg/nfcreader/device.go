@@ -1,11 +1,18 @@
+//go:build cgo
+// +build cgo
+
 package nfcreader
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/fuzxxl/nfc/2.0/nfc"
+	"github.com/jheimbach/nfc-cash-system/pkg/chip"
 )
 
 type passiveTargetLister interface {
@@ -13,64 +20,291 @@ type passiveTargetLister interface {
 	InitiatorListPassiveTargets(m nfc.Modulation) ([]nfc.Target, error)
 }
 
+// tagTransceiver selects a target and sends raw commands to it
+type tagTransceiver interface {
+	InitiatorSelectPassiveTarget(m nfc.Modulation, initData []byte) (nfc.Target, error)
+	InitiatorTransceiveBytes(tx, rx []byte, timeout int) (int, error)
+}
+
+// ErrDeviceClosed is returned when a closed device is used
+var ErrDeviceClosed = errors.New("device is closed")
+
+// Device is a reader that is connected with libnfc
 type Device struct {
-	device               passiveTargetLister
+	mu     sync.Mutex
+	device passiveTargetLister
+	closed bool
+	// name and open are used to reopen the device after errors
+	name string
+	open func(name string) (passiveTargetLister, error)
+
 	PollingTimeOut       time.Duration
 	AllowMultipleTargets bool
-	Modulation           nfc.Modulation
-	LastErr              error
+	// Modulations are polled in every cycle, if it is empty DefaultModulations are polled
+	Modulations []nfc.Modulation
+	LastErr     error
+
+	// RetryBackoff is the time Listen waits after a failed polling cycle, it doubles with every failure in a row up to MaxBackoff
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+	// MaxRetries is the number of failures in a row after which Listen stops, with 0 Listen retries until it is cancelled
+	MaxRetries int
+	// OnRetry is called with every error Listen recovers from
+	OnRetry func(err error, attempt int)
+	// Debouncer turns the reads into card events, if it is nil Listen uses NewDebouncer
+	Debouncer *Debouncer
 }
 
 func (d *Device) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.closed = true
+	if d.device == nil {
+		return nil
+	}
 	return d.device.Close()
 }
 
-func (d *Device) ListenForCardUids(send chan<- []byte) {
+// ListenForCards polls the device every PollingTimeOut and sends the cards on the reader in every cycle,
+// it stops at the first error. Listen debounces the reads and recovers from errors
+func (d *Device) ListenForCards(send chan<- Card) {
 	for {
-		targets, err := d.device.InitiatorListPassiveTargets(d.Modulation)
+		targets, err := d.listTargets(false)
 		if err != nil {
 			d.LastErr = fmt.Errorf("could not listen for targets: %v", err)
 			close(send)
 			break
 		}
 
-		if len(targets) == 0 {
-			time.Sleep(d.PollingTimeOut)
+		for _, card := range d.targetCards(targets) {
+			send <- card
+		}
+
+		time.Sleep(d.PollingTimeOut)
+	}
+}
+
+// Listen polls the device every PollingTimeOut until ctx is cancelled or the device is closed
+// and sends an event when a card is put on or removed from the reader.
+// Failed polling cycles are retried with backoff and the device is reopened before every retry,
+// so a reader that was unplugged works again when it is plugged back in.
+// The error channel receives the error that stopped Listen, the events have to be read until the channel is closed
+func (d *Device) Listen(ctx context.Context) (<-chan CardEvent, <-chan error) {
+	return listen(ctx, d.Debouncer, d.poll)
+}
+
+func (d *Device) poll(ctx context.Context, reads chan<- Card) error {
+	failures := 0
+	for {
+		targets, err := d.listTargets(failures > 0)
+		if err == ErrDeviceClosed {
+			return nil
+		}
+		if err != nil {
+			failures++
+			if d.MaxRetries > 0 && failures > d.MaxRetries {
+				return fmt.Errorf("could not listen for targets: %v", err)
+			}
+			if d.OnRetry != nil {
+				d.OnRetry(err, failures)
+			}
+			if !sleepContext(ctx, d.backoff(failures)) {
+				return nil
+			}
 			continue
 		}
+		failures = 0
 
-		if d.AllowMultipleTargets {
-			for _, t := range targets {
-				sendTargetUid(t, send)
+		for _, card := range d.targetCards(targets) {
+			select {
+			case reads <- card:
+			case <-ctx.Done():
+				return nil
 			}
+		}
+
+		if !sleepContext(ctx, d.PollingTimeOut) {
+			return nil
+		}
+	}
+}
+
+// listTargets lists the targets on the reader, if reopen is true the device is reopened first
+func (d *Device) listTargets(reopen bool) ([]nfc.Target, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil, ErrDeviceClosed
+	}
+
+	if reopen && d.open != nil {
+		if d.device != nil {
+			_ = d.device.Close()
+			d.device = nil
+		}
+		device, err := d.open(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.device = device
+	}
+
+	modulations := d.Modulations
+	if len(modulations) == 0 {
+		modulations = DefaultModulations
+	}
+
+	var targets []nfc.Target
+	for _, m := range modulations {
+		t, err := d.device.InitiatorListPassiveTargets(m)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, t...)
+	}
+	return targets, nil
+}
+
+// targetCards returns the cards of the targets that are reported in one polling cycle
+func (d *Device) targetCards(targets []nfc.Target) []Card {
+	cards := make([]Card, 0, len(targets))
+	for _, t := range targets {
+		card, ok := targetCard(t)
+		if !ok {
 			continue
 		}
+		cards = append(cards, card)
+		if !d.AllowMultipleTargets {
+			break
+		}
+	}
+	return cards
+}
 
-		sendTargetUid(targets[0], send)
+// backoff returns the wait after failures failed polling cycles in a row
+func (d *Device) backoff(failures int) time.Duration {
+	if failures > 16 {
+		failures = 16
+	}
+	wait := d.RetryBackoff << uint(failures-1)
+	if d.MaxBackoff > 0 && wait > d.MaxBackoff {
+		return d.MaxBackoff
 	}
+	return wait
 }
 
 func (d *Device) HasError() bool {
 	return d.LastErr != nil
 }
 
-func sendTargetUid(target nfc.Target, send chan<- []byte) {
-	card, ok := target.(*nfc.ISO14443aTarget)
-	if ok {
-		send <- card.UID[:card.UIDLen]
-	}
+func (d *Device) Err() error {
+	return d.LastErr
 }
 
-func OpenDevice(name string) (*Device, error) {
-	pnd, err := nfc.Open(name)
+// listDevices returns the connection strings of the libnfc devices
+var listDevices = nfc.ListDevices
 
+// openDevice opens the libnfc device name for Open
+func openDevice(name string) (Reader, error) {
+	return OpenDevice(name)
+}
+
+func OpenDevice(name string) (*Device, error) {
+	pnd, err := openLibnfc(name)
 	if err != nil {
-		return nil, fmt.Errorf("could not open device %q: %v", name, err)
+		return nil, err
 	}
 
 	return &Device{
 		device:         pnd,
+		name:           name,
+		open:           openLibnfc,
 		PollingTimeOut: 100 * time.Millisecond,
-		Modulation:     nfc.Modulation{Type: nfc.ISO14443a, BaudRate: nfc.Nbr106},
+		RetryBackoff:   100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
 	}, nil
 }
+
+func openLibnfc(name string) (passiveTargetLister, error) {
+	pnd, err := nfc.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not open device %q: %v", name, err)
+	}
+	return pnd, nil
+}
+
+// sleepContext waits for d, it returns false if ctx was cancelled
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// SelectTag selects the card with uid and calls fn with its tag, the card has to stay on the reader until fn returns.
+// The device is locked for the whole session, polling waits until fn returns. Only NTAG and MIFARE Ultralight cards are supported
+func (d *Device) SelectTag(uid []byte, fn func(tag chip.Tag) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return ErrDeviceClosed
+	}
+	transceiver, ok := d.device.(tagTransceiver)
+	if !ok {
+		return errors.New("device can not send commands to cards")
+	}
+
+	if _, err := transceiver.InitiatorSelectPassiveTarget(ISO14443A.Modulation(), uid); err != nil {
+		return fmt.Errorf("could not select card %x: %v", uid, err)
+	}
+
+	return fn(&ultralightTag{device: transceiver})
+}
+
+const (
+	ultralightRead  = 0x30
+	ultralightWrite = 0xA2
+
+	// defaultTimeout lets libnfc choose the timeout of a command
+	defaultTimeout = -1
+)
+
+// ultralightTag reads and writes the pages of a selected NTAG or MIFARE Ultralight card
+type ultralightTag struct {
+	device tagTransceiver
+}
+
+func (u *ultralightTag) ReadPages(page byte) ([]byte, error) {
+	rx := make([]byte, 4*chip.PageSize)
+	n, err := u.device.InitiatorTransceiveBytes([]byte{ultralightRead, page}, rx, defaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not read page %d: %v", page, err)
+	}
+	if n != len(rx) {
+		return nil, fmt.Errorf("could not read page %d: got %d bytes", page, n)
+	}
+	return rx, nil
+}
+
+func (u *ultralightTag) WritePage(page byte, data []byte) error {
+	if len(data) != chip.PageSize {
+		return fmt.Errorf("could not write page %d: got %d bytes", page, len(data))
+	}
+
+	tx := append([]byte{ultralightWrite, page}, data...)
+	if _, err := u.device.InitiatorTransceiveBytes(tx, make([]byte, 1), defaultTimeout); err != nil {
+		return fmt.Errorf("could not write page %d: %v", page, err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build !cgo
+// +build !cgo
+
+package nfcreader
+
+// libnfc needs cgo, without it only the simulator and remote readers can be opened
+
+// listDevices returns ErrNoLibnfc, no libnfc device can be listed
+var listDevices = func() ([]string, error) {
+	return nil, ErrNoLibnfc
+}
+
+// openDevice returns ErrNoLibnfc, no libnfc device can be opened
+func openDevice(name string) (Reader, error) {
+	return nil, ErrNoLibnfc
+}
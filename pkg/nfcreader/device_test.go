@@ -1,7 +1,12 @@
+//go:build cgo
+// +build cgo
+
 package nfcreader
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +18,7 @@ import (
 
 var targetId = [10]byte{0, 1, 2, 3, 4, 5, 6}
 var targetIdSlice = targetId[:]
+var targetIdCard = Card{Uid: "00010203040506000000", Type: ISO14443A}
 
 type mockReader struct {
 	isClosed          bool
@@ -81,8 +87,8 @@ func TestDevice_Listen(t *testing.T) {
 		if !ok {
 			t.Errorf("reciever timed out")
 		}
-		if !cmp.Equal(got, targetIdSlice) {
-			t.Errorf("got %v, expected %v", got, targetIdSlice)
+		if !cmp.Equal(got, targetIdCard) {
+			t.Errorf("got %v, expected %v", got, targetIdCard)
 		}
 	})
 	t.Run("multiple targets concurrently without AllowMultipleTargets", func(t *testing.T) {
@@ -101,8 +107,8 @@ func TestDevice_Listen(t *testing.T) {
 			t.Errorf("reciever timed out")
 		}
 
-		if !cmp.Equal(got, targetIdSlice) {
-			t.Errorf("got %v, expected %v", got, targetIdSlice)
+		if !cmp.Equal(got, targetIdCard) {
+			t.Errorf("got %v, expected %v", got, targetIdCard)
 		}
 	})
 	t.Run("multiple targets concurrently with AllowMultipleTargets", func(t *testing.T) {
@@ -121,8 +127,8 @@ func TestDevice_Listen(t *testing.T) {
 
 		for i := 0; i < 2; i++ {
 			got, _ := targetFromChannel(t, recv)
-			if got[0] != byte(i) {
-				t.Errorf("got %v, expected %v", got[0], byte(i))
+			if want := fmt.Sprintf("%02x", i); got.Uid[:2] != want {
+				t.Errorf("got %v, expected %v", got.Uid[:2], want)
 			}
 		}
 	})
@@ -147,6 +153,29 @@ func TestDevice_Listen(t *testing.T) {
 			t.Errorf("got %d targets, wanted 2", targetsRecv)
 		}
 	})
+	t.Run("polling timeout while target is present", func(t *testing.T) {
+		reader.setConcurrentTargets(1)
+		defer func() {
+			reader.setConcurrentTargets(0)
+		}()
+		dev := &Device{device: reader, PollingTimeOut: 20 * time.Millisecond}
+		recv := makeChannelAndListen(t, dev)
+
+		reads := 0
+		timeout := time.After(100 * time.Millisecond)
+	loop:
+		for {
+			select {
+			case <-recv:
+				reads++
+			case <-timeout:
+				break loop
+			}
+		}
+		if reads > 6 {
+			t.Errorf("got %d reads in 100ms, expected at most 6", reads)
+		}
+	})
 	t.Run("targets lister returns error", func(t *testing.T) {
 		reader := &mockReader{
 			concurrentTargets: 1,
@@ -184,22 +213,188 @@ func TestDevice_Close(t *testing.T) {
 	}
 }
 
-func targetFromChannel(t *testing.T, recv <-chan []byte) ([]byte, bool) {
+func targetFromChannel(t *testing.T, recv <-chan Card) (Card, bool) {
 	t.Helper()
 	select {
 	case target := <-recv:
 		return target, true
 	case <-time.After(10 * time.Millisecond):
-		return nil, false
+		return Card{}, false
 	}
 }
 
-func makeChannelAndListen(t *testing.T, dev *Device) chan []byte {
+func makeChannelAndListen(t *testing.T, dev *Device) chan Card {
 	t.Helper()
-	recv := make(chan []byte)
-	go func(chan []byte) {
-		dev.ListenForCardUids(recv)
+	recv := make(chan Card)
+	go func(chan Card) {
+		dev.ListenForCards(recv)
 	}(recv)
 
 	return recv
 }
+
+func TestDevice_ListenEvents(t *testing.T) {
+	is := isPkg.New(t)
+	reader := &mockReader{
+		concurrentTargets: 1,
+		createTargets:     createTargets,
+	}
+	dev := &Device{
+		device:    reader,
+		Debouncer: &Debouncer{RemoveAfter: 20 * time.Millisecond, Cooldown: time.Hour},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, errs := dev.Listen(ctx)
+
+	e := eventFromChannel(t, events)
+	is.Equal(e.Type, CardPresent)
+	is.Equal(e.Card, targetIdCard)
+
+	reader.setConcurrentTargets(0)
+	e = eventFromChannel(t, events)
+	is.Equal(e.Type, CardRemoved) // card left the reader
+
+	cancel()
+	is.NoErr(waitForStop(t, events, errs, nil))
+}
+
+func TestDevice_ListenRecovers(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   int
+		openFails  int
+		maxRetries int
+		wantErr    bool
+		wantOpens  int
+	}{
+		{name: "transient error", failures: 2, wantOpens: 2},
+		{name: "device is unplugged and plugged back in", failures: 1, openFails: 3, wantOpens: 4},
+		{name: "device does not recover", failures: 100, maxRetries: 3, wantErr: true, wantOpens: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			var mu sync.Mutex
+			failures, opens, retries := 0, 0, 0
+			failingTargets := func(m nfc.Modulation, targetNum int) ([]nfc.Target, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if failures < tt.failures {
+					failures++
+					return nil, errors.New("device unplugged")
+				}
+				return createTargets(m, targetNum)
+			}
+			first := &mockReader{concurrentTargets: 1, createTargets: failingTargets}
+
+			dev := &Device{
+				device: first,
+				name:   "pn532_uart:/dev/ttyUSB0",
+				open: func(name string) (passiveTargetLister, error) {
+					is.Equal(name, "pn532_uart:/dev/ttyUSB0")
+					mu.Lock()
+					defer mu.Unlock()
+					opens++
+					if opens <= tt.openFails {
+						return nil, errors.New("device not found")
+					}
+					return &mockReader{concurrentTargets: 1, createTargets: failingTargets}, nil
+				},
+				RetryBackoff: time.Millisecond,
+				MaxBackoff:   4 * time.Millisecond,
+				MaxRetries:   tt.maxRetries,
+				OnRetry: func(err error, attempt int) {
+					mu.Lock()
+					retries++
+					mu.Unlock()
+				},
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, errs := dev.Listen(ctx)
+
+			if tt.wantErr {
+				err := waitForStop(t, events, errs, nil)
+				is.True(err != nil) // listen stopped with error
+			} else {
+				e := eventFromChannel(t, events)
+				is.Equal(e.Type, CardPresent)
+				cancel()
+				// the card is removed when listening stops
+				is.NoErr(waitForStop(t, events, errs, []EventType{CardRemoved}))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			is.Equal(opens, tt.wantOpens)
+			is.Equal(retries, tt.wantOpens)
+			is.True(first.isClosed) // broken device was closed
+		})
+	}
+}
+
+func TestDevice_ListenClose(t *testing.T) {
+	is := isPkg.New(t)
+	reader := &mockReader{createTargets: createTargets}
+	dev := &Device{device: reader, PollingTimeOut: time.Millisecond}
+
+	events, errs := dev.Listen(context.Background())
+	time.Sleep(5 * time.Millisecond)
+	is.NoErr(dev.Close())
+
+	is.NoErr(waitForStop(t, events, errs, nil))
+	is.True(reader.isClosed)
+}
+
+func createTargets(_ nfc.Modulation, targetNum int) ([]nfc.Target, error) {
+	targets := make([]nfc.Target, 0, targetNum)
+	for i := 0; i < targetNum; i++ {
+		targets = append(targets, &nfc.ISO14443aTarget{UID: targetId, UIDLen: len(targetId)})
+	}
+	return targets, nil
+}
+
+func TestDevice_Modulations(t *testing.T) {
+	is := isPkg.New(t)
+
+	var mu sync.Mutex
+	var polled []nfc.Modulation
+	reader := &mockReader{
+		createTargets: func(m nfc.Modulation, _ int) ([]nfc.Target, error) {
+			mu.Lock()
+			polled = append(polled, m)
+			mu.Unlock()
+			switch m.Type {
+			case nfc.ISO14443b:
+				return []nfc.Target{&nfc.ISO14443bTarget{Pupi: [4]byte{1, 2, 3, 4}}}, nil
+			case nfc.Felica:
+				return []nfc.Target{&nfc.FelicaTarget{ID: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}}}, nil
+			}
+			return nil, nil
+		},
+	}
+	dev := &Device{
+		device:               reader,
+		Modulations:          Modulations(ISO14443A, ISO14443B, FeliCa),
+		AllowMultipleTargets: true,
+		PollingTimeOut:       time.Hour,
+	}
+
+	recv := makeChannelAndListen(t, dev)
+	first, _ := targetFromChannel(t, recv)
+	second, _ := targetFromChannel(t, recv)
+	is.Equal(first, Card{Uid: "01020304", Type: ISO14443B})
+	is.Equal(second, Card{Uid: "0102030405060708", Type: FeliCa})
+
+	mu.Lock()
+	defer mu.Unlock()
+	is.Equal(polled, []nfc.Modulation{
+		{Type: nfc.ISO14443a, BaudRate: nfc.Nbr106},
+		{Type: nfc.ISO14443b, BaudRate: nfc.Nbr106},
+		{Type: nfc.Felica, BaudRate: nfc.Nbr212},
+	})
+}
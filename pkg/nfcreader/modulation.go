@@ -0,0 +1,59 @@
+//go:build cgo
+// +build cgo
+
+package nfcreader
+
+import "github.com/fuzxxl/nfc/2.0/nfc"
+
+// DefaultModulations are polled by devices without modulations
+var DefaultModulations = []nfc.Modulation{ISO14443A.Modulation()}
+
+// Modulation returns the modulation that is used to poll cards of this type
+func (c CardType) Modulation() nfc.Modulation {
+	switch c {
+	case ISO14443B:
+		return nfc.Modulation{Type: nfc.ISO14443b, BaudRate: nfc.Nbr106}
+	case FeliCa:
+		return nfc.Modulation{Type: nfc.Felica, BaudRate: nfc.Nbr212}
+	case Jewel:
+		return nfc.Modulation{Type: nfc.Jewel, BaudRate: nfc.Nbr106}
+	}
+	return nfc.Modulation{Type: nfc.ISO14443a, BaudRate: nfc.Nbr106}
+}
+
+// Modulations returns the modulations to poll the card types
+func Modulations(types ...CardType) []nfc.Modulation {
+	modulations := make([]nfc.Modulation, 0, len(types))
+	for _, c := range types {
+		modulations = append(modulations, c.Modulation())
+	}
+	return modulations
+}
+
+// targetCard returns the card of target, it returns false for unsupported targets
+func targetCard(target nfc.Target) (Card, bool) {
+	var uid []byte
+	var cardType CardType
+
+	switch t := target.(type) {
+	case *nfc.ISO14443aTarget:
+		if t.UIDLen < 0 || t.UIDLen > len(t.UID) {
+			return Card{}, false
+		}
+		uid, cardType = t.UID[:t.UIDLen], ISO14443A
+	case *nfc.ISO14443bTarget:
+		uid, cardType = t.Pupi[:], ISO14443B
+	case *nfc.FelicaTarget:
+		uid, cardType = t.ID[:], FeliCa
+	case *nfc.JewelTarget:
+		uid, cardType = t.ID[:], Jewel
+	default:
+		return Card{}, false
+	}
+
+	formatted, err := FormatUid(uid)
+	if err != nil {
+		return Card{}, false
+	}
+	return Card{Uid: formatted, Type: cardType}, true
+}
@@ -0,0 +1,61 @@
+//go:build cgo
+// +build cgo
+
+package nfcreader
+
+import (
+	"testing"
+
+	"github.com/fuzxxl/nfc/2.0/nfc"
+	isPkg "github.com/matryer/is"
+)
+
+func TestTargetCard(t *testing.T) {
+	tests := []struct {
+		name   string
+		target nfc.Target
+		want   Card
+		wantOk bool
+	}{
+		{
+			name:   "iso14443a",
+			target: &nfc.ISO14443aTarget{UID: [10]byte{4, 161, 178, 195, 212, 229, 246}, UIDLen: 7},
+			want:   Card{Uid: "04a1b2c3d4e5f6", Type: ISO14443A},
+			wantOk: true,
+		},
+		{
+			name:   "iso14443b",
+			target: &nfc.ISO14443bTarget{Pupi: [4]byte{1, 2, 3, 4}},
+			want:   Card{Uid: "01020304", Type: ISO14443B},
+			wantOk: true,
+		},
+		{
+			name:   "felica",
+			target: &nfc.FelicaTarget{ID: [8]byte{1, 46, 74, 202, 11, 22, 33, 44}},
+			want:   Card{Uid: "012e4aca0b16212c", Type: FeliCa},
+			wantOk: true,
+		},
+		{
+			name:   "jewel",
+			target: &nfc.JewelTarget{ID: [4]byte{0xDE, 0xAD, 0xBE, 0xEF}},
+			want:   Card{Uid: "deadbeef", Type: Jewel},
+			wantOk: true,
+		},
+		{
+			name:   "invalid uid length",
+			target: &nfc.ISO14443aTarget{UIDLen: 11},
+		},
+		{
+			name:   "unsupported target",
+			target: &nfc.ISO14443b2srTarget{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			got, ok := targetCard(tt.target)
+			is.Equal(ok, tt.wantOk)
+			is.Equal(got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package nfcreader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// AutoDevice selects the first libnfc device
+	AutoDevice = "auto"
+
+	// SimulatorPrefix selects the simulator, the rest of the device string is the path of the script, "-" or nothing reads stdin
+	SimulatorPrefix = "sim:"
+	// TCPPrefix selects a remote reader, the rest of the device string is its address
+	TCPPrefix = "tcp:"
+)
+
+// Reader is a source of card reads
+type Reader interface {
+	io.Closer
+	// ListenForCards sends every read of a card to send, it closes send when the reader stops
+	ListenForCards(send chan<- Card)
+	// Listen sends an event when a card is put on or removed from the reader until ctx is cancelled or the reader stops.
+	// Both channels are closed when the reader stopped, the error channel receives the error that stopped it
+	Listen(ctx context.Context) (<-chan CardEvent, <-chan error)
+	// HasError reports if the reader stopped because of an error
+	HasError() bool
+	// Err returns the error that stopped the reader
+	Err() error
+}
+
+// ErrNoDevice is returned if no libnfc device is connected
+var ErrNoDevice = errors.New("no nfc device found")
+
+// ErrNoLibnfc is returned for libnfc devices if the reader was built without cgo
+var ErrNoLibnfc = errors.New("nfc reader was built without libnfc, only sim: and tcp: devices are supported")
+
+// Open opens the reader for device.
+// Device strings starting with "sim:" open the simulator and "tcp:" a remote reader, all others are resolved with ResolveDevice
+func Open(device string) (Reader, error) {
+	switch {
+	case device == "sim" || strings.HasPrefix(device, SimulatorPrefix):
+		return OpenSimulator(strings.TrimPrefix(strings.TrimPrefix(device, "sim"), ":"))
+	case strings.HasPrefix(device, TCPPrefix):
+		return DialTCP(strings.TrimPrefix(device, TCPPrefix))
+	}
+
+	name, err := ResolveDevice(device)
+	if err != nil {
+		return nil, err
+	}
+	return openDevice(name)
+}
+
+// ListDevices returns the connection strings of the connected libnfc devices
+func ListDevices() ([]string, error) {
+	devices, err := listDevices()
+	if err != nil {
+		return nil, fmt.Errorf("could not list devices: %v", err)
+	}
+	return devices, nil
+}
+
+// ResolveDevice returns the libnfc connection string of device.
+// "auto" selects the first connected device and a number the device with this index in ListDevices,
+// all other devices are returned unchanged
+func ResolveDevice(device string) (string, error) {
+	index, err := strconv.Atoi(device)
+	switch {
+	case device == AutoDevice:
+		index = 0
+	case err != nil:
+		return device, nil
+	}
+
+	devices, err := ListDevices()
+	if err != nil {
+		return "", err
+	}
+	if len(devices) == 0 {
+		return "", ErrNoDevice
+	}
+	if index < 0 || index >= len(devices) {
+		return "", fmt.Errorf("device %d not found, %d devices are connected", index, len(devices))
+	}
+	return devices[index], nil
+}
@@ -0,0 +1,46 @@
+package nfcreader
+
+import (
+	"errors"
+	"testing"
+
+	isPkg "github.com/matryer/is"
+)
+
+func TestResolveDevice(t *testing.T) {
+	tests := []struct {
+		name    string
+		device  string
+		devices []string
+		listErr error
+		want    string
+		wantErr error
+	}{
+		{name: "connection string", device: "pn532_uart:/dev/ttyUSB0", want: "pn532_uart:/dev/ttyUSB0"},
+		{name: "auto", device: AutoDevice, devices: []string{"acr122_usb:001:004", "pn532_uart:/dev/ttyUSB0"}, want: "acr122_usb:001:004"},
+		{name: "auto without devices", device: AutoDevice, wantErr: ErrNoDevice},
+		{name: "index", device: "1", devices: []string{"acr122_usb:001:004", "pn532_uart:/dev/ttyUSB0"}, want: "pn532_uart:/dev/ttyUSB0"},
+		{name: "index out of range", device: "2", devices: []string{"acr122_usb:001:004", "pn532_uart:/dev/ttyUSB0"}},
+		{name: "negative index", device: "-1", devices: []string{"acr122_usb:001:004"}},
+		{name: "list fails", device: AutoDevice, listErr: errors.New("libnfc error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			old := listDevices
+			listDevices = func() ([]string, error) {
+				return tt.devices, tt.listErr
+			}
+			defer func() {
+				listDevices = old
+			}()
+
+			got, err := ResolveDevice(tt.device)
+			is.Equal(got, tt.want)
+			if tt.wantErr != nil {
+				is.Equal(err, tt.wantErr)
+			}
+			is.Equal(err != nil, tt.want == "") // error if no device was resolved
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package nfcreader
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Simulator replays card reads from a script, so the reader can be used without hardware.
+//
+// Every line of the script is the hex encoded uid of a card, colons and spaces in uids are ignored.
+// The uid can be followed by the card type, the default is iso14443a.
+// A line "wait <duration>" pauses the simulator, empty lines and lines starting with # are skipped:
+//
+//	# two cards, one second apart
+//	04a1b2c3d4e5f6
+//	wait 1s
+//	04:a1:b2:c3:d4:e5:f7
+//	0102030405060708 felica
+type Simulator struct {
+	script    io.ReadCloser
+	done      chan struct{}
+	closeOnce sync.Once
+	LastErr   error
+
+	// Debouncer turns the reads into card events, if it is nil Listen uses NewDebouncer
+	Debouncer *Debouncer
+
+	// eofErr is reported when the script ends, nil means the script ended normally
+	eofErr error
+}
+
+// NewSimulator returns a simulator that replays script, Close closes script
+func NewSimulator(script io.ReadCloser) *Simulator {
+	return &Simulator{
+		script: script,
+		done:   make(chan struct{}),
+	}
+}
+
+// OpenSimulator opens the script at path, an empty path or "-" reads the script from stdin
+func OpenSimulator(path string) (*Simulator, error) {
+	if path == "" || path == "-" {
+		return NewSimulator(ioutil.NopCloser(os.Stdin)), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open simulator script: %v", err)
+	}
+	return NewSimulator(f), nil
+}
+
+func (s *Simulator) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = s.script.Close()
+	})
+	return err
+}
+
+func (s *Simulator) ListenForCards(send chan<- Card) {
+	defer close(send)
+	s.LastErr = s.replay(context.Background(), send)
+}
+
+// Listen replays the script until it ends, ctx is cancelled or the simulator is closed
+// and sends an event when a card of the script is put on or removed from the reader
+func (s *Simulator) Listen(ctx context.Context) (<-chan CardEvent, <-chan error) {
+	return listen(ctx, s.Debouncer, s.replay)
+}
+
+// replay sends the cards of the script to send until it ends, ctx is cancelled or the simulator is closed
+func (s *Simulator) replay(ctx context.Context, send chan<- Card) error {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go s.readLines(ctx, lines, readErr)
+
+	for line := 1; ; line++ {
+		var text string
+		select {
+		case l, ok := <-lines:
+			if !ok {
+				return s.scriptEnded(<-readErr)
+			}
+			text = strings.TrimSpace(l)
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return nil
+		}
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "wait ") {
+			d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(text, "wait ")))
+			if err != nil {
+				return fmt.Errorf("line %d: invalid wait: %v", line, err)
+			}
+			select {
+			case <-time.After(d):
+				continue
+			case <-ctx.Done():
+				return nil
+			case <-s.done:
+				return nil
+			}
+		}
+
+		card, err := parseCard(text)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+
+		select {
+		case send <- card:
+		case <-ctx.Done():
+			return nil
+		case <-s.done:
+			return nil
+		}
+	}
+}
+
+// readLines sends the lines of the script to lines, it runs in its own goroutine because reads from stdin can not be cancelled
+func (s *Simulator) readLines(ctx context.Context, lines chan<- string, readErr chan<- error) {
+	defer close(lines)
+
+	scanner := bufio.NewScanner(s.script)
+	for scanner.Scan() {
+		select {
+		case lines <- scanner.Text():
+		case <-ctx.Done():
+			readErr <- nil
+			return
+		case <-s.done:
+			readErr <- nil
+			return
+		}
+	}
+	readErr <- scanner.Err()
+}
+
+// scriptEnded returns the error for the end of the script
+func (s *Simulator) scriptEnded(err error) error {
+	select {
+	case <-s.done:
+		// the script was closed by Close, read errors are expected
+		return nil
+	default:
+	}
+	if err != nil {
+		return fmt.Errorf("could not read script: %v", err)
+	}
+	return s.eofErr
+}
+
+func (s *Simulator) HasError() bool {
+	return s.LastErr != nil
+}
+
+func (s *Simulator) Err() error {
+	return s.LastErr
+}
+
+// parseCard decodes a script line with a hex encoded uid and an optional card type,
+// colons and spaces between the bytes of the uid are ignored
+func parseCard(text string) (Card, error) {
+	card := Card{Type: ISO14443A}
+	if fields := strings.Fields(text); len(fields) > 1 {
+		if cardType, err := ParseCardType(fields[len(fields)-1]); err == nil {
+			card.Type = cardType
+			text = strings.Join(fields[:len(fields)-1], "")
+		}
+	}
+
+	cleaned := strings.NewReplacer(":", "", " ", "").Replace(text)
+	uid, err := hex.DecodeString(cleaned)
+	if err != nil {
+		return Card{}, fmt.Errorf("invalid uid %q", text)
+	}
+	if card.Uid, err = FormatUid(uid); err != nil {
+		return Card{}, fmt.Errorf("invalid uid %q: %v", text, err)
+	}
+	return card, nil
+}
@@ -0,0 +1,220 @@
+package nfcreader
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	isPkg "github.com/matryer/is"
+)
+
+func TestSimulator_ListenForCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    []Card
+		wantErr bool
+	}{
+		{
+			name:   "empty script",
+			script: "",
+		},
+		{
+			name: "uids with comments and waits",
+			script: `# first card
+04a1b2c3d4e5f6
+
+wait 1ms
+04:A1:B2:C3:D4:E5:F7
+  01 02 03 04  `,
+			want: []Card{
+				{Uid: "04a1b2c3d4e5f6", Type: ISO14443A},
+				{Uid: "04a1b2c3d4e5f7", Type: ISO14443A},
+				{Uid: "01020304", Type: ISO14443A},
+			},
+		},
+		{
+			name:   "card types",
+			script: "01020304 ISO14443B\n0102030405060708 felica\n01 02 03 04 jewel\n04a1b2c3d4e5f6 iso14443a",
+			want: []Card{
+				{Uid: "01020304", Type: ISO14443B},
+				{Uid: "0102030405060708", Type: FeliCa},
+				{Uid: "01020304", Type: Jewel},
+				{Uid: "04a1b2c3d4e5f6", Type: ISO14443A},
+			},
+		},
+		{
+			name:    "uid too long",
+			script:  "0102030405060708090a0b",
+			wantErr: true,
+		},
+		{
+			name:    "invalid uid",
+			script:  "04a1b2c3d4e5f6\nno uid\n01020304",
+			want:    []Card{{Uid: "04a1b2c3d4e5f6", Type: ISO14443A}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid wait",
+			script:  "wait forever\n01020304",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim := NewSimulator(ioutil.NopCloser(strings.NewReader(tt.script)))
+
+			got := collectCards(t, sim)
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("got uids %v, expected %v", got, tt.want)
+			}
+			if sim.HasError() != tt.wantErr {
+				t.Errorf("got err %v, expected err %v", sim.Err(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSimulator_Close(t *testing.T) {
+	is := isPkg.New(t)
+	sim := NewSimulator(ioutil.NopCloser(strings.NewReader("01020304\nwait 1h\n01020305")))
+
+	recv := make(chan Card)
+	go sim.ListenForCards(recv)
+
+	card := <-recv
+	is.Equal(card.Uid, "01020304")
+
+	is.NoErr(sim.Close())
+	select {
+	case _, ok := <-recv:
+		is.True(!ok) // no uid after close
+	case <-time.After(time.Second):
+		t.Fatal("simulator did not stop")
+	}
+	is.NoErr(sim.Err())
+	is.NoErr(sim.Close()) // second close
+}
+
+func TestSimulator_Listen(t *testing.T) {
+	t.Run("script ends", func(t *testing.T) {
+		is := isPkg.New(t)
+		sim := NewSimulator(ioutil.NopCloser(strings.NewReader("01020304\n01020304\nno uid")))
+
+		events, errs := sim.Listen(context.Background())
+		e := eventFromChannel(t, events)
+		is.Equal(e.Type, CardPresent)
+		is.Equal(e.Card, Card{Uid: "01020304", Type: ISO14443A})
+
+		err := waitForStop(t, events, errs, []EventType{CardRemoved})
+		is.True(err != nil) // invalid line in script
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		is := isPkg.New(t)
+		script, stdin := io.Pipe()
+		defer stdin.Close()
+		sim := NewSimulator(script)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, errs := sim.Listen(ctx)
+		_, err := stdin.Write([]byte("01020304\n"))
+		is.NoErr(err)
+		is.Equal(eventFromChannel(t, events).Type, CardPresent)
+
+		cancel()
+		is.NoErr(waitForStop(t, events, errs, []EventType{CardRemoved}))
+	})
+}
+
+func TestOpen(t *testing.T) {
+	is := isPkg.New(t)
+
+	dir, err := ioutil.TempDir("", "nfcreader")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	script := filepath.Join(dir, "cards.txt")
+	is.NoErr(ioutil.WriteFile(script, []byte("01020304\n"), 0600))
+
+	reader, err := Open(SimulatorPrefix + script)
+	is.NoErr(err)
+	is.Equal(collectCards(t, reader), []Card{{Uid: "01020304", Type: ISO14443A}})
+	is.NoErr(reader.Close())
+
+	_, err = Open(SimulatorPrefix + filepath.Join(dir, "missing.txt"))
+	is.True(err != nil) // script does not exist
+
+	reader, err = Open("sim")
+	is.NoErr(err)
+	_, ok := reader.(*Simulator)
+	is.True(ok) // stdin simulator
+}
+
+// collectCards returns all reads of reader until it stops
+func collectCards(t *testing.T, reader Reader) []Card {
+	t.Helper()
+	recv := make(chan Card)
+	go reader.ListenForCards(recv)
+
+	var cards []Card
+	for {
+		select {
+		case card, ok := <-recv:
+			if !ok {
+				return cards
+			}
+			cards = append(cards, card)
+		case <-time.After(time.Second):
+			t.Fatal("reader did not stop")
+		}
+	}
+}
+
+func eventFromChannel(t *testing.T, events <-chan CardEvent) CardEvent {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("events closed")
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+	}
+	return CardEvent{}
+}
+
+// waitForStop drains events until listening stopped and returns the error of the reader
+func waitForStop(t *testing.T, events <-chan CardEvent, errs <-chan error, wantEvents []EventType) error {
+	t.Helper()
+	timeout := time.After(time.Second)
+	var got []EventType
+	for events != nil {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			got = append(got, e.Type)
+		case <-timeout:
+			t.Fatal("listen did not stop")
+		}
+	}
+	if len(got) != len(wantEvents) {
+		t.Errorf("got events %v after stop, expected %v", got, wantEvents)
+	}
+
+	select {
+	case err := <-errs:
+		return err
+	case <-timeout:
+		t.Fatal("errors not closed")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+//go:build cgo
+// +build cgo
+
+package nfcreader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fuzxxl/nfc/2.0/nfc"
+	"github.com/jheimbach/nfc-cash-system/pkg/chip"
+	isPkg "github.com/matryer/is"
+)
+
+// mockTagReader answers ultralight commands from a tag in memory
+type mockTagReader struct {
+	mockReader
+	uid []byte
+	tag *chip.MemoryTag
+}
+
+func (m *mockTagReader) InitiatorSelectPassiveTarget(_ nfc.Modulation, uid []byte) (nfc.Target, error) {
+	if !bytes.Equal(uid, m.uid) {
+		return nil, errors.New("no target found")
+	}
+	return &nfc.ISO14443aTarget{}, nil
+}
+
+func (m *mockTagReader) InitiatorTransceiveBytes(tx, rx []byte, _ int) (int, error) {
+	switch tx[0] {
+	case ultralightRead:
+		data, err := m.tag.ReadPages(tx[1])
+		if err != nil {
+			return 0, err
+		}
+		return copy(rx, data), nil
+	case ultralightWrite:
+		return 0, m.tag.WritePage(tx[1], tx[2:])
+	}
+	return 0, errors.New("unknown command")
+}
+
+func TestDevice_SelectTag(t *testing.T) {
+	is := isPkg.New(t)
+	key := []byte("test key")
+	uid := []byte{4, 161, 178, 195, 212, 229, 246}
+	uidHex := "04a1b2c3d4e5f6"
+
+	reader := &mockTagReader{uid: uid, tag: chip.NewMemoryTag(45)}
+	dev := &Device{device: reader}
+
+	var balance chip.Balance
+	err := dev.SelectTag(uid, func(tag chip.Tag) error {
+		if err := chip.WriteBalance(tag, key, uidHex, chip.Balance{AccountId: 1, Saldo: 10, Counter: 1}); err != nil {
+			return err
+		}
+
+		var err error
+		balance, err = chip.Charge(tag, key, uidHex, 2.5, false)
+		return err
+	})
+	is.NoErr(err)
+	is.Equal(balance, chip.Balance{AccountId: 1, Saldo: 7.5, Counter: 2})
+
+	// balance is stored in the simulated tag
+	stored, err := chip.ReadBalance(reader.tag, key, uidHex)
+	is.NoErr(err)
+	is.Equal(stored, balance)
+
+	sessionErr := errors.New("session failed")
+	err = dev.SelectTag(uid, func(chip.Tag) error { return sessionErr })
+	is.Equal(err, sessionErr) // error of the session is returned
+
+	err = dev.SelectTag([]byte{1, 2, 3, 4}, func(chip.Tag) error { return nil })
+	is.True(err != nil) // card is not on the reader
+
+	err = (&Device{device: &mockReader{}}).SelectTag(uid, func(chip.Tag) error { return nil })
+	is.True(err != nil) // device can not transceive
+
+	is.NoErr(dev.Close())
+	err = dev.SelectTag(uid, func(chip.Tag) error { return nil })
+	is.Equal(err, ErrDeviceClosed)
+}
+
+func TestDevice_SelectTagPausesPolling(t *testing.T) {
+	is := isPkg.New(t)
+	uid := []byte{4, 161, 178, 195, 212, 229, 246}
+
+	var inSession int32
+	reader := &mockTagReader{uid: uid, tag: chip.NewMemoryTag(45)}
+	reader.concurrentTargets = 1
+	reader.createTargets = func(m nfc.Modulation, targetNum int) ([]nfc.Target, error) {
+		if atomic.LoadInt32(&inSession) == 1 {
+			t.Error("device was polled during the tag session")
+		}
+		return createTargets(m, targetNum)
+	}
+	dev := &Device{device: reader, PollingTimeOut: time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, errs := dev.Listen(ctx)
+	is.Equal(eventFromChannel(t, events).Type, CardPresent)
+
+	err := dev.SelectTag(uid, func(tag chip.Tag) error {
+		atomic.StoreInt32(&inSession, 1)
+		defer atomic.StoreInt32(&inSession, 0)
+
+		// several polling cycles pass while the session reads the tag
+		time.Sleep(20 * time.Millisecond)
+		_, err := tag.ReadPages(chip.FirstPage)
+		return err
+	})
+	is.NoErr(err)
+
+	cancel()
+	for range events {
+	}
+	is.NoErr(<-errs)
+}
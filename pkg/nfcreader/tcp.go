@@ -0,0 +1,27 @@
+package nfcreader
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// dialTimeout is the time DialTCP waits for the remote reader
+const dialTimeout = 5 * time.Second
+
+// ErrConnectionClosed is returned by a remote reader when it closes the connection
+var ErrConnectionClosed = errors.New("remote reader closed the connection")
+
+// DialTCP connects to a remote reader at address.
+// The remote reader writes the uids of the cards in the simulator script format, usually one hex encoded uid per line
+func DialTCP(address string) (*Simulator, error) {
+	conn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to reader %q: %v", address, err)
+	}
+
+	s := NewSimulator(conn)
+	s.eofErr = ErrConnectionClosed
+	return s, nil
+}
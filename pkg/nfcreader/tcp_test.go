@@ -0,0 +1,36 @@
+package nfcreader
+
+import (
+	"net"
+	"testing"
+
+	isPkg "github.com/matryer/is"
+)
+
+func TestDialTCP(t *testing.T) {
+	is := isPkg.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	is.NoErr(err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("01020304\n04a1b2c3d4e5f6\n"))
+		_ = conn.Close()
+	}()
+
+	reader, err := Open(TCPPrefix + listener.Addr().String())
+	is.NoErr(err)
+	defer reader.Close()
+
+	is.Equal(collectCards(t, reader), []Card{{Uid: "01020304", Type: ISO14443A}, {Uid: "04a1b2c3d4e5f6", Type: ISO14443A}})
+	is.Equal(reader.Err(), ErrConnectionClosed) // remote reader closed the connection
+
+	listener.Close()
+	_, err = DialTCP(listener.Addr().String())
+	is.True(err != nil) // reader is not reachable
+}
@@ -0,0 +1,200 @@
+package offline
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+// Queue holds the signed charges a terminal made while it was offline.
+// Every change is written to the queue file before it returns, a restarted terminal neither loses charges
+// nor reuses a sequence
+type Queue struct {
+	mu         sync.Mutex
+	path       string
+	terminalId string
+	key        ed25519.PrivateKey
+	state      queueState
+	now        func() time.Time
+}
+
+// queueState is the content of the queue file
+type queueState struct {
+	LastSequence uint64               `json:"last_sequence"`
+	Pending      []*queuedTransaction `json:"pending"`
+}
+
+type queuedTransaction struct {
+	Sequence  uint64    `json:"sequence"`
+	AccountId int32     `json:"account_id"`
+	Amount    float64   `json:"amount"`
+	Created   time.Time `json:"created"`
+	Signature []byte    `json:"signature"`
+}
+
+// OpenQueue opens the queue of terminal terminalId stored in path, the file is created with the first charge
+func OpenQueue(path, terminalId string, key ed25519.PrivateKey) (*Queue, error) {
+	q := &Queue{
+		path:       path,
+		terminalId: terminalId,
+		key:        key,
+		now:        time.Now,
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return q, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &q.state); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Add signs a charge of amount for account accountId with the next sequence and queues it
+func (q *Queue) Add(accountId int32, amount float64) (*api.OfflineTransaction, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queued := &queuedTransaction{
+		Sequence:  q.state.LastSequence + 1,
+		AccountId: accountId,
+		Amount:    amount,
+		Created:   q.now().UTC(),
+	}
+	transaction, err := queued.offlineTransaction()
+	if err != nil {
+		return nil, err
+	}
+	Sign(q.key, q.terminalId, transaction)
+	queued.Signature = transaction.Signature
+
+	state := queueState{
+		LastSequence: queued.Sequence,
+		Pending:      append(q.state.Pending[:len(q.state.Pending):len(q.state.Pending)], queued),
+	}
+	if err := q.save(state); err != nil {
+		return nil, err
+	}
+	q.state = state
+
+	return transaction, nil
+}
+
+// Pending returns all queued charges in order of their sequence
+func (q *Queue) Pending() ([]*api.OfflineTransaction, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	transactions := make([]*api.OfflineTransaction, 0, len(q.state.Pending))
+	for _, queued := range q.state.Pending {
+		transaction, err := queued.offlineTransaction()
+		if err != nil {
+			return nil, err
+		}
+		transaction.Signature = queued.Signature
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// Ack removes every charge up to lastSequence from the queue.
+// If the server is ahead of the queue, the next charge continues after lastSequence
+func (q *Queue) Ack(lastSequence uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	state := queueState{LastSequence: q.state.LastSequence}
+	if lastSequence > state.LastSequence {
+		state.LastSequence = lastSequence
+	}
+	for _, queued := range q.state.Pending {
+		if queued.Sequence > lastSequence {
+			state.Pending = append(state.Pending, queued)
+		}
+	}
+
+	if err := q.save(state); err != nil {
+		return err
+	}
+	q.state = state
+	return nil
+}
+
+// Sync sends all queued charges to the server and removes the processed ones from the queue.
+// Conflicts are part of the returned response, they are not retried
+func (q *Queue) Sync(ctx context.Context, client api.TransactionsServiceClient) (*api.SyncOfflineTransactionsResponse, error) {
+	pending, err := q.Pending()
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return &api.SyncOfflineTransactionsResponse{}, nil
+	}
+
+	res, err := client.SyncOfflineTransactions(ctx, &api.SyncOfflineTransactionsRequest{
+		TerminalId:   q.terminalId,
+		Transactions: pending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.Ack(res.LastSequence); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// save writes state to a temporary file and moves it over the queue file, so the queue file is never half written
+func (q *Queue) save(state queueState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(q.path), filepath.Base(q.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), q.path)
+}
+
+func (t *queuedTransaction) offlineTransaction() (*api.OfflineTransaction, error) {
+	created, err := ptypes.TimestampProto(t.Created)
+	if err != nil {
+		return nil, err
+	}
+	return &api.OfflineTransaction{
+		Sequence:  t.Sequence,
+		AccountId: t.AccountId,
+		Amount:    t.Amount,
+		Created:   created,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package offline
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	isPkg "github.com/matryer/is"
+	"google.golang.org/grpc"
+)
+
+type syncClientMock struct {
+	api.TransactionsServiceClient
+	syncFunc func(req *api.SyncOfflineTransactionsRequest) (*api.SyncOfflineTransactionsResponse, error)
+}
+
+func (s *syncClientMock) SyncOfflineTransactions(_ context.Context, in *api.SyncOfflineTransactionsRequest, _ ...grpc.CallOption) (*api.SyncOfflineTransactionsResponse, error) {
+	return s.syncFunc(in)
+}
+
+func TestQueue(t *testing.T) {
+	is := isPkg.New(t)
+
+	publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+	is.NoErr(err)
+
+	dir, err := ioutil.TempDir("", "offline")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "queue.json")
+
+	q, err := OpenQueue(path, "bar-1", key)
+	is.NoErr(err)
+	q.now = func() time.Time { return time.Date(2020, 3, 1, 20, 15, 0, 500, time.Local) }
+
+	first, err := q.Add(1, 2.5)
+	is.NoErr(err)
+	second, err := q.Add(2, 5)
+	is.NoErr(err)
+	is.Equal(first.Sequence, uint64(1))
+	is.Equal(second.Sequence, uint64(2))
+
+	// a reopened queue has the same charges and continues the sequence
+	q, err = OpenQueue(path, "bar-1", key)
+	is.NoErr(err)
+
+	pending, err := q.Pending()
+	is.NoErr(err)
+	is.Equal(len(pending), 2)
+	for i, want := range []*api.OfflineTransaction{first, second} {
+		is.Equal(pending[i].String(), want.String())    // queued charge changed
+		is.True(Verify(publicKey, "bar-1", pending[i])) // queued charge is signed
+	}
+
+	third, err := q.Add(1, 1)
+	is.NoErr(err)
+	is.Equal(third.Sequence, uint64(3))
+
+	is.NoErr(q.Ack(2))
+	pending, err = q.Pending()
+	is.NoErr(err)
+	is.Equal(len(pending), 1)
+	is.Equal(pending[0].Sequence, uint64(3))
+
+	// the server is ahead, the queue continues after its sequence
+	is.NoErr(q.Ack(10))
+	next, err := q.Add(1, 1)
+	is.NoErr(err)
+	is.Equal(next.Sequence, uint64(11))
+}
+
+func TestQueue_Sync(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		charges     int
+		lastSeq     uint64
+		returnErr   error
+		wantCall    bool
+		wantPending int
+	}{
+		{name: "nothing to sync"},
+		{name: "all charges processed", charges: 3, lastSeq: 3, wantCall: true},
+		{name: "server processed part of the charges", charges: 3, lastSeq: 2, wantCall: true, wantPending: 1},
+		{name: "sync fails", charges: 2, returnErr: errors.New("unavailable"), wantCall: true, wantPending: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			dir, err := ioutil.TempDir("", "offline")
+			is.NoErr(err)
+			defer os.RemoveAll(dir)
+
+			q, err := OpenQueue(filepath.Join(dir, "queue.json"), "bar-1", key)
+			is.NoErr(err)
+			for i := 0; i < tt.charges; i++ {
+				_, err := q.Add(1, 1)
+				is.NoErr(err)
+			}
+
+			called := false
+			client := &syncClientMock{
+				syncFunc: func(req *api.SyncOfflineTransactionsRequest) (*api.SyncOfflineTransactionsResponse, error) {
+					called = true
+					is.Equal(req.TerminalId, "bar-1")
+					is.Equal(len(req.Transactions), tt.charges)
+					if tt.returnErr != nil {
+						return nil, tt.returnErr
+					}
+					return &api.SyncOfflineTransactionsResponse{LastSequence: tt.lastSeq}, nil
+				},
+			}
+
+			_, err = q.Sync(context.Background(), client)
+			is.Equal(err, tt.returnErr)
+			is.Equal(called, tt.wantCall) // unexpected sync call
+
+			pending, err := q.Pending()
+			is.NoErr(err)
+			is.Equal(len(pending), tt.wantPending)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Package offline lets terminals keep charging accounts while they can not reach the server.
+// Charges are signed with the ed25519 key of the terminal and queued with a monotonic sequence,
+// the queue is replayed with SyncOfflineTransactions as soon as the server is reachable again.
+package offline
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+// Payload returns the bytes of transaction that terminal terminalId signs.
+// It is the terminal id followed by a zero byte, the sequence, the account id, the amount in cents
+// and the created time in seconds and nanos, all numbers big endian
+func Payload(terminalId string, transaction *api.OfflineTransaction) []byte {
+	buf := make([]byte, 0, len(terminalId)+1+8+4+8+8+4)
+	buf = append(buf, terminalId...)
+	buf = append(buf, 0)
+
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], transaction.Sequence)
+	buf = append(buf, n[:]...)
+	binary.BigEndian.PutUint32(n[:4], uint32(transaction.AccountId))
+	buf = append(buf, n[:4]...)
+	binary.BigEndian.PutUint64(n[:], uint64(int64(math.Round(transaction.Amount*100))))
+	buf = append(buf, n[:]...)
+
+	var seconds int64
+	var nanos int32
+	if transaction.Created != nil {
+		seconds, nanos = transaction.Created.Seconds, transaction.Created.Nanos
+	}
+	binary.BigEndian.PutUint64(n[:], uint64(seconds))
+	buf = append(buf, n[:]...)
+	binary.BigEndian.PutUint32(n[:4], uint32(nanos))
+	buf = append(buf, n[:4]...)
+
+	return buf
+}
+
+// Sign sets the signature of transaction
+func Sign(key ed25519.PrivateKey, terminalId string, transaction *api.OfflineTransaction) {
+	transaction.Signature = ed25519.Sign(key, Payload(terminalId, transaction))
+}
+
+// Verify reports whether transaction is signed by the terminal with publicKey
+func Verify(publicKey []byte, terminalId string, transaction *api.OfflineTransaction) bool {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(publicKey, Payload(terminalId, transaction), transaction.Signature)
+}
+
+// LoadOrCreateKey reads the hex encoded ed25519 seed of the terminal from path,
+// if the file does not exist a new key is generated and saved to path
+func LoadOrCreateKey(path string) (ed25519.PrivateKey, error) {
+	content, err := ioutil.ReadFile(path)
+	if err == nil {
+		seed, err := hex.DecodeString(strings.TrimSpace(string(content)))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid terminal key in %s", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	err = ioutil.WriteFile(path, []byte(hex.EncodeToString(key.Seed())+"\n"), 0600)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
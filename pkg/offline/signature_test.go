@@ -0,0 +1,93 @@
+package offline
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jheimbach/nfc-cash-system/api"
+	isPkg "github.com/matryer/is"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	is := isPkg.New(t)
+
+	publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+	is.NoErr(err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	is.NoErr(err)
+
+	transaction := func() *api.OfflineTransaction {
+		tx := &api.OfflineTransaction{
+			Sequence:  7,
+			AccountId: 3,
+			Amount:    2.5,
+			Created:   &timestamp.Timestamp{Seconds: 1583000000, Nanos: 500},
+		}
+		Sign(key, "bar-1", tx)
+		return tx
+	}
+
+	tests := []struct {
+		name       string
+		publicKey  []byte
+		terminalId string
+		modify     func(*api.OfflineTransaction)
+		want       bool
+	}{
+		{name: "valid signature", publicKey: publicKey, terminalId: "bar-1", want: true},
+		{name: "key of another terminal", publicKey: otherPublicKey, terminalId: "bar-1"},
+		{name: "invalid public key", publicKey: []byte("short"), terminalId: "bar-1"},
+		{name: "other terminal id", publicKey: publicKey, terminalId: "bar-2"},
+		{name: "changed sequence", publicKey: publicKey, terminalId: "bar-1", modify: func(tx *api.OfflineTransaction) { tx.Sequence++ }},
+		{name: "changed account", publicKey: publicKey, terminalId: "bar-1", modify: func(tx *api.OfflineTransaction) { tx.AccountId = 4 }},
+		{name: "changed amount", publicKey: publicKey, terminalId: "bar-1", modify: func(tx *api.OfflineTransaction) { tx.Amount = 0.25 }},
+		{name: "changed created", publicKey: publicKey, terminalId: "bar-1", modify: func(tx *api.OfflineTransaction) { tx.Created.Nanos = 0 }},
+		{name: "missing signature", publicKey: publicKey, terminalId: "bar-1", modify: func(tx *api.OfflineTransaction) { tx.Signature = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			tx := transaction()
+			if tt.modify != nil {
+				tt.modify(tx)
+			}
+			is.Equal(Verify(tt.publicKey, tt.terminalId, tx), tt.want)
+		})
+	}
+}
+
+func TestPayload(t *testing.T) {
+	is := isPkg.New(t)
+
+	tx := &api.OfflineTransaction{Sequence: 1, AccountId: 2, Amount: 0.1 + 0.2}
+	same := &api.OfflineTransaction{Sequence: 1, AccountId: 2, Amount: 0.3}
+	is.Equal(Payload("bar-1", tx), Payload("bar-1", same)) // amounts are compared in cents
+
+	// the terminal id is terminated, so it can not be shifted into the sequence
+	is.True(string(Payload("bar-1", tx)) != string(Payload("bar-", &api.OfflineTransaction{Sequence: 1, AccountId: 2, Amount: 0.3})))
+}
+
+func TestLoadOrCreateKey(t *testing.T) {
+	is := isPkg.New(t)
+
+	dir, err := ioutil.TempDir("", "offline")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "terminal.key")
+
+	key, err := LoadOrCreateKey(path)
+	is.NoErr(err)
+
+	loaded, err := LoadOrCreateKey(path)
+	is.NoErr(err)
+	is.Equal(loaded, key) // key is not generated again
+
+	is.NoErr(ioutil.WriteFile(path, []byte("invalid"), 0600))
+	_, err = LoadOrCreateKey(path)
+	is.True(err != nil) // invalid key file
+}
@@ -11,4 +11,6 @@ var (
 	ErrCouldNotAuthorize  = status.Error(codes.Internal, "authorization failed")
 	ErrNoBasicAuth        = status.Error(codes.Unauthenticated, "basic authorization required")
 	ErrNoUserNamePassword = status.Error(codes.Unauthenticated, "authorization required username and password")
+	ErrInvalidKioskKey    = status.Error(codes.Unauthenticated, "kiosk key is not valid")
+	ErrKioskNotAllowed    = status.Error(codes.PermissionDenied, "kiosk credential is only valid for the kiosk service")
 )
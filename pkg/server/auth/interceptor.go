@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/base64"
 	"strings"
 
@@ -16,11 +17,24 @@ var bypassAuth = map[string]struct{}{
 	"/api.UserService/RefreshToken":     {},
 }
 
-func InitInterceptor(gen TokenGenerator) func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+// kioskMethods can be called with the kiosk credential, it grants access to nothing else
+var kioskMethods = map[string]struct{}{
+	"/api.KioskService/GetBalance": {},
+}
+
+// InitInterceptor authenticates the users with their access token,
+// kiosks authenticate with kioskKey ("Authorization: Kiosk <kioskKey>"), kiosks are disabled if kioskKey is empty
+func InitInterceptor(gen TokenGenerator, kioskKey string) func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		if _, ok := bypassAuth[info.FullMethod]; ok {
 			return handler(ctx, req)
 		}
+		if key, ok := kioskAuthorization(ctx); ok {
+			if err := verifyKiosk(info.FullMethod, key, kioskKey); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
 		token, err := bearerAuthorization(ctx)
 		if err != nil {
 			return nil, err
@@ -35,6 +49,35 @@ func InitInterceptor(gen TokenGenerator) func(ctx context.Context, req interface
 	}
 }
 
+func InitStreamInterceptor(gen TokenGenerator) func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := bypassAuth[info.FullMethod]; ok {
+			return handler(srv, ss)
+		}
+		token, err := bearerAuthorization(ss.Context())
+		if err != nil {
+			return err
+		}
+		user, _, err := gen.VerifyToken(token, AccessToken)
+		if err != nil {
+			return err
+		}
+		ctx := context.WithValue(ss.Context(), "user", user)
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream replaces the context of the wrapped stream with one containing the authenticated user
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
 func bearerAuthorization(ctx context.Context) (string, error) {
 	header, err := authorizationHeader(ctx)
 	if err != nil {
@@ -50,6 +93,31 @@ func bearerAuthorization(ctx context.Context) (string, error) {
 	return authorization[1], nil
 }
 
+// kioskAuthorization returns the key of the kiosk authorization, ok is false if the request does not come from a kiosk
+func kioskAuthorization(ctx context.Context) (key string, ok bool) {
+	header, err := authorizationHeader(ctx)
+	if err != nil {
+		return "", false
+	}
+
+	authorization := strings.SplitN(header, " ", 2)
+	if len(authorization) != 2 || authorization[0] != "Kiosk" {
+		return "", false
+	}
+	return authorization[1], true
+}
+
+// verifyKiosk checks that the kiosk sent the kiosk key and calls a kiosk method
+func verifyKiosk(method, key, kioskKey string) error {
+	if kioskKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(kioskKey)) != 1 {
+		return ErrInvalidKioskKey
+	}
+	if _, ok := kioskMethods[method]; !ok {
+		return ErrKioskNotAllowed
+	}
+	return nil
+}
+
 func authorizationHeader(ctx context.Context) (string, error) {
 	// load metadata
 	mb, _ := metadata.FromIncomingContext(ctx)
@@ -159,6 +159,8 @@ func TestBasicAuthorization(t *testing.T) {
 	})
 }
 
+const testKioskKey = "kiosk-key"
+
 func TestUnaryInterceptor(t *testing.T) {
 	mUser := &api.User{
 		Id:      1,
@@ -227,6 +229,26 @@ func TestUnaryInterceptor(t *testing.T) {
 			wantErr: ErrNoAuthHeader,
 			handler: mockHandler,
 		},
+		{
+			name:    "kiosk calls kiosk method",
+			info:    &grpc.UnaryServerInfo{FullMethod: "/api.KioskService/GetBalance"},
+			header:  map[string]string{"authorization": "Kiosk " + testKioskKey},
+			handler: mockHandler,
+		},
+		{
+			name:    "kiosk calls other method",
+			info:    &grpc.UnaryServerInfo{FullMethod: "/api.AccountService/GetAccount"},
+			header:  map[string]string{"authorization": "Kiosk " + testKioskKey},
+			wantErr: ErrKioskNotAllowed,
+			handler: mockHandler,
+		},
+		{
+			name:    "kiosk with wrong key",
+			info:    &grpc.UnaryServerInfo{FullMethod: "/api.KioskService/GetBalance"},
+			header:  map[string]string{"authorization": "Kiosk guessed"},
+			wantErr: ErrInvalidKioskKey,
+			handler: mockHandler,
+		},
 		{
 			name:   "is AuthenticateUser route",
 			info:   &grpc.UnaryServerInfo{FullMethod: "/api.UserService/AuthenticateUser"},
@@ -255,7 +277,7 @@ func TestUnaryInterceptor(t *testing.T) {
 			ctx := context.Background()
 			ctx = metadata.NewIncomingContext(ctx, metadata.New(tt.header))
 
-			_, err := InitInterceptor(gen)(ctx, new(interface{}), tt.info, mockHandler)
+			_, err := InitInterceptor(gen, testKioskKey)(ctx, new(interface{}), tt.info, mockHandler)
 
 			if tt.wantErr != nil {
 				if err != tt.wantErr {
@@ -291,3 +313,83 @@ func compareValidationError(got, want error) (error, bool) {
 	}
 	return nil, false
 }
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	mUser := &api.User{
+		Id:      1,
+		Name:    "testuser1",
+		Email:   "test@example.com",
+		Created: mockTimeStamp(),
+	}
+	gen := JWTAuthenticator{keyStorage: mockKeyStorage}
+	token, err := gen.CreateToken(mUser, time.Now().Add(5*time.Minute), AccessToken)
+	if err != nil {
+		t.Fatalf("could not generate token: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		info     *grpc.StreamServerInfo
+		header   map[string]string
+		wantErr  error
+		wantUser *api.User
+	}{
+		{
+			name:     "valid token",
+			info:     &grpc.StreamServerInfo{FullMethod: "test/url"},
+			header:   map[string]string{"authorization": fmt.Sprintf("Bearer %s", token)},
+			wantUser: mUser,
+		},
+		{
+			name:    "no token send",
+			info:    &grpc.StreamServerInfo{FullMethod: "test/url"},
+			header:  map[string]string{"authorization": ""},
+			wantErr: ErrNoBearerAuth,
+		},
+		{
+			name:    "no auth header send",
+			info:    &grpc.StreamServerInfo{FullMethod: "test/url"},
+			header:  map[string]string{},
+			wantErr: ErrNoAuthHeader,
+		},
+		{
+			name:   "is bypassed route",
+			info:   &grpc.StreamServerInfo{FullMethod: "/api.HealthService/Health"},
+			header: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(tt.header))
+			stream := &mockServerStream{ctx: ctx}
+
+			handlerCalled := false
+			handler := func(srv interface{}, ss grpc.ServerStream) error {
+				handlerCalled = true
+				user, _ := ss.Context().Value("user").(*api.User)
+				if !reflect.DeepEqual(user, tt.wantUser) {
+					t.Errorf("got user %v from stream context, expected %v", user, tt.wantUser)
+				}
+				return nil
+			}
+
+			err := InitStreamInterceptor(gen)(nil, stream, tt.info, handler)
+
+			if err != tt.wantErr {
+				t.Errorf("got err %v, expected %v", err, tt.wantErr)
+			}
+			if handlerCalled != (tt.wantErr == nil) {
+				t.Errorf("handler called: %v, expected %v", handlerCalled, tt.wantErr == nil)
+			}
+		})
+	}
+}
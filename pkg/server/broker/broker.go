@@ -0,0 +1,90 @@
+// Package broker distributes new transactions in process to everyone watching the transaction feed
+package broker
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+// Filter selects the transactions a subscriber receives, zero values match every transaction
+type Filter struct {
+	AccountId  int32
+	GroupId    int32
+	TerminalId string
+}
+
+func (f Filter) matches(t *api.Transaction) bool {
+	if f.TerminalId != "" && f.TerminalId != t.TerminalId {
+		return false
+	}
+	if f.AccountId > 0 && (t.Account == nil || f.AccountId != t.Account.Id) {
+		return false
+	}
+	if f.GroupId > 0 && (t.Account == nil || t.Account.Group == nil || f.GroupId != t.Account.Group.Id) {
+		return false
+	}
+	return true
+}
+
+// TransactionBroker is a publish/subscribe hub for new transactions.
+// Publish never blocks, a subscriber that does not keep up with its buffer is dropped and its channel is closed.
+type TransactionBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan *api.Transaction]Filter
+	bufferSize  int
+}
+
+// NewTransactionBroker returns a broker that buffers up to bufferSize transactions per subscriber
+func NewTransactionBroker(bufferSize int) *TransactionBroker {
+	return &TransactionBroker{
+		subscribers: make(map[chan *api.Transaction]Filter),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Publish sends a copy of transaction to all subscribers with a matching filter, the caller may change transaction
+// after Publish returns. The chip balance is for the terminal that made the transaction only, it is not published
+func (b *TransactionBroker) Publish(transaction *api.Transaction) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var published *api.Transaction
+	for ch, filter := range b.subscribers {
+		if !filter.matches(transaction) {
+			continue
+		}
+		if published == nil {
+			published = proto.Clone(transaction).(*api.Transaction)
+			published.ChipBalance = nil
+		}
+		select {
+		case ch <- published:
+		default:
+			// subscriber is too slow, drop it instead of blocking everyone else
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns a channel that receives all published transactions matching filter.
+// The channel is closed when the returned cancel func is called or the subscriber falls behind
+func (b *TransactionBroker) Subscribe(filter Filter) (<-chan *api.Transaction, func()) {
+	ch := make(chan *api.Transaction, b.bufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = filter
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	isPkg "github.com/matryer/is"
+)
+
+func TestFilter_matches(t *testing.T) {
+	transaction := &api.Transaction{
+		Id:         1,
+		Account:    &api.Account{Id: 2, Group: &api.Group{Id: 3}},
+		TerminalId: "kiosk-1",
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{name: "empty filter", filter: Filter{}, want: true},
+		{name: "matching account", filter: Filter{AccountId: 2}, want: true},
+		{name: "other account", filter: Filter{AccountId: 1}, want: false},
+		{name: "matching group", filter: Filter{GroupId: 3}, want: true},
+		{name: "other group", filter: Filter{GroupId: 1}, want: false},
+		{name: "matching terminal", filter: Filter{TerminalId: "kiosk-1"}, want: true},
+		{name: "other terminal", filter: Filter{TerminalId: "kiosk-2"}, want: false},
+		{name: "all fields match", filter: Filter{AccountId: 2, GroupId: 3, TerminalId: "kiosk-1"}, want: true},
+		{name: "one field does not match", filter: Filter{AccountId: 2, GroupId: 3, TerminalId: "kiosk-2"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			is.Equal(tt.filter.matches(transaction), tt.want)
+		})
+	}
+}
+
+func TestTransactionBroker(t *testing.T) {
+	t.Run("subscribers receive matching transactions", func(t *testing.T) {
+		is := isPkg.New(t)
+		b := NewTransactionBroker(5)
+
+		all, cancelAll := b.Subscribe(Filter{})
+		defer cancelAll()
+		accountOne, cancelOne := b.Subscribe(Filter{AccountId: 1})
+		defer cancelOne()
+
+		first := &api.Transaction{Id: 1, Account: &api.Account{Id: 1}}
+		second := &api.Transaction{Id: 2, Account: &api.Account{Id: 2}}
+		b.Publish(first)
+		b.Publish(second)
+
+		is.Equal(<-all, first)
+		is.Equal(<-all, second)
+		is.Equal(<-accountOne, first)
+		is.Equal(len(accountOne), 0) // account 1 subscriber should not receive other transactions
+	})
+
+	t.Run("cancel closes channel", func(t *testing.T) {
+		is := isPkg.New(t)
+		b := NewTransactionBroker(5)
+
+		ch, cancel := b.Subscribe(Filter{})
+		cancel()
+		cancel() // calling cancel twice must not panic
+
+		_, ok := <-ch
+		is.True(!ok) // channel should be closed
+		b.Publish(&api.Transaction{Id: 1})
+	})
+
+	t.Run("slow subscriber is dropped", func(t *testing.T) {
+		is := isPkg.New(t)
+		b := NewTransactionBroker(1)
+
+		ch, cancel := b.Subscribe(Filter{})
+		defer cancel()
+
+		b.Publish(&api.Transaction{Id: 1})
+		b.Publish(&api.Transaction{Id: 2})
+
+		is.Equal((<-ch).Id, int32(1))
+		_, ok := <-ch
+		is.True(!ok) // channel of slow subscriber should be closed
+	})
+}
@@ -1,22 +1,32 @@
 package server
 
 import (
-	"database/sql"
 	"fmt"
 	"net"
 
 	"github.com/jheimbach/nfc-cash-system/pkg/server/auth"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/broker"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/handlers"
-	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/mysql"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
+// transactionFeedBuffer is the number of transactions a WatchTransactions stream can fall behind before it is dropped
+const transactionFeedBuffer = 64
+
 type Grpc struct {
 	*grpc.Server
 }
 
-func NewGrpcServer(database *sql.DB, cert, certKey string, accessTknKey, refreshTknKey string) (*Grpc, error) {
+// NewTransactionFeed returns the broker for WatchTransactions, it has to be the publisher of the transaction storage
+func NewTransactionFeed() *broker.TransactionBroker {
+	return broker.NewTransactionBroker(transactionFeedBuffer)
+}
+
+// NewGrpcServer creates the grpc server with all services on storage, feed publishes the transactions created by storage.
+// chipBalanceKey is the hmac key of the balances stored on the chips, chip balances are disabled if it is empty.
+// kioskKey is the credential of the balance kiosks, kiosks are disabled if it is empty
+func NewGrpcServer(storage *Storage, feed *broker.TransactionBroker, cert, certKey string, accessTknKey, refreshTknKey, chipBalanceKey, kioskKey string) (*Grpc, error) {
 	creds, err := credentials.NewServerTLSFromFile(cert, certKey)
 	if err != nil {
 		return nil, err
@@ -26,20 +36,21 @@ func NewGrpcServer(database *sql.DB, cert, certKey string, accessTknKey, refresh
 	if err != nil {
 		return nil, err
 	}
-	s := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(auth.InitInterceptor(tokenGen)))
+	s := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.UnaryInterceptor(auth.InitInterceptor(tokenGen, kioskKey)),
+		grpc.StreamInterceptor(auth.InitStreamInterceptor(tokenGen)),
+	)
 
 	handlers.RegisterHealthServer(s)
 
-	userModel := mysql.NewUserModel(database)
-	handlers.RegisterUserServer(s, userModel, tokenGen)
-
-	groupRepository := mysql.NewGroupRepository(database)
-	handlers.RegisterGroupServer(s, groupRepository)
-
-	accountRepository := mysql.NewAccountRepository(database, groupRepository)
-	transactionRepository := mysql.NewTransactionRepository(database, accountRepository)
-	handlers.RegisterAccountServer(s, accountRepository, transactionRepository)
-	handlers.RegisterTransactionServer(s, transactionRepository)
+	handlers.RegisterUserServer(s, storage.Users, tokenGen)
+	handlers.RegisterGroupServer(s, storage.Groups)
+	handlers.RegisterAccountServer(s, storage.Accounts, storage.Transactions)
+	handlers.RegisterTransactionServer(s, storage.Transactions, storage.Accounts, storage.Terminals, feed, []byte(chipBalanceKey))
+	handlers.RegisterKioskServer(s, storage.Accounts, storage.Transactions)
+	handlers.RegisterReportServer(s, storage.Reports)
+	handlers.RegisterAdminServer(s, storage.Transactions, storage.Terminals)
 
 	return &Grpc{Server: s}, nil
 }
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/jheimbach/nfc-cash-system/api"
@@ -21,23 +23,78 @@ func RegisterAccountServer(s *grpc.Server, storage repositories.AccountStorager,
 }
 
 func (a *accountserver) ListAccounts(ctx context.Context, req *api.ListAccountsRequest) (*api.ListAccountsResponse, error) {
-	var limit int32 = 0
-	var offset int32 = 0
+	filter, err := accountFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := pagingOptions(req.Paging, accountScope(filter))
+	if err != nil {
+		return nil, err
+	}
 
-	if req.Paging != nil {
-		limit = req.Paging.Limit
-		offset = req.Paging.Offset
+	res := &api.ListAccountsResponse{}
+	more := false
+	if page.useOffset() {
+		accounts, totalCount, err := a.storage.GetAll(ctx, filter, page.size, page.offset)
+		if err != nil {
+			return nil, ErrGetAll
+		}
+		res.Accounts = accounts
+		res.TotalCount = int32(totalCount)
+		more = page.hasMore(len(accounts), totalCount)
+	} else {
+		accounts, err := a.storage.GetPage(ctx, filter, page.after, page.size+1)
+		if err != nil {
+			return nil, ErrGetAll
+		}
+		if more = int32(len(accounts)) > page.size; more {
+			accounts = accounts[:page.size]
+		}
+		res.Accounts = accounts
+
+		if page.countTotal {
+			totalCount, err := a.storage.Count(ctx, filter)
+			if err != nil {
+				return nil, ErrGetAll
+			}
+			res.TotalCount = int32(totalCount)
+		}
 	}
-	accounts, totalCount, err := a.storage.GetAll(ctx, req.GroupId, limit, offset)
 
-	if err != nil {
-		return nil, ErrGetAll
+	if more && len(res.Accounts) > 0 {
+		last := res.Accounts[len(res.Accounts)-1]
+		res.NextPageToken = page.nextPageToken(repositories.Cursor{Name: last.Name, Saldo: last.Saldo, Id: last.Id})
 	}
 
-	return &api.ListAccountsResponse{
-		Accounts:   accounts,
-		TotalCount: int32(totalCount),
-	}, nil
+	return res, nil
+}
+
+// accountFilter returns the repositories.AccountFilter of req, it returns ErrInvalidSaldoRange if min_saldo is greater than max_saldo
+func accountFilter(req *api.ListAccountsRequest) (repositories.AccountFilter, error) {
+	filter := repositories.AccountFilter{
+		GroupId:         req.GroupId,
+		Search:          req.Search,
+		NfcChipIdPrefix: req.NfcChipIdPrefix,
+		Status:          req.Status,
+		SortBy:          req.SortBy,
+		Descending:      strings.ToLower(req.Order) == "desc",
+	}
+	if req.MinSaldo != nil {
+		filter.MinSaldo = &req.MinSaldo.Value
+	}
+	if req.MaxSaldo != nil {
+		filter.MaxSaldo = &req.MaxSaldo.Value
+	}
+	if filter.MinSaldo != nil && filter.MaxSaldo != nil && *filter.MinSaldo > *filter.MaxSaldo {
+		return filter, ErrInvalidSaldoRange
+	}
+
+	return filter, nil
+}
+
+// accountScope returns the page scope of the accounts matching filter in the order of filter
+func accountScope(filter repositories.AccountFilter) pageScope {
+	return newPageScope(filter.SortBy.String(), filter.Descending, filter)
 }
 
 func (a *accountserver) CreateAccount(ctx context.Context, req *api.CreateAccountRequest) (*api.Account, error) {
@@ -75,12 +132,58 @@ func (a *accountserver) UpdateAccount(ctx context.Context, req *api.Account) (*a
 		if err == repositories.ErrGroupNotFound {
 			return nil, ErrGroupNotFound
 		}
+		if err == repositories.ErrDuplicateNfcChipId {
+			return nil, status.Error(codes.AlreadyExists, "nfc chip is already in use")
+		}
 		return nil, ErrSomethingWentWrong
 	}
 
 	return acc, nil
 }
 
+func (a *accountserver) ImportAccounts(ctx context.Context, req *api.ImportAccountsRequest) (*api.ImportAccountsResponse, error) {
+	accounts, err := a.storage.Import(ctx, req.Accounts, req.DryRun)
+	if err != nil {
+		var importErrs repositories.ImportErrors
+		if errors.As(err, &importErrs) {
+			return &api.ImportAccountsResponse{
+				Errors: importErrorsToProto(importErrs),
+				DryRun: req.DryRun,
+			}, nil
+		}
+		return nil, ErrCouldNotImport
+	}
+
+	return &api.ImportAccountsResponse{
+		Accounts: accounts,
+		DryRun:   req.DryRun,
+	}, nil
+}
+
+// importErrorsToProto converts the rejected rows of an import to the api representation
+func importErrorsToProto(importErrs repositories.ImportErrors) []*api.ImportAccountError {
+	errs := make([]*api.ImportAccountError, len(importErrs))
+	for i, importErr := range importErrs {
+		reason := api.ImportAccountError_UNKNOWN
+		switch {
+		case errors.Is(importErr, repositories.ErrDuplicateNfcChipId):
+			reason = api.ImportAccountError_DUPLICATE_NFC_CHIP_ID
+		case errors.Is(importErr, repositories.ErrGroupNotFound):
+			reason = api.ImportAccountError_GROUP_NOT_FOUND
+		case errors.Is(importErr, repositories.ErrMissingField):
+			reason = api.ImportAccountError_INVALID_ROW
+		}
+
+		errs[i] = &api.ImportAccountError{
+			Row:       int32(importErr.Row),
+			NfcChipId: importErr.NfcChipId,
+			Reason:    reason,
+			Message:   importErr.Err.Error(),
+		}
+	}
+	return errs
+}
+
 func (a *accountserver) DeleteAccount(ctx context.Context, req *api.DeleteAccountRequest) (*empty.Empty, error) {
 	err := a.tStorage.DeleteAllByAccount(ctx, req.Id)
 	if err != nil {
@@ -5,137 +5,210 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"reflect"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jheimbach/nfc-cash-system/api"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test/mock"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/memory"
 	isPkg "github.com/matryer/is"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 func TestAccountserver_ListAccounts(t *testing.T) {
+	accounts := append(getAccountModels(2, 1), getAccountModels(2, 2)...)
+	for i, account := range accounts {
+		account.Id = int32(i + 1)
+	}
+	token := func(id int32, filter repositories.AccountFilter) string {
+		return encodePageToken(repositories.Cursor{Name: "test", Id: id}, accountScope(filter))
+	}
+	sortedByName := repositories.AccountFilter{
+		GroupId:         2,
+		Search:          "Test",
+		NfcChipIdPrefix: "ncf",
+		MinSaldo:        func() *float64 { v := -10.0; return &v }(),
+		MaxSaldo:        func() *float64 { v := 0.0; return &v }(),
+		Status:          []api.Account_Status{api.Account_ACTIVE},
+		SortBy:          api.ListAccountsRequest_NAME,
+		Descending:      true,
+	}
+
 	tests := []struct {
-		name    string
-		input   *api.ListAccountsRequest
-		want    *api.ListAccountsResponse
-		wantErr error
+		name       string
+		input      *api.ListAccountsRequest
+		want       *api.ListAccountsResponse
+		wantSize   int32
+		wantFilter repositories.AccountFilter
+		wantErr    error
 	}{
 		{
-			name: "get simple list of accounts",
-			input: &api.ListAccountsRequest{
-				GroupId: 0,
-				Paging: &api.Paging{
-					Limit:  0,
-					Offset: 0,
-				},
-			},
-			want: &api.ListAccountsResponse{
-				Accounts: func() []*api.Account {
-					return append(getAccountModels(2, 1), getAccountModels(2, 2)...)
-				}(),
-				TotalCount: 4,
-			},
+			name:     "get simple list of accounts",
+			input:    &api.ListAccountsRequest{Paging: &api.Paging{}},
+			want:     &api.ListAccountsResponse{Accounts: accounts, TotalCount: 4},
+			wantSize: maxPageSize + 1,
 		},
 		{
-			name: "has error",
-			input: &api.ListAccountsRequest{
-				GroupId: 0,
-				Paging: &api.Paging{
-					Limit:  0,
-					Offset: 0,
-				},
-			},
-			want:    nil,
+			name:     "without paging",
+			input:    &api.ListAccountsRequest{},
+			want:     &api.ListAccountsResponse{Accounts: accounts, TotalCount: 4},
+			wantSize: maxPageSize + 1,
+		},
+		{
+			name:    "has error",
+			input:   &api.ListAccountsRequest{Paging: &api.Paging{}},
 			wantErr: ErrGetAll,
 		},
 		{
-			name: "with group",
-			input: &api.ListAccountsRequest{
-				GroupId: 1,
-				Paging: &api.Paging{
-					Limit:  0,
-					Offset: 0,
-				},
-			},
-			want: &api.ListAccountsResponse{
-				Accounts:   getAccountModels(2, 1),
-				TotalCount: 2,
-			},
+			name:       "with group",
+			input:      &api.ListAccountsRequest{GroupId: 1, Paging: &api.Paging{}},
+			want:       &api.ListAccountsResponse{Accounts: accounts[:2], TotalCount: 2},
+			wantSize:   maxPageSize + 1,
+			wantFilter: repositories.AccountFilter{GroupId: 1},
 		},
 		{
-			name: "with limit",
+			name: "with filters and sorting",
 			input: &api.ListAccountsRequest{
-				GroupId: 0,
-				Paging: &api.Paging{
-					Limit:  2,
-					Offset: 0,
-				},
-			},
-			want: &api.ListAccountsResponse{
-				Accounts:   getAccountModels(2, 1),
-				TotalCount: 2,
+				GroupId:         2,
+				Search:          "Test",
+				NfcChipIdPrefix: "ncf",
+				MinSaldo:        &wrappers.DoubleValue{Value: -10},
+				MaxSaldo:        &wrappers.DoubleValue{Value: 0},
+				Status:          []api.Account_Status{api.Account_ACTIVE},
+				SortBy:          api.ListAccountsRequest_NAME,
+				Order:           "DESC",
+				Paging:          &api.Paging{Limit: 1},
 			},
+			want:       &api.ListAccountsResponse{Accounts: accounts[2:3], TotalCount: 2, NextPageToken: token(3, sortedByName)},
+			wantSize:   2,
+			wantFilter: sortedByName,
 		},
 		{
-			name: "with limit and offset",
+			name: "with min saldo above max saldo",
 			input: &api.ListAccountsRequest{
-				GroupId: 0,
-				Paging: &api.Paging{
-					Limit:  1,
-					Offset: 1,
-				},
-			},
-			want: &api.ListAccountsResponse{
-				Accounts:   getAccountModels(2, 1)[1:],
-				TotalCount: 1,
+				MinSaldo: &wrappers.DoubleValue{Value: 10},
+				MaxSaldo: &wrappers.DoubleValue{Value: 5},
 			},
+			wantErr: ErrInvalidSaldoRange,
+		},
+		{
+			name:     "with limit",
+			input:    &api.ListAccountsRequest{Paging: &api.Paging{Limit: 2}},
+			want:     &api.ListAccountsResponse{Accounts: accounts[:2], TotalCount: 4, NextPageToken: token(2, repositories.AccountFilter{})},
+			wantSize: 3,
+		},
+		{
+			name:     "limit above maximum page size",
+			input:    &api.ListAccountsRequest{Paging: &api.Paging{Limit: maxPageSize + 100}},
+			want:     &api.ListAccountsResponse{Accounts: accounts, TotalCount: 4},
+			wantSize: maxPageSize + 1,
+		},
+		{
+			name:     "with page token",
+			input:    &api.ListAccountsRequest{Paging: &api.Paging{Limit: 2, PageToken: token(2, repositories.AccountFilter{})}},
+			want:     &api.ListAccountsResponse{Accounts: accounts[2:], TotalCount: 4},
+			wantSize: 3,
+		},
+		{
+			name:     "page token ignores offset",
+			input:    &api.ListAccountsRequest{Paging: &api.Paging{Limit: 1, Offset: 3, PageToken: token(1, repositories.AccountFilter{})}},
+			want:     &api.ListAccountsResponse{Accounts: accounts[1:2], TotalCount: 4, NextPageToken: token(2, repositories.AccountFilter{})},
+			wantSize: 2,
+		},
+		{
+			name:     "skip total count",
+			input:    &api.ListAccountsRequest{Paging: &api.Paging{Limit: 2, SkipTotalCount: true}},
+			want:     &api.ListAccountsResponse{Accounts: accounts[:2], NextPageToken: token(2, repositories.AccountFilter{})},
+			wantSize: 3,
+		},
+		{
+			name:    "invalid page token",
+			input:   &api.ListAccountsRequest{Paging: &api.Paging{PageToken: "not a token"}},
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "page token of another sort order",
+			input:   &api.ListAccountsRequest{SortBy: api.ListAccountsRequest_SALDO, Paging: &api.Paging{PageToken: token(2, repositories.AccountFilter{})}},
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "page token of another direction",
+			input:   &api.ListAccountsRequest{Order: "desc", Paging: &api.Paging{PageToken: token(2, repositories.AccountFilter{})}},
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:    "page token of another filter",
+			input:   &api.ListAccountsRequest{GroupId: 1, Paging: &api.Paging{PageToken: token(2, repositories.AccountFilter{})}},
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name:  "with limit and offset",
+			input: &api.ListAccountsRequest{Paging: &api.Paging{Limit: 1, Offset: 1}},
+			want:  &api.ListAccountsResponse{Accounts: accounts[1:2], TotalCount: 4, NextPageToken: token(2, repositories.AccountFilter{})},
+		},
+		{
+			name:  "with offset on the last page",
+			input: &api.ListAccountsRequest{Paging: &api.Paging{Limit: 2, Offset: 2}},
+			want:  &api.ListAccountsResponse{Accounts: accounts[2:], TotalCount: 4},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			a := &accountserver{
-				storage: &mock.AccountRepository{GetAllFunc: func(groupId, limit, offset int32) ([]*api.Account, int, error) {
-					if tt.wantErr != nil {
-						return nil, 0, sql.ErrNoRows
-					}
-
-					groupOne := getAccountModels(2, 1)
-					groupTwo := getAccountModels(2, 2)
-
-					if groupId == 1 {
-						return groupOne, len(groupOne), nil
-					}
+			is := isPkg.New(t)
 
-					if groupId == 2 {
-						return groupTwo, len(groupTwo), nil
+			inGroup := func(groupId int32) []*api.Account {
+				var filtered []*api.Account
+				for _, account := range accounts {
+					if groupId == 0 || account.Group.Id == groupId {
+						filtered = append(filtered, account)
 					}
+				}
+				return filtered
+			}
 
-					groups := append(groupOne, groupTwo...)
-					if limit > 0 {
-						off := int32(0)
-						if offset > 0 {
-							off = offset
+			a := &accountserver{
+				storage: &mock.AccountRepository{
+					GetAllFunc: func(filter repositories.AccountFilter, limit, offset int32) ([]*api.Account, int, error) {
+						is.Equal(filter, tt.wantFilter) // unexpected filter
+						filtered := inGroup(filter.GroupId)
+						end := offset + limit
+						if end > int32(len(filtered)) {
+							end = int32(len(filtered))
 						}
-						return groups[off : off+limit], int(limit), nil
-					}
-					return groups, len(groups), nil
-				},
+						return filtered[offset:end], len(filtered), nil
+					},
+					GetPageFunc: func(filter repositories.AccountFilter, after *repositories.Cursor, size int32) ([]*api.Account, error) {
+						if tt.wantErr != nil {
+							return nil, sql.ErrNoRows
+						}
+						is.Equal(size, tt.wantSize)     // unexpected page size
+						is.Equal(filter, tt.wantFilter) // unexpected filter
+
+						var page []*api.Account
+						for _, account := range inGroup(filter.GroupId) {
+							if (after == nil || account.Id > after.Id) && int32(len(page)) < size {
+								page = append(page, account)
+							}
+						}
+						return page, nil
+					},
+					CountFunc: func(filter repositories.AccountFilter) (int, error) {
+						return len(inGroup(filter.GroupId)), nil
+					},
 				},
 			}
 			got, err := a.ListAccounts(context.Background(), tt.input)
 
-			if err != tt.wantErr {
-				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr != nil {
+				is.Equal(err, tt.wantErr)
 				return
 			}
 
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("List() got = %v, want %v", got, tt.want)
-			}
+			is.NoErr(err)
+			is.Equal(got, tt.want)
 		})
 	}
 }
@@ -340,6 +413,19 @@ func TestAccountserver_UpdateAccount(t *testing.T) {
 			returnErr: errors.New("test error"),
 			wantErr:   ErrSomethingWentWrong,
 		},
+		{
+			name: "update uses nfc chip of other account",
+			input: &api.Account{
+				Id:        1,
+				Name:      "test",
+				NfcChipId: "nfc_chip_2",
+				Group: &api.Group{
+					Id: 1,
+				},
+			},
+			returnErr: repositories.ErrDuplicateNfcChipId,
+			wantErr:   status.Error(codes.AlreadyExists, "nfc chip is already in use"),
+		},
 		{
 			name: "update tries to update saldo",
 			input: &api.Account{
@@ -455,6 +541,136 @@ func TestAccountserver_DeleteAccount(t *testing.T) {
 
 }
 
+func TestAccountserver_MemoryStorage(t *testing.T) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	db := memory.NewDatabase()
+	transactions := memory.NewTransactionRepository(db, nil)
+	server := &accountserver{
+		storage:  memory.NewAccountRepository(db),
+		tStorage: transactions,
+	}
+	group, err := memory.NewGroupRepository(db).Create(ctx, "group", "", false)
+	is.NoErr(err) // could not create group
+
+	account, err := server.CreateAccount(ctx, &api.CreateAccountRequest{
+		Name: "account", Saldo: 10, GroupId: group.Id, NfcChipId: "chip",
+	})
+	is.NoErr(err)                  // could not create account
+	is.Equal(account.Group, group) // account has its group
+	is.Equal(account.NfcChipId, "chip")
+
+	_, err = server.CreateAccount(ctx, &api.CreateAccountRequest{Name: "duplicate", GroupId: group.Id, NfcChipId: "CHIP"})
+	is.Equal(status.Code(err), codes.AlreadyExists) // chip ids are case insensitive
+
+	_, err = server.CreateAccount(ctx, &api.CreateAccountRequest{Name: "no group", GroupId: group.Id + 1, NfcChipId: "other"})
+	is.Equal(err, ErrGroupNotFound)
+
+	_, err = transactions.Create(ctx, 2.5, account.Id, "")
+	is.NoErr(err) // could not create transaction
+
+	list, err := server.ListAccounts(ctx, &api.ListAccountsRequest{GroupId: group.Id})
+	is.NoErr(err)
+	is.Equal(list.TotalCount, int32(1))
+	is.Equal(list.Accounts[0].Saldo, 7.5) // saldo is updated by the transaction
+
+	_, err = server.DeleteAccount(ctx, &api.DeleteAccountRequest{Id: account.Id})
+	is.NoErr(err) // account with transactions is deleted
+
+	_, err = server.GetAccount(ctx, &api.GetAccountRequest{Id: account.Id})
+	is.Equal(err, ErrAccountNotFound)
+}
+
+func TestAccountserver_ImportAccounts(t *testing.T) {
+	is := isPkg.New(t)
+
+	importRows := []*api.CreateAccountRequest{
+		{Name: "test", Saldo: 12, NfcChipId: "nfc_chip_1", GroupId: 1},
+		{Name: "test2", Saldo: 5, NfcChipId: "nfc_chip_2", GroupId: 2},
+	}
+
+	tests := []struct {
+		name      string
+		input     *api.ImportAccountsRequest
+		returnAcc []*api.Account
+		returnErr error
+		want      *api.ImportAccountsResponse
+		wantErr   error
+	}{
+		{
+			name:      "import accounts",
+			input:     &api.ImportAccountsRequest{Accounts: importRows},
+			returnAcc: getAccountModels(2, 1),
+			want: &api.ImportAccountsResponse{
+				Accounts: getAccountModels(2, 1),
+			},
+		},
+		{
+			name:      "import accounts with dry run",
+			input:     &api.ImportAccountsRequest{Accounts: importRows, DryRun: true},
+			returnAcc: getAccountModels(2, 1),
+			want: &api.ImportAccountsResponse{
+				Accounts: getAccountModels(2, 1),
+				DryRun:   true,
+			},
+		},
+		{
+			name:  "import with rejected rows",
+			input: &api.ImportAccountsRequest{Accounts: importRows},
+			returnErr: repositories.ImportErrors{
+				{Row: 1, NfcChipId: "nfc_chip_1", Err: repositories.ErrDuplicateNfcChipId},
+				{Row: 2, NfcChipId: "nfc_chip_2", Err: repositories.ErrGroupNotFound},
+				{Row: 3, Err: repositories.ErrMissingField},
+				{Row: 4, Err: errors.New("test error")},
+			},
+			want: &api.ImportAccountsResponse{
+				Errors: []*api.ImportAccountError{
+					{Row: 1, NfcChipId: "nfc_chip_1", Reason: api.ImportAccountError_DUPLICATE_NFC_CHIP_ID, Message: repositories.ErrDuplicateNfcChipId.Error()},
+					{Row: 2, NfcChipId: "nfc_chip_2", Reason: api.ImportAccountError_GROUP_NOT_FOUND, Message: repositories.ErrGroupNotFound.Error()},
+					{Row: 3, Reason: api.ImportAccountError_INVALID_ROW, Message: repositories.ErrMissingField.Error()},
+					{Row: 4, Reason: api.ImportAccountError_UNKNOWN, Message: "test error"},
+				},
+			},
+		},
+		{
+			name:      "import returns unknown error",
+			input:     &api.ImportAccountsRequest{Accounts: importRows},
+			returnErr: errors.New("test error"),
+			wantErr:   ErrCouldNotImport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			server := &accountserver{
+				storage: &mock.AccountRepository{
+					ImportFunc: func(accounts []*api.CreateAccountRequest, dryRun bool) ([]*api.Account, error) {
+						is.Equal(accounts, tt.input.Accounts) // accounts are not passed to storage
+						is.Equal(dryRun, tt.input.DryRun)     // dry run is not passed to storage
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						return tt.returnAcc, nil
+					},
+				},
+			}
+
+			got, err := server.ImportAccounts(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				is.Equal(err, tt.wantErr)
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
 func getAccountModels(num int, groupId int32) []*api.Account {
 	accounts := make([]*api.Account, 0, num)
 
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ed25519"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type adminServer struct {
+	ledger    repositories.LedgerVerifier
+	terminals repositories.TerminalStorager
+}
+
+func RegisterAdminServer(server *grpc.Server, ledger repositories.LedgerVerifier, terminals repositories.TerminalStorager) {
+	api.RegisterAdminServiceServer(server, &adminServer{ledger: ledger, terminals: terminals})
+}
+
+func (a *adminServer) VerifyLedger(ctx context.Context, req *api.VerifyLedgerRequest) (*api.LedgerReport, error) {
+	report, err := a.ledger.VerifyLedger(ctx, req.AccountId, req.Fix)
+	if err != nil {
+		return nil, ErrCouldNotVerifyLedger
+	}
+
+	return report, nil
+}
+
+func (a *adminServer) RegisterTerminal(ctx context.Context, req *api.RegisterTerminalRequest) (*api.Terminal, error) {
+	if req.Id == "" || req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "terminal id and name are required")
+	}
+	if len(req.PublicKey) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+
+	terminal, err := a.terminals.Register(ctx, req.Id, req.Name, req.PublicKey)
+	if err != nil {
+		if err == repositories.ErrDuplicateTerminal {
+			return nil, ErrTerminalExists
+		}
+		return nil, ErrCouldNotRegisterTerminal
+	}
+
+	return terminal, nil
+}
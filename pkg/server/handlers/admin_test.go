@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test/mock"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminServer_VerifyLedger(t *testing.T) {
+	report := &api.LedgerReport{
+		CheckedAccounts:     2,
+		CheckedTransactions: 10,
+		Discrepancies: []*api.LedgerDiscrepancy{
+			{Kind: api.LedgerDiscrepancy_SALDO_MISMATCH, AccountId: 1, TransactionId: 4, Expected: 50, Actual: 80, AdjustmentId: 11},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		input     *api.VerifyLedgerRequest
+		want      *api.LedgerReport
+		wantErr   error
+		returnErr error
+	}{
+		{
+			name:  "verify all accounts",
+			input: &api.VerifyLedgerRequest{},
+			want:  report,
+		},
+		{
+			name:  "verify and fix account",
+			input: &api.VerifyLedgerRequest{AccountId: 1, Fix: true},
+			want:  report,
+		},
+		{
+			name:      "storage returns error",
+			input:     &api.VerifyLedgerRequest{},
+			wantErr:   ErrCouldNotVerifyLedger,
+			returnErr: errors.New("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := adminServer{
+				ledger: &mock.TransactionRepository{
+					VerifyLedgerFunc: func(accountId int32, fix bool) (*api.LedgerReport, error) {
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						if accountId != tt.input.AccountId || fix != tt.input.Fix {
+							t.Errorf("got account %d and fix %v, expected %d and %v", accountId, fix, tt.input.AccountId, tt.input.Fix)
+						}
+						return report, nil
+					},
+				},
+			}
+
+			got, err := server.VerifyLedger(context.Background(), tt.input)
+			if err != tt.wantErr {
+				t.Fatalf("got err %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminServer_RegisterTerminal(t *testing.T) {
+	publicKey := make([]byte, ed25519.PublicKeySize)
+
+	tests := []struct {
+		name      string
+		input     *api.RegisterTerminalRequest
+		wantErr   error
+		returnErr error
+	}{
+		{
+			name:  "register terminal",
+			input: &api.RegisterTerminalRequest{Id: "bar-1", Name: "Bar 1", PublicKey: publicKey},
+		},
+		{
+			name:    "missing id",
+			input:   &api.RegisterTerminalRequest{Name: "Bar 1", PublicKey: publicKey},
+			wantErr: status.Error(codes.InvalidArgument, "terminal id and name are required"),
+		},
+		{
+			name:    "invalid public key",
+			input:   &api.RegisterTerminalRequest{Id: "bar-1", Name: "Bar 1", PublicKey: []byte("short")},
+			wantErr: ErrInvalidPublicKey,
+		},
+		{
+			name:      "terminal already registered",
+			input:     &api.RegisterTerminalRequest{Id: "bar-1", Name: "Bar 1", PublicKey: publicKey},
+			wantErr:   ErrTerminalExists,
+			returnErr: repositories.ErrDuplicateTerminal,
+		},
+		{
+			name:      "storage returns error",
+			input:     &api.RegisterTerminalRequest{Id: "bar-1", Name: "Bar 1", PublicKey: publicKey},
+			wantErr:   ErrCouldNotRegisterTerminal,
+			returnErr: errors.New("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := &api.Terminal{Id: tt.input.Id, Name: tt.input.Name, PublicKey: tt.input.PublicKey}
+			server := adminServer{
+				terminals: &mock.TerminalRepository{
+					RegisterFunc: func(id, name string, publicKey []byte) (*api.Terminal, error) {
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						return &api.Terminal{Id: id, Name: name, PublicKey: publicKey}, nil
+					},
+				},
+			}
+
+			got, err := server.RegisterTerminal(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				if !reflect.DeepEqual(err, tt.wantErr) {
+					t.Errorf("got err %v, expected %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got err %v, did not expect one", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, expected %v", got, want)
+			}
+		})
+	}
+}
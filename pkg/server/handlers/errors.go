@@ -6,14 +6,28 @@ import (
 )
 
 var (
-	ErrGetAll                = status.Error(codes.NotFound, "could not load list of accounts")
-	ErrCouldNotCreateAccount = status.Error(codes.Internal, "could not save new account")
-	ErrAccountNotFound       = status.Error(codes.NotFound, "could not find account")
-	ErrTransactionNotFound   = status.Error(codes.NotFound, "could not find transaction")
-	ErrGroupNotFound         = status.Error(codes.NotFound, "could not find group")
-	ErrSomethingWentWrong    = status.Error(codes.Internal, "something went wrong")
-	ErrNameOrPasswdWrong     = status.Error(codes.Unauthenticated, "username or password wrong")
-	ErrNoRefreshToken        = status.Error(codes.Unauthenticated, "refresh token required")
-	ErrCouldNotLogOut        = status.Error(codes.Internal, "could not log user out")
-	ErrCouldNotCreateGroup   = status.Error(codes.Internal, "could not create group")
+	ErrGetAll                   = status.Error(codes.NotFound, "could not load list of accounts")
+	ErrCouldNotCreateAccount    = status.Error(codes.Internal, "could not save new account")
+	ErrAccountNotFound          = status.Error(codes.NotFound, "could not find account")
+	ErrTransactionNotFound      = status.Error(codes.NotFound, "could not find transaction")
+	ErrGroupNotFound            = status.Error(codes.NotFound, "could not find group")
+	ErrSomethingWentWrong       = status.Error(codes.Internal, "something went wrong")
+	ErrNameOrPasswdWrong        = status.Error(codes.Unauthenticated, "username or password wrong")
+	ErrNoRefreshToken           = status.Error(codes.Unauthenticated, "refresh token required")
+	ErrCouldNotLogOut           = status.Error(codes.Internal, "could not log user out")
+	ErrCouldNotCreateGroup      = status.Error(codes.Internal, "could not create group")
+	ErrCouldNotImport           = status.Error(codes.Internal, "could not import accounts")
+	ErrInvalidTimeRange         = status.Error(codes.InvalidArgument, "invalid time range")
+	ErrTransactionFeedLagged    = status.Error(codes.ResourceExhausted, "transaction feed lagged behind, reconnect to continue")
+	ErrCouldNotVerifyLedger     = status.Error(codes.Internal, "could not verify ledger")
+	ErrAccountBlocked           = status.Error(codes.FailedPrecondition, "account is blocked")
+	ErrTerminalNotFound         = status.Error(codes.NotFound, "could not find terminal")
+	ErrTerminalExists           = status.Error(codes.AlreadyExists, "terminal is already registered")
+	ErrInvalidPublicKey         = status.Error(codes.InvalidArgument, "public key must be a 32 byte ed25519 key")
+	ErrCouldNotRegisterTerminal = status.Error(codes.Internal, "could not register terminal")
+	ErrInvalidChipBalance       = status.Error(codes.FailedPrecondition, "chip balance is not valid")
+	ErrChipBalanceNotIssued     = status.Error(codes.Internal, "transaction was saved, but no chip balance could be issued")
+	ErrInvalidPageToken         = status.Error(codes.InvalidArgument, "invalid page token")
+	ErrInvalidSaldoRange        = status.Error(codes.InvalidArgument, "min saldo is greater than max saldo")
+	ErrInvalidAmountRange       = status.Error(codes.InvalidArgument, "min amount is greater than max amount")
 )
@@ -19,21 +19,49 @@ func RegisterGroupServer(s *grpc.Server, storage repositories.GroupStorager) {
 	api.RegisterGroupsServiceServer(s, &groupserver{storage: storage})
 }
 
+// groupScope is the page scope of the groups, they are always ordered by id and not filtered
+var groupScope = newPageScope("id", false, nil)
+
 func (g *groupserver) ListGroups(ctx context.Context, req *api.ListGroupsRequest) (*api.ListGroupsResponse, error) {
-	var limit, offset int32
-	if req.Paging != nil {
-		limit = req.Paging.Limit
-		offset = req.Paging.Offset
+	page, err := pagingOptions(req.Paging, groupScope)
+	if err != nil {
+		return nil, err
 	}
-	groups, count, err := g.storage.GetAll(ctx, limit, offset)
 
-	if err != nil {
-		return nil, ErrGetAll
+	res := &api.ListGroupsResponse{}
+	more := false
+	if page.useOffset() {
+		groups, count, err := g.storage.GetAll(ctx, page.size, page.offset)
+		if err != nil {
+			return nil, ErrGetAll
+		}
+		res.Groups = groups
+		res.TotalCount = int32(count)
+		more = page.hasMore(len(groups), count)
+	} else {
+		groups, err := g.storage.GetPage(ctx, page.after, page.size+1)
+		if err != nil {
+			return nil, ErrGetAll
+		}
+		if more = int32(len(groups)) > page.size; more {
+			groups = groups[:page.size]
+		}
+		res.Groups = groups
+
+		if page.countTotal {
+			count, err := g.storage.Count(ctx)
+			if err != nil {
+				return nil, ErrGetAll
+			}
+			res.TotalCount = int32(count)
+		}
 	}
-	return &api.ListGroupsResponse{
-		Groups:     groups,
-		TotalCount: int32(count),
-	}, nil
+
+	if more && len(res.Groups) > 0 {
+		res.NextPageToken = page.nextPageToken(repositories.Cursor{Id: res.Groups[len(res.Groups)-1].Id})
+	}
+
+	return res, nil
 }
 
 func (g *groupserver) CreateGroup(ctx context.Context, req *api.CreateGroupRequest) (*api.Group, error) {
@@ -17,6 +17,10 @@ import (
 )
 
 func TestGroupserver_ListGroups(t *testing.T) {
+	token := func(id int32) string {
+		return encodePageToken(repositories.Cursor{Id: id}, groupScope)
+	}
+
 	var tests = []struct {
 		name      string
 		input     *api.ListGroupsRequest
@@ -46,10 +50,45 @@ func TestGroupserver_ListGroups(t *testing.T) {
 				Paging: &api.Paging{Limit: 5},
 			},
 			want: &api.ListGroupsResponse{
-				Groups:     genGroupModels(5),
-				TotalCount: 5,
+				Groups:        genGroupModels(5),
+				TotalCount:    10,
+				NextPageToken: token(5),
+			},
+		},
+		{
+			name: "has page token",
+			input: &api.ListGroupsRequest{
+				Paging: &api.Paging{Limit: 5, PageToken: token(5)},
+			},
+			want: &api.ListGroupsResponse{
+				Groups:     genGroupModels(10)[5:],
+				TotalCount: 10,
+			},
+		},
+		{
+			name: "skips total count",
+			input: &api.ListGroupsRequest{
+				Paging: &api.Paging{Limit: 3, SkipTotalCount: true},
+			},
+			want: &api.ListGroupsResponse{
+				Groups:        genGroupModels(3),
+				NextPageToken: token(3),
 			},
 		},
+		{
+			name: "has invalid page token",
+			input: &api.ListGroupsRequest{
+				Paging: &api.Paging{PageToken: token(0)},
+			},
+			wantErr: ErrInvalidPageToken,
+		},
+		{
+			name: "has page token of the accounts",
+			input: &api.ListGroupsRequest{
+				Paging: &api.Paging{PageToken: encodePageToken(repositories.Cursor{Id: 3}, accountScope(repositories.AccountFilter{}))},
+			},
+			wantErr: ErrInvalidPageToken,
+		},
 		{
 			name: "has limit and offset",
 			input: &api.ListGroupsRequest{
@@ -59,8 +98,9 @@ func TestGroupserver_ListGroups(t *testing.T) {
 				},
 			},
 			want: &api.ListGroupsResponse{
-				Groups:     genGroupModels(10)[2:5],
-				TotalCount: 3,
+				Groups:        genGroupModels(10)[2:5],
+				TotalCount:    10,
+				NextPageToken: token(5),
 			},
 		},
 	}
@@ -69,20 +109,24 @@ func TestGroupserver_ListGroups(t *testing.T) {
 			a := &groupserver{
 				storage: &mock.GroupRepository{
 					GetAllFunc: func(limit, offset int32) ([]*api.Group, int, error) {
+						groups := genGroupModels(10)
+						return groups[offset : offset+limit], len(groups), nil
+					},
+					GetPageFunc: func(after *repositories.Cursor, size int32) ([]*api.Group, error) {
 						if tt.returnErr != nil {
-							return nil, 0, tt.returnErr
+							return nil, tt.returnErr
 						}
-						groups := genGroupModels(10)
 
-						if limit > 0 {
-							var off int32
-							if offset > 0 {
-								off = offset
+						var page []*api.Group
+						for _, group := range genGroupModels(10) {
+							if (after == nil || group.Id > after.Id) && int32(len(page)) < size {
+								page = append(page, group)
 							}
-							return groups[off : off+limit], int(limit), nil
 						}
-
-						return groups, len(groups), nil
+						return page, nil
+					},
+					CountFunc: func() (int, error) {
+						return 10, nil
 					},
 				},
 			}
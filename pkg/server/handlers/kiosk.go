@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultKioskTransactions = 5
+	maxKioskTransactions     = 20
+)
+
+// kioskServer answers the balance requests of the kiosks, it only returns what a guest may see about the own chip
+type kioskServer struct {
+	accounts     repositories.AccountStorager
+	transactions repositories.TransactionStorager
+}
+
+func RegisterKioskServer(s *grpc.Server, accounts repositories.AccountStorager, transactions repositories.TransactionStorager) {
+	api.RegisterKioskServiceServer(s, &kioskServer{accounts: accounts, transactions: transactions})
+}
+
+func (k *kioskServer) GetBalance(ctx context.Context, req *api.GetKioskBalanceRequest) (*api.KioskBalance, error) {
+	if req.NfcChipId == "" {
+		return nil, status.Error(codes.InvalidArgument, "nfc chip id required")
+	}
+
+	account, err := k.accounts.ReadByNfcChipId(ctx, req.NfcChipId)
+	if err != nil {
+		if err == repositories.ErrNotFound {
+			return nil, ErrAccountNotFound
+		}
+		return nil, ErrSomethingWentWrong
+	}
+
+	limit := req.TransactionLimit
+	if limit <= 0 {
+		limit = defaultKioskTransactions
+	}
+	if limit > maxKioskTransactions {
+		limit = maxKioskTransactions
+	}
+
+	// the kiosk only shows the latest transactions, a page does not count all transactions of the account
+	transactions, err := k.transactions.GetPage(ctx, repositories.TransactionFilter{AccountId: account.Id, Order: "desc"}, nil, limit)
+	if err != nil {
+		return nil, ErrSomethingWentWrong
+	}
+
+	balance := &api.KioskBalance{
+		AccountName:  account.Name,
+		Saldo:        account.Saldo,
+		Status:       account.Status,
+		Transactions: make([]*api.KioskTransaction, 0, len(transactions)),
+	}
+	for _, t := range transactions {
+		balance.Transactions = append(balance.Transactions, &api.KioskTransaction{
+			Amount:   t.Amount,
+			NewSaldo: t.NewSaldo,
+			Created:  t.Created,
+		})
+	}
+	return balance, nil
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test/mock"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestKioskServer_GetBalance(t *testing.T) {
+	is := isPkg.New(t)
+	account := &api.Account{
+		Id:        3,
+		Name:      "tim",
+		Saldo:     7.5,
+		NfcChipId: "04a1b2c3d4e5f6",
+		Group:     &api.Group{Id: 1, Name: "crew"},
+	}
+	created := &timestamp.Timestamp{Seconds: 1583093700}
+
+	tests := []struct {
+		name      string
+		input     *api.GetKioskBalanceRequest
+		wantLimit int32
+		want      *api.KioskBalance
+		wantErr   error
+	}{
+		{
+			name:      "balance with default number of transactions",
+			input:     &api.GetKioskBalanceRequest{NfcChipId: "04a1b2c3d4e5f6"},
+			wantLimit: defaultKioskTransactions,
+			want: &api.KioskBalance{
+				AccountName: "tim",
+				Saldo:       7.5,
+				Transactions: []*api.KioskTransaction{
+					{Amount: 2.5, NewSaldo: 7.5, Created: created},
+				},
+			},
+		},
+		{
+			name:      "transaction limit is capped",
+			input:     &api.GetKioskBalanceRequest{NfcChipId: "04a1b2c3d4e5f6", TransactionLimit: 1000},
+			wantLimit: maxKioskTransactions,
+			want: &api.KioskBalance{
+				AccountName: "tim",
+				Saldo:       7.5,
+				Transactions: []*api.KioskTransaction{
+					{Amount: 2.5, NewSaldo: 7.5, Created: created},
+				},
+			},
+		},
+		{
+			name:    "chip is not registered",
+			input:   &api.GetKioskBalanceRequest{NfcChipId: "deadbeef"},
+			wantErr: ErrAccountNotFound,
+		},
+		{
+			name:    "chip id is missing",
+			input:   &api.GetKioskBalanceRequest{},
+			wantErr: status.Error(codes.InvalidArgument, "nfc chip id required"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			server := kioskServer{
+				accounts: &mock.AccountRepository{
+					ReadByNfcChipIdFunc: func(nfcChipId string) (*api.Account, error) {
+						if nfcChipId == account.NfcChipId {
+							return account, nil
+						}
+						return nil, repositories.ErrNotFound
+					},
+				},
+				transactions: &mock.TransactionRepository{
+					GetPageFunc: func(filter repositories.TransactionFilter, after *repositories.Cursor, size int32) ([]*api.Transaction, error) {
+						is.Equal(filter.AccountId, account.Id)
+						is.Equal(filter.Order, "desc") // newest transactions first
+						is.True(after == nil)          // first page
+						is.Equal(size, tt.wantLimit)
+						return []*api.Transaction{
+							{Id: 9, OldSaldo: 10, NewSaldo: 7.5, Amount: 2.5, Created: created, Account: account, TerminalId: "bar-1"},
+						}, nil
+					},
+				},
+			}
+
+			got, err := server.GetBalance(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				is.Equal(err.Error(), tt.wantErr.Error())
+				return
+			}
+
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
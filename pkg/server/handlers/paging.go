@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// maxPageSize is the largest page a list rpc returns, a missing or larger limit is reduced to it
+const maxPageSize int32 = 500
+
+// pageScope is the order and the filter of a list, a page token is only valid for the list it was created for
+type pageScope struct {
+	sortKey    string
+	descending bool
+	// filter is a hash of the filter, so the token stays short
+	filter string
+}
+
+// newPageScope returns the scope of a list sorted by sortKey and filtered with filter,
+// filter has to be a struct that can be marshaled to json
+func newPageScope(sortKey string, descending bool, filter interface{}) pageScope {
+	// the filters are structs of strings, numbers, times and pointers to numbers, marshaling them can not fail
+	raw, _ := json.Marshal(filter)
+	sum := sha256.Sum256(raw)
+	return pageScope{
+		sortKey:    sortKey,
+		descending: descending,
+		filter:     base64.RawURLEncoding.EncodeToString(sum[:12]),
+	}
+}
+
+// pageRequest is the parsed paging of a list request.
+// Requests with an offset and without page token are served with limit and offset,
+// all others with the cursor after
+type pageRequest struct {
+	size       int32
+	offset     int32
+	after      *repositories.Cursor
+	countTotal bool
+	scope      pageScope
+}
+
+// pagingOptions parses req of a list with scope, it returns ErrInvalidPageToken if the page token can not be decoded
+// or was created for a list with another order or filter
+func pagingOptions(req *api.Paging, scope pageScope) (pageRequest, error) {
+	page := pageRequest{size: maxPageSize, countTotal: true, scope: scope}
+	if req == nil {
+		return page, nil
+	}
+
+	if req.Limit > 0 && req.Limit < maxPageSize {
+		page.size = req.Limit
+	}
+	page.countTotal = !req.SkipTotalCount
+
+	if req.PageToken != "" {
+		after, err := decodePageToken(req.PageToken, scope)
+		if err != nil {
+			return page, ErrInvalidPageToken
+		}
+		page.after = after
+		return page, nil
+	}
+
+	if req.Offset > 0 {
+		page.offset = req.Offset
+	}
+	return page, nil
+}
+
+// useOffset returns true if the page has to be loaded with limit and offset
+func (p pageRequest) useOffset() bool {
+	return p.after == nil && p.offset > 0
+}
+
+// hasMore returns true if a page of n items loaded with limit and offset is followed by another page
+func (p pageRequest) hasMore(n, total int) bool {
+	return int(p.offset)+n < total
+}
+
+// nextPageToken returns the page token of the page after cursor
+func (p pageRequest) nextPageToken(cursor repositories.Cursor) string {
+	return encodePageToken(cursor, p.scope)
+}
+
+// pageToken is the content of a page token, the position of the last entry of the previous page
+// and the scope of the list
+type pageToken struct {
+	Created    int64   `json:"c,omitempty"`
+	Name       string  `json:"n,omitempty"`
+	Saldo      float64 `json:"s,omitempty"`
+	Id         int32   `json:"i"`
+	SortKey    string  `json:"k"`
+	Descending bool    `json:"d,omitempty"`
+	Filter     string  `json:"f"`
+}
+
+// encodePageToken returns the opaque page token for cursor in the list with scope
+func encodePageToken(cursor repositories.Cursor, scope pageScope) string {
+	token := pageToken{
+		Name:       cursor.Name,
+		Saldo:      cursor.Saldo,
+		Id:         cursor.Id,
+		SortKey:    scope.sortKey,
+		Descending: scope.descending,
+		Filter:     scope.filter,
+	}
+	if !cursor.Created.IsZero() {
+		token.Created = cursor.Created.UnixNano()
+	}
+
+	// marshaling a struct of strings and numbers can not fail
+	raw, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePageToken returns the cursor of a token created with encodePageToken for a list with scope
+func decodePageToken(token string, scope pageScope) (*repositories.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded pageToken
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	if decoded.Id <= 0 {
+		return nil, fmt.Errorf("invalid id %d in page token", decoded.Id)
+	}
+	if decoded.SortKey != scope.sortKey || decoded.Descending != scope.descending || decoded.Filter != scope.filter {
+		return nil, fmt.Errorf("page token is for another order or filter")
+	}
+
+	cursor := &repositories.Cursor{Name: decoded.Name, Saldo: decoded.Saldo, Id: decoded.Id}
+	if decoded.Created != 0 {
+		cursor.Created = time.Unix(0, decoded.Created).UTC()
+	}
+	return cursor, nil
+}
+
+// transactionPageToken returns the page token of the page that starts after transaction
+func (p pageRequest) transactionPageToken(transaction *api.Transaction) (string, error) {
+	created, err := ptypes.Timestamp(transaction.Created)
+	if err != nil {
+		return "", err
+	}
+	return p.nextPageToken(repositories.Cursor{Created: created, Id: transaction.Id}), nil
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+func TestPageToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor repositories.Cursor
+	}{
+		{name: "id", cursor: repositories.Cursor{Id: 42}},
+		{name: "created and id", cursor: repositories.Cursor{Created: time.Date(2019, 01, 17, 16, 15, 14, 123456789, time.UTC), Id: 7}},
+		{name: "created before 1970", cursor: repositories.Cursor{Created: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), Id: 1}},
+		{name: "name and id", cursor: repositories.Cursor{Name: "Jürgen: \"the\" 1st", Id: 3}},
+		{name: "saldo and id", cursor: repositories.Cursor{Saldo: -12.34, Id: 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			scope := newPageScope("created", true, repositories.TransactionFilter{AccountId: 3})
+			got, err := decodePageToken(encodePageToken(tt.cursor, scope), scope)
+			is.NoErr(err)
+			is.Equal(*got, tt.cursor)
+		})
+	}
+}
+
+func TestPageToken_Invalid(t *testing.T) {
+	for _, token := range []string{"not a token", "", "MTIzNDU", encodePageToken(repositories.Cursor{Id: -1}, groupScope)} {
+		is := isPkg.New(t)
+
+		_, err := decodePageToken(token, groupScope)
+		is.True(err != nil) // token should be invalid
+	}
+}
+
+func TestPageToken_OtherScope(t *testing.T) {
+	scope := newPageScope("created", true, repositories.TransactionFilter{AccountId: 3})
+	token := encodePageToken(repositories.Cursor{Id: 7}, scope)
+
+	tests := []struct {
+		name  string
+		scope pageScope
+	}{
+		{name: "sort key", scope: newPageScope("name", true, repositories.TransactionFilter{AccountId: 3})},
+		{name: "direction", scope: newPageScope("created", false, repositories.TransactionFilter{AccountId: 3})},
+		{name: "filter", scope: newPageScope("created", true, repositories.TransactionFilter{AccountId: 4})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			_, err := decodePageToken(token, tt.scope)
+			is.True(err != nil) // token of another list should be invalid
+
+			_, err = pagingOptions(&api.Paging{PageToken: token}, tt.scope)
+			is.Equal(err, ErrInvalidPageToken)
+		})
+	}
+}
+
+func TestPagingOptions(t *testing.T) {
+	is := isPkg.New(t)
+
+	page, err := pagingOptions(&api.Paging{Limit: maxPageSize * 2, Offset: 10}, groupScope)
+	is.NoErr(err)
+	is.Equal(page.size, maxPageSize) // limit above maximum is reduced
+	is.True(page.useOffset())        // offset without page token uses limit and offset
+
+	page, err = pagingOptions(&api.Paging{Offset: 10, PageToken: encodePageToken(repositories.Cursor{Id: 3}, groupScope), SkipTotalCount: true}, groupScope)
+	is.NoErr(err)
+	is.Equal(page.size, maxPageSize) // missing limit is the maximum
+	is.True(!page.useOffset())       // page token ignores offset
+	is.True(!page.countTotal)        // total count is skipped
+	is.Equal(page.after.Id, int32(3))
+}
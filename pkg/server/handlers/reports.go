@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"google.golang.org/grpc"
+)
+
+type reportServer struct {
+	storage repositories.ReportStorager
+}
+
+func RegisterReportServer(server *grpc.Server, storage repositories.ReportStorager) {
+	api.RegisterReportServiceServer(server, &reportServer{storage: storage})
+}
+
+func (r *reportServer) GetRevenue(ctx context.Context, req *api.RevenueRequest) (*api.RevenueReport, error) {
+	from, to, err := reportRange(req.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := r.storage.Revenue(ctx, from, to, req.GroupBy)
+	if err != nil {
+		return nil, ErrSomethingWentWrong
+	}
+
+	report := &api.RevenueReport{Buckets: buckets}
+	for _, bucket := range buckets {
+		report.Total += bucket.Revenue
+	}
+
+	return report, nil
+}
+
+func (r *reportServer) GetCashFlow(ctx context.Context, req *api.TimeRange) (*api.CashFlowReport, error) {
+	from, to, err := reportRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := r.storage.CashFlow(ctx, from, to)
+	if err != nil {
+		return nil, ErrSomethingWentWrong
+	}
+
+	return report, nil
+}
+
+func (r *reportServer) GetOutstandingBalance(ctx context.Context, req *api.TimeRange) (*api.BalanceReport, error) {
+	_, to, err := reportRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := r.storage.OutstandingBalance(ctx, to)
+	if err != nil {
+		return nil, ErrSomethingWentWrong
+	}
+
+	return report, nil
+}
+
+func (r *reportServer) GetAverageSpend(ctx context.Context, req *api.TimeRange) (*api.AverageSpendReport, error) {
+	from, to, err := reportRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := r.storage.AverageSpend(ctx, from, to)
+	if err != nil {
+		return nil, ErrSomethingWentWrong
+	}
+
+	return report, nil
+}
+
+// reportRange returns from and to of req, a missing range is unbounded
+func reportRange(req *api.TimeRange) (time.Time, time.Time, error) {
+	if req == nil {
+		return time.Time{}, time.Time{}, nil
+	}
+	return timeRange(req.From, req.To)
+}
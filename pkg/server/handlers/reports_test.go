@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test/mock"
+)
+
+func TestReportServer_GetRevenue(t *testing.T) {
+	from := time.Date(2019, 01, 01, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2019, 02, 01, 0, 0, 0, 0, time.UTC)
+	fromProto, _ := ptypes.TimestampProto(from)
+	toProto, _ := ptypes.TimestampProto(to)
+
+	buckets := []*api.RevenueBucket{
+		{GroupId: 1, GroupName: "group1", Revenue: 12.5, TransactionCount: 3},
+		{GroupId: 2, GroupName: "group2", Revenue: 7.5, TransactionCount: 1},
+	}
+
+	tests := []struct {
+		name      string
+		input     *api.RevenueRequest
+		wantFrom  time.Time
+		wantTo    time.Time
+		want      *api.RevenueReport
+		wantErr   error
+		returnErr error
+	}{
+		{
+			name:  "revenue without range",
+			input: &api.RevenueRequest{GroupBy: api.RevenueRequest_GROUP},
+			want:  &api.RevenueReport{Buckets: buckets, Total: 20},
+		},
+		{
+			name: "revenue with range",
+			input: &api.RevenueRequest{
+				Range:   &api.TimeRange{From: fromProto, To: toProto},
+				GroupBy: api.RevenueRequest_GROUP,
+			},
+			wantFrom: from,
+			wantTo:   to,
+			want:     &api.RevenueReport{Buckets: buckets, Total: 20},
+		},
+		{
+			name: "from after to",
+			input: &api.RevenueRequest{
+				Range: &api.TimeRange{From: toProto, To: fromProto},
+			},
+			wantErr: ErrInvalidTimeRange,
+		},
+		{
+			name:      "storage returns error",
+			input:     &api.RevenueRequest{},
+			wantErr:   ErrSomethingWentWrong,
+			returnErr: errors.New("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := reportServer{
+				storage: &mock.ReportRepository{
+					RevenueFunc: func(from, to time.Time, groupBy api.RevenueRequest_Grouping) ([]*api.RevenueBucket, error) {
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+							t.Errorf("got range %v - %v, expected %v - %v", from, to, tt.wantFrom, tt.wantTo)
+						}
+						if groupBy != tt.input.GroupBy {
+							t.Errorf("got grouping %v, expected %v", groupBy, tt.input.GroupBy)
+						}
+						return buckets, nil
+					},
+				},
+			}
+
+			got, err := server.GetRevenue(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("got err %v, expected %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got err %v, did not expect one", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportServer_GetCashFlow(t *testing.T) {
+	want := &api.CashFlowReport{TopUps: 50, TopUpCount: 2, Purchases: 30.5, PurchaseCount: 6}
+
+	tests := []struct {
+		name      string
+		wantErr   error
+		returnErr error
+	}{
+		{name: "returns cash flow"},
+		{name: "storage returns error", wantErr: ErrSomethingWentWrong, returnErr: errors.New("test error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := reportServer{
+				storage: &mock.ReportRepository{
+					CashFlowFunc: func(from, to time.Time) (*api.CashFlowReport, error) {
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						return want, nil
+					},
+				},
+			}
+
+			got, err := server.GetCashFlow(context.Background(), &api.TimeRange{})
+			if err != tt.wantErr {
+				t.Fatalf("got err %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, expected %v", got, want)
+			}
+		})
+	}
+}
+
+func TestReportServer_GetOutstandingBalance(t *testing.T) {
+	at := time.Date(2019, 02, 01, 0, 0, 0, 0, time.UTC)
+	atProto, _ := ptypes.TimestampProto(at)
+	want := &api.BalanceReport{Total: 120, AccountCount: 3, OverdrawnTotal: -10, OverdrawnCount: 1}
+
+	tests := []struct {
+		name      string
+		input     *api.TimeRange
+		wantAt    time.Time
+		wantErr   error
+		returnErr error
+	}{
+		{name: "current balance", input: &api.TimeRange{}},
+		{name: "balance at end of range", input: &api.TimeRange{To: atProto}, wantAt: at},
+		{name: "storage returns error", input: &api.TimeRange{}, wantErr: ErrSomethingWentWrong, returnErr: errors.New("test error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := reportServer{
+				storage: &mock.ReportRepository{
+					OutstandingBalanceFunc: func(at time.Time) (*api.BalanceReport, error) {
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						if !at.Equal(tt.wantAt) {
+							t.Errorf("got time %v, expected %v", at, tt.wantAt)
+						}
+						return want, nil
+					},
+				},
+			}
+
+			got, err := server.GetOutstandingBalance(context.Background(), tt.input)
+			if err != tt.wantErr {
+				t.Fatalf("got err %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, expected %v", got, want)
+			}
+		})
+	}
+}
+
+func TestReportServer_GetAverageSpend(t *testing.T) {
+	want := &api.AverageSpendReport{Average: 15, Total: 45, AccountCount: 3}
+
+	tests := []struct {
+		name      string
+		wantErr   error
+		returnErr error
+	}{
+		{name: "returns average spend"},
+		{name: "storage returns error", wantErr: ErrSomethingWentWrong, returnErr: errors.New("test error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := reportServer{
+				storage: &mock.ReportRepository{
+					AverageSpendFunc: func(from, to time.Time) (*api.AverageSpendReport, error) {
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						return want, nil
+					},
+				},
+			}
+
+			got, err := server.GetAverageSpend(context.Background(), nil)
+			if err != tt.wantErr {
+				t.Fatalf("got err %v, expected %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, expected %v", got, want)
+			}
+		})
+	}
+}
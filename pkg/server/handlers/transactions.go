@@ -2,74 +2,216 @@ package handlers
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/chip"
+	"github.com/jheimbach/nfc-cash-system/pkg/offline"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/broker"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type transactionServer struct {
-	storage repositories.TransactionStorager
+	storage   repositories.TransactionStorager
+	accounts  repositories.AccountStorager
+	terminals repositories.TerminalStorager
+	feed      *broker.TransactionBroker
+	chipKey   []byte
 }
 
-func RegisterTransactionServer(server *grpc.Server, storage repositories.TransactionStorager) {
-	api.RegisterTransactionsServiceServer(server, &transactionServer{storage: storage})
+// RegisterTransactionServer registers the transaction service, chip balances are only verified and issued if chipKey is set
+func RegisterTransactionServer(server *grpc.Server, storage repositories.TransactionStorager, accounts repositories.AccountStorager, terminals repositories.TerminalStorager, feed *broker.TransactionBroker, chipKey []byte) {
+	api.RegisterTransactionsServiceServer(server, &transactionServer{
+		storage:   storage,
+		accounts:  accounts,
+		terminals: terminals,
+		feed:      feed,
+		chipKey:   chipKey,
+	})
 }
 
 func (t *transactionServer) ListTransactions(ctx context.Context, req *api.ListTransactionRequest) (*api.ListTransactionsResponse, error) {
-	limit, offset := pagingOptions(req.Paging)
+	filter, err := transactionFilter(req.CreatedFrom, req.CreatedTo, req.MinAmount, req.MaxAmount)
+	if err != nil {
+		return nil, err
+	}
+	filter.GroupId = req.GroupId
+	filter.TerminalId = req.TerminalId
+	filter.Order = req.Order
+
+	return t.listTransactions(ctx, filter, req.Paging)
+}
 
-	transactions, count, err := t.storage.GetAll(ctx, 0, req.Order, limit, offset)
+func (t *transactionServer) ListTransactionsByAccount(ctx context.Context, req *api.ListTransactionsByAccountRequest) (*api.ListTransactionsResponse, error) {
+	filter, err := transactionFilter(req.CreatedFrom, req.CreatedTo, req.MinAmount, req.MaxAmount)
 	if err != nil {
-		return nil, ErrSomethingWentWrong
+		return nil, err
 	}
+	filter.AccountId = req.AccountId
+	filter.GroupId = req.GroupId
+	filter.TerminalId = req.TerminalId
+	filter.Order = req.Order
 
-	return &api.ListTransactionsResponse{
-		Transactions: transactions,
-		TotalCount:   int32(count),
-	}, nil
+	return t.listTransactions(ctx, filter, req.Paging)
 }
 
-func pagingOptions(req *api.Paging) (int32, int32) {
-	var limit, offset int32
+// transactionFilter returns a filter with the time and amount range of a list request.
+// It returns ErrInvalidTimeRange or ErrInvalidAmountRange if a range is invalid
+func transactionFilter(from, to *timestamp.Timestamp, minAmount, maxAmount *wrappers.DoubleValue) (repositories.TransactionFilter, error) {
+	var filter repositories.TransactionFilter
 
-	if req != nil {
-		if req.Limit > 0 {
-			limit = req.Limit
-			if req.Offset > 0 {
-				offset = req.Offset
-			}
-		}
+	var err error
+	filter.From, filter.To, err = timeRange(from, to)
+	if err != nil {
+		return filter, err
+	}
+
+	if minAmount != nil {
+		filter.MinAmount = &minAmount.Value
+	}
+	if maxAmount != nil {
+		filter.MaxAmount = &maxAmount.Value
 	}
-	return limit, offset
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, ErrInvalidAmountRange
+	}
+
+	return filter, nil
 }
 
-func (t *transactionServer) ListTransactionsByAccount(ctx context.Context, req *api.ListTransactionsByAccountRequest) (*api.ListTransactionsResponse, error) {
-	limit, offset := pagingOptions(req.Paging)
+// transactionScope returns the page scope of the transactions matching filter, they are sorted by created.
+// The order is part of the scope as direction, so "asc" and "ASC" are the same order
+func transactionScope(filter repositories.TransactionFilter) pageScope {
+	descending := !strings.EqualFold(filter.Order, "asc")
+	filter.Order = ""
+	return newPageScope("created", descending, filter)
+}
 
-	transactions, count, err := t.storage.GetAll(ctx, req.AccountId, req.Order, limit, offset)
+// listTransactions returns a page of the transactions matching filter
+func (t *transactionServer) listTransactions(ctx context.Context, filter repositories.TransactionFilter, paging *api.Paging) (*api.ListTransactionsResponse, error) {
+	page, err := pagingOptions(paging, transactionScope(filter))
 	if err != nil {
-		return nil, ErrSomethingWentWrong
+		return nil, err
+	}
+
+	res := &api.ListTransactionsResponse{}
+	more := false
+	if page.useOffset() {
+		transactions, count, err := t.storage.GetAll(ctx, filter, page.size, page.offset)
+		if err != nil {
+			return nil, ErrSomethingWentWrong
+		}
+		res.Transactions = transactions
+		res.TotalCount = int32(count)
+		more = page.hasMore(len(transactions), count)
+	} else {
+		transactions, err := t.storage.GetPage(ctx, filter, page.after, page.size+1)
+		if err != nil {
+			return nil, ErrSomethingWentWrong
+		}
+		if more = int32(len(transactions)) > page.size; more {
+			transactions = transactions[:page.size]
+		}
+		res.Transactions = transactions
+
+		if page.countTotal {
+			count, err := t.storage.Count(ctx, filter)
+			if err != nil {
+				return nil, ErrSomethingWentWrong
+			}
+			res.TotalCount = int32(count)
+		}
+	}
+
+	if more && len(res.Transactions) > 0 {
+		token, err := page.transactionPageToken(res.Transactions[len(res.Transactions)-1])
+		if err != nil {
+			return nil, ErrSomethingWentWrong
+		}
+		res.NextPageToken = token
 	}
 
-	return &api.ListTransactionsResponse{
-		Transactions: transactions,
-		TotalCount:   int32(count),
-	}, nil
+	return res, nil
 }
 
 func (t *transactionServer) CreateTransaction(ctx context.Context, req *api.CreateTransactionRequest) (*api.Transaction, error) {
-	transaction, err := t.storage.Create(ctx, req.Amount, req.AccountId)
+	var chipBalance *chip.Balance
+	if len(req.ChipBalance) > 0 && len(t.chipKey) > 0 {
+		balance, err := t.readChipBalance(ctx, req.AccountId, req.ChipBalance)
+		if err != nil {
+			return nil, err
+		}
+		chipBalance = &balance
+	}
+
+	transaction, err := t.storage.Create(ctx, req.Amount, req.AccountId, req.TerminalId)
 	if err != nil {
 		if err == repositories.ErrAccountNotFound {
 			return nil, ErrAccountNotFound
 		}
+		if err == repositories.ErrAccountBlocked {
+			return nil, ErrAccountBlocked
+		}
 		return nil, ErrSomethingWentWrong
 	}
 
+	if chipBalance != nil {
+		transaction.ChipBalance, err = t.issueChipBalance(ctx, transaction, *chipBalance)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return transaction, nil
 }
 
+// readChipBalance verifies that blob is a balance of the chip of the account
+func (t *transactionServer) readChipBalance(ctx context.Context, accountId int32, blob []byte) (chip.Balance, error) {
+	account, err := t.accounts.Read(ctx, accountId)
+	if err != nil {
+		if err == repositories.ErrNotFound {
+			return chip.Balance{}, ErrAccountNotFound
+		}
+		return chip.Balance{}, ErrSomethingWentWrong
+	}
+
+	balance, err := chip.Decode(t.chipKey, account.NfcChipId, blob)
+	if err != nil || balance.AccountId != accountId {
+		return chip.Balance{}, ErrInvalidChipBalance
+	}
+	return balance, nil
+}
+
+// issueChipBalance returns the balance blob the terminal writes to the chip after transaction.
+// Offline charges are on the chip before they are synced to the server, so the chip keeps the lower of both saldos.
+// If the chip presented an outdated blob, its saldo is ignored and the saldo of the server is used.
+// Returns ErrChipBalanceNotIssued if no counter could be issued, the transaction is saved already in that case
+func (t *transactionServer) issueChipBalance(ctx context.Context, transaction *api.Transaction, balance chip.Balance) ([]byte, error) {
+	counter, replayed, err := t.accounts.IssueChipCounter(ctx, transaction.Account.Id, balance.Counter)
+	if err != nil {
+		return nil, ErrChipBalanceNotIssued
+	}
+
+	saldo := transaction.NewSaldo
+	if !replayed && balance.Saldo < transaction.OldSaldo {
+		saldo = balance.Saldo - transaction.Amount
+	}
+
+	return chip.Encode(t.chipKey, transaction.Account.NfcChipId, chip.Balance{
+		AccountId: transaction.Account.Id,
+		Saldo:     saldo,
+		Counter:   counter,
+	}), nil
+}
+
 func (t *transactionServer) GetTransaction(ctx context.Context, req *api.GetTransactionRequest) (*api.Transaction, error) {
 	transaction, err := t.storage.Read(ctx, req.Id)
 	if err != nil {
@@ -85,3 +227,124 @@ func (t *transactionServer) GetTransaction(ctx context.Context, req *api.GetTran
 
 	return transaction, nil
 }
+
+// SyncOfflineTransactions verifies the signatures of the offline transactions with the key of the terminal
+// and applies the valid ones in order of their sequence. Transactions with an invalid signature are reported as conflicts
+func (t *transactionServer) SyncOfflineTransactions(ctx context.Context, req *api.SyncOfflineTransactionsRequest) (*api.SyncOfflineTransactionsResponse, error) {
+	terminal, err := t.terminals.Read(ctx, req.TerminalId)
+	if err != nil {
+		if err == repositories.ErrTerminalNotFound {
+			return nil, ErrTerminalNotFound
+		}
+		return nil, ErrSomethingWentWrong
+	}
+
+	var signed []*api.OfflineTransaction
+	var conflicts []*api.OfflineConflict
+	for _, transaction := range req.Transactions {
+		if !offline.Verify(terminal.PublicKey, terminal.Id, transaction) {
+			conflicts = append(conflicts, &api.OfflineConflict{
+				Sequence:  transaction.Sequence,
+				AccountId: transaction.AccountId,
+				Amount:    transaction.Amount,
+				Reason:    api.OfflineConflict_INVALID_SIGNATURE,
+			})
+			continue
+		}
+		signed = append(signed, transaction)
+	}
+	sort.SliceStable(signed, func(i, j int) bool {
+		return signed[i].Sequence < signed[j].Sequence
+	})
+
+	res, err := t.storage.ApplyOffline(ctx, terminal.Id, signed)
+	if err != nil {
+		if err == repositories.ErrTerminalNotFound {
+			return nil, ErrTerminalNotFound
+		}
+		return nil, ErrSomethingWentWrong
+	}
+	res.Conflicts = append(conflicts, res.Conflicts...)
+
+	return res, nil
+}
+
+func (t *transactionServer) ExportTransactions(req *api.ExportTransactionsRequest, stream api.TransactionsService_ExportTransactionsServer) error {
+	from, to, err := timeRange(req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	filter := repositories.TransactionFilter{
+		AccountId: req.AccountId,
+		GroupId:   req.GroupId,
+		From:      from,
+		To:        to,
+		Order:     req.Order,
+	}
+
+	err = t.storage.Export(stream.Context(), filter, stream.Send)
+	if err != nil {
+		// errors from stream.Send are already grpc status errors
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return ErrSomethingWentWrong
+	}
+
+	return nil
+}
+
+func (t *transactionServer) WatchTransactions(req *api.WatchTransactionsRequest, stream api.TransactionsService_WatchTransactionsServer) error {
+	transactions, cancel := t.feed.Subscribe(broker.Filter{
+		AccountId:  req.AccountId,
+		GroupId:    req.GroupId,
+		TerminalId: req.TerminalId,
+	})
+	defer cancel()
+
+	// headers tell the client that the subscription is ready, otherwise it would not know before the first transaction
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case transaction, ok := <-transactions:
+			if !ok {
+				// the broker dropped us, because we did not keep up
+				return ErrTransactionFeedLagged
+			}
+			if err := stream.Send(transaction); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// timeRange converts from and to into times, nil timestamps result in zero times.
+// It returns ErrInvalidTimeRange if a timestamp is invalid or from is not before to
+func timeRange(from, to *timestamp.Timestamp) (time.Time, time.Time, error) {
+	fromTime, err := optionalTime(from)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidTimeRange
+	}
+	toTime, err := optionalTime(to)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidTimeRange
+	}
+	if !fromTime.IsZero() && !toTime.IsZero() && !fromTime.Before(toTime) {
+		return time.Time{}, time.Time{}, ErrInvalidTimeRange
+	}
+	return fromTime, toTime, nil
+}
+
+// optionalTime converts ts to time.Time, nil results in the zero time
+func optionalTime(ts *timestamp.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	return ptypes.Timestamp(ts)
+}
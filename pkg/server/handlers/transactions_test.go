@@ -2,33 +2,62 @@ package handlers
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/chip"
+	"github.com/jheimbach/nfc-cash-system/pkg/offline"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/broker"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test/mock"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestTransactionServer_ListTransactions(t *testing.T) {
+	from := time.Date(2019, 01, 17, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2019, 01, 18, 0, 0, 0, 0, time.UTC)
+	fromProto, _ := ptypes.TimestampProto(from)
+	toProto, _ := ptypes.TimestampProto(to)
+	minAmount, maxAmount := -10.0, 5.0
+	allFilters := repositories.TransactionFilter{
+		GroupId:    2,
+		TerminalId: "bar-1",
+		From:       from,
+		To:         to,
+		MinAmount:  &minAmount,
+		MaxAmount:  &maxAmount,
+		Order:      "asc",
+	}
+
 	tests := []struct {
-		name      string
-		input     *api.ListTransactionRequest
-		want      *api.ListTransactionsResponse
-		wantErr   error
-		returnErr error
+		name       string
+		input      *api.ListTransactionRequest
+		want       *api.ListTransactionsResponse
+		wantSize   int32
+		wantFilter repositories.TransactionFilter
+		wantErr    error
+		returnErr  error
 	}{
 		{
 			name:  "return all transactions",
 			input: &api.ListTransactionRequest{},
 			want: &api.ListTransactionsResponse{
-				Transactions: genTransactionModels(2, 1),
-				TotalCount:   2,
+				Transactions: genTransactionModels(10, 1),
+				TotalCount:   10,
 			},
+			wantSize: maxPageSize + 1,
 		},
 		{
 			name:      "storage returns error",
@@ -42,52 +71,152 @@ func TestTransactionServer_ListTransactions(t *testing.T) {
 				Paging: &api.Paging{
 					Limit: 5,
 				},
+				Order: "asc",
 			},
 			want: &api.ListTransactionsResponse{
-				Transactions: genTransactionModels(5, 1),
-				TotalCount:   5,
+				Transactions:  genTransactionModels(5, 1),
+				TotalCount:    10,
+				NextPageToken: transactionToken(t, 5, repositories.TransactionFilter{Order: "asc"}),
+			},
+			wantSize:   6,
+			wantFilter: repositories.TransactionFilter{Order: "asc"},
+		},
+		{
+			name: "return transactions with all filters combined",
+			input: &api.ListTransactionRequest{
+				Paging:      &api.Paging{Limit: 2},
+				Order:       "asc",
+				CreatedFrom: fromProto,
+				CreatedTo:   toProto,
+				MinAmount:   &wrappers.DoubleValue{Value: minAmount},
+				MaxAmount:   &wrappers.DoubleValue{Value: maxAmount},
+				GroupId:     2,
+				TerminalId:  "bar-1",
+			},
+			want: &api.ListTransactionsResponse{
+				Transactions:  genTransactionModels(2, 1),
+				TotalCount:    10,
+				NextPageToken: transactionToken(t, 2, allFilters),
+			},
+			wantSize:   3,
+			wantFilter: allFilters,
+		},
+		{
+			name: "return transactions with open time range and amount range",
+			input: &api.ListTransactionRequest{
+				Paging:      &api.Paging{Limit: 2},
+				CreatedFrom: fromProto,
+				MaxAmount:   &wrappers.DoubleValue{Value: maxAmount},
+			},
+			want: &api.ListTransactionsResponse{
+				Transactions:  genTransactionModels(2, 1),
+				TotalCount:    10,
+				NextPageToken: transactionToken(t, 2, repositories.TransactionFilter{From: from, MaxAmount: &maxAmount}),
+			},
+			wantSize:   3,
+			wantFilter: repositories.TransactionFilter{From: from, MaxAmount: &maxAmount},
+		},
+		{
+			name: "return error if created from is not before created to",
+			input: &api.ListTransactionRequest{
+				CreatedFrom: toProto,
+				CreatedTo:   fromProto,
+			},
+			wantErr: ErrInvalidTimeRange,
+		},
+		{
+			name: "return error if min amount is greater than max amount",
+			input: &api.ListTransactionRequest{
+				MinAmount: &wrappers.DoubleValue{Value: maxAmount},
+				MaxAmount: &wrappers.DoubleValue{Value: minAmount},
+			},
+			wantErr: ErrInvalidAmountRange,
+		},
+		{
+			name: "return transactions with page token",
+			input: &api.ListTransactionRequest{
+				Paging: &api.Paging{
+					Limit:     5,
+					PageToken: transactionToken(t, 5, repositories.TransactionFilter{}),
+				},
+			},
+			want: &api.ListTransactionsResponse{
+				Transactions: genTransactionModels(10, 1)[5:10],
+				TotalCount:   10,
+			},
+			wantSize: 6,
+		},
+		{
+			name: "return transactions without total count",
+			input: &api.ListTransactionRequest{
+				Paging: &api.Paging{
+					Limit:          8,
+					SkipTotalCount: true,
+				},
+			},
+			want: &api.ListTransactionsResponse{
+				Transactions:  genTransactionModels(8, 1),
+				NextPageToken: transactionToken(t, 8, repositories.TransactionFilter{}),
+			},
+			wantSize: 9,
+		},
+		{
+			name: "return error for invalid page token",
+			input: &api.ListTransactionRequest{
+				Paging: &api.Paging{
+					PageToken: "MTIzNDU",
+				},
 			},
+			wantErr: ErrInvalidPageToken,
 		},
 		{
 			name: "return transactions with limit and offset",
 			input: &api.ListTransactionRequest{
 				Paging: &api.Paging{
 					Limit:  5,
-					Offset: 5,
+					Offset: 3,
 				},
 			},
 			want: &api.ListTransactionsResponse{
-				Transactions: genTransactionModels(10, 1)[5:10],
-				TotalCount:   5,
+				Transactions:  genTransactionModels(10, 1)[3:8],
+				TotalCount:    10,
+				NextPageToken: transactionToken(t, 8, repositories.TransactionFilter{}),
 			},
 		},
+		{
+			name: "return filtered transactions with limit and offset",
+			input: &api.ListTransactionRequest{
+				Paging: &api.Paging{
+					Limit:  5,
+					Offset: 3,
+				},
+				TerminalId: "bar-1",
+				GroupId:    4,
+			},
+			want: &api.ListTransactionsResponse{
+				Transactions:  genTransactionModels(10, 1)[3:8],
+				TotalCount:    10,
+				NextPageToken: transactionToken(t, 8, repositories.TransactionFilter{GroupId: 4, TerminalId: "bar-1"}),
+			},
+			wantFilter: repositories.TransactionFilter{GroupId: 4, TerminalId: "bar-1"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			server := transactionServer{
-				storage: &mock.TransactionRepository{
-					GetAllFunc: func(accountId int32, order string, limit, offset int32) ([]*api.Transaction, int, error) {
-						if tt.returnErr != nil {
-							return nil, 0, tt.returnErr
-						}
-
-						if tt.input.Order != order {
-							t.Errorf("got order %q, expected %q", order, tt.input.Order)
-						}
-
-						if tt.input.Paging != nil {
-							if limit != tt.input.Paging.Limit {
-								t.Errorf("got limit %d, expected %d", limit, tt.input.Paging.Limit)
-							}
-							if offset != tt.input.Paging.Offset {
-								t.Errorf("got offset %d, expected %d", offset, tt.input.Paging.Offset)
-							}
-						}
-
-						return tt.want.Transactions, len(tt.want.Transactions), nil
-					},
-				},
+			storage := transactionPages(t, genTransactionModels(10, 1), tt.wantFilter)
+			storage.GetPageFunc = func(filter repositories.TransactionFilter, after *repositories.Cursor, size int32) ([]*api.Transaction, error) {
+				if tt.returnErr != nil {
+					return nil, tt.returnErr
+				}
+				if !reflect.DeepEqual(filter, tt.wantFilter) {
+					t.Errorf("got filter %+v, expected %+v", filter, tt.wantFilter)
+				}
+				if size != tt.wantSize {
+					t.Errorf("got size %d, expected %d", size, tt.wantSize)
+				}
+				return transactionsAfter(genTransactionModels(10, 1), after, size), nil
 			}
+			server := transactionServer{storage: storage}
 			got, err := server.ListTransactions(context.Background(), tt.input)
 
 			if tt.wantErr != nil {
@@ -108,33 +237,56 @@ func TestTransactionServer_ListTransactions(t *testing.T) {
 }
 
 func TestTransactionServer_ListTransactionsByAccount(t *testing.T) {
+	from := time.Date(2019, 01, 17, 0, 0, 0, 0, time.UTC)
+	fromProto, _ := ptypes.TimestampProto(from)
+	minAmount := -20.0
+
 	tests := []struct {
-		name      string
-		input     *api.ListTransactionsByAccountRequest
-		want      *api.ListTransactionsResponse
-		wantErr   error
-		returnErr error
+		name       string
+		input      *api.ListTransactionsByAccountRequest
+		want       *api.ListTransactionsResponse
+		wantFilter repositories.TransactionFilter
+		wantErr    error
+		returnErr  error
 	}{
 		{
 			name:  "return all account transaction",
 			input: &api.ListTransactionsByAccountRequest{AccountId: 1},
 			want: &api.ListTransactionsResponse{
-				Transactions: genTransactionModels(3, 1),
-				TotalCount:   3,
+				Transactions: genTransactionModels(5, 1),
+				TotalCount:   5,
 			},
+			wantFilter: repositories.TransactionFilter{AccountId: 1},
 		},
 		{
 			name: "return account transaction with limit",
 			input: &api.ListTransactionsByAccountRequest{
 				AccountId: 1,
 				Paging: &api.Paging{
-					Limit: 5,
+					Limit: 3,
 				},
 			},
 			want: &api.ListTransactionsResponse{
-				Transactions: genTransactionModels(5, 1),
+				Transactions:  genTransactionModels(3, 1),
+				TotalCount:    5,
+				NextPageToken: transactionToken(t, 3, repositories.TransactionFilter{AccountId: 1}),
+			},
+			wantFilter: repositories.TransactionFilter{AccountId: 1},
+		},
+		{
+			name: "return account transaction with page token",
+			input: &api.ListTransactionsByAccountRequest{
+				AccountId: 1,
+				Paging: &api.Paging{
+					Limit:     3,
+					PageToken: transactionToken(t, 3, repositories.TransactionFilter{AccountId: 1}),
+				},
+			},
+			want: &api.ListTransactionsResponse{
+				Transactions: genTransactionModels(5, 1)[3:5],
 				TotalCount:   5,
 			},
+			wantFilter: repositories.TransactionFilter{AccountId: 1},
 		},
 		{
 			name: "return account transaction with limit and offset",
@@ -147,9 +299,51 @@ func TestTransactionServer_ListTransactionsByAccount(t *testing.T) {
 			},
 			want: &api.ListTransactionsResponse{
 				Transactions: genTransactionModels(5, 1)[2:5],
-				TotalCount:   3,
+				TotalCount:   5,
+			},
+			wantFilter: repositories.TransactionFilter{AccountId: 1},
+		},
+		{
+			name: "return account transaction with filters combined",
+			input: &api.ListTransactionsByAccountRequest{
+				AccountId:   1,
+				Order:       "desc",
+				CreatedFrom: fromProto,
+				MinAmount:   &wrappers.DoubleValue{Value: minAmount},
+				GroupId:     2,
+				TerminalId:  "kiosk-1",
+			},
+			want: &api.ListTransactionsResponse{
+				Transactions: genTransactionModels(5, 1),
+				TotalCount:   5,
+			},
+			wantFilter: repositories.TransactionFilter{
+				AccountId:  1,
+				GroupId:    2,
+				TerminalId: "kiosk-1",
+				From:       from,
+				MinAmount:  &minAmount,
+				Order:      "desc",
 			},
 		},
+		{
+			name: "return error if created to is before created from",
+			input: &api.ListTransactionsByAccountRequest{
+				AccountId:   1,
+				CreatedFrom: fromProto,
+				CreatedTo:   fromProto,
+			},
+			wantErr: ErrInvalidTimeRange,
+		},
+		{
+			name: "return error if min amount is greater than max amount",
+			input: &api.ListTransactionsByAccountRequest{
+				AccountId: 1,
+				MinAmount: &wrappers.DoubleValue{Value: 1},
+				MaxAmount: &wrappers.DoubleValue{Value: 0},
+			},
+			wantErr: ErrInvalidAmountRange,
+		},
 		{
 			name: "return error",
 			input: &api.ListTransactionsByAccountRequest{
@@ -161,33 +355,20 @@ func TestTransactionServer_ListTransactionsByAccount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			server := &transactionServer{
-				storage: &mock.TransactionRepository{
-					GetAllFunc: func(accountId int32, order string, limit, offset int32) ([]*api.Transaction, int, error) {
-						if accountId != tt.input.AccountId {
-							t.Fatalf("got accountid %d, expected %d", accountId, tt.input.AccountId)
-						}
-						if tt.returnErr != nil {
-							return nil, 0, tt.returnErr
-						}
-
-						if tt.input.Order != order {
-							t.Errorf("got order %q, expected %q", order, tt.input.Order)
-						}
-						if tt.input.Paging != nil {
-							if limit != tt.input.Paging.Limit {
-								t.Errorf("got limit %d, expected %d", limit, tt.input.Paging.Limit)
-							}
-							if offset != tt.input.Paging.Offset {
-								t.Errorf("got offset %d, expected %d", offset, tt.input.Paging.Offset)
-							}
-						}
-
-						return tt.want.Transactions, len(tt.want.Transactions), nil
-
-					},
-				},
+			storage := transactionPages(t, genTransactionModels(5, 1), tt.wantFilter)
+			storage.GetPageFunc = func(filter repositories.TransactionFilter, after *repositories.Cursor, size int32) ([]*api.Transaction, error) {
+				if filter.AccountId != tt.input.AccountId {
+					t.Fatalf("got accountid %d, expected %d", filter.AccountId, tt.input.AccountId)
+				}
+				if tt.returnErr != nil {
+					return nil, tt.returnErr
+				}
+				if !reflect.DeepEqual(filter, tt.wantFilter) {
+					t.Errorf("got filter %+v, expected %+v", filter, tt.wantFilter)
+				}
+				return transactionsAfter(genTransactionModels(5, 1), after, size), nil
 			}
+			server := &transactionServer{storage: storage}
 			got, err := server.ListTransactionsByAccount(context.Background(), tt.input)
 			if tt.wantErr != nil {
 				if err != tt.wantErr {
@@ -207,6 +388,51 @@ func TestTransactionServer_ListTransactionsByAccount(t *testing.T) {
 	}
 }
 
+// transactionPages returns a TransactionRepository that pages with limit and offset through transactions and counts them,
+// the filter of every call has to be wantFilter
+func transactionPages(t *testing.T, transactions []*api.Transaction, wantFilter repositories.TransactionFilter) *mock.TransactionRepository {
+	return &mock.TransactionRepository{
+		GetAllFunc: func(filter repositories.TransactionFilter, limit, offset int32) ([]*api.Transaction, int, error) {
+			if !reflect.DeepEqual(filter, wantFilter) {
+				t.Errorf("got filter %+v, expected %+v", filter, wantFilter)
+			}
+			end := offset + limit
+			if end > int32(len(transactions)) {
+				end = int32(len(transactions))
+			}
+			return transactions[offset:end], len(transactions), nil
+		},
+		CountFunc: func(filter repositories.TransactionFilter) (int, error) {
+			if !reflect.DeepEqual(filter, wantFilter) {
+				t.Errorf("got filter %+v, expected %+v", filter, wantFilter)
+			}
+			return len(transactions), nil
+		},
+	}
+}
+
+// transactionsAfter returns up to size transactions with an id greater than the id of after
+func transactionsAfter(transactions []*api.Transaction, after *repositories.Cursor, size int32) []*api.Transaction {
+	var page []*api.Transaction
+	for _, transaction := range transactions {
+		if (after == nil || transaction.Id > after.Id) && int32(len(page)) < size {
+			page = append(page, transaction)
+		}
+	}
+	return page
+}
+
+// transactionToken returns the page token of the page after the transaction with id of genTransactionModels
+// in the list of the transactions matching filter
+func transactionToken(t *testing.T, id int32, filter repositories.TransactionFilter) string {
+	page := pageRequest{scope: transactionScope(filter)}
+	token, err := page.transactionPageToken(genTransactionModels(int(id), 1)[id-1])
+	if err != nil {
+		t.Fatalf("could not create page token: %v", err)
+	}
+	return token
+}
+
 func TestTransactionServer_CreateTransaction(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -221,6 +447,14 @@ func TestTransactionServer_CreateTransaction(t *testing.T) {
 				AccountId: 1,
 			},
 		},
+		{
+			name: "create transaction with terminal id",
+			input: &api.CreateTransactionRequest{
+				Amount:     5,
+				AccountId:  1,
+				TerminalId: "kiosk-1",
+			},
+		},
 		{
 			name: "storage returns AccountNotFound",
 			input: &api.CreateTransactionRequest{
@@ -230,23 +464,33 @@ func TestTransactionServer_CreateTransaction(t *testing.T) {
 			returnErr: repositories.ErrAccountNotFound,
 			wantErr:   ErrAccountNotFound,
 		},
+		{
+			name: "storage returns AccountBlocked",
+			input: &api.CreateTransactionRequest{
+				Amount:    5,
+				AccountId: 1,
+			},
+			returnErr: repositories.ErrAccountBlocked,
+			wantErr:   ErrAccountBlocked,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := transactionServer{
 				storage: &mock.TransactionRepository{
-					CreateFunc: func(amount float64, accountId int32) (*api.Transaction, error) {
+					CreateFunc: func(amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
 						if tt.returnErr != nil {
 							return nil, tt.returnErr
 						}
 						return &api.Transaction{
-							Id:       1,
-							Amount:   amount,
-							OldSaldo: 120,
-							NewSaldo: 120 - amount,
-							Account:  &api.Account{Id: accountId},
-							Created:  timeStamp(),
+							Id:         1,
+							Amount:     amount,
+							OldSaldo:   120,
+							NewSaldo:   120 - amount,
+							Account:    &api.Account{Id: accountId},
+							Created:    timeStamp(),
+							TerminalId: terminalId,
 						}, nil
 					},
 				},
@@ -264,12 +508,13 @@ func TestTransactionServer_CreateTransaction(t *testing.T) {
 				t.Fatalf("got err %v, did not expect one", err)
 			}
 			want := &api.Transaction{
-				Id:       1,
-				OldSaldo: 120,
-				NewSaldo: 120 - tt.input.Amount,
-				Amount:   tt.input.Amount,
-				Created:  timeStamp(),
-				Account:  &api.Account{Id: 1},
+				Id:         1,
+				OldSaldo:   120,
+				NewSaldo:   120 - tt.input.Amount,
+				Amount:     tt.input.Amount,
+				Created:    timeStamp(),
+				Account:    &api.Account{Id: 1},
+				TerminalId: tt.input.TerminalId,
 			}
 
 			if !reflect.DeepEqual(got, want) {
@@ -279,6 +524,229 @@ func TestTransactionServer_CreateTransaction(t *testing.T) {
 	}
 }
 
+func TestTransactionServer_CreateTransactionWithChipBalance(t *testing.T) {
+	key := []byte("chip balance key")
+	const uid = "04a1b2c3d4e5f6"
+	account := &api.Account{Id: 1, NfcChipId: uid}
+
+	tests := []struct {
+		name        string
+		chip        chip.Balance
+		blob        func([]byte) []byte
+		serverSaldo float64
+		amount      float64
+		replayed    bool
+		issueErr    error
+		want        chip.Balance
+		wantErr     error
+		wantCharged bool
+	}{
+		{
+			name:        "chip and server agree",
+			chip:        chip.Balance{AccountId: 1, Saldo: 20, Counter: 4},
+			serverSaldo: 20,
+			amount:      2.5,
+			want:        chip.Balance{AccountId: 1, Saldo: 17.5, Counter: 5},
+			wantCharged: true,
+		},
+		{
+			name:        "offline charges are not synced yet",
+			chip:        chip.Balance{AccountId: 1, Saldo: 15, Counter: 6},
+			serverSaldo: 20,
+			amount:      2.5,
+			want:        chip.Balance{AccountId: 1, Saldo: 12.5, Counter: 7},
+			wantCharged: true,
+		},
+		{
+			name:        "top up at the desk",
+			chip:        chip.Balance{AccountId: 1, Saldo: 20, Counter: 4},
+			serverSaldo: 20,
+			amount:      -10,
+			want:        chip.Balance{AccountId: 1, Saldo: 30, Counter: 5},
+			wantCharged: true,
+		},
+		{
+			name:        "outdated blob on the chip",
+			chip:        chip.Balance{AccountId: 1, Saldo: 50, Counter: 2},
+			serverSaldo: 20,
+			amount:      2.5,
+			replayed:    true,
+			want:        chip.Balance{AccountId: 1, Saldo: 17.5, Counter: 11},
+			wantCharged: true,
+		},
+		{
+			name:        "counter can not be issued",
+			chip:        chip.Balance{AccountId: 1, Saldo: 20, Counter: 4},
+			serverSaldo: 20,
+			amount:      2.5,
+			issueErr:    errors.New("connection lost"),
+			wantErr:     ErrChipBalanceNotIssued,
+			wantCharged: true,
+		},
+		{
+			name:    "tampered blob",
+			chip:    chip.Balance{AccountId: 1, Saldo: 20, Counter: 4},
+			blob:    func(b []byte) []byte { b[10]++; return b },
+			amount:  2.5,
+			wantErr: ErrInvalidChipBalance,
+		},
+		{
+			name:    "blob of another account",
+			chip:    chip.Balance{AccountId: 2, Saldo: 20, Counter: 4},
+			amount:  2.5,
+			wantErr: ErrInvalidChipBalance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the terminal reads the blob from the chip
+			tag := chip.NewMemoryTag(45)
+			if err := chip.WriteBalance(tag, key, uid, tt.chip); err != nil {
+				t.Fatal(err)
+			}
+			blob, err := tag.ReadPages(chip.FirstPage)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rest, err := tag.ReadPages(chip.FirstPage + 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			blob = append(blob, rest...)
+			if tt.blob != nil {
+				blob = tt.blob(blob)
+			}
+
+			charged := false
+			server := transactionServer{
+				chipKey: key,
+				accounts: &mock.AccountRepository{
+					ReadFunc: func(id int32) (*api.Account, error) {
+						return account, nil
+					},
+					IssueChipCounterFunc: func(id int32, seen uint32) (uint32, bool, error) {
+						if seen != tt.chip.Counter {
+							t.Errorf("got seen counter %d, expected %d", seen, tt.chip.Counter)
+						}
+						if tt.issueErr != nil {
+							return 0, false, tt.issueErr
+						}
+						if tt.replayed {
+							return 11, true, nil
+						}
+						return seen + 1, false, nil
+					},
+				},
+				storage: &mock.TransactionRepository{
+					CreateFunc: func(amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+						charged = true
+						return &api.Transaction{
+							Id:       1,
+							Amount:   amount,
+							OldSaldo: tt.serverSaldo,
+							NewSaldo: tt.serverSaldo - amount,
+							Account:  account,
+						}, nil
+					},
+				},
+			}
+
+			got, err := server.CreateTransaction(context.Background(), &api.CreateTransactionRequest{
+				Amount:      tt.amount,
+				AccountId:   1,
+				ChipBalance: blob,
+			})
+			if charged != tt.wantCharged {
+				t.Errorf("account charged %v, expected %v", charged, tt.wantCharged)
+			}
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("got err %v, expected %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got err %v, did not expect one", err)
+			}
+
+			// the terminal writes the new blob back to the chip
+			if err := chip.WriteBlob(tag, got.ChipBalance); err != nil {
+				t.Fatal(err)
+			}
+			balance, err := chip.ReadBalance(tag, key, uid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if balance != tt.want {
+				t.Errorf("got chip balance %v, expected %v", balance, tt.want)
+			}
+		})
+	}
+}
+
+// TestTransactionServer_CreateTransactionPublishesCopy runs a watch subscriber while a transaction with chip balance
+// is created, run with -race to see that the subscriber does not share the transaction the handler returns
+func TestTransactionServer_CreateTransactionPublishesCopy(t *testing.T) {
+	key := []byte("chip balance key")
+	const uid = "04a1b2c3d4e5f6"
+	account := &api.Account{Id: 1, NfcChipId: uid}
+
+	feed := broker.NewTransactionBroker(1)
+	published, cancel := feed.Subscribe(broker.Filter{})
+	defer cancel()
+
+	// the subscriber marshals the transaction like the watch stream does
+	received := make(chan *api.Transaction)
+	go func() {
+		transaction := <-published
+		if _, err := proto.Marshal(transaction); err != nil {
+			t.Errorf("could not marshal published transaction: %v", err)
+		}
+		received <- transaction
+	}()
+
+	server := transactionServer{
+		chipKey: key,
+		feed:    feed,
+		accounts: &mock.AccountRepository{
+			ReadFunc: func(id int32) (*api.Account, error) {
+				return account, nil
+			},
+			IssueChipCounterFunc: func(id int32, seen uint32) (uint32, bool, error) {
+				return seen + 1, false, nil
+			},
+		},
+		storage: &mock.TransactionRepository{
+			CreateFunc: func(amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+				// the repositories publish the transaction they return
+				transaction := &api.Transaction{Id: 1, Amount: amount, OldSaldo: 20, NewSaldo: 20 - amount, Account: account}
+				feed.Publish(transaction)
+				return transaction, nil
+			},
+		},
+	}
+
+	got, err := server.CreateTransaction(context.Background(), &api.CreateTransactionRequest{
+		Amount:      2.5,
+		AccountId:   1,
+		ChipBalance: chip.Encode(key, uid, chip.Balance{AccountId: 1, Saldo: 20, Counter: 4}),
+	})
+	if err != nil {
+		t.Fatalf("got err %v, did not expect one", err)
+	}
+	if len(got.ChipBalance) == 0 {
+		t.Errorf("expected chip balance in the response")
+	}
+
+	transaction := <-received
+	if transaction.Id != got.Id {
+		t.Errorf("got published transaction %d, expected %d", transaction.Id, got.Id)
+	}
+	if len(transaction.ChipBalance) > 0 {
+		t.Errorf("chip balance was published to the transaction feed")
+	}
+}
+
 func TestTransactionServer_GetTransaction(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -347,6 +815,306 @@ func TestTransactionServer_GetTransaction(t *testing.T) {
 	}
 }
 
+type exportStreamMock struct {
+	grpc.ServerStream
+	sent    []*api.Transaction
+	sendErr error
+}
+
+func (e *exportStreamMock) Context() context.Context {
+	return context.Background()
+}
+
+func (e *exportStreamMock) Send(transaction *api.Transaction) error {
+	if e.sendErr != nil {
+		return e.sendErr
+	}
+	e.sent = append(e.sent, transaction)
+	return nil
+}
+
+func TestTransactionServer_SyncOfflineTransactions(t *testing.T) {
+	publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := func(k ed25519.PrivateKey, sequence uint64, accountId int32, amount float64) *api.OfflineTransaction {
+		transaction := &api.OfflineTransaction{Sequence: sequence, AccountId: accountId, Amount: amount, Created: timeStamp()}
+		offline.Sign(k, "bar-1", transaction)
+		return transaction
+	}
+
+	first := signed(key, 1, 1, 2.5)
+	second := signed(key, 2, 2, 5)
+	forged := signed(otherKey, 3, 1, 100)
+	tampered := signed(key, 4, 1, 10)
+	tampered.Amount = 0.1
+
+	tests := []struct {
+		name          string
+		input         *api.SyncOfflineTransactionsRequest
+		wantApply     []*api.OfflineTransaction
+		wantConflicts []*api.OfflineConflict
+		wantErr       error
+		terminalErr   error
+		returnErr     error
+	}{
+		{
+			name: "applies transactions in order of sequence",
+			input: &api.SyncOfflineTransactionsRequest{
+				TerminalId:   "bar-1",
+				Transactions: []*api.OfflineTransaction{second, first},
+			},
+			wantApply: []*api.OfflineTransaction{first, second},
+		},
+		{
+			name: "invalid signatures are conflicts",
+			input: &api.SyncOfflineTransactionsRequest{
+				TerminalId:   "bar-1",
+				Transactions: []*api.OfflineTransaction{first, forged, tampered},
+			},
+			wantApply: []*api.OfflineTransaction{first},
+			wantConflicts: []*api.OfflineConflict{
+				{Sequence: 3, AccountId: 1, Amount: 100, Reason: api.OfflineConflict_INVALID_SIGNATURE},
+				{Sequence: 4, AccountId: 1, Amount: 0.1, Reason: api.OfflineConflict_INVALID_SIGNATURE},
+			},
+		},
+		{
+			name:        "unknown terminal",
+			input:       &api.SyncOfflineTransactionsRequest{TerminalId: "bar-2"},
+			wantErr:     ErrTerminalNotFound,
+			terminalErr: repositories.ErrTerminalNotFound,
+		},
+		{
+			name:      "storage returns error",
+			input:     &api.SyncOfflineTransactionsRequest{TerminalId: "bar-1", Transactions: []*api.OfflineTransaction{first}},
+			wantErr:   ErrSomethingWentWrong,
+			returnErr: errors.New("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := transactionServer{
+				terminals: &mock.TerminalRepository{
+					ReadFunc: func(id string) (*api.Terminal, error) {
+						if tt.terminalErr != nil {
+							return nil, tt.terminalErr
+						}
+						return &api.Terminal{Id: id, PublicKey: publicKey}, nil
+					},
+				},
+				storage: &mock.TransactionRepository{
+					ApplyOfflineFunc: func(terminalId string, transactions []*api.OfflineTransaction) (*api.SyncOfflineTransactionsResponse, error) {
+						if tt.returnErr != nil {
+							return nil, tt.returnErr
+						}
+						if terminalId != tt.input.TerminalId {
+							t.Errorf("got terminal %q, expected %q", terminalId, tt.input.TerminalId)
+						}
+						if !reflect.DeepEqual(transactions, tt.wantApply) {
+							t.Errorf("got transactions %v, expected %v", transactions, tt.wantApply)
+						}
+						return &api.SyncOfflineTransactionsResponse{LastSequence: transactions[len(transactions)-1].Sequence}, nil
+					},
+				},
+			}
+
+			got, err := server.SyncOfflineTransactions(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("got err %v, expected %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got err %v, did not expect one", err)
+			}
+
+			if !reflect.DeepEqual(got.Conflicts, tt.wantConflicts) {
+				t.Errorf("got conflicts %v, expected %v", got.Conflicts, tt.wantConflicts)
+			}
+			if got.LastSequence != tt.wantApply[len(tt.wantApply)-1].Sequence {
+				t.Errorf("got last sequence %d, expected %d", got.LastSequence, tt.wantApply[len(tt.wantApply)-1].Sequence)
+			}
+		})
+	}
+}
+
+func TestTransactionServer_ExportTransactions(t *testing.T) {
+	from := time.Date(2019, 01, 01, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2019, 02, 01, 0, 0, 0, 0, time.UTC)
+	fromProto, _ := ptypes.TimestampProto(from)
+	toProto, _ := ptypes.TimestampProto(to)
+	sendErr := status.Error(codes.Canceled, "context canceled")
+
+	tests := []struct {
+		name       string
+		input      *api.ExportTransactionsRequest
+		wantFilter repositories.TransactionFilter
+		want       []*api.Transaction
+		wantErr    error
+		returnErr  error
+		sendErr    error
+	}{
+		{
+			name:  "export all transactions",
+			input: &api.ExportTransactionsRequest{},
+			want:  genTransactionModels(3, 1),
+		},
+		{
+			name: "export transactions with filter",
+			input: &api.ExportTransactionsRequest{
+				From:      fromProto,
+				To:        toProto,
+				GroupId:   2,
+				AccountId: 1,
+				Order:     "asc",
+			},
+			wantFilter: repositories.TransactionFilter{AccountId: 1, GroupId: 2, From: from, To: to, Order: "asc"},
+			want:       genTransactionModels(3, 1),
+		},
+		{
+			name: "from is after to",
+			input: &api.ExportTransactionsRequest{
+				From: toProto,
+				To:   fromProto,
+			},
+			wantErr: ErrInvalidTimeRange,
+		},
+		{
+			name:      "storage returns error",
+			input:     &api.ExportTransactionsRequest{},
+			wantErr:   ErrSomethingWentWrong,
+			returnErr: errors.New("test error"),
+		},
+		{
+			name:    "stream returns error",
+			input:   &api.ExportTransactionsRequest{},
+			want:    genTransactionModels(3, 1),
+			wantErr: sendErr,
+			sendErr: sendErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := transactionServer{
+				storage: &mock.TransactionRepository{
+					ExportFunc: func(filter repositories.TransactionFilter, fn func(*api.Transaction) error) error {
+						if tt.returnErr != nil {
+							return tt.returnErr
+						}
+						if !reflect.DeepEqual(filter, tt.wantFilter) {
+							t.Errorf("got filter %v, expected %v", filter, tt.wantFilter)
+						}
+						for _, transaction := range tt.want {
+							if err := fn(transaction); err != nil {
+								return err
+							}
+						}
+						return nil
+					},
+				},
+			}
+			stream := &exportStreamMock{sendErr: tt.sendErr}
+
+			err := server.ExportTransactions(tt.input, stream)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("got err %v, expected %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got err %v, did not expect one", err)
+			}
+
+			if !reflect.DeepEqual(stream.sent, tt.want) {
+				t.Errorf("got %v, expected %v", stream.sent, tt.want)
+			}
+		})
+	}
+}
+
+type watchStreamMock struct {
+	grpc.ServerStream
+	ctx   context.Context
+	ready chan struct{}
+	sent  chan *api.Transaction
+}
+
+func (w *watchStreamMock) Context() context.Context {
+	return w.ctx
+}
+
+// SendHeader closes ready, WatchTransactions is subscribed to the feed at this point
+func (w *watchStreamMock) SendHeader(metadata.MD) error {
+	close(w.ready)
+	return nil
+}
+
+func (w *watchStreamMock) Send(transaction *api.Transaction) error {
+	w.sent <- transaction
+	return nil
+}
+
+func TestTransactionServer_WatchTransactions(t *testing.T) {
+	startWatch := func(feed *broker.TransactionBroker, req *api.WatchTransactionsRequest) (*watchStreamMock, context.CancelFunc, chan error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &watchStreamMock{ctx: ctx, ready: make(chan struct{}), sent: make(chan *api.Transaction, 10)}
+		server := transactionServer{feed: feed}
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.WatchTransactions(req, stream)
+		}()
+		<-stream.ready
+		return stream, cancel, errCh
+	}
+
+	t.Run("sends matching transactions until client disconnects", func(t *testing.T) {
+		feed := broker.NewTransactionBroker(10)
+		stream, cancel, errCh := startWatch(feed, &api.WatchTransactionsRequest{TerminalId: "kiosk-1"})
+
+		want := &api.Transaction{Id: 2, TerminalId: "kiosk-1", Account: &api.Account{Id: 1}}
+		feed.Publish(&api.Transaction{Id: 1, TerminalId: "kiosk-2", Account: &api.Account{Id: 1}})
+		feed.Publish(want)
+
+		if got := <-stream.sent; !proto.Equal(got, want) {
+			t.Errorf("got transaction %v, expected %v", got, want)
+		}
+
+		cancel()
+		if err := <-errCh; err != nil {
+			t.Errorf("got err %v, did not expect one", err)
+		}
+	})
+
+	t.Run("returns error if feed drops the stream", func(t *testing.T) {
+		feed := broker.NewTransactionBroker(0)
+		_, cancel, errCh := startWatch(feed, &api.WatchTransactionsRequest{})
+		defer cancel()
+
+		// buffer size 0 drops every subscriber that is not waiting on the channel,
+		// publish until the handler is dropped
+		for {
+			feed.Publish(&api.Transaction{Id: 1, Account: &api.Account{Id: 1}})
+			select {
+			case err := <-errCh:
+				if err != ErrTransactionFeedLagged {
+					t.Errorf("got err %v, expected %v", err, ErrTransactionFeedLagged)
+				}
+				return
+			default:
+			}
+		}
+	})
+}
+
 func genTransactionModels(num int, accountId int32) []*api.Transaction {
 	account := &api.Account{Id: accountId}
 	var transactions []*api.Transaction
@@ -3,17 +3,45 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"net/url"
 	"os"
 	"time"
 )
 
-func CreateDsn(user, password, host, name string) string {
-	return fmt.Sprintf("%s:%s@tcp(%s)/%s?parseTime=true&multiStatements=true", user, password, host, name)
+// supported database drivers
+const (
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// CreateDsn returns the dsn for driver, for SQLite name is the path of the database file and the other values are ignored
+func CreateDsn(driver, user, password, host, name string) (string, error) {
+	switch driver {
+	case MySQL:
+		return fmt.Sprintf("%s:%s@tcp(%s)/%s?parseTime=true&multiStatements=true", user, password, host, name), nil
+	case Postgres:
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(user, password),
+			Host:     host,
+			Path:     name,
+			RawQuery: "sslmode=disable",
+		}
+		return dsn.String(), nil
+	case SQLite:
+		// foreign keys are off by default in sqlite, immediate transactions take the write lock when they begin,
+		// so read-modify-write transactions do not need SELECT ... FOR UPDATE
+		return fmt.Sprintf("file:%s?_foreign_keys=1&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate", name), nil
+	default:
+		return "", fmt.Errorf("unknown database driver %q, supported drivers: %s, %s, %s", driver, MySQL, Postgres, SQLite)
+	}
 }
 
-func OpenDatabase(dsn string) (*sql.DB, error) {
+// OpenDatabase opens the database with driver and waits until it is reachable
+func OpenDatabase(driver, dsn string) (*sql.DB, error) {
 	populated := os.ExpandEnv(dsn)
-	db, err := sql.Open("mysql", populated)
+	db, err := sql.Open(sqlDriverName(driver), populated)
 	if err != nil {
 		return nil, err
 	}
@@ -25,6 +53,14 @@ func OpenDatabase(dsn string) (*sql.DB, error) {
 	return db, nil
 }
 
+// sqlDriverName returns the name driver is registered with in database/sql
+func sqlDriverName(driver string) string {
+	if driver == SQLite {
+		return "sqlite3"
+	}
+	return driver
+}
+
 func ping(db *sql.DB) error {
 	if err := db.Ping(); err != nil {
 		time.Sleep(1 * time.Second)
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// ErrLockTimeout is returned if the migration lock is not released by another server in time
+var ErrLockTimeout = errors.New("migration lock is held by another server")
+
+// lockRetryInterval is the time between two attempts to take the migration lock
+const lockRetryInterval = time.Second
+
+// LockMigrations takes the migration lock of databaseName and waits until ctx is done if another server holds it.
+// The lock belongs to a connection of db, it is released by unlock or when the connection closes.
+// SQLite has no lock between processes, a sqlite database is expected to be migrated by the one server that uses it
+func LockMigrations(ctx context.Context, db *sql.DB, driver, databaseName string) (unlock func() error, err error) {
+	name := fmt.Sprintf("nfc-cash-system:migrate:%s", databaseName)
+
+	var tryLock, release string
+	var key interface{}
+	switch driver {
+	case MySQL:
+		tryLock, release, key = "SELECT COALESCE(GET_LOCK(?, 0), 0) = 1", "SELECT RELEASE_LOCK(?)", name
+	case Postgres:
+		// advisory locks of postgres are scoped to the database already, their key is a number
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(name))
+		tryLock, release, key = "SELECT pg_try_advisory_lock($1)", "SELECT pg_advisory_unlock($1)", int64(h.Sum64())
+	case SQLite:
+		return func() error { return nil }, nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", driver)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, tryLock, key).Scan(&locked); err != nil {
+			conn.Close()
+			if ctx.Err() != nil {
+				return nil, ErrLockTimeout
+			}
+			return nil, fmt.Errorf("could not take migration lock: %w", err)
+		}
+		if locked {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return nil, ErrLockTimeout
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), release, key)
+		return err
+	}, nil
+}
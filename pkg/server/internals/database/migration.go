@@ -6,25 +6,66 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4"
+	migrateDatabase "github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	bindata "github.com/golang-migrate/migrate/v4/source/go_bindata"
+	"github.com/jheimbach/nfc-cash-system/migrations"
 )
 
-func initMigrationDriver(db *sql.DB, databaseName, migrationDir string) (*migrate.Migrate, error) {
-	driver, err := mysql.WithInstance(db, &mysql.Config{})
+// initMigrationDriver returns the migration instance for db and its source, migrationDir has to contain the migrations of driver.
+// An empty migrationDir uses the migrations embedded into the binary
+func initMigrationDriver(db *sql.DB, driver, databaseName, migrationDir string) (*migrate.Migrate, source.Driver, error) {
+	var instance migrateDatabase.Driver
+	var err error
+	switch driver {
+	case MySQL:
+		instance, err = mysql.WithInstance(db, &mysql.Config{})
+	case Postgres:
+		instance, err = postgres.WithInstance(db, &postgres.Config{})
+	case SQLite:
+		instance, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	default:
+		err = fmt.Errorf("unknown database driver %q", driver)
+	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file://%s", migrationDir), databaseName, driver)
+	src, sourceName, err := openMigrationSource(driver, migrationDir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	m, err := migrate.NewWithInstance(sourceName, src, databaseName, instance)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, src, nil
+}
+
+// openMigrationSource returns the source of the migrations of driver in migrationDir, or the embedded migrations if it is empty
+func openMigrationSource(driver, migrationDir string) (src source.Driver, name string, err error) {
+	if migrationDir != "" {
+		src, err = source.Open(fmt.Sprintf("file://%s", migrationDir))
+		return src, "file", err
+	}
+
+	names := migrations.Names(driver)
+	if len(names) == 0 {
+		return nil, "", fmt.Errorf("no migrations embedded for database driver %q", driver)
 	}
-	return m, nil
+	src, err = bindata.WithInstance(bindata.Resource(names, func(name string) ([]byte, error) {
+		return migrations.Asset(driver, name)
+	}))
+	return src, "go-bindata", err
 }
 
-func UpdateDatabase(db *sql.DB, databaseName, migrationDir string, force bool) error {
-	m, err := initMigrationDriver(db, databaseName, migrationDir)
+func UpdateDatabase(db *sql.DB, driver, databaseName, migrationDir string, force bool) error {
+	m, _, err := initMigrationDriver(db, driver, databaseName, migrationDir)
 	if err != nil {
 		return err
 	}
@@ -41,8 +82,8 @@ func UpdateDatabase(db *sql.DB, databaseName, migrationDir string, force bool) e
 	return checkMigrationError(err)
 }
 
-func DowngradeDatabase(db *sql.DB, databaseName, migrationDir string) error {
-	m, err := initMigrationDriver(db, databaseName, migrationDir)
+func DowngradeDatabase(db *sql.DB, driver, databaseName, migrationDir string) error {
+	m, _, err := initMigrationDriver(db, driver, databaseName, migrationDir)
 	if err != nil {
 		return err
 	}
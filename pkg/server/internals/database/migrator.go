@@ -0,0 +1,188 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// migration errors
+var (
+	ErrInvalidSteps         = errors.New("number of migrations to roll back has to be at least 1")
+	ErrUnknownVersion       = errors.New("version is not a migration")
+	ErrInvalidMigrationName = errors.New("migration name may only contain lowercase letters, digits and underscores")
+)
+
+// migrationVersionFormat is the format of the timestamp that versions new migrations
+const migrationVersionFormat = "20060102150405"
+
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Migration is a migration of the migration source
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// MigrationStatus is the migration state of a database, Version is 0 if no migration is applied.
+// A dirty database has a failed migration at Version that has to be repaired by hand and then forced
+type MigrationStatus struct {
+	Version    uint
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Migrator migrates a database with the migrations of a directory or the embedded migrations.
+// Unlike UpdateDatabase it never forces a dirty version, that is left to the operator
+type Migrator struct {
+	migrate *migrate.Migrate
+	source  source.Driver
+}
+
+// NewMigrator returns a Migrator for db, migrationDir has to contain the migrations of driver.
+// An empty migrationDir uses the migrations embedded into the binary
+func NewMigrator(db *sql.DB, driver, databaseName, migrationDir string) (*Migrator, error) {
+	m, src, err := initMigrationDriver(db, driver, databaseName, migrationDir)
+	if err != nil {
+		return nil, err
+	}
+	m.Log = migrationLogger{}
+
+	return &Migrator{migrate: m, source: src}, nil
+}
+
+// Up applies all migrations that are not applied yet, a dirty database is not migrated
+func (m *Migrator) Up() error {
+	return dirtyError(checkMigrationError(m.migrate.Up()))
+}
+
+// Down rolls back the last steps applied migrations, nothing is rolled back if fewer migrations are applied
+func (m *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return ErrInvalidSteps
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return dirtyError(migrate.ErrDirty{Version: int(status.Version)})
+	}
+	applied := 0
+	for _, migration := range status.Migrations {
+		if migration.Applied {
+			applied++
+		}
+	}
+	if steps > applied {
+		return fmt.Errorf("only %d migrations are applied, could not roll back %d", applied, steps)
+	}
+
+	return dirtyError(checkMigrationError(m.migrate.Steps(-steps)))
+}
+
+// Force sets the version of the database without running migrations and marks it as not dirty,
+// version has to be a migration of the source or 0, which marks no migration as applied
+func (m *Migrator) Force(version uint) error {
+	if version == 0 {
+		return m.migrate.Force(-1)
+	}
+
+	r, _, err := m.source.ReadUp(version)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+		}
+		return err
+	}
+	r.Close()
+
+	return m.migrate.Force(int(version))
+}
+
+// Status returns the version of the database and all migrations of the source
+func (m *Migrator) Status() (*MigrationStatus, error) {
+	status := &MigrationStatus{}
+
+	version, dirty, err := m.migrate.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return nil, err
+	}
+	status.Version, status.Dirty = version, dirty
+
+	next, err := m.source.First()
+	for err == nil {
+		r, name, readErr := m.source.ReadUp(next)
+		if readErr != nil {
+			return nil, readErr
+		}
+		r.Close()
+
+		status.Migrations = append(status.Migrations, Migration{
+			Version: next,
+			Name:    name,
+			Applied: next < version || (next == version && !dirty),
+		})
+		next, err = m.source.Next(next)
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// CreateMigration creates empty up and down files of a new migration for every driver in migrationsDir,
+// the migration is versioned with now and the paths of the files are returned
+func CreateMigration(migrationsDir, name string, now time.Time) ([]string, error) {
+	if !migrationName.MatchString(name) {
+		return nil, ErrInvalidMigrationName
+	}
+
+	var paths []string
+	prefix := fmt.Sprintf("%s_%s", now.UTC().Format(migrationVersionFormat), name)
+	for _, driver := range []string{MySQL, Postgres, SQLite} {
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(migrationsDir, driver, fmt.Sprintf("%s.%s.sql", prefix, direction))
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+			if err != nil {
+				return paths, err
+			}
+			paths = append(paths, path)
+			if err := f.Close(); err != nil {
+				return paths, err
+			}
+		}
+	}
+	return paths, nil
+}
+
+// dirtyError explains how to repair a dirty database
+func dirtyError(err error) error {
+	var dirty migrate.ErrDirty
+	if errors.As(err, &dirty) {
+		return fmt.Errorf("database is dirty at version %d, repair the failed migration by hand and force the repaired version: %w", dirty.Version, err)
+	}
+	return err
+}
+
+// migrationLogger logs the migrations that are applied
+type migrationLogger struct{}
+
+func (migrationLogger) Printf(format string, v ...interface{}) {
+	log.Printf("migration "+format, v...)
+}
+
+func (migrationLogger) Verbose() bool {
+	return false
+}
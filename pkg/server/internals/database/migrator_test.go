@@ -0,0 +1,158 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	isPkg "github.com/matryer/is"
+)
+
+// testMigrations are the up and down statements of the sqlite migrations in the migration directory of testMigrator
+var testMigrations = []struct {
+	file string
+	sql  string
+}{
+	{"1_first.up.sql", "CREATE TABLE first (id INTEGER);"},
+	{"1_first.down.sql", "DROP TABLE first;"},
+	{"2_second.up.sql", "CREATE TABLE second (id INTEGER);"},
+	{"2_second.down.sql", "DROP TABLE second;"},
+}
+
+func testMigrator(t *testing.T) (*Migrator, *sql.DB, func()) {
+	dir, err := ioutil.TempDir("", "nfc-cash-system-migrations")
+	if err != nil {
+		t.Fatalf("could not create directory: %v", err)
+	}
+	teardown := func() { _ = os.RemoveAll(dir) }
+
+	for _, m := range testMigrations {
+		if err := ioutil.WriteFile(filepath.Join(dir, m.file), []byte(m.sql), 0644); err != nil {
+			teardown()
+			t.Fatalf("could not write migration: %v", err)
+		}
+	}
+
+	dsn, _ := CreateDsn(SQLite, "", "", "", filepath.Join(dir, "test.db"))
+	db, err := OpenDatabase(SQLite, dsn)
+	if err != nil {
+		teardown()
+		t.Fatalf("could not open database: %v", err)
+	}
+
+	migrator, err := NewMigrator(db, SQLite, "test", dir)
+	if err != nil {
+		db.Close()
+		teardown()
+		t.Fatalf("could not create migrator: %v", err)
+	}
+
+	return migrator, db, func() {
+		db.Close()
+		teardown()
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	is := isPkg.New(t)
+	migrator, db, teardown := testMigrator(t)
+	defer teardown()
+
+	status, err := migrator.Status()
+	is.NoErr(err)
+	is.Equal(status, &MigrationStatus{Migrations: []Migration{{1, "first", false}, {2, "second", false}}})
+
+	is.NoErr(migrator.Up())
+	status, err = migrator.Status()
+	is.NoErr(err)
+	is.Equal(status, &MigrationStatus{Version: 2, Migrations: []Migration{{1, "first", true}, {2, "second", true}}})
+
+	err = migrator.Down(3)
+	is.True(err != nil) // can not roll back more migrations than are applied
+	status, err = migrator.Status()
+	is.NoErr(err)
+	is.Equal(status.Version, uint(2)) // nothing is rolled back
+
+	is.True(errors.Is(migrator.Down(0), ErrInvalidSteps))
+
+	is.NoErr(migrator.Down(1))
+	status, err = migrator.Status()
+	is.NoErr(err)
+	is.Equal(status.Version, uint(1))
+	_, err = db.Exec("SELECT id FROM second")
+	is.True(err != nil) // second migration is rolled back
+
+	is.True(errors.Is(migrator.Force(3), ErrUnknownVersion))
+	is.NoErr(migrator.Force(0))
+	status, err = migrator.Status()
+	is.NoErr(err)
+	is.Equal(status.Version, uint(0))
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	is := isPkg.New(t)
+	migrator, db, teardown := testMigrator(t)
+	defer teardown()
+
+	is.NoErr(migrator.Up())
+	_, err := db.Exec("UPDATE schema_migrations SET dirty = 1")
+	is.NoErr(err)
+
+	status, err := migrator.Status()
+	is.NoErr(err)
+	is.Equal(status, &MigrationStatus{Version: 2, Dirty: true, Migrations: []Migration{{1, "first", true}, {2, "second", false}}})
+
+	is.True(migrator.Up() != nil)    // dirty database is not migrated
+	is.True(migrator.Down(1) != nil) // dirty database is not rolled back
+	is.NoErr(migrator.Force(2))      // operator marks the repaired version
+	is.NoErr(migrator.Down(1))
+}
+
+func TestMigrator_Embedded(t *testing.T) {
+	is := isPkg.New(t)
+	_, db, teardown := testMigrator(t)
+	defer teardown()
+
+	migrator, err := NewMigrator(db, SQLite, "test", "")
+	is.NoErr(err)
+	is.NoErr(migrator.Up())
+
+	status, err := migrator.Status()
+	is.NoErr(err)
+	is.True(len(status.Migrations) > 0)
+	is.Equal(status.Version, status.Migrations[len(status.Migrations)-1].Version) // all embedded migrations are applied
+
+	_, err = db.Exec("SELECT id, saldo FROM accounts")
+	is.NoErr(err) // schema is created by the embedded migrations
+
+	_, err = NewMigrator(db, "oracle", "test", "")
+	is.True(err != nil)
+}
+
+func TestCreateMigration(t *testing.T) {
+	is := isPkg.New(t)
+	dir, err := ioutil.TempDir("", "nfc-cash-system-migrations")
+	is.NoErr(err)
+	defer os.RemoveAll(dir)
+
+	for _, driver := range []string{MySQL, Postgres, SQLite} {
+		is.NoErr(os.Mkdir(filepath.Join(dir, driver), 0755))
+	}
+
+	now := time.Date(2020, 4, 1, 12, 30, 0, 0, time.UTC)
+	paths, err := CreateMigration(dir, "add_tips", now)
+	is.NoErr(err)
+	is.Equal(len(paths), 6)
+	is.Equal(paths[0], filepath.Join(dir, MySQL, "20200401123000_add_tips.up.sql"))
+	is.Equal(paths[5], filepath.Join(dir, SQLite, "20200401123000_add_tips.down.sql"))
+
+	_, err = CreateMigration(dir, "add_tips", now)
+	is.True(os.IsExist(err)) // existing migration is not overwritten
+
+	_, err = CreateMigration(dir, "Add Tips", now)
+	is.Equal(err, ErrInvalidMigrationName)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 
 	"github.com/docker/go-connections/nat"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/database"
@@ -17,7 +19,11 @@ const (
 	MysqlUser           = "test"
 	MysqlPassword       = "test"
 	MysqlDatabase       = "mysql_test"
-	defaultMigrationDir = "./migrations"
+	defaultMigrationDir = "./migrations/mysql"
+
+	PostgresUser     = "test"
+	PostgresPassword = "test"
+	PostgresDatabase = "postgres_test"
 )
 
 func StartDbContainer(networkName string) (local, network string, err error) {
@@ -81,16 +87,97 @@ func DbConnection(migrationsPath string) (db *sql.DB, teardown func(), err error
 }
 
 func OpenAndMigrateDatabase(addr string, migrationDir string) (*sql.DB, error) {
-	db, err := database.OpenDatabase(database.CreateDsn(MysqlUser, MysqlPassword, addr, MysqlDatabase))
+	if migrationDir == "" {
+		migrationDir = defaultMigrationDir
+	}
+
+	return openAndMigrate(database.MySQL, MysqlUser, MysqlPassword, addr, MysqlDatabase, migrationDir)
+}
+
+// StartPostgresContainer starts a postgres container and returns the address of the database
+func StartPostgresContainer() (string, error) {
+	ctx := context.Background()
+	postgresPort, err := nat.NewPort("tcp", "5432")
 	if err != nil {
-		return nil, fmt.Errorf("could not conntect to database: %w", err)
+		return "", err
 	}
 
-	if migrationDir == "" {
-		migrationDir = defaultMigrationDir
+	postgresC, err := tc.GenericContainer(ctx, tc.GenericContainerRequest{
+		ContainerRequest: tc.ContainerRequest{
+			Image:        "postgres",
+			ExposedPorts: []string{postgresPort.Port()},
+			Env: map[string]string{
+				"POSTGRES_USER":     PostgresUser,
+				"POSTGRES_PASSWORD": PostgresPassword,
+				"POSTGRES_DB":       PostgresDatabase,
+			},
+			WaitingFor: wait.ForAll(
+				wait.ForLog("database system is ready to accept connections"),
+				wait.ForListeningPort(postgresPort),
+			),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not start container: %w", err)
+	}
+
+	mappedPort, err := postgresC.MappedPort(ctx, postgresPort)
+	if err != nil {
+		return "", fmt.Errorf("could not get internal endpoint address: %w", err)
+	}
+
+	return net.JoinHostPort("localhost", mappedPort.Port()), nil
+}
+
+// PostgresConnection starts a postgres container and returns the migrated database
+func PostgresConnection(migrationDir string) (db *sql.DB, teardown func(), err error) {
+	addr, err := StartPostgresContainer()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err = openAndMigrate(database.Postgres, PostgresUser, PostgresPassword, addr, PostgresDatabase, migrationDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, func() {
+		db.Close()
+	}, nil
+}
+
+// SqliteConnection returns a migrated sqlite database in a temporary file, teardown closes and removes it
+func SqliteConnection(migrationDir string) (db *sql.DB, teardown func(), err error) {
+	dir, err := ioutil.TempDir("", "nfc-cash-system-sqlite")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err = openAndMigrate(database.SQLite, "", "", "", filepath.Join(dir, "test.db"), migrationDir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, nil, err
+	}
+
+	return db, func() {
+		db.Close()
+		_ = os.RemoveAll(dir)
+	}, nil
+}
+
+func openAndMigrate(driver, user, password, addr, name, migrationDir string) (*sql.DB, error) {
+	dsn, err := database.CreateDsn(driver, user, password, addr, name)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := database.OpenDatabase(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("could not conntect to database: %w", err)
 	}
 
-	err = database.UpdateDatabase(db, MysqlDatabase, migrationDir, false)
+	err = database.UpdateDatabase(db, driver, name, migrationDir, false)
 	if err != nil {
 		return nil, fmt.Errorf("could not migrate database: %w", err)
 	}
@@ -4,24 +4,38 @@ import (
 	"context"
 
 	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
 )
 
 type AccountRepository struct {
-	CreateFunc      func(string, string, float64, int32, string) (*api.Account, error)
-	GetAllFunc      func(int32, int32, int32) ([]*api.Account, int, error)
-	GetAllByIdsFunc func([]int32) (map[int32]*api.Account, error)
-	ReadFunc        func(int32) (*api.Account, error)
-	DeleteFunc      func(int32) error
-	UpdateFunc      func(*api.Account) (*api.Account, error)
-	UpdateSaldoFunc func(*api.Account, float64) error
+	CreateFunc           func(string, string, float64, int32, string) (*api.Account, error)
+	GetAllFunc           func(repositories.AccountFilter, int32, int32) ([]*api.Account, int, error)
+	GetPageFunc          func(repositories.AccountFilter, *repositories.Cursor, int32) ([]*api.Account, error)
+	CountFunc            func(repositories.AccountFilter) (int, error)
+	GetAllByIdsFunc      func([]int32) (map[int32]*api.Account, error)
+	ReadFunc             func(int32) (*api.Account, error)
+	ReadByNfcChipIdFunc  func(string) (*api.Account, error)
+	DeleteFunc           func(int32) error
+	UpdateFunc           func(*api.Account) (*api.Account, error)
+	UpdateSaldoFunc      func(*api.Account, float64) error
+	ImportFunc           func([]*api.CreateAccountRequest, bool) ([]*api.Account, error)
+	IssueChipCounterFunc func(int32, uint32) (uint32, bool, error)
 }
 
 func (a *AccountRepository) Create(_ context.Context, name, description string, startSaldo float64, groupId int32, nfcChipId string) (*api.Account, error) {
 	return a.CreateFunc(name, description, startSaldo, groupId, nfcChipId)
 }
 
-func (a *AccountRepository) GetAll(_ context.Context, groupId, limit, offset int32) ([]*api.Account, int, error) {
-	return a.GetAllFunc(groupId, limit, offset)
+func (a *AccountRepository) GetAll(_ context.Context, filter repositories.AccountFilter, limit, offset int32) ([]*api.Account, int, error) {
+	return a.GetAllFunc(filter, limit, offset)
+}
+
+func (a *AccountRepository) GetPage(_ context.Context, filter repositories.AccountFilter, after *repositories.Cursor, size int32) ([]*api.Account, error) {
+	return a.GetPageFunc(filter, after, size)
+}
+
+func (a *AccountRepository) Count(_ context.Context, filter repositories.AccountFilter) (int, error) {
+	return a.CountFunc(filter)
 }
 
 func (a *AccountRepository) GetAllByIds(_ context.Context, ids []int32) (map[int32]*api.Account, error) {
@@ -32,6 +46,10 @@ func (a *AccountRepository) Read(_ context.Context, id int32) (*api.Account, err
 	return a.ReadFunc(id)
 }
 
+func (a *AccountRepository) ReadByNfcChipId(_ context.Context, nfcChipId string) (*api.Account, error) {
+	return a.ReadByNfcChipIdFunc(nfcChipId)
+}
+
 func (a *AccountRepository) Delete(_ context.Context, id int32) error {
 	return a.DeleteFunc(id)
 }
@@ -43,3 +61,11 @@ func (a *AccountRepository) Update(_ context.Context, m *api.Account) (*api.Acco
 func (a *AccountRepository) UpdateSaldo(_ context.Context, m *api.Account, newSaldo float64) error {
 	return a.UpdateSaldoFunc(m, newSaldo)
 }
+
+func (a *AccountRepository) Import(_ context.Context, accounts []*api.CreateAccountRequest, dryRun bool) ([]*api.Account, error) {
+	return a.ImportFunc(accounts, dryRun)
+}
+
+func (a *AccountRepository) IssueChipCounter(_ context.Context, id int32, seen uint32) (uint32, bool, error) {
+	return a.IssueChipCounterFunc(id, seen)
+}
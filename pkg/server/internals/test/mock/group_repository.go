@@ -4,12 +4,15 @@ import (
 	"context"
 
 	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
 )
 
 type GroupRepository struct {
 	GetAllByIdsFunc func(ids []int32) (map[int32]*api.Group, error)
 	CreateFunc      func(string, string, bool) (*api.Group, error)
 	GetAllFunc      func(int32, int32) ([]*api.Group, int, error)
+	GetPageFunc     func(*repositories.Cursor, int32) ([]*api.Group, error)
+	CountFunc       func() (int, error)
 	ReadFunc        func(int32) (*api.Group, error)
 	UpdateFunc      func(*api.Group) (*api.Group, error)
 	DeleteFunc      func(int32) error
@@ -27,6 +30,14 @@ func (g *GroupRepository) GetAll(_ context.Context, limit, offset int32) ([]*api
 	return g.GetAllFunc(limit, offset)
 }
 
+func (g *GroupRepository) GetPage(_ context.Context, after *repositories.Cursor, size int32) ([]*api.Group, error) {
+	return g.GetPageFunc(after, size)
+}
+
+func (g *GroupRepository) Count(_ context.Context) (int, error) {
+	return g.CountFunc()
+}
+
 func (g *GroupRepository) Read(_ context.Context, id int32) (*api.Group, error) {
 	return g.ReadFunc(id)
 }
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+type ReportRepository struct {
+	RevenueFunc            func(time.Time, time.Time, api.RevenueRequest_Grouping) ([]*api.RevenueBucket, error)
+	CashFlowFunc           func(time.Time, time.Time) (*api.CashFlowReport, error)
+	OutstandingBalanceFunc func(time.Time) (*api.BalanceReport, error)
+	AverageSpendFunc       func(time.Time, time.Time) (*api.AverageSpendReport, error)
+}
+
+func (r *ReportRepository) Revenue(_ context.Context, from, to time.Time, groupBy api.RevenueRequest_Grouping) ([]*api.RevenueBucket, error) {
+	return r.RevenueFunc(from, to, groupBy)
+}
+
+func (r *ReportRepository) CashFlow(_ context.Context, from, to time.Time) (*api.CashFlowReport, error) {
+	return r.CashFlowFunc(from, to)
+}
+
+func (r *ReportRepository) OutstandingBalance(_ context.Context, at time.Time) (*api.BalanceReport, error) {
+	return r.OutstandingBalanceFunc(at)
+}
+
+func (r *ReportRepository) AverageSpend(_ context.Context, from, to time.Time) (*api.AverageSpendReport, error) {
+	return r.AverageSpendFunc(from, to)
+}
@@ -0,0 +1,20 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+type TerminalRepository struct {
+	RegisterFunc func(string, string, []byte) (*api.Terminal, error)
+	ReadFunc     func(string) (*api.Terminal, error)
+}
+
+func (t *TerminalRepository) Register(_ context.Context, id, name string, publicKey []byte) (*api.Terminal, error) {
+	return t.RegisterFunc(id, name, publicKey)
+}
+
+func (t *TerminalRepository) Read(_ context.Context, id string) (*api.Terminal, error) {
+	return t.ReadFunc(id)
+}
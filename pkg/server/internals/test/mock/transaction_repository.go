@@ -4,21 +4,35 @@ import (
 	"context"
 
 	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
 )
 
 type TransactionRepository struct {
-	CreateFunc             func(float64, int32) (*api.Transaction, error)
-	GetAllFunc             func(int32, string, int32, int32) ([]*api.Transaction, int, error)
+	CreateFunc             func(float64, int32, string) (*api.Transaction, error)
+	GetAllFunc             func(repositories.TransactionFilter, int32, int32) ([]*api.Transaction, int, error)
+	GetPageFunc            func(repositories.TransactionFilter, *repositories.Cursor, int32) ([]*api.Transaction, error)
+	CountFunc              func(repositories.TransactionFilter) (int, error)
 	ReadFunc               func(int32) (*api.Transaction, error)
 	DeleteAllByAccountFunc func(int32) error
+	ExportFunc             func(repositories.TransactionFilter, func(*api.Transaction) error) error
+	VerifyLedgerFunc       func(int32, bool) (*api.LedgerReport, error)
+	ApplyOfflineFunc       func(string, []*api.OfflineTransaction) (*api.SyncOfflineTransactionsResponse, error)
 }
 
-func (t *TransactionRepository) Create(_ context.Context, amount float64, accountId int32) (*api.Transaction, error) {
-	return t.CreateFunc(amount, accountId)
+func (t *TransactionRepository) Create(_ context.Context, amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+	return t.CreateFunc(amount, accountId, terminalId)
 }
 
-func (t *TransactionRepository) GetAll(_ context.Context, accountId int32, order string, limit, offset int32) ([]*api.Transaction, int, error) {
-	return t.GetAllFunc(accountId, order, limit, offset)
+func (t *TransactionRepository) GetAll(_ context.Context, filter repositories.TransactionFilter, limit, offset int32) ([]*api.Transaction, int, error) {
+	return t.GetAllFunc(filter, limit, offset)
+}
+
+func (t *TransactionRepository) GetPage(_ context.Context, filter repositories.TransactionFilter, after *repositories.Cursor, size int32) ([]*api.Transaction, error) {
+	return t.GetPageFunc(filter, after, size)
+}
+
+func (t *TransactionRepository) Count(_ context.Context, filter repositories.TransactionFilter) (int, error) {
+	return t.CountFunc(filter)
 }
 
 func (t *TransactionRepository) Read(_ context.Context, id int32) (*api.Transaction, error) {
@@ -28,3 +42,15 @@ func (t *TransactionRepository) Read(_ context.Context, id int32) (*api.Transact
 func (t *TransactionRepository) DeleteAllByAccount(_ context.Context, accountId int32) error {
 	return t.DeleteAllByAccountFunc(accountId)
 }
+
+func (t *TransactionRepository) Export(_ context.Context, filter repositories.TransactionFilter, fn func(*api.Transaction) error) error {
+	return t.ExportFunc(filter, fn)
+}
+
+func (t *TransactionRepository) VerifyLedger(_ context.Context, accountId int32, fix bool) (*api.LedgerReport, error) {
+	return t.VerifyLedgerFunc(accountId, fix)
+}
+
+func (t *TransactionRepository) ApplyOffline(_ context.Context, terminalId string, transactions []*api.OfflineTransaction) (*api.SyncOfflineTransactionsResponse, error) {
+	return t.ApplyOfflineFunc(terminalId, transactions)
+}
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/database"
+)
+
+// QueryCounter counts the statements that a database of CountingSqliteConnection sends to the driver
+type QueryCounter struct {
+	count int64
+}
+
+// Count returns the number of statements since the last Reset
+func (c *QueryCounter) Count() int {
+	return int(atomic.LoadInt64(&c.count))
+}
+
+// Reset sets the number of statements back to zero
+func (c *QueryCounter) Reset() {
+	atomic.StoreInt64(&c.count, 0)
+}
+
+func (c *QueryCounter) add() {
+	atomic.AddInt64(&c.count, 1)
+}
+
+// CountingSqliteConnection returns a migrated sqlite database in a temporary file like SqliteConnection,
+// every query and exec sent through the database is counted by the returned QueryCounter
+func CountingSqliteConnection(migrationDir string) (db *sql.DB, counter *QueryCounter, teardown func(), err error) {
+	dir, err := ioutil.TempDir("", "nfc-cash-system-sqlite")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	name := filepath.Join(dir, "test.db")
+
+	migrated, err := openAndMigrate(database.SQLite, "", "", "", name, migrationDir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, nil, nil, err
+	}
+
+	dsn, err := database.CreateDsn(database.SQLite, "", "", "", name)
+	if err != nil {
+		migrated.Close()
+		_ = os.RemoveAll(dir)
+		return nil, nil, nil, err
+	}
+
+	counter = &QueryCounter{}
+	db = sql.OpenDB(&countingConnector{driver: migrated.Driver(), dsn: dsn, counter: counter})
+
+	return db, counter, func() {
+		db.Close()
+		migrated.Close()
+		_ = os.RemoveAll(dir)
+	}, nil
+}
+
+// countingConnector opens connections of driver that count their statements
+type countingConnector struct {
+	driver  driver.Driver
+	dsn     string
+	counter *QueryCounter
+}
+
+func (c *countingConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &countingConn{Conn: conn, counter: c.counter}, nil
+}
+
+func (c *countingConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// countingConn counts queries and execs, a prepared statement is counted every time it is executed
+type countingConn struct {
+	driver.Conn
+	counter *QueryCounter
+}
+
+func (c *countingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	c.counter.add()
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (c *countingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	c.counter.add()
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *countingConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *countingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &countingStmt{Stmt: stmt, counter: c.counter}, nil
+}
+
+func (c *countingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+// countingStmt counts every execution of a prepared statement
+type countingStmt struct {
+	driver.Stmt
+	counter *QueryCounter
+}
+
+func (s *countingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	s.counter.add()
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	return s.Stmt.Exec(namedValues(args))
+}
+
+func (s *countingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	s.counter.add()
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	return s.Stmt.Query(namedValues(args))
+}
+
+// namedValues returns the values of args in their order
+func namedValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// accountKey is the cache key of an account
+type accountKey int32
+
+// chipKey is the cache key of the account id of an nfc chip, chip ids are compared case insensitive
+type chipKey string
+
+func newChipKey(nfcChipId string) chipKey {
+	return chipKey(strings.ToLower(nfcChipId))
+}
+
+// AccountRepository caches the accounts of another AccountStorager by id and nfc chip id, all other calls are passed through.
+// Changes of the saldo made by transactions are only seen if the transactions are created through TransactionRepository.
+// An account takes two entries of the cache, one for its id and one for its nfc chip id
+type AccountRepository struct {
+	repositories.AccountStorager
+	entries *lru
+	stats   *Stats
+}
+
+// NewAccountRepository returns an AccountRepository that caches the accounts of next.
+// The cached accounts contain their group, all of them are dropped if a group is changed through groups
+func NewAccountRepository(next repositories.AccountStorager, groups *GroupRepository, config Config) *AccountRepository {
+	entries := newLRU(config)
+	groups.onChange = append(groups.onChange, entries.purge)
+
+	return &AccountRepository{
+		AccountStorager: next,
+		entries:         entries,
+		stats:           &Stats{entries: entries.len},
+	}
+}
+
+// Stats returns the hits and misses of the cache
+func (a *AccountRepository) Stats() *Stats {
+	return a.stats
+}
+
+// Read returns the cached account with id or reads it from the storage
+func (a *AccountRepository) Read(ctx context.Context, id int32) (*api.Account, error) {
+	if account, ok := a.cached(id); ok {
+		a.stats.hit()
+		return account, nil
+	}
+	a.stats.miss()
+
+	version := a.entries.currentVersion()
+	account, err := a.AccountStorager.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	a.add(account, version)
+
+	return account, nil
+}
+
+// ReadByNfcChipId returns the cached account of the nfc chip or reads it from the storage
+func (a *AccountRepository) ReadByNfcChipId(ctx context.Context, nfcChipId string) (*api.Account, error) {
+	if id, ok := a.entries.get(newChipKey(nfcChipId)); ok {
+		// the chip of the account could have been changed since the chip was cached
+		if account, ok := a.cached(id.(int32)); ok && strings.EqualFold(account.NfcChipId, nfcChipId) {
+			a.stats.hit()
+			return account, nil
+		}
+	}
+	a.stats.miss()
+
+	version := a.entries.currentVersion()
+	account, err := a.AccountStorager.ReadByNfcChipId(ctx, nfcChipId)
+	if err != nil {
+		return nil, err
+	}
+	a.add(account, version)
+
+	return account, nil
+}
+
+// GetAllByIds returns the cached accounts of ids, the missing accounts are read from the storage with one call
+func (a *AccountRepository) GetAllByIds(ctx context.Context, ids []int32) (map[int32]*api.Account, error) {
+	accounts := make(map[int32]*api.Account, len(ids))
+	var missing []int32
+	for _, id := range ids {
+		if account, ok := a.cached(id); ok {
+			a.stats.hit()
+			accounts[id] = account
+			continue
+		}
+		a.stats.miss()
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return accounts, nil
+	}
+
+	version := a.entries.currentVersion()
+	loaded, err := a.AccountStorager.GetAllByIds(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for id, account := range loaded {
+		a.add(account, version)
+		accounts[id] = account
+	}
+
+	return accounts, nil
+}
+
+// Update updates the account in the storage and drops it from the cache
+func (a *AccountRepository) Update(ctx context.Context, m *api.Account) (*api.Account, error) {
+	updated, err := a.AccountStorager.Update(ctx, m)
+	a.invalidate(m.Id)
+	return updated, err
+}
+
+// Delete deletes the account from the storage and drops it from the cache
+func (a *AccountRepository) Delete(ctx context.Context, id int32) error {
+	err := a.AccountStorager.Delete(ctx, id)
+	a.invalidate(id)
+	return err
+}
+
+// UpdateSaldo updates the saldo in the storage and drops the account from the cache
+func (a *AccountRepository) UpdateSaldo(ctx context.Context, m *api.Account, newSaldo float64) error {
+	err := a.AccountStorager.UpdateSaldo(ctx, m, newSaldo)
+	a.invalidate(m.Id)
+	return err
+}
+
+// cached returns a copy of the cached account with id
+func (a *AccountRepository) cached(id int32) (*api.Account, bool) {
+	cached, ok := a.entries.get(accountKey(id))
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(cached.(*api.Account)).(*api.Account), true
+}
+
+// add caches a copy of account by its id and nfc chip id if nothing was invalidated since version
+func (a *AccountRepository) add(account *api.Account, version uint64) {
+	a.entries.add(accountKey(account.Id), proto.Clone(account), version)
+	a.entries.add(newChipKey(account.NfcChipId), account.Id, version)
+}
+
+// invalidate drops the accounts with ids, the entries of their chips are ignored once the account is missing
+func (a *AccountRepository) invalidate(ids ...int32) {
+	keys := make([]interface{}, len(ids))
+	for i, id := range ids {
+		keys[i] = accountKey(id)
+	}
+	a.entries.remove(keys...)
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/memory"
+	isPkg "github.com/matryer/is"
+)
+
+func TestAccountRepository_HitsAndMisses(t *testing.T) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+	groups, accounts, _ := newCachedStorage(memory.NewDatabase())
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	first, err := accounts.Create(ctx, "first", "", 10, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	second, err := accounts.Create(ctx, "second", "", 10, group.Id, "04d5e6f7")
+	is.NoErr(err) // could not create account
+
+	_, err = accounts.Read(ctx, first.Id)
+	is.NoErr(err)
+	got, err := accounts.Read(ctx, first.Id)
+	is.NoErr(err)
+	is.Equal(got, first)
+	is.Equal(accounts.Stats().Misses(), int64(1)) // first read is passed to the storage
+	is.Equal(accounts.Stats().Hits(), int64(1))   // second read is served from the cache
+
+	got, err = accounts.ReadByNfcChipId(ctx, "04A1B2C3")
+	is.NoErr(err)
+	is.Equal(got.Id, first.Id)
+	is.Equal(accounts.Stats().Hits(), int64(2)) // account read by id is found by its chip
+
+	byIds, err := accounts.GetAllByIds(ctx, []int32{first.Id, second.Id})
+	is.NoErr(err)
+	is.Equal(len(byIds), 2)
+	is.Equal(accounts.Stats().Hits(), int64(3))   // cached account is served from the cache
+	is.Equal(accounts.Stats().Misses(), int64(2)) // missing account is read from the storage
+
+	got.Name = "changed"
+	got, err = accounts.Read(ctx, first.Id)
+	is.NoErr(err)
+	is.Equal(got.Name, "first") // changing a returned account does not change the cache
+
+	_, err = groups.Read(ctx, group.Id)
+	is.NoErr(err)
+	_, err = groups.Read(ctx, group.Id)
+	is.NoErr(err)
+	is.Equal(groups.Stats().Hits(), int64(1))
+	is.Equal(groups.Stats().Misses(), int64(1))
+	is.Equal(groups.Stats().String(), `{"hits": 1, "misses": 1, "entries": 1}`)
+}
+
+func TestAccountRepository_Invalidation(t *testing.T) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+	groups, accounts, _ := newCachedStorage(memory.NewDatabase())
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := accounts.Create(ctx, "guest", "", 10, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	_, err = accounts.Read(ctx, account.Id)
+	is.NoErr(err) // could not read account
+
+	account.Name = "artist"
+	account.NfcChipId = "05a1b2c3"
+	_, err = accounts.Update(ctx, account)
+	is.NoErr(err) // could not update account
+
+	got, err := accounts.Read(ctx, account.Id)
+	is.NoErr(err)
+	is.Equal(got.Name, "artist") // updated account is read again
+
+	_, err = accounts.ReadByNfcChipId(ctx, "04a1b2c3")
+	is.True(err != nil) // old chip does not find the account anymore
+
+	group.Name = "visitors"
+	_, err = groups.Update(ctx, group)
+	is.NoErr(err) // could not update group
+
+	got, err = accounts.Read(ctx, account.Id)
+	is.NoErr(err)
+	is.Equal(got.Group.Name, "visitors") // accounts are dropped with their group
+
+	is.NoErr(accounts.Delete(ctx, account.Id))
+	_, err = accounts.Read(ctx, account.Id)
+	is.True(err != nil) // deleted account is not served from the cache
+}
+
+func TestTransactionRepository_Saldo(t *testing.T) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+	db := memory.NewDatabase()
+	groups, accounts, transactions := newCachedStorage(db)
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := accounts.Create(ctx, "guest", "", 20, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+
+	for _, amount := range []float64{5, 7.5} {
+		_, err = accounts.Read(ctx, account.Id)
+		is.NoErr(err) // could not cache account
+
+		// the saldo is changed by the storage of the transactions, not through the account cache
+		transaction, err := transactions.Create(ctx, amount, account.Id, "")
+		is.NoErr(err) // could not create transaction
+
+		got, err := accounts.Read(ctx, account.Id)
+		is.NoErr(err)
+		is.Equal(got.Saldo, transaction.NewSaldo) // saldo is not stale after a transaction
+	}
+
+	_, err = memory.NewTerminalRepository(db).Register(ctx, "bar-1", "bar", []byte("key"))
+	is.NoErr(err) // could not register terminal
+	_, err = accounts.ReadByNfcChipId(ctx, "04a1b2c3")
+	is.NoErr(err) // could not cache account
+
+	_, err = transactions.ApplyOffline(ctx, "bar-1", []*api.OfflineTransaction{{Sequence: 1, AccountId: account.Id, Amount: 2.5}})
+	is.NoErr(err) // could not apply offline transaction
+
+	got, err := accounts.ReadByNfcChipId(ctx, "04a1b2c3")
+	is.NoErr(err)
+	is.Equal(got.Saldo, 5.0) // saldo is not stale after an offline sync
+}
@@ -0,0 +1,5 @@
+// cache provides read-through caches for the groups and accounts of another storage backend.
+// Mutations made through the caches invalidate their entries, changes made by other processes are seen
+// once the entries expire. The transactions of the backend have to read their accounts without cache,
+// so the saldo of an account is never stale while a transaction is created
+package cache
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// GroupRepository caches the groups of another GroupStorager by id, all other calls are passed through
+type GroupRepository struct {
+	repositories.GroupStorager
+	entries *lru
+	stats   *Stats
+	// onChange is called after a group was changed or deleted
+	onChange []func()
+}
+
+// NewGroupRepository returns a GroupRepository that caches the groups of next
+func NewGroupRepository(next repositories.GroupStorager, config Config) *GroupRepository {
+	entries := newLRU(config)
+	return &GroupRepository{
+		GroupStorager: next,
+		entries:       entries,
+		stats:         &Stats{entries: entries.len},
+	}
+}
+
+// Stats returns the hits and misses of the cache
+func (g *GroupRepository) Stats() *Stats {
+	return g.stats
+}
+
+// Read returns the cached group with id or reads it from the storage
+func (g *GroupRepository) Read(ctx context.Context, id int32) (*api.Group, error) {
+	if cached, ok := g.entries.get(id); ok {
+		g.stats.hit()
+		return proto.Clone(cached.(*api.Group)).(*api.Group), nil
+	}
+	g.stats.miss()
+
+	version := g.entries.currentVersion()
+	group, err := g.GroupStorager.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	g.entries.add(id, proto.Clone(group), version)
+
+	return group, nil
+}
+
+// GetAllByIds returns the cached groups of ids, the missing groups are read from the storage with one call
+func (g *GroupRepository) GetAllByIds(ctx context.Context, ids []int32) (map[int32]*api.Group, error) {
+	if len(ids) == 0 {
+		return g.GroupStorager.GetAllByIds(ctx, ids)
+	}
+
+	groups := make(map[int32]*api.Group, len(ids))
+	var missing []int32
+	for _, id := range ids {
+		if cached, ok := g.entries.get(id); ok {
+			g.stats.hit()
+			groups[id] = proto.Clone(cached.(*api.Group)).(*api.Group)
+			continue
+		}
+		g.stats.miss()
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return groups, nil
+	}
+
+	version := g.entries.currentVersion()
+	loaded, err := g.GroupStorager.GetAllByIds(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		// keep the result of the storage if no group is known, it can be nil
+		groups = loaded
+	}
+	for id, group := range loaded {
+		g.entries.add(id, proto.Clone(group), version)
+		groups[id] = group
+	}
+
+	return groups, nil
+}
+
+// Update updates the group in the storage and drops it from the cache
+func (g *GroupRepository) Update(ctx context.Context, group *api.Group) (*api.Group, error) {
+	updated, err := g.GroupStorager.Update(ctx, group)
+	g.invalidate(group.Id)
+	return updated, err
+}
+
+// Delete deletes the group from the storage and drops it from the cache
+func (g *GroupRepository) Delete(ctx context.Context, id int32) error {
+	err := g.GroupStorager.Delete(ctx, id)
+	g.invalidate(id)
+	return err
+}
+
+// invalidate drops the group with id, even if the storage failed it could have changed the group
+func (g *GroupRepository) invalidate(id int32) {
+	g.entries.remove(id)
+	for _, fn := range g.onChange {
+		fn()
+	}
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Config limits a cache, Size is the maximum number of entries and TTL the time an entry is served.
+// The least recently used entry is dropped if a new entry does not fit, a TTL of 0 keeps entries until they are dropped
+type Config struct {
+	Size int
+	TTL  time.Duration
+}
+
+// lruEntry is an entry of the lru list
+type lruEntry struct {
+	key     interface{}
+	value   interface{}
+	expires time.Time
+}
+
+// lru is a size limited cache that drops expired and least recently used entries, it is safe for concurrent use
+type lru struct {
+	mu      sync.Mutex
+	config  Config
+	now     func() time.Time
+	entries map[interface{}]*list.Element
+	order   *list.List
+	// version is increased by every invalidation, values loaded before an invalidation are not added
+	version uint64
+}
+
+func newLRU(config Config) *lru {
+	return &lru{
+		config:  config,
+		now:     time.Now,
+		entries: make(map[interface{}]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns the value of key if it is cached and not expired
+func (c *lru) get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.removeElement(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// currentVersion returns the version a value has to be loaded at to be added
+func (c *lru) currentVersion() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.version
+}
+
+// add caches value for key if nothing was invalidated since version,
+// otherwise value could have been loaded before the invalidation and be stale
+func (c *lru) add(key, value interface{}, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if version != c.version || c.config.Size <= 0 {
+		return
+	}
+
+	var expires time.Time
+	if c.config.TTL > 0 {
+		expires = c.now().Add(c.config.TTL)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = &lruEntry{key: key, value: value, expires: expires}
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.config.Size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// remove drops the entries of keys
+func (c *lru) remove(keys ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.removeElement(element)
+		}
+	}
+}
+
+// purge drops all entries
+func (c *lru) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	c.entries = make(map[interface{}]*list.Element)
+	c.order.Init()
+}
+
+// len returns the number of cached entries, expired entries are included until they are dropped
+func (c *lru) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *lru) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
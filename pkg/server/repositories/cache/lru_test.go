@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	isPkg "github.com/matryer/is"
+)
+
+func TestLRU_TTL(t *testing.T) {
+	is := isPkg.New(t)
+
+	now := time.Date(2019, 1, 17, 12, 0, 0, 0, time.UTC)
+	c := newLRU(Config{Size: 10, TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.add(1, "one", c.currentVersion())
+	now = now.Add(59 * time.Second)
+	got, ok := c.get(1)
+	is.True(ok) // entry is served until it expires
+	is.Equal(got, "one")
+
+	now = now.Add(time.Second)
+	_, ok = c.get(1)
+	is.True(!ok)         // expired entry is not served
+	is.Equal(c.len(), 0) // expired entry is dropped
+}
+
+func TestLRU_Size(t *testing.T) {
+	is := isPkg.New(t)
+
+	c := newLRU(Config{Size: 2})
+	c.add(1, "one", c.currentVersion())
+	c.add(2, "two", c.currentVersion())
+	_, _ = c.get(1)
+	c.add(3, "three", c.currentVersion())
+
+	is.Equal(c.len(), 2)
+	_, ok := c.get(2)
+	is.True(!ok) // least recently used entry is dropped
+	_, ok = c.get(1)
+	is.True(ok) // recently used entry is kept
+	_, ok = c.get(3)
+	is.True(ok) // new entry is kept
+}
+
+func TestLRU_Disabled(t *testing.T) {
+	is := isPkg.New(t)
+
+	c := newLRU(Config{})
+	c.add(1, "one", c.currentVersion())
+	_, ok := c.get(1)
+	is.True(!ok) // size 0 caches nothing
+}
+
+func TestLRU_Invalidation(t *testing.T) {
+	is := isPkg.New(t)
+
+	c := newLRU(Config{Size: 10})
+	c.add(1, "one", c.currentVersion())
+	c.add(2, "two", c.currentVersion())
+
+	c.remove(1)
+	_, ok := c.get(1)
+	is.True(!ok) // removed entry is dropped
+	_, ok = c.get(2)
+	is.True(ok) // other entries are kept
+
+	// a value loaded before an invalidation could be stale
+	version := c.currentVersion()
+	c.remove(3)
+	c.add(3, "three", version)
+	_, ok = c.get(3)
+	is.True(!ok) // value loaded before the invalidation is not cached
+
+	c.purge()
+	is.Equal(c.len(), 0) // purge drops all entries
+}
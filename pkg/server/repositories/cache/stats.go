@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Stats counts the hits and misses of a cache, it implements expvar.Var
+type Stats struct {
+	hits    int64
+	misses  int64
+	entries func() int
+}
+
+// Hits returns the number of reads that were served from the cache
+func (s *Stats) Hits() int64 {
+	return atomic.LoadInt64(&s.hits)
+}
+
+// Misses returns the number of reads that were passed to the storage behind the cache
+func (s *Stats) Misses() int64 {
+	return atomic.LoadInt64(&s.misses)
+}
+
+// String returns the stats as json object
+func (s *Stats) String() string {
+	return fmt.Sprintf(`{"hits": %d, "misses": %d, "entries": %d}`, s.Hits(), s.Misses(), s.entries())
+}
+
+func (s *Stats) hit() {
+	atomic.AddInt64(&s.hits, 1)
+}
+
+func (s *Stats) miss() {
+	atomic.AddInt64(&s.misses, 1)
+}
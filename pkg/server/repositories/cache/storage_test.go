@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/memory"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/storagetest"
+)
+
+var testConfig = Config{Size: 100, TTL: time.Minute}
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, storagetest.Factories{
+		Groups: func(t *testing.T) (repositories.GroupStorager, func()) {
+			return NewGroupRepository(memory.NewGroupRepository(memory.NewDatabase()), testConfig), func() {}
+		},
+		Accounts: func(t *testing.T) (repositories.AccountStorager, repositories.GroupStorager, func()) {
+			groups, accounts, _ := newCachedStorage(memory.NewDatabase())
+			return accounts, groups, func() {}
+		},
+		Transactions: func(t *testing.T) (repositories.TransactionStorager, repositories.AccountStorager, repositories.GroupStorager, func()) {
+			groups, accounts, transactions := newCachedStorage(memory.NewDatabase())
+			return transactions, accounts, groups, func() {}
+		},
+	})
+}
+
+// newCachedStorage returns the cached repositories of db, the transactions of db change the saldo without the cache
+func newCachedStorage(db *memory.Database) (*GroupRepository, *AccountRepository, *TransactionRepository) {
+	groups := NewGroupRepository(memory.NewGroupRepository(db), testConfig)
+	accounts := NewAccountRepository(memory.NewAccountRepository(db), groups, testConfig)
+	transactions := NewTransactionRepository(memory.NewTransactionRepository(db, nil), accounts)
+	return groups, accounts, transactions
+}
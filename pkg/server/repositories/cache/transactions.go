@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// Transactions is the transaction storage behind TransactionRepository
+type Transactions interface {
+	repositories.TransactionStorager
+	repositories.LedgerVerifier
+}
+
+// TransactionRepository drops the cached accounts whose saldo is changed by a transaction, all calls are passed through.
+// The Transactions behind it have to read the accounts from the storage and not from the AccountRepository,
+// so transactions are always created with the current saldo
+type TransactionRepository struct {
+	Transactions
+	accounts *AccountRepository
+}
+
+// NewTransactionRepository returns a TransactionRepository that drops the accounts of the transactions of next from accounts
+func NewTransactionRepository(next Transactions, accounts *AccountRepository) *TransactionRepository {
+	return &TransactionRepository{
+		Transactions: next,
+		accounts:     accounts,
+	}
+}
+
+// Create creates the transaction in the storage and drops its account from the cache
+func (t *TransactionRepository) Create(ctx context.Context, amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+	transaction, err := t.Transactions.Create(ctx, amount, accountId, terminalId)
+	t.accounts.invalidate(accountId)
+	return transaction, err
+}
+
+// ApplyOffline applies the offline transactions in the storage and drops their accounts from the cache
+func (t *TransactionRepository) ApplyOffline(ctx context.Context, terminalId string, transactions []*api.OfflineTransaction) (*api.SyncOfflineTransactionsResponse, error) {
+	res, err := t.Transactions.ApplyOffline(ctx, terminalId, transactions)
+
+	ids := make([]int32, len(transactions))
+	for i, transaction := range transactions {
+		ids[i] = transaction.AccountId
+	}
+	t.accounts.invalidate(ids...)
+
+	return res, err
+}
+
+// VerifyLedger verifies the ledger in the storage, the fixed accounts are dropped from the cache
+func (t *TransactionRepository) VerifyLedger(ctx context.Context, accountId int32, fix bool) (*api.LedgerReport, error) {
+	report, err := t.Transactions.VerifyLedger(ctx, accountId, fix)
+	if fix {
+		if accountId > 0 {
+			t.accounts.invalidate(accountId)
+		} else {
+			t.accounts.entries.purge()
+		}
+	}
+	return report, err
+}
@@ -0,0 +1,342 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// AccountRepository provides API for the accounts of the Database
+type AccountRepository struct {
+	db *Database
+}
+
+func NewAccountRepository(db *Database) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+// Create inserts new account it returns error repositories.ErrGroupNotFound if the groupId is not associated with a group
+// it returns repositories.ErrDuplicateNfcChipId if the provided nfcchipid is already used by another account
+func (a *AccountRepository) Create(_ context.Context, name, description string, startSaldo float64, groupId int32, nfcChipId string) (*api.Account, error) {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	if _, ok := a.db.groups[groupId]; !ok {
+		return nil, repositories.ErrGroupNotFound
+	}
+	if a.db.accountByNfcChipId(nfcChipId) != nil {
+		return nil, repositories.ErrDuplicateNfcChipId
+	}
+
+	account := a.insert(name, description, startSaldo, groupId, nfcChipId)
+
+	return a.db.accountProto(account), nil
+}
+
+// insert saves a new account, db has to be locked
+func (a *AccountRepository) insert(name, description string, saldo float64, groupId int32, nfcChipId string) *account {
+	a.db.lastAccountId++
+	account := &account{
+		id:          a.db.lastAccountId,
+		name:        name,
+		description: description,
+		saldo:       cents(saldo),
+		groupId:     groupId,
+		nfcChipId:   nfcChipId,
+	}
+	a.db.accounts[account.id] = account
+
+	return account
+}
+
+// Read returns account for given id, returns repositories.ErrNotFound if the account does not exist
+func (a *AccountRepository) Read(_ context.Context, id int32) (*api.Account, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	account, ok := a.db.accounts[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return a.db.accountProto(account), nil
+}
+
+// ReadByNfcChipId returns the account of the nfc chip
+func (a *AccountRepository) ReadByNfcChipId(_ context.Context, nfcChipId string) (*api.Account, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	account := a.db.accountByNfcChipId(nfcChipId)
+	if account == nil {
+		return nil, repositories.ErrNotFound
+	}
+
+	return a.db.accountProto(account), nil
+}
+
+// Update saves the (changed) account, will return repositories.ErrGroupNotFound if group id is not associated with a group
+func (a *AccountRepository) Update(_ context.Context, m *api.Account) (*api.Account, error) {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	account, ok := a.db.accounts[m.Id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	if m.Saldo != 0 && m.Saldo != account.saldo {
+		return nil, repositories.ErrUpdateSaldo
+	}
+
+	groupId := m.GetGroup().GetId()
+	if _, ok := a.db.groups[groupId]; !ok {
+		return nil, repositories.ErrGroupNotFound
+	}
+
+	if other := a.db.accountByNfcChipId(m.NfcChipId); other != nil && other.id != account.id {
+		return nil, repositories.ErrDuplicateNfcChipId
+	}
+
+	account.name = m.Name
+	account.description = m.Description
+	account.groupId = groupId
+	account.nfcChipId = m.NfcChipId
+	account.status = m.Status
+
+	return a.db.accountProto(account), nil
+}
+
+// Delete deletes a account, returns repositories.ErrNonEmptyDelete if the account still has transactions
+func (a *AccountRepository) Delete(_ context.Context, id int32) error {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	for _, t := range a.db.transactions {
+		if t.accountId == id {
+			return repositories.ErrNonEmptyDelete
+		}
+	}
+	delete(a.db.accounts, id)
+
+	return nil
+}
+
+// UpdateSaldo provides update method for the saldo field
+func (a *AccountRepository) UpdateSaldo(_ context.Context, m *api.Account, newSaldo float64) error {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	if account, ok := a.db.accounts[m.Id]; ok {
+		account.saldo = cents(newSaldo)
+	}
+
+	return nil
+}
+
+// GetAll returns the accounts matching filter in the order of filter.
+// The total count is the number of all matching accounts
+func (a *AccountRepository) GetAll(_ context.Context, filter repositories.AccountFilter, limit int32, offset int32) ([]*api.Account, int, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	matching := a.filter(filter)
+	start, end := page(len(matching), limit, offset)
+
+	var accounts []*api.Account
+	for _, account := range matching[start:end] {
+		accounts = append(accounts, a.db.accountProto(account))
+	}
+
+	return accounts, len(matching), nil
+}
+
+// GetPage returns up to size accounts matching filter that come after the cursor in the order of filter,
+// after nil starts with the first account
+func (a *AccountRepository) GetPage(_ context.Context, filter repositories.AccountFilter, after *repositories.Cursor, size int32) ([]*api.Account, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	var cursor *account
+	if after != nil {
+		cursor = &account{id: after.Id, name: after.Name, saldo: after.Saldo}
+	}
+
+	var accounts []*api.Account
+	for _, account := range a.filter(filter) {
+		if int32(len(accounts)) >= size {
+			break
+		}
+		if cursor != nil && !accountBefore(filter, cursor, account) {
+			continue
+		}
+		accounts = append(accounts, a.db.accountProto(account))
+	}
+
+	return accounts, nil
+}
+
+// Count returns the number of accounts matching filter
+func (a *AccountRepository) Count(_ context.Context, filter repositories.AccountFilter) (int, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	return len(a.filter(filter)), nil
+}
+
+// filter returns the accounts matching filter in the order of filter, db has to be locked
+func (a *AccountRepository) filter(filter repositories.AccountFilter) []*account {
+	search := strings.ToLower(filter.Search)
+	chipPrefix := strings.ToLower(filter.NfcChipIdPrefix)
+
+	var matching []*account
+	for _, account := range a.db.sortedAccounts() {
+		if filter.GroupId > 0 && account.groupId != filter.GroupId {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(account.name), search) && !strings.Contains(strings.ToLower(account.description), search) {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(account.nfcChipId), chipPrefix) {
+			continue
+		}
+		if filter.MinSaldo != nil && account.saldo < *filter.MinSaldo {
+			continue
+		}
+		if filter.MaxSaldo != nil && account.saldo > *filter.MaxSaldo {
+			continue
+		}
+		if !hasStatus(filter.Status, account.status) {
+			continue
+		}
+		matching = append(matching, account)
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return accountBefore(filter, matching[i], matching[j])
+	})
+
+	return matching
+}
+
+// accountBefore returns true if a comes before b in the order of filter, accounts with the same sort value are ordered by id
+func accountBefore(filter repositories.AccountFilter, a, b *account) bool {
+	if filter.Descending {
+		a, b = b, a
+	}
+
+	switch {
+	case filter.SortBy == api.ListAccountsRequest_NAME && a.name != b.name:
+		return a.name < b.name
+	case filter.SortBy == api.ListAccountsRequest_SALDO && a.saldo != b.saldo:
+		return a.saldo < b.saldo
+	}
+	return a.id < b.id
+}
+
+// hasStatus returns true if status is one of statuses or statuses is empty
+func hasStatus(statuses []api.Account_Status, status api.Account_Status) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAllByIds returns map of accounts, is used by to complete objects that are dependent on accounts
+func (a *AccountRepository) GetAllByIds(_ context.Context, ids []int32) (map[int32]*api.Account, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	m := make(map[int32]*api.Account, len(ids))
+	for _, id := range ids {
+		if account, ok := a.db.accounts[id]; ok {
+			m[id] = a.db.accountProto(account)
+		}
+	}
+
+	return m, nil
+}
+
+// Import validates and creates all given accounts at once.
+// Rows without name or nfc chip id, with an unknown group or with an nfc chip id that is already
+// in use (by an account or in an earlier row) are rejected with repositories.ImportErrors, in that case nothing is saved.
+// With dryRun the accounts are only validated, returned accounts have no id.
+func (a *AccountRepository) Import(_ context.Context, rows []*api.CreateAccountRequest, dryRun bool) ([]*api.Account, error) {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	var importErrs repositories.ImportErrors
+	rejectRow := func(row int, nfcChipId string, err error) {
+		importErrs = append(importErrs, &repositories.ImportError{Row: row + 1, NfcChipId: nfcChipId, Err: err})
+	}
+
+	chips := make(map[string]bool, len(rows))
+	accounts := make([]*api.Account, 0, len(rows))
+	for i, row := range rows {
+		chip := strings.ToLower(row.NfcChipId)
+		switch {
+		case row.Name == "" || row.NfcChipId == "":
+			rejectRow(i, row.NfcChipId, repositories.ErrMissingField)
+			continue
+		case chips[chip] || a.db.accountByNfcChipId(row.NfcChipId) != nil:
+			rejectRow(i, row.NfcChipId, repositories.ErrDuplicateNfcChipId)
+			continue
+		}
+		chips[chip] = true
+
+		if _, ok := a.db.groups[row.GroupId]; !ok {
+			rejectRow(i, row.NfcChipId, repositories.ErrGroupNotFound)
+			continue
+		}
+
+		accounts = append(accounts, a.db.accountProto(&account{
+			name:        row.Name,
+			description: row.Description,
+			saldo:       row.Saldo,
+			groupId:     row.GroupId,
+			nfcChipId:   row.NfcChipId,
+		}))
+	}
+
+	if len(importErrs) > 0 {
+		return nil, importErrs
+	}
+
+	if dryRun {
+		return accounts, nil
+	}
+
+	for i, row := range rows {
+		accounts[i].Id = a.insert(row.Name, row.Description, row.Saldo, row.GroupId, row.NfcChipId).id
+	}
+
+	return accounts, nil
+}
+
+// IssueChipCounter increases the chip counter of the account past seen and returns it
+func (a *AccountRepository) IssueChipCounter(_ context.Context, id int32, seen uint32) (uint32, bool, error) {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	account, ok := a.db.accounts[id]
+	if !ok {
+		return 0, false, repositories.ErrAccountNotFound
+	}
+
+	replayed := account.chipCounter > seen
+	issued := seen + 1
+	if replayed {
+		issued = account.chipCounter + 1
+	}
+	account.chipCounter = issued
+
+	return issued, replayed, nil
+}
@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+// Database holds the tables of the in-memory storage, all repositories created with the same Database share their data.
+// Every repository call locks the whole database, so each call is atomic
+type Database struct {
+	mu sync.RWMutex
+
+	users        map[int32]*user
+	groups       map[int32]*api.Group
+	accounts     map[int32]*account
+	transactions []*transaction // ordered by id
+	terminals    map[string]*terminal
+
+	lastUserId        int32
+	lastGroupId       int32
+	lastAccountId     int32
+	lastTransactionId int32
+}
+
+// NewDatabase returns an empty Database
+func NewDatabase() *Database {
+	return &Database{
+		users:     make(map[int32]*user),
+		groups:    make(map[int32]*api.Group),
+		accounts:  make(map[int32]*account),
+		terminals: make(map[string]*terminal),
+	}
+}
+
+type user struct {
+	id             int32
+	name           string
+	email          string
+	hashedPassword []byte
+	created        time.Time
+}
+
+type account struct {
+	id          int32
+	name        string
+	description string
+	saldo       float64
+	groupId     int32
+	nfcChipId   string
+	status      api.Account_Status
+	chipCounter uint32
+}
+
+type transaction struct {
+	id              int32
+	newSaldo        float64
+	oldSaldo        float64
+	amount          float64
+	accountId       int32
+	created         time.Time
+	terminalId      string
+	transactionType api.Transaction_Type
+}
+
+type terminal struct {
+	id           string
+	name         string
+	publicKey    []byte
+	lastSequence uint64
+	created      time.Time
+}
+
+// accountProto returns the api representation of a with its group, db has to be locked
+func (db *Database) accountProto(a *account) *api.Account {
+	m := &api.Account{
+		Id:          a.id,
+		Name:        a.name,
+		Description: a.description,
+		Saldo:       a.saldo,
+		NfcChipId:   a.nfcChipId,
+		Status:      a.status,
+	}
+	if group, ok := db.groups[a.groupId]; ok {
+		m.Group = proto.Clone(group).(*api.Group)
+	}
+	return m
+}
+
+// accountByNfcChipId returns the account of the chip, chip ids are compared case insensitive as with the sql backends.
+// db has to be locked
+func (db *Database) accountByNfcChipId(nfcChipId string) *account {
+	for _, a := range db.accounts {
+		if strings.EqualFold(a.nfcChipId, nfcChipId) {
+			return a
+		}
+	}
+	return nil
+}
+
+// sortedAccounts returns all accounts ordered by id, db has to be locked
+func (db *Database) sortedAccounts() []*account {
+	accounts := make([]*account, 0, len(db.accounts))
+	for _, a := range db.accounts {
+		accounts = append(accounts, a)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].id < accounts[j].id })
+	return accounts
+}
+
+// insertTransaction saves a new transaction and sets the saldo of the account, db has to be locked
+func (db *Database) insertTransaction(a *account, amount float64, terminalId string, transactionType api.Transaction_Type) *transaction {
+	db.lastTransactionId++
+	t := &transaction{
+		id:              db.lastTransactionId,
+		oldSaldo:        a.saldo,
+		newSaldo:        cents(a.saldo - amount),
+		amount:          cents(amount),
+		accountId:       a.id,
+		created:         time.Now(),
+		terminalId:      terminalId,
+		transactionType: transactionType,
+	}
+	db.transactions = append(db.transactions, t)
+	a.saldo = t.newSaldo
+
+	return t
+}
+
+// transactionProto returns the api representation of t, the account only has its id set
+func transactionProto(t *transaction) *api.Transaction {
+	created, _ := ptypes.TimestampProto(t.created)
+	return &api.Transaction{
+		Id:         t.id,
+		OldSaldo:   t.oldSaldo,
+		NewSaldo:   t.newSaldo,
+		Amount:     t.amount,
+		Created:    created,
+		Account:    &api.Account{Id: t.accountId},
+		TerminalId: t.terminalId,
+		Type:       t.transactionType,
+	}
+}
+
+// page returns the bounds of the page of n entries, limit 0 returns all entries as the sql backends do
+func page(n int, limit, offset int32) (int, int) {
+	if limit <= 0 {
+		return 0, n
+	}
+
+	start := int(offset)
+	if start > n {
+		start = n
+	}
+	end := start + int(limit)
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// cents rounds v to 2 decimals, the sql backends store saldos and amounts with 2 decimals
+func cents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// inTimeRange returns true if t is in [from, to), zero times are left out
+func inTimeRange(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !t.Before(to) {
+		return false
+	}
+	return true
+}
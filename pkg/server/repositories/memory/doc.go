@@ -0,0 +1,3 @@
+// memory provides repositories account, groups, transactions and users data storage in memory.
+// It is meant for demos and tests, all data is lost when the process ends
+package memory
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// GroupRepository provides API for the groups of the Database
+type GroupRepository struct {
+	db *Database
+}
+
+func NewGroupRepository(db *Database) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// Creates inserts new group with given fields
+func (g *GroupRepository) Create(_ context.Context, name, description string, canOverdraw bool) (*api.Group, error) {
+	g.db.mu.Lock()
+	defer g.db.mu.Unlock()
+
+	g.db.lastGroupId++
+	group := &api.Group{
+		Id:          g.db.lastGroupId,
+		Name:        name,
+		Description: description,
+		CanOverdraw: canOverdraw,
+	}
+	g.db.groups[group.Id] = group
+
+	return proto.Clone(group).(*api.Group), nil
+}
+
+// Read returns the group for given id, will return repositories.ErrNotFound if no group is found
+func (g *GroupRepository) Read(_ context.Context, id int32) (*api.Group, error) {
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	group, ok := g.db.groups[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return proto.Clone(group).(*api.Group), nil
+}
+
+// Update saves given (changed) group
+// NOTE: every field will be overwritten with given value
+func (g *GroupRepository) Update(_ context.Context, group *api.Group) (*api.Group, error) {
+	if group.Id == 0 {
+		return nil, repositories.ErrModelNotSaved
+	}
+
+	g.db.mu.Lock()
+	defer g.db.mu.Unlock()
+
+	// as with an sql UPDATE, nothing happens if the group does not exist
+	if _, ok := g.db.groups[group.Id]; ok {
+		g.db.groups[group.Id] = proto.Clone(group).(*api.Group)
+	}
+
+	return group, nil
+}
+
+// Delete removes group with given id
+// returns repositories.ErrNonEmptyDelete if accounts are associated with group
+func (g *GroupRepository) Delete(_ context.Context, id int32) error {
+	g.db.mu.Lock()
+	defer g.db.mu.Unlock()
+
+	for _, a := range g.db.accounts {
+		if a.groupId == id {
+			return repositories.ErrNonEmptyDelete
+		}
+	}
+	delete(g.db.groups, id)
+
+	return nil
+}
+
+// GetAll returns the groups ordered by id and the total number of groups
+func (g *GroupRepository) GetAll(_ context.Context, limit, offset int32) ([]*api.Group, int, error) {
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	ids := g.sortedIds()
+	start, end := page(len(ids), limit, offset)
+
+	var groups []*api.Group
+	for _, id := range ids[start:end] {
+		groups = append(groups, proto.Clone(g.db.groups[id]).(*api.Group))
+	}
+
+	return groups, len(ids), nil
+}
+
+// GetPage returns up to size groups ordered by id that come after the cursor, after nil starts with the first group
+func (g *GroupRepository) GetPage(_ context.Context, after *repositories.Cursor, size int32) ([]*api.Group, error) {
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	var groups []*api.Group
+	for _, id := range g.sortedIds() {
+		if int32(len(groups)) >= size {
+			break
+		}
+		if after != nil && id <= after.Id {
+			continue
+		}
+		groups = append(groups, proto.Clone(g.db.groups[id]).(*api.Group))
+	}
+
+	return groups, nil
+}
+
+// Count returns the number of groups
+func (g *GroupRepository) Count(_ context.Context) (int, error) {
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	return len(g.db.groups), nil
+}
+
+// sortedIds returns the ids of all groups in ascending order, db has to be locked
+func (g *GroupRepository) sortedIds() []int32 {
+	ids := make([]int32, 0, len(g.db.groups))
+	for id := range g.db.groups {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// GetAllByIds returns the groups with given ids, returns repositories.ErrNotFound if ids is empty
+func (g *GroupRepository) GetAllByIds(_ context.Context, ids []int32) (map[int32]*api.Group, error) {
+	if len(ids) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+
+	g.db.mu.RLock()
+	defer g.db.mu.RUnlock()
+
+	var m map[int32]*api.Group
+	for _, id := range ids {
+		group, ok := g.db.groups[id]
+		if !ok {
+			continue
+		}
+		if m == nil {
+			m = make(map[int32]*api.Group, len(ids))
+		}
+		m[id] = proto.Clone(group).(*api.Group)
+	}
+
+	return m, nil
+}
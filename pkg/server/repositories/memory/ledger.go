@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+// saldoTolerance is the maximal difference of two saldos that are considered equal, saldos are stored with 2 decimals
+const saldoTolerance = 0.005
+
+// VerifyLedger walks the transactions of every account in the order they were created.
+// It reports a CHAIN_BREAK for every transaction whose old saldo is not the new saldo of the transaction before it,
+// and a SALDO_MISMATCH if the new saldo of the last transaction is not the saldo of the account.
+// Accounts without transactions are not checked, their start saldo is not recorded anywhere.
+func (t *TransactionRepository) VerifyLedger(_ context.Context, accountId int32, fix bool) (*api.LedgerReport, error) {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	var transactions []*transaction
+	for _, transaction := range t.db.transactions {
+		if accountId > 0 && transaction.accountId != accountId {
+			continue
+		}
+		transactions = append(transactions, transaction)
+	}
+	sort.SliceStable(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if a.accountId != b.accountId {
+			return a.accountId < b.accountId
+		}
+		return a.created.Before(b.created)
+	})
+
+	report := &api.LedgerReport{}
+	var mismatches []*api.LedgerDiscrepancy
+
+	var current *account
+	var lastId int32
+	var lastNewSaldo float64
+	checkSaldo := func() {
+		if current == nil || saldosEqual(lastNewSaldo, current.saldo) {
+			return
+		}
+		mismatches = append(mismatches, &api.LedgerDiscrepancy{
+			Kind:          api.LedgerDiscrepancy_SALDO_MISMATCH,
+			AccountId:     current.id,
+			TransactionId: lastId,
+			Expected:      current.saldo,
+			Actual:        lastNewSaldo,
+		})
+	}
+
+	for _, transaction := range transactions {
+		account, ok := t.db.accounts[transaction.accountId]
+		if !ok {
+			// as with the sql join, transactions without account are not checked
+			continue
+		}
+		report.CheckedTransactions++
+
+		if account != current {
+			checkSaldo()
+			current = account
+			report.CheckedAccounts++
+		} else if !saldosEqual(transaction.oldSaldo, lastNewSaldo) {
+			report.Discrepancies = append(report.Discrepancies, &api.LedgerDiscrepancy{
+				Kind:          api.LedgerDiscrepancy_CHAIN_BREAK,
+				AccountId:     account.id,
+				TransactionId: transaction.id,
+				Expected:      lastNewSaldo,
+				Actual:        transaction.oldSaldo,
+			})
+		}
+
+		lastId = transaction.id
+		lastNewSaldo = transaction.newSaldo
+	}
+	checkSaldo()
+
+	if fix {
+		for _, mismatch := range mismatches {
+			mismatch.AdjustmentId = t.adjustSaldo(t.db.accounts[mismatch.AccountId], mismatch.Actual)
+		}
+	}
+	report.Discrepancies = append(report.Discrepancies, mismatches...)
+
+	return report, nil
+}
+
+// adjustSaldo writes an ADJUSTMENT transaction that moves the ledger of the account from lastNewSaldo
+// to the saldo of the account. The saldo of the account is not changed, db has to be locked
+func (t *TransactionRepository) adjustSaldo(a *account, lastNewSaldo float64) int32 {
+	saldo := a.saldo
+	a.saldo = lastNewSaldo
+	adjustment := t.db.insertTransaction(a, lastNewSaldo-saldo, "", api.Transaction_ADJUSTMENT)
+	a.saldo = saldo
+
+	return adjustment.id
+}
+
+func saldosEqual(a, b float64) bool {
+	return math.Abs(a-b) < saldoTolerance
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// ApplyOffline applies the offline transactions of the terminal one after another.
+// The saved transactions are created at sync time.
+// A conflict uses up the sequence of the offline transaction, a repeated sync reports it as DUPLICATE_SEQUENCE.
+func (t *TransactionRepository) ApplyOffline(_ context.Context, terminalId string, transactions []*api.OfflineTransaction) (*api.SyncOfflineTransactionsResponse, error) {
+	t.db.mu.Lock()
+	terminal, ok := t.db.terminals[terminalId]
+	if !ok {
+		t.db.mu.Unlock()
+		return nil, repositories.ErrTerminalNotFound
+	}
+
+	res := &api.SyncOfflineTransactionsResponse{LastSequence: terminal.lastSequence}
+	for _, offline := range transactions {
+		if offline.Sequence <= terminal.lastSequence {
+			res.Conflicts = append(res.Conflicts, offlineConflict(offline, api.OfflineConflict_DUPLICATE_SEQUENCE))
+			continue
+		}
+		terminal.lastSequence = offline.Sequence
+		res.LastSequence = offline.Sequence
+
+		account, ok := t.db.accounts[offline.AccountId]
+		if !ok {
+			res.Conflicts = append(res.Conflicts, offlineConflict(offline, api.OfflineConflict_ACCOUNT_NOT_FOUND))
+			continue
+		}
+		if account.status == api.Account_BLOCKED {
+			res.Conflicts = append(res.Conflicts, offlineConflict(offline, api.OfflineConflict_ACCOUNT_BLOCKED))
+			continue
+		}
+		canOverdraw := t.db.groups[account.groupId].GetCanOverdraw()
+		if offline.Amount > 0 && account.saldo-offline.Amount < 0 && !canOverdraw {
+			res.Conflicts = append(res.Conflicts, offlineConflict(offline, api.OfflineConflict_INSUFFICIENT_BALANCE))
+			continue
+		}
+
+		transaction := transactionProto(t.db.insertTransaction(account, offline.Amount, terminalId, api.Transaction_PAYMENT))
+		transaction.Account = t.db.accountProto(account)
+		res.Applied = append(res.Applied, transaction)
+	}
+	t.db.mu.Unlock()
+
+	if t.publisher != nil {
+		for _, transaction := range res.Applied {
+			t.publisher.Publish(transaction)
+		}
+	}
+
+	return res, nil
+}
+
+func offlineConflict(offline *api.OfflineTransaction, reason api.OfflineConflict_Reason) *api.OfflineConflict {
+	return &api.OfflineConflict{
+		Sequence:  offline.Sequence,
+		AccountId: offline.AccountId,
+		Amount:    offline.Amount,
+		Reason:    reason,
+	}
+}
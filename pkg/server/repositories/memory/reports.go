@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+// ReportRepository computes reports over the transactions and accounts of the Database,
+// ADJUSTMENT transactions only repair the saldo chain and are left out
+type ReportRepository struct {
+	db *Database
+}
+
+func NewReportRepository(db *Database) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// Revenue returns the sum and count of purchases in the time range per bucket, ordered by bucket.
+// Buckets without purchases are not returned
+func (r *ReportRepository) Revenue(_ context.Context, from, to time.Time, groupBy api.RevenueRequest_Grouping) ([]*api.RevenueBucket, error) {
+	var bucketKey func(t *transaction) (interface{}, *api.RevenueBucket)
+	switch groupBy {
+	case api.RevenueRequest_HOUR, api.RevenueRequest_DAY:
+		bucketKey = func(t *transaction) (interface{}, *api.RevenueBucket) {
+			start := t.created.UTC().Truncate(time.Hour)
+			if groupBy == api.RevenueRequest_DAY {
+				start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+			}
+			startProto, _ := ptypes.TimestampProto(start)
+			return start.Unix(), &api.RevenueBucket{Start: startProto}
+		}
+	case api.RevenueRequest_GROUP:
+		bucketKey = func(t *transaction) (interface{}, *api.RevenueBucket) {
+			account, ok := r.db.accounts[t.accountId]
+			if !ok {
+				return nil, nil
+			}
+			group, ok := r.db.groups[account.groupId]
+			if !ok {
+				return nil, nil
+			}
+			return group.Id, &api.RevenueBucket{GroupId: group.Id, GroupName: group.Name}
+		}
+	case api.RevenueRequest_TERMINAL:
+		bucketKey = func(t *transaction) (interface{}, *api.RevenueBucket) {
+			return t.terminalId, &api.RevenueBucket{TerminalId: t.terminalId}
+		}
+	default:
+		return nil, fmt.Errorf("unknown revenue grouping %v", groupBy)
+	}
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	buckets := make(map[interface{}]*api.RevenueBucket)
+	var keys []interface{}
+	for _, t := range r.db.transactions {
+		if t.amount <= 0 || t.isAdjustment() || !inTimeRange(t.created, from, to) {
+			continue
+		}
+		key, newBucket := bucketKey(t)
+		if newBucket == nil {
+			continue
+		}
+		bucket, ok := buckets[key]
+		if !ok {
+			bucket = newBucket
+			buckets[key] = bucket
+			keys = append(keys, key)
+		}
+		bucket.Revenue += t.amount
+		bucket.TransactionCount++
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		switch key := keys[i].(type) {
+		case int64:
+			return key < keys[j].(int64)
+		case int32:
+			return key < keys[j].(int32)
+		default:
+			return key.(string) < keys[j].(string)
+		}
+	})
+
+	var result []*api.RevenueBucket
+	for _, key := range keys {
+		bucket := buckets[key]
+		bucket.Revenue = cents(bucket.Revenue)
+		result = append(result, bucket)
+	}
+
+	return result, nil
+}
+
+// CashFlow returns sum and count of top-ups (negative amounts) and purchases (positive amounts) in the time range,
+// both sums are positive
+func (r *ReportRepository) CashFlow(_ context.Context, from, to time.Time) (*api.CashFlowReport, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	report := &api.CashFlowReport{}
+	for _, t := range r.db.transactions {
+		if t.isAdjustment() || !inTimeRange(t.created, from, to) {
+			continue
+		}
+		switch {
+		case t.amount < 0:
+			report.TopUps -= t.amount
+			report.TopUpCount++
+		case t.amount > 0:
+			report.Purchases += t.amount
+			report.PurchaseCount++
+		}
+	}
+	report.TopUps = cents(report.TopUps)
+	report.Purchases = cents(report.Purchases)
+
+	return report, nil
+}
+
+// OutstandingBalance returns the total saldo of all accounts at time at.
+// The saldo at a point in time is the current saldo plus the amounts of all later transactions
+func (r *ReportRepository) OutstandingBalance(_ context.Context, at time.Time) (*api.BalanceReport, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	balances := make(map[int32]float64, len(r.db.accounts))
+	for id, account := range r.db.accounts {
+		balances[id] = account.saldo
+	}
+	if !at.IsZero() {
+		for _, t := range r.db.transactions {
+			if _, ok := balances[t.accountId]; ok && !t.isAdjustment() && !t.created.Before(at) {
+				balances[t.accountId] += t.amount
+			}
+		}
+	}
+
+	report := &api.BalanceReport{}
+	for _, saldo := range balances {
+		saldo = cents(saldo)
+		report.Total += saldo
+		report.AccountCount++
+		if saldo < 0 {
+			report.OverdrawnTotal += saldo
+			report.OverdrawnCount++
+		}
+	}
+	report.Total = cents(report.Total)
+	report.OverdrawnTotal = cents(report.OverdrawnTotal)
+
+	return report, nil
+}
+
+// AverageSpend returns the average purchase sum per account for all accounts with purchases in the time range
+func (r *ReportRepository) AverageSpend(_ context.Context, from, to time.Time) (*api.AverageSpendReport, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	report := &api.AverageSpendReport{}
+	accounts := make(map[int32]bool)
+	for _, t := range r.db.transactions {
+		if t.amount <= 0 || t.isAdjustment() || !inTimeRange(t.created, from, to) {
+			continue
+		}
+		report.Total += t.amount
+		accounts[t.accountId] = true
+	}
+	report.Total = cents(report.Total)
+	report.AccountCount = int32(len(accounts))
+
+	if report.AccountCount > 0 {
+		report.Average = report.Total / float64(report.AccountCount)
+	}
+
+	return report, nil
+}
+
+// isAdjustment reports if t was written by VerifyLedger to repair the saldo chain
+func (t *transaction) isAdjustment() bool {
+	return t.transactionType == api.Transaction_ADJUSTMENT
+}
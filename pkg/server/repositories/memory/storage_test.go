@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, storagetest.Factories{
+		Groups: func(t *testing.T) (repositories.GroupStorager, func()) {
+			return NewGroupRepository(NewDatabase()), func() {}
+		},
+		Accounts: func(t *testing.T) (repositories.AccountStorager, repositories.GroupStorager, func()) {
+			db := NewDatabase()
+			return NewAccountRepository(db), NewGroupRepository(db), func() {}
+		},
+		Transactions: func(t *testing.T) (repositories.TransactionStorager, repositories.AccountStorager, repositories.GroupStorager, func()) {
+			db := NewDatabase()
+			return NewTransactionRepository(db, nil), NewAccountRepository(db), NewGroupRepository(db), func() {}
+		},
+		Users: func(t *testing.T) (repositories.UserStorager, func(ctx context.Context, name, email, password string) error, func()) {
+			users := NewUserModel(NewDatabase())
+			return users, users.Create, func() {}
+		},
+		Ledger: func(t *testing.T) (*storagetest.LedgerStorage, func()) {
+			db := NewDatabase()
+			return &storagetest.LedgerStorage{
+				Transactions: NewTransactionRepository(db, nil),
+				Accounts:     NewAccountRepository(db),
+				Groups:       NewGroupRepository(db),
+				Terminals:    NewTerminalRepository(db),
+				Reports:      NewReportRepository(db),
+			}, func() {}
+		},
+	})
+}
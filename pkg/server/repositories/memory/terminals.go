@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// TerminalRepository provides API for the terminals of the Database
+type TerminalRepository struct {
+	db *Database
+}
+
+func NewTerminalRepository(db *Database) *TerminalRepository {
+	return &TerminalRepository{db: db}
+}
+
+// Register inserts a new terminal, returns repositories.ErrDuplicateTerminal if the id is already registered
+func (r *TerminalRepository) Register(_ context.Context, id, name string, publicKey []byte) (*api.Terminal, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.terminals[id]; ok {
+		return nil, repositories.ErrDuplicateTerminal
+	}
+
+	terminal := &terminal{
+		id:        id,
+		name:      name,
+		publicKey: append([]byte(nil), publicKey...),
+		created:   time.Now(),
+	}
+	r.db.terminals[id] = terminal
+
+	return terminalProto(terminal), nil
+}
+
+// Read returns the terminal with id, returns repositories.ErrTerminalNotFound if it does not exist
+func (r *TerminalRepository) Read(_ context.Context, id string) (*api.Terminal, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	terminal, ok := r.db.terminals[id]
+	if !ok {
+		return nil, repositories.ErrTerminalNotFound
+	}
+
+	return terminalProto(terminal), nil
+}
+
+func terminalProto(t *terminal) *api.Terminal {
+	created, _ := ptypes.TimestampProto(t.created)
+	return &api.Terminal{
+		Id:           t.id,
+		Name:         t.name,
+		PublicKey:    append([]byte(nil), t.publicKey...),
+		LastSequence: t.lastSequence,
+		Created:      created,
+	}
+}
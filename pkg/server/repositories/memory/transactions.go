@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// TransactionRepository provides API for the transactions of the Database
+type TransactionRepository struct {
+	db        *Database
+	publisher repositories.TransactionPublisher
+}
+
+// NewTransactionRepository returns a TransactionRepository, every created transaction is sent to publisher.
+// publisher can be nil
+func NewTransactionRepository(db *Database, publisher repositories.TransactionPublisher) *TransactionRepository {
+	return &TransactionRepository{
+		db:        db,
+		publisher: publisher,
+	}
+}
+
+// Create inserts new Transaction and updates the saldo of the account
+// It will return repositories.ErrAccountNotFound if account with accountId is not found
+// and repositories.ErrAccountBlocked if the account is blocked
+// terminalId is optional and identifies the terminal the transaction was made on
+func (t *TransactionRepository) Create(_ context.Context, amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+	t.db.mu.Lock()
+	account, ok := t.db.accounts[accountId]
+	if !ok {
+		t.db.mu.Unlock()
+		return nil, repositories.ErrAccountNotFound
+	}
+	if account.status == api.Account_BLOCKED {
+		t.db.mu.Unlock()
+		return nil, repositories.ErrAccountBlocked
+	}
+
+	transaction := transactionProto(t.db.insertTransaction(account, amount, terminalId, api.Transaction_PAYMENT))
+	transaction.Account = t.db.accountProto(account)
+	t.db.mu.Unlock()
+
+	if t.publisher != nil {
+		t.publisher.Publish(transaction)
+	}
+
+	return transaction, nil
+}
+
+// Read returns Transaction with given id, returns repositories.ErrNotFound if transaction with id does not exist
+func (t *TransactionRepository) Read(_ context.Context, id int32) (*api.Transaction, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	for _, transaction := range t.db.transactions {
+		if transaction.id == id {
+			return t.withAccount(transaction), nil
+		}
+	}
+
+	return nil, repositories.ErrNotFound
+}
+
+// GetAll returns the transactions matching filter ordered by create date, the order of filter can change it (default DESC)
+func (t *TransactionRepository) GetAll(_ context.Context, filter repositories.TransactionFilter, limit, offset int32) ([]*api.Transaction, int, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	matching := t.filter(filter)
+	start, end := page(len(matching), limit, offset)
+
+	var transactions []*api.Transaction
+	for _, transaction := range matching[start:end] {
+		transactions = append(transactions, t.withAccount(transaction))
+	}
+
+	return transactions, len(matching), nil
+}
+
+// GetPage returns up to size transactions matching filter ordered by created and id that come after the cursor
+// in the order of filter, after nil starts with the first transaction
+func (t *TransactionRepository) GetPage(_ context.Context, filter repositories.TransactionFilter, after *repositories.Cursor, size int32) ([]*api.Transaction, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	ascending := isAscending(filter.Order)
+	var cursor *transaction
+	if after != nil {
+		cursor = &transaction{id: after.Id, created: after.Created}
+	}
+
+	var transactions []*api.Transaction
+	for _, transaction := range t.filter(filter) {
+		if int32(len(transactions)) >= size {
+			break
+		}
+		if cursor != nil && ascending && !before(cursor, transaction) {
+			continue
+		}
+		if cursor != nil && !ascending && !before(transaction, cursor) {
+			continue
+		}
+		transactions = append(transactions, t.withAccount(transaction))
+	}
+
+	return transactions, nil
+}
+
+// Count returns the number of transactions matching filter
+func (t *TransactionRepository) Count(_ context.Context, filter repositories.TransactionFilter) (int, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	return len(t.filter(filter)), nil
+}
+
+// DeleteAllByAccount deletes all transactions for given account id
+func (t *TransactionRepository) DeleteAllByAccount(_ context.Context, accountId int32) error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	kept := t.db.transactions[:0]
+	for _, transaction := range t.db.transactions {
+		if transaction.accountId != accountId {
+			kept = append(kept, transaction)
+		}
+	}
+	t.db.transactions = kept
+
+	return nil
+}
+
+// Export calls fn for every transaction matching filter.
+// The matching transactions are collected first, fn is called without holding the lock of the database
+func (t *TransactionRepository) Export(ctx context.Context, filter repositories.TransactionFilter, fn func(*api.Transaction) error) error {
+	t.db.mu.RLock()
+	matching := t.filter(filter)
+	transactions := make([]*api.Transaction, len(matching))
+	for i, transaction := range matching {
+		transactions[i] = t.withAccount(transaction)
+	}
+	t.db.mu.RUnlock()
+
+	for _, transaction := range transactions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filter returns the transactions matching filter ordered by created, db has to be locked
+func (t *TransactionRepository) filter(filter repositories.TransactionFilter) []*transaction {
+	var matching []*transaction
+	for _, transaction := range t.db.transactions {
+		if filter.AccountId > 0 && transaction.accountId != filter.AccountId {
+			continue
+		}
+		if filter.GroupId > 0 {
+			account, ok := t.db.accounts[transaction.accountId]
+			if !ok || account.groupId != filter.GroupId {
+				continue
+			}
+		}
+		if filter.TerminalId != "" && transaction.terminalId != filter.TerminalId {
+			continue
+		}
+		if !inTimeRange(transaction.created, filter.From, filter.To) {
+			continue
+		}
+		if filter.MinAmount != nil && transaction.amount < *filter.MinAmount {
+			continue
+		}
+		if filter.MaxAmount != nil && transaction.amount > *filter.MaxAmount {
+			continue
+		}
+		matching = append(matching, transaction)
+	}
+
+	ascending := isAscending(filter.Order)
+	sort.Slice(matching, func(i, j int) bool {
+		if ascending {
+			return before(matching[i], matching[j])
+		}
+		return before(matching[j], matching[i])
+	})
+
+	return matching
+}
+
+// before returns true if a was created before b, transactions created at the same time are ordered by id
+func before(a, b *transaction) bool {
+	if a.created.Equal(b.created) {
+		return a.id < b.id
+	}
+	return a.created.Before(b.created)
+}
+
+// isAscending returns true if order is ASC or asc, all other orders are descending
+func isAscending(order string) bool {
+	return strings.ToLower(order) == "asc"
+}
+
+// withAccount returns the api representation of transaction with its account, db has to be locked
+func (t *TransactionRepository) withAccount(transaction *transaction) *api.Transaction {
+	m := transactionProto(transaction)
+	if account, ok := t.db.accounts[transaction.accountId]; ok {
+		m.Account = t.db.accountProto(account)
+	}
+	return m
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UserRepository handles the users of the Database
+type UserRepository struct {
+	db *Database
+}
+
+func NewUserModel(db *Database) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create creates a new user.
+// if a user with the same email already exists, Create will return a repositories.ErrDuplicateEmail
+func (u *UserRepository) Create(_ context.Context, name, email, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+	if err != nil {
+		return err
+	}
+
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+
+	if u.byEmail(email) != nil {
+		return repositories.ErrDuplicateEmail
+	}
+
+	u.db.lastUserId++
+	u.db.users[u.db.lastUserId] = &user{
+		id:             u.db.lastUserId,
+		name:           name,
+		email:          email,
+		hashedPassword: hashedPassword,
+		created:        time.Now(),
+	}
+
+	return nil
+}
+
+// Get returns the user with given id, if id does not exists Get will return a repositories.ErrNotFound
+func (u *UserRepository) Get(_ context.Context, id int) (*api.User, error) {
+	u.db.mu.RLock()
+	defer u.db.mu.RUnlock()
+
+	user, ok := u.db.users[int32(id)]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return userProto(user), nil
+}
+
+// Authenticate returns the user if it exists with given email and password
+// if email does not exists or the password is wrong Authenticate will return a repositories.ErrInvalidCredentials
+func (u *UserRepository) Authenticate(_ context.Context, email, password string) (*api.User, error) {
+	u.db.mu.RLock()
+	user := u.byEmail(email)
+	u.db.mu.RUnlock()
+
+	if user == nil {
+		return nil, repositories.ErrInvalidCredentials
+	}
+
+	err := bcrypt.CompareHashAndPassword(user.hashedPassword, []byte(password))
+	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return nil, repositories.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	return userProto(user), nil
+}
+
+// byEmail returns the user with email, emails are compared case insensitive as with the sql backends.
+// db has to be locked
+func (u *UserRepository) byEmail(email string) *user {
+	for _, user := range u.db.users {
+		if strings.EqualFold(user.email, email) {
+			return user
+		}
+	}
+	return nil
+}
+
+func userProto(u *user) *api.User {
+	created, _ := ptypes.TimestampProto(u.created)
+	return &api.User{
+		Id:      u.id,
+		Name:    u.name,
+		Email:   u.email,
+		Created: created,
+	}
+}
@@ -213,6 +213,54 @@ func TestAccountModel_Read(t *testing.T) {
 	}
 }
 
+func TestAccountModel_ReadByNfcChipId(t *testing.T) {
+	is, td := initAccountIntegrationTest(t)
+	defer td()
+
+	account := api.Account{
+		Id:        1,
+		Name:      "tim",
+		Saldo:     12,
+		NfcChipId: "04a1b2c3d4e5f6",
+		Group:     mockGroupOne,
+	}
+	tests := []struct {
+		name      string
+		nfcChipId string
+		wantErr   error
+	}{
+		{
+			name:      "read account of chip",
+			nfcChipId: "04a1b2c3d4e5f6",
+		},
+		{
+			name:      "chip is not registered",
+			nfcChipId: "deadbeef",
+			wantErr:   repositories.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			teardown := initDBForAccounts(t)
+			defer teardown()
+
+			if err := insertTestAccount(t, account); err != nil {
+				t.Fatalf("could not create mock account: %v", err)
+			}
+
+			got, err := _accountModel.ReadByNfcChipId(context.Background(), tt.nfcChipId)
+			if tt.wantErr != nil {
+				is.Equal(err, tt.wantErr)
+				return
+			}
+
+			is.NoErr(err) // got error from read, did not expect it
+			is.Equal(got, &account)
+		})
+	}
+}
+
 func TestAccountModel_Update(t *testing.T) {
 	is, td := initAccountIntegrationTest(t)
 	defer td()
@@ -288,6 +336,26 @@ func TestAccountModel_Update(t *testing.T) {
 				Group:     mockGroupOne,
 			},
 		},
+		{
+			name: "block account",
+			inital: api.Account{
+				Id:    1,
+				Name:  "tim",
+				Group: mockGroupOne,
+			},
+			input: api.Account{
+				Id:     1,
+				Name:   "tim",
+				Group:  mockGroupOne,
+				Status: api.Account_BLOCKED,
+			},
+			want: api.Account{
+				Id:     1,
+				Name:   "tim",
+				Group:  mockGroupOne,
+				Status: api.Account_BLOCKED,
+			},
+		},
 		{
 			name: "update saldo 0 is ignored",
 			inital: api.Account{
@@ -526,6 +594,78 @@ func TestAccountModel_UpdateSaldo(t *testing.T) {
 	}
 }
 
+func TestAccountModel_IssueChipCounter(t *testing.T) {
+	is, td := initAccountIntegrationTest(t)
+	defer td()
+
+	tests := []struct {
+		name         string
+		stored       uint32
+		seen         uint32
+		accountId    int32
+		wantIssued   uint32
+		wantReplayed bool
+		wantErr      error
+	}{
+		{
+			name:       "counter of the chip is current",
+			stored:     4,
+			seen:       4,
+			accountId:  1,
+			wantIssued: 5,
+		},
+		{
+			name:       "terminal charged the chip offline",
+			stored:     4,
+			seen:       7,
+			accountId:  1,
+			wantIssued: 8,
+		},
+		{
+			name:         "old balance was written back to the chip",
+			stored:       9,
+			seen:         4,
+			accountId:    1,
+			wantIssued:   10,
+			wantReplayed: true,
+		},
+		{
+			name:      "account does not exist",
+			seen:      4,
+			accountId: 10,
+			wantErr:   repositories.ErrAccountNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			teardown := initDBForAccounts(t)
+			defer teardown()
+
+			err := insertTestAccount(t, api.Account{Id: 1, Name: "tim", Group: &api.Group{Id: 1}})
+			if err != nil {
+				t.Fatalf("could not create mock account: %v", err)
+			}
+			_, err = _conn.Exec("UPDATE accounts SET chip_counter=? WHERE id=1", tt.stored)
+			is.NoErr(err)
+
+			issued, replayed, err := _accountModel.IssueChipCounter(context.Background(), tt.accountId, tt.seen)
+			is.Equal(err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+			is.Equal(issued, tt.wantIssued)
+			is.Equal(replayed, tt.wantReplayed)
+
+			var dbCounter uint32
+			err = _conn.QueryRow("SELECT chip_counter from accounts WHERE id=?", tt.accountId).Scan(&dbCounter)
+			is.NoErr(err)
+			is.Equal(dbCounter, tt.wantIssued)
+		})
+	}
+}
+
 func TestAccountModel_GetAll(t *testing.T) {
 	is, td := initAccountIntegrationTest(t)
 	defer td()
@@ -577,7 +717,7 @@ func TestAccountModel_GetAll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			is := is.New(t)
-			accounts, count, err := _accountModel.GetAll(context.Background(), tt.input.groupId, tt.input.limit, tt.input.offset)
+			accounts, count, err := _accountModel.GetAll(context.Background(), repositories.AccountFilter{GroupId: tt.input.groupId}, tt.input.limit, tt.input.offset)
 			is.NoErr(err)
 			is.Equal(accounts, tt.want)
 			is.Equal(count, tt.wantCount)
@@ -632,13 +772,89 @@ func TestAccountModel_GetAllByIds(t *testing.T) {
 	}
 }
 
+func TestAccountModel_Import(t *testing.T) {
+	is, td := initAccountIntegrationTest(t)
+	defer td()
+
+	tests := []struct {
+		name      string
+		input     []*api.CreateAccountRequest
+		dryRun    bool
+		want      []*api.Account
+		wantErr   repositories.ImportErrors
+		wantSaved int
+	}{
+		{
+			name: "import accounts",
+			input: []*api.CreateAccountRequest{
+				{Name: "tim", Saldo: 12, GroupId: 1, NfcChipId: "importchip1"},
+				{Name: "tom", Description: "with description", Saldo: 5, GroupId: 2, NfcChipId: "importchip2"},
+			},
+			want: []*api.Account{
+				{Id: 10, Name: "tim", Saldo: 12, NfcChipId: "importchip1", Group: mockGroupOne},
+				{Id: 11, Name: "tom", Description: "with description", Saldo: 5, NfcChipId: "importchip2", Group: mockGroupTwo},
+			},
+			wantSaved: 11,
+		},
+		{
+			name: "import accounts with dry run",
+			input: []*api.CreateAccountRequest{
+				{Name: "tim", Saldo: 12, GroupId: 1, NfcChipId: "importchip1"},
+			},
+			dryRun: true,
+			want: []*api.Account{
+				{Name: "tim", Saldo: 12, NfcChipId: "importchip1", Group: mockGroupOne},
+			},
+			wantSaved: 9,
+		},
+		{
+			name: "import rejects rows and saves nothing",
+			input: []*api.CreateAccountRequest{
+				{Name: "tim", Saldo: 12, GroupId: 1, NfcChipId: "importchip1"},
+				{Name: "tom", Saldo: 12, GroupId: 100, NfcChipId: "importchip2"},
+				{Name: "tam", Saldo: 12, GroupId: 1, NfcChipId: "chipid1"},
+				{Name: "tum", Saldo: 12, GroupId: 1, NfcChipId: "importchip1"},
+				{Name: "", Saldo: 12, GroupId: 1, NfcChipId: "importchip3"},
+			},
+			wantErr: repositories.ImportErrors{
+				{Row: 2, NfcChipId: "importchip2", Err: repositories.ErrGroupNotFound},
+				{Row: 3, NfcChipId: "chipid1", Err: repositories.ErrDuplicateNfcChipId},
+				{Row: 4, NfcChipId: "importchip1", Err: repositories.ErrDuplicateNfcChipId},
+				{Row: 5, NfcChipId: "importchip3", Err: repositories.ErrMissingField},
+			},
+			wantSaved: 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			teardown := initDBForAccountLists(t)
+			defer teardown()
+
+			got, err := _accountModel.Import(context.Background(), tt.input, tt.dryRun)
+			if tt.wantErr != nil {
+				is.Equal(err, tt.wantErr) // rejected rows don't match
+			} else {
+				is.NoErr(err)
+				is.Equal(got, tt.want) // imported accounts don't match
+			}
+
+			var count int
+			err = _conn.QueryRow("SELECT COUNT(id) FROM accounts").Scan(&count)
+			is.NoErr(err)
+			is.Equal(count, tt.wantSaved) // number of saved accounts is wrong
+		})
+	}
+}
+
 func initAccountIntegrationTest(t *testing.T) (*isPkg.I, func()) {
 	test.IsIntegrationTest(t)
 	is := isPkg.New(t)
 
-	_accountModel.groups = groupMock
+	_accountModel = NewAccountRepository(_conn, groupMock)
 	return is, func() {
-		_accountModel.groups = nil
+		_accountModel = NewAccountRepository(_conn, nil)
 	}
 }
 
@@ -648,7 +864,7 @@ func insertTestAccount(t *testing.T, account api.Account) error {
 	_, err := _conn.Exec("INSERT INTO accounts (id, name, description, saldo, group_id, nfc_chip_uid) VALUES (?,?,?,?,?,?)",
 		account.Id,
 		account.Name,
-		createNullableString(account.Description),
+		sql.NullString{String: account.Description, Valid: account.Description != ""},
 		account.Saldo,
 		account.Group.Id,
 		account.NfcChipId,
@@ -0,0 +1,30 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// dialect is the sql of mysql for the repositories of sqlcommon
+var dialect = &sqlcommon.Dialect{
+	Placeholder: sqlcommon.QuestionMark,
+	LockRows: func(string) string {
+		return " FOR UPDATE"
+	},
+	TimeBucket: func(column string, hour bool) string {
+		if hour {
+			return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d %%H:00:00')", column)
+		}
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d 00:00:00')", column)
+	},
+	Like: func(column, placeholder string) string {
+		// the columns have a case insensitive collation
+		return column + " LIKE " + placeholder
+	},
+	FoldCase: func(expr string) string {
+		return expr
+	},
+	IsUniqueViolation:     isUniqueViolation,
+	IsForeignKeyViolation: isForeignKeyViolation,
+}
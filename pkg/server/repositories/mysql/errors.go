@@ -0,0 +1,21 @@
+package mysql
+
+import "github.com/go-sql-driver/mysql"
+
+// mysql error numbers, see https://dev.mysql.com/doc/refman/8.0/en/server-error-reference.html
+const (
+	duplicateEntry  = 1062
+	rowIsReferenced = 1451
+)
+
+// isUniqueViolation returns true if err is a duplicate entry of a unique key
+func isUniqueViolation(err error) bool {
+	mysqlErr, ok := err.(*mysql.MySQLError)
+	return ok && mysqlErr.Number == duplicateEntry
+}
+
+// isForeignKeyViolation returns true if err is a deleted row that is still referenced by a foreign key
+func isForeignKeyViolation(err error) bool {
+	mysqlErr, ok := err.(*mysql.MySQLError)
+	return ok && mysqlErr.Number == rowIsReferenced
+}
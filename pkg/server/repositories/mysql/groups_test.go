@@ -76,7 +76,7 @@ func TestGroupModel_Create(t *testing.T) {
 			err = row.Scan(&dbGroup.Id, &dbGroup.Name, &nullDesc, &dbGroup.CanOverdraw)
 			is.NoErr(err)
 
-			dbGroup.Description = decodeNullableString(nullDesc)
+			dbGroup.Description = nullDesc.String
 
 			is.Equal(dbGroup, tt.want)
 		})
@@ -447,7 +447,7 @@ func insertMockGroup(t *testing.T, group *api.Group) error {
 	_, err := _conn.Exec(
 		"INSERT INTO `account_groups` (id, name, description, can_overdraw) VALUES (?,?,?,?)",
 		group.Id, group.Name,
-		createNullableString(group.Description),
+		sql.NullString{String: group.Description, Valid: group.Description != ""},
 		group.CanOverdraw,
 	)
 	return err
@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test"
+	isPkg "github.com/matryer/is"
+)
+
+func TestTransactionModel_VerifyLedger(t *testing.T) {
+	test.IsIntegrationTest(t)
+	is := isPkg.New(t)
+
+	chainBreak := &api.LedgerDiscrepancy{
+		Kind:          api.LedgerDiscrepancy_CHAIN_BREAK,
+		AccountId:     3,
+		TransactionId: 6,
+		Expected:      5,
+		Actual:        7,
+	}
+	saldoMismatch := func(adjustmentId int32) *api.LedgerDiscrepancy {
+		return &api.LedgerDiscrepancy{
+			Kind:          api.LedgerDiscrepancy_SALDO_MISMATCH,
+			AccountId:     2,
+			TransactionId: 4,
+			Expected:      50,
+			Actual:        80,
+			AdjustmentId:  adjustmentId,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		accountId int32
+		fix       bool
+		want      *api.LedgerReport
+	}{
+		{
+			name: "verify all accounts",
+			want: &api.LedgerReport{
+				CheckedAccounts:     3,
+				CheckedTransactions: 6,
+				Discrepancies:       []*api.LedgerDiscrepancy{chainBreak, saldoMismatch(0)},
+			},
+		},
+		{
+			name:      "verify consistent account",
+			accountId: 1,
+			want: &api.LedgerReport{
+				CheckedAccounts:     1,
+				CheckedTransactions: 2,
+			},
+		},
+		{
+			name: "verify and fix all accounts",
+			fix:  true,
+			want: &api.LedgerReport{
+				CheckedAccounts:     3,
+				CheckedTransactions: 6,
+				Discrepancies:       []*api.LedgerDiscrepancy{chainBreak, saldoMismatch(7)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			td := initDbForLedger(t)
+			defer td()
+
+			got, err := _transactionModel.VerifyLedger(context.Background(), tt.accountId, tt.fix)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+
+			if !tt.fix {
+				return
+			}
+
+			var adjustment api.Transaction
+			var transactionType string
+			err = _conn.QueryRow(`SELECT old_saldo, new_saldo, amount, type FROM transactions WHERE id = 7`).Scan(
+				&adjustment.OldSaldo, &adjustment.NewSaldo, &adjustment.Amount, &transactionType,
+			)
+			is.NoErr(err)
+			is.Equal(adjustment.OldSaldo, float64(80))                     // adjustment should start at last new_saldo
+			is.Equal(adjustment.NewSaldo, float64(50))                     // adjustment should end at account saldo
+			is.Equal(adjustment.Amount, float64(30))                       // amount of adjustment is wrong
+			is.Equal(transactionType, api.Transaction_ADJUSTMENT.String()) // transaction is no adjustment
+
+			var saldo float64
+			err = _conn.QueryRow(`SELECT saldo FROM accounts WHERE id = 2`).Scan(&saldo)
+			is.NoErr(err)
+			is.Equal(saldo, float64(50)) // adjustment must not change the account saldo
+
+			got, err = _transactionModel.VerifyLedger(context.Background(), 0, false)
+			is.NoErr(err)
+			is.Equal(got.Discrepancies, []*api.LedgerDiscrepancy{chainBreak}) // only the chain break should be left
+		})
+	}
+}
+
+func initDbForLedger(t *testing.T) func() error {
+	t.Helper()
+	err := test.SetupDB(_conn, dataFor("ledger"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return teardownDB(_conn)
+}
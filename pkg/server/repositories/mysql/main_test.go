@@ -16,12 +16,14 @@ var (
 	_groupModel       *GroupRepository
 	_accountModel     *AccountRepository
 	_transactionModel *TransactionRepository
+	_reportModel      *ReportRepository
+	_terminalModel    *TerminalRepository
 	_conn             *sql.DB
 )
 
 func TestMain(m *testing.M) {
 	var err error
-	_conn, _, err = test.DbConnection("../../../../migrations")
+	_conn, _, err = test.DbConnection("../../../../migrations/mysql")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -30,7 +32,9 @@ func TestMain(m *testing.M) {
 	_userModel = NewUserModel(_conn)
 	_groupModel = NewGroupRepository(_conn)
 	_accountModel = NewAccountRepository(_conn, nil)
-	_transactionModel = NewTransactionRepository(_conn, nil)
+	_transactionModel = NewTransactionRepository(_conn, nil, nil)
+	_reportModel = NewReportRepository(_conn)
+	_terminalModel = NewTerminalRepository(_conn)
 
 	os.Exit(m.Run())
 }
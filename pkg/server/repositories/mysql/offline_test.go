@@ -0,0 +1,138 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test/mock"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+func TestTransactionModel_ApplyOffline(t *testing.T) {
+	test.IsIntegrationTest(t)
+	is := isPkg.New(t)
+
+	_transactionModel = NewTransactionRepository(_conn, &mock.AccountRepository{
+		ReadFunc: func(id int32) (*api.Account, error) {
+			return &api.Account{Id: id}, nil
+		},
+	}, nil)
+	defer func() { _transactionModel = NewTransactionRepository(_conn, nil, nil) }()
+
+	td := initDbForOffline(t)
+	defer td()
+
+	transactions := []*api.OfflineTransaction{
+		{Sequence: 2, AccountId: 1, Amount: 1},
+		{Sequence: 3, AccountId: 1, Amount: 4},
+		{Sequence: 4, AccountId: 1, Amount: 10},
+		{Sequence: 5, AccountId: 2, Amount: 5},
+		{Sequence: 6, AccountId: 3, Amount: 1},
+		{Sequence: 7, AccountId: 99, Amount: 1},
+		{Sequence: 8, AccountId: 1, Amount: -4},
+	}
+
+	t.Run("apply transactions", func(t *testing.T) {
+		is := is.New(t)
+
+		got, err := _transactionModel.ApplyOffline(context.Background(), "bar-1", transactions)
+		is.NoErr(err)
+
+		is.Equal(got.LastSequence, uint64(8))
+		is.Equal(got.Conflicts, []*api.OfflineConflict{
+			{Sequence: 2, AccountId: 1, Amount: 1, Reason: api.OfflineConflict_DUPLICATE_SEQUENCE},
+			{Sequence: 4, AccountId: 1, Amount: 10, Reason: api.OfflineConflict_INSUFFICIENT_BALANCE},
+			{Sequence: 6, AccountId: 3, Amount: 1, Reason: api.OfflineConflict_ACCOUNT_BLOCKED},
+			{Sequence: 7, AccountId: 99, Amount: 1, Reason: api.OfflineConflict_ACCOUNT_NOT_FOUND},
+		})
+
+		type saldos struct {
+			account            int32
+			oldSaldo, newSaldo float64
+		}
+		want := []saldos{{1, 10, 6}, {2, 0, -5}, {1, 6, 10}}
+		is.Equal(len(got.Applied), len(want))
+		for i, transaction := range got.Applied {
+			is.Equal(saldos{transaction.Account.Id, transaction.OldSaldo, transaction.NewSaldo}, want[i])
+			is.Equal(transaction.TerminalId, "bar-1")
+		}
+
+		terminal, err := _terminalModel.Read(context.Background(), "bar-1")
+		is.NoErr(err)
+		is.Equal(terminal.LastSequence, uint64(8))
+
+		report, err := _transactionModel.VerifyLedger(context.Background(), 0, false)
+		is.NoErr(err)
+		is.Equal(len(report.Discrepancies), 0) // saldo chain is broken after replay
+	})
+
+	t.Run("replay is not applied again", func(t *testing.T) {
+		is := is.New(t)
+
+		got, err := _transactionModel.ApplyOffline(context.Background(), "bar-1", transactions)
+		is.NoErr(err)
+
+		is.Equal(len(got.Applied), 0)
+		is.Equal(len(got.Conflicts), len(transactions))
+		for _, conflict := range got.Conflicts {
+			is.Equal(conflict.Reason, api.OfflineConflict_DUPLICATE_SEQUENCE)
+		}
+		is.Equal(got.LastSequence, uint64(8))
+	})
+
+	t.Run("unknown terminal", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := _transactionModel.ApplyOffline(context.Background(), "bar-2", transactions)
+		is.Equal(err, repositories.ErrTerminalNotFound)
+	})
+}
+
+func TestTerminalModel_Register(t *testing.T) {
+	test.IsIntegrationTest(t)
+	is := isPkg.New(t)
+
+	td := initDbForOffline(t)
+	defer td()
+
+	publicKey := []byte("01234567890123456789012345678901")
+
+	t.Run("register terminal", func(t *testing.T) {
+		is := is.New(t)
+
+		got, err := _terminalModel.Register(context.Background(), "bar-2", "Bar 2", publicKey)
+		is.NoErr(err)
+
+		terminal, err := _terminalModel.Read(context.Background(), "bar-2")
+		is.NoErr(err)
+		is.Equal(terminal.Name, got.Name)
+		is.Equal(terminal.PublicKey, publicKey)
+		is.Equal(terminal.LastSequence, uint64(0))
+	})
+
+	t.Run("duplicate terminal", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := _terminalModel.Register(context.Background(), "bar-1", "Bar 1", publicKey)
+		is.Equal(err, repositories.ErrDuplicateTerminal)
+	})
+
+	t.Run("terminal not found", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := _terminalModel.Read(context.Background(), "bar-3")
+		is.Equal(err, repositories.ErrTerminalNotFound)
+	})
+}
+
+func initDbForOffline(t *testing.T) func() error {
+	t.Helper()
+	err := test.SetupDB(_conn, dataFor("offline"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return teardownDB(_conn)
+}
@@ -0,0 +1,199 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test"
+	isPkg "github.com/matryer/is"
+)
+
+func TestReportModel_Revenue(t *testing.T) {
+	test.IsIntegrationTest(t)
+	is := isPkg.New(t)
+
+	bucketStart := func(year, month, day, hour int) *api.RevenueBucket {
+		start, _ := ptypes.TimestampProto(time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC))
+		return &api.RevenueBucket{Start: start}
+	}
+	withRevenue := func(bucket *api.RevenueBucket, revenue float64, count int32) *api.RevenueBucket {
+		bucket.Revenue = revenue
+		bucket.TransactionCount = count
+		return bucket
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		groupBy  api.RevenueRequest_Grouping
+		want     []*api.RevenueBucket
+	}{
+		{
+			name:    "revenue per day",
+			groupBy: api.RevenueRequest_DAY,
+			want: []*api.RevenueBucket{
+				withRevenue(bucketStart(2019, 1, 17, 0), 17.5, 3),
+				withRevenue(bucketStart(2019, 1, 18, 0), 7.5, 1),
+			},
+		},
+		{
+			name:    "revenue per hour",
+			groupBy: api.RevenueRequest_HOUR,
+			want: []*api.RevenueBucket{
+				withRevenue(bucketStart(2019, 1, 17, 10), 7.5, 2),
+				withRevenue(bucketStart(2019, 1, 17, 12), 10, 1),
+				withRevenue(bucketStart(2019, 1, 18, 13), 7.5, 1),
+			},
+		},
+		{
+			name:    "revenue per group",
+			groupBy: api.RevenueRequest_GROUP,
+			want: []*api.RevenueBucket{
+				{GroupId: 1, GroupName: "testgroup1", Revenue: 17.5, TransactionCount: 3},
+				{GroupId: 2, GroupName: "testgroup2", Revenue: 7.5, TransactionCount: 1},
+			},
+		},
+		{
+			name:    "revenue per terminal",
+			groupBy: api.RevenueRequest_TERMINAL,
+			want: []*api.RevenueBucket{
+				{TerminalId: "kiosk-1", Revenue: 15, TransactionCount: 2},
+				{TerminalId: "kiosk-2", Revenue: 10, TransactionCount: 2},
+			},
+		},
+		{
+			name:    "revenue per day in time range",
+			from:    time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC),
+			to:      time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+			groupBy: api.RevenueRequest_DAY,
+			want: []*api.RevenueBucket{
+				withRevenue(bucketStart(2019, 1, 18, 0), 7.5, 1),
+			},
+		},
+		{
+			name:    "no transactions in time range",
+			from:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			groupBy: api.RevenueRequest_DAY,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			td := initDbForReports(t)
+			defer td()
+
+			got, err := _reportModel.Revenue(context.Background(), tt.from, tt.to, tt.groupBy)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestReportModel_CashFlow(t *testing.T) {
+	test.IsIntegrationTest(t)
+	is := isPkg.New(t)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     *api.CashFlowReport
+	}{
+		{
+			name: "cash flow of all transactions",
+			want: &api.CashFlowReport{TopUps: 22, TopUpCount: 2, Purchases: 25, PurchaseCount: 4},
+		},
+		{
+			name: "cash flow in time range",
+			to:   time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC),
+			want: &api.CashFlowReport{Purchases: 17.5, PurchaseCount: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			td := initDbForReports(t)
+			defer td()
+
+			got, err := _reportModel.CashFlow(context.Background(), tt.from, tt.to)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestReportModel_OutstandingBalance(t *testing.T) {
+	test.IsIntegrationTest(t)
+	is := isPkg.New(t)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want *api.BalanceReport
+	}{
+		{
+			name: "current balance",
+			want: &api.BalanceReport{Total: 25, AccountCount: 3, OverdrawnTotal: -5, OverdrawnCount: 1},
+		},
+		{
+			name: "balance in the past",
+			at:   time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC),
+			want: &api.BalanceReport{Total: 10.5, AccountCount: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			td := initDbForReports(t)
+			defer td()
+
+			got, err := _reportModel.OutstandingBalance(context.Background(), tt.at)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestReportModel_AverageSpend(t *testing.T) {
+	test.IsIntegrationTest(t)
+	is := isPkg.New(t)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     *api.AverageSpendReport
+	}{
+		{
+			name: "average spend in time range",
+			to:   time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC),
+			want: &api.AverageSpendReport{Average: 8.75, Total: 17.5, AccountCount: 2},
+		},
+		{
+			name: "no purchases in time range",
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: &api.AverageSpendReport{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			td := initDbForReports(t)
+			defer td()
+
+			got, err := _reportModel.AverageSpend(context.Background(), tt.from, tt.to)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func initDbForReports(t *testing.T) func() error {
+	t.Helper()
+	err := test.SetupDB(_conn, dataFor("report"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return teardownDB(_conn)
+}
@@ -0,0 +1,50 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/storagetest"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, storagetest.Factories{
+		Groups: func(t *testing.T) (repositories.GroupStorager, func()) {
+			return NewGroupRepository(_conn), teardownStorage(t)
+		},
+		Accounts: func(t *testing.T) (repositories.AccountStorager, repositories.GroupStorager, func()) {
+			groups := NewGroupRepository(_conn)
+			return NewAccountRepository(_conn, groups), groups, teardownStorage(t)
+		},
+		Transactions: func(t *testing.T) (repositories.TransactionStorager, repositories.AccountStorager, repositories.GroupStorager, func()) {
+			groups := NewGroupRepository(_conn)
+			accounts := NewAccountRepository(_conn, groups)
+			return NewTransactionRepository(_conn, accounts, nil), accounts, groups, teardownStorage(t)
+		},
+		Users: func(t *testing.T) (repositories.UserStorager, func(ctx context.Context, name, email, password string) error, func()) {
+			users := NewUserModel(_conn)
+			return users, users.Create, teardownStorage(t)
+		},
+		Ledger: func(t *testing.T) (*storagetest.LedgerStorage, func()) {
+			groups := NewGroupRepository(_conn)
+			accounts := NewAccountRepository(_conn, groups)
+			return &storagetest.LedgerStorage{
+				Transactions: NewTransactionRepository(_conn, accounts, nil),
+				Accounts:     accounts,
+				Groups:       groups,
+				Terminals:    NewTerminalRepository(_conn),
+				Reports:      NewReportRepository(_conn),
+			}, teardownStorage(t)
+		},
+	})
+}
+
+// teardownStorage returns the teardown of the conformance tests, it empties the database
+func teardownStorage(t *testing.T) func() {
+	return func() {
+		if err := teardownDB(_conn)(); err != nil {
+			t.Fatalf("could not teardown database: %v", err)
+		}
+	}
+}
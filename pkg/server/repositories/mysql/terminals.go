@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// TerminalRepository provides API for the terminals table
+type TerminalRepository struct {
+	db *sql.DB
+}
+
+func NewTerminalRepository(db *sql.DB) *TerminalRepository {
+	return &TerminalRepository{db: db}
+}
+
+// Register inserts a new terminal, returns repositories.ErrDuplicateTerminal if the id is already registered
+func (r *TerminalRepository) Register(ctx context.Context, id, name string, publicKey []byte) (*api.Terminal, error) {
+	now := time.Now()
+
+	insertStmt := `INSERT INTO terminals (id, name, public_key, created) VALUES (?,?,?,?)`
+	_, err := r.db.ExecContext(ctx, insertStmt, id, name, publicKey, now)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repositories.ErrDuplicateTerminal
+		}
+		return nil, err
+	}
+
+	nowProto, _ := ptypes.TimestampProto(now)
+	return &api.Terminal{
+		Id:        id,
+		Name:      name,
+		PublicKey: publicKey,
+		Created:   nowProto,
+	}, nil
+}
+
+// Read returns the terminal with id, returns repositories.ErrTerminalNotFound if it does not exist
+func (r *TerminalRepository) Read(ctx context.Context, id string) (*api.Terminal, error) {
+	readStmt := `SELECT id, name, public_key, last_sequence, created FROM terminals WHERE id=?`
+
+	terminal := &api.Terminal{}
+	var created time.Time
+	err := r.db.QueryRowContext(ctx, readStmt, id).Scan(
+		&terminal.Id, &terminal.Name, &terminal.PublicKey, &terminal.LastSequence, &created,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repositories.ErrTerminalNotFound
+		}
+		return nil, err
+	}
+
+	terminal.Created, err = ptypes.TimestampProto(created)
+	if err != nil {
+		return nil, err
+	}
+
+	return terminal, nil
+}
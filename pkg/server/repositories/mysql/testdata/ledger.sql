@@ -0,0 +1,15 @@
+INSERT INTO `account_groups` (id, name, description)
+VALUES (1, 'testgroup1', NULL);
+
+INSERT INTO `accounts` (id, name, saldo, group_id, nfc_chip_uid)
+VALUES (1, 'consistent', 10, 1, 'testchipid1'),
+       (2, 'saldo mismatch', 50, 1, 'testchipid2'),
+       (3, 'chain break', 0, 1, 'testchipid3');
+
+INSERT INTO `transactions` (old_saldo, new_saldo, amount, account_id, created)
+VALUES (20, 15, 5, 1, '2019-01-17 10:00:00'),
+       (15, 10, 5, 1, '2019-01-17 11:00:00'),
+       (100, 90, 10, 2, '2019-01-17 10:00:00'),
+       (90, 80, 10, 2, '2019-01-17 11:00:00'),
+       (10, 5, 5, 3, '2019-01-17 10:00:00'),
+       (7, 0, 7, 3, '2019-01-17 11:00:00');
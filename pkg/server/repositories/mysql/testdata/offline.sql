@@ -0,0 +1,11 @@
+INSERT INTO `account_groups` (id, name, description, can_overdraw)
+VALUES (1, 'testgroup1', NULL, false),
+       (2, 'overdraw', NULL, true);
+
+INSERT INTO `accounts` (id, name, saldo, group_id, nfc_chip_uid, status)
+VALUES (1, 'active', 10, 1, 'testchipid1', 'ACTIVE'),
+       (2, 'can overdraw', 0, 2, 'testchipid2', 'ACTIVE'),
+       (3, 'blocked', 50, 1, 'testchipid3', 'BLOCKED');
+
+INSERT INTO `terminals` (id, name, public_key, last_sequence, created)
+VALUES ('bar-1', 'Bar 1', X'0000000000000000000000000000000000000000000000000000000000000000', 2, '2020-03-01 10:00:00');
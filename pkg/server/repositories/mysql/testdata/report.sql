@@ -0,0 +1,16 @@
+INSERT INTO `account_groups` (id, name, description)
+VALUES (1, 'testgroup1', NULL),
+       (2, 'testgroup2', NULL);
+
+INSERT INTO `accounts` (id, name, saldo, group_id, nfc_chip_uid)
+VALUES (1, 'testaccount1', 10, 1, 'testchipid1'),
+       (2, 'testaccount2', 20, 1, 'testchipid2'),
+       (3, 'testaccount3', -5, 2, 'testchipid3');
+
+INSERT INTO `transactions` (old_saldo, new_saldo, amount, account_id, created, terminal_id)
+VALUES (17.5, 12.5, 5, 1, '2019-01-17 10:15:00', 'kiosk-1'),
+       (12.5, 10, 2.5, 1, '2019-01-17 10:45:00', 'kiosk-2'),
+       (10, 0, 10, 2, '2019-01-17 12:05:00', 'kiosk-1'),
+       (0, 20, -20, 2, '2019-01-18 09:00:00', NULL),
+       (0.5, -7, 7.5, 3, '2019-01-18 13:30:00', 'kiosk-2'),
+       (-7, -5, -2, 3, '2019-02-01 08:00:00', 'kiosk-1');
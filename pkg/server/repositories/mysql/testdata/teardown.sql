@@ -3,4 +3,5 @@ TRUNCATE transactions ;
 TRUNCATE accounts;
 TRUNCATE account_groups;
 TRUNCATE users;
+TRUNCATE terminals;
 SET FOREIGN_KEY_CHECKS = 1;
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"sort"
 	"testing"
 	"time"
@@ -68,14 +69,16 @@ func TestTransactionModel_Create(t *testing.T) {
 		{
 			name: "create new transaction",
 			input: &api.CreateTransactionRequest{
-				Amount:    6,
-				AccountId: 1,
+				Amount:     6,
+				AccountId:  1,
+				TerminalId: "kiosk-1",
 			},
 			want: &api.Transaction{
-				Id:       1,
-				OldSaldo: 12,
-				NewSaldo: 6,
-				Amount:   6,
+				Id:         1,
+				OldSaldo:   12,
+				NewSaldo:   6,
+				Amount:     6,
+				TerminalId: "kiosk-1",
 				Account: &api.Account{
 					Id:        1,
 					Name:      "testaccount",
@@ -104,16 +107,22 @@ func TestTransactionModel_Create(t *testing.T) {
 			td := initDbForTransactions(t)
 			defer td()
 
-			got, err := _transactionModel.Create(context.Background(), tt.input.Amount, tt.input.AccountId)
+			publisher := &publisherMock{}
+			_transactionModel = NewTransactionRepository(_conn, accountMock, publisher)
+			defer func() { _transactionModel = NewTransactionRepository(_conn, accountMock, nil) }()
+
+			got, err := _transactionModel.Create(context.Background(), tt.input.Amount, tt.input.AccountId, tt.input.TerminalId)
 
 			if tt.wantErr {
 				if err != tt.expectedErr {
 					t.Errorf("dbTransaction err %v, expected %v", err, tt.expectedErr)
 				}
+				is.Equal(len(publisher.published), 0) // failed transaction should not be published
 				return
 			}
 
 			is.NoErr(err)
+			is.Equal(publisher.published, []*api.Transaction{got}) // created transaction should be published
 
 			is.True(got.Created.Nanos != 0) // got.created not set
 			tt.want.Created = got.Created   // set timestamp to want for not messing with equality of timestamps
@@ -123,9 +132,9 @@ func TestTransactionModel_Create(t *testing.T) {
 			dbTransaction := api.Transaction{Account: &api.Account{}}
 			var created time.Time
 
-			stmt := `SELECT id, new_saldo, old_saldo, amount,created, account_id from transactions WHERE id=?`
+			stmt := `SELECT id, new_saldo, old_saldo, amount,created, account_id, terminal_id from transactions WHERE id=?`
 			err = _conn.QueryRow(stmt, 1).Scan(
-				&dbTransaction.Id, &dbTransaction.NewSaldo, &dbTransaction.OldSaldo, &dbTransaction.Amount, &created, &dbTransaction.Account.Id,
+				&dbTransaction.Id, &dbTransaction.NewSaldo, &dbTransaction.OldSaldo, &dbTransaction.Amount, &created, &dbTransaction.Account.Id, &dbTransaction.TerminalId,
 			)
 			is.NoErr(err)
 			dbTransaction.Created, _ = ptypes.TimestampProto(created)
@@ -135,6 +144,7 @@ func TestTransactionModel_Create(t *testing.T) {
 			is.Equal(dbTransaction.NewSaldo, tt.want.NewSaldo)     // newSaldo does not match
 			is.True(!created.IsZero())                             // created is zero, should be timestamp
 			is.Equal(dbTransaction.Account.Id, tt.want.Account.Id) // account does not match
+			is.Equal(dbTransaction.TerminalId, tt.want.TerminalId) // terminal id does not match
 
 		})
 	}
@@ -150,13 +160,13 @@ func TestTransactionModel_Get(t *testing.T) {
 	t.Run("get a transaction", func(t *testing.T) {
 		is := is.New(t)
 
-		_transactionModel.accounts = &mock.AccountRepository{
+		_transactionModel = NewTransactionRepository(_conn, &mock.AccountRepository{
 			ReadFunc: func(id int32) (account *api.Account, err error) {
 				return &api.Account{
 					Id: id,
 				}, nil
 			},
-		}
+		}, nil)
 		created, _ := ptypes.TimestampProto(time.Date(2019, 01, 17, 16, 15, 14, 0, time.UTC))
 
 		want := &api.Transaction{
@@ -306,7 +316,7 @@ func TestTransactionModel_GetAll(t *testing.T) {
 			td := initDbForTransactionList(t)
 			defer td()
 
-			got, count, err := _transactionModel.GetAll(context.Background(), tt.input.accountId, tt.input.order, tt.input.limit, tt.input.offset)
+			got, count, err := _transactionModel.GetAll(context.Background(), repositories.TransactionFilter{AccountId: tt.input.accountId, Order: tt.input.order}, tt.input.limit, tt.input.offset)
 			is.NoErr(err)
 			is.Equal(got, tt.want)
 			is.Equal(count, tt.wantCount)
@@ -315,6 +325,85 @@ func TestTransactionModel_GetAll(t *testing.T) {
 
 }
 
+func TestTransactionModel_Export(t *testing.T) {
+	is, teardown := initTransactionIntegrationTest(t)
+	defer teardown()
+
+	tests := []struct {
+		name   string
+		filter repositories.TransactionFilter
+		want   []*api.Transaction
+	}{
+		{
+			name:   "export all transactions",
+			filter: repositories.TransactionFilter{},
+			want:   transisitonList(0),
+		},
+		{
+			name:   "export transactions for account id 1",
+			filter: repositories.TransactionFilter{AccountId: 1},
+			want:   transisitonList(1),
+		},
+		{
+			name:   "export transactions for group id 1",
+			filter: repositories.TransactionFilter{GroupId: 1},
+			want:   transisitonList(0),
+		},
+		{
+			name:   "export transactions for group without accounts",
+			filter: repositories.TransactionFilter{GroupId: 2},
+		},
+		{
+			name: "export transactions in time range",
+			filter: repositories.TransactionFilter{
+				From: time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: transisitonList(0)[3:7],
+		},
+		{
+			name:   "export transactions with order asc",
+			filter: repositories.TransactionFilter{AccountId: 2, Order: "asc"},
+			want: func() []*api.Transaction {
+				s := SortTransactions(transisitonList(2))
+				sort.Sort(s)
+				return s
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			td := initDbForTransactionList(t)
+			defer td()
+
+			var got []*api.Transaction
+			err := _transactionModel.Export(context.Background(), tt.filter, func(transaction *api.Transaction) error {
+				got = append(got, transaction)
+				return nil
+			})
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+
+	t.Run("stops with error from fn", func(t *testing.T) {
+		is := is.New(t)
+		td := initDbForTransactionList(t)
+		defer td()
+
+		wantErr := errors.New("stop")
+		calls := 0
+		err := _transactionModel.Export(context.Background(), repositories.TransactionFilter{}, func(transaction *api.Transaction) error {
+			calls++
+			return wantErr
+		})
+		is.Equal(err, wantErr)
+		is.Equal(calls, 1)
+	})
+}
+
 func TestTransactionModel_DeleteAllByAccount(t *testing.T) {
 	is, teardown := initTransactionIntegrationTest(t)
 	defer teardown()
@@ -441,6 +530,14 @@ func transisitonList(accountId int) []*api.Transaction {
 	}
 }
 
+type publisherMock struct {
+	published []*api.Transaction
+}
+
+func (p *publisherMock) Publish(transaction *api.Transaction) {
+	p.published = append(p.published, transaction)
+}
+
 type SortTransactions []*api.Transaction
 
 func (s SortTransactions) Less(i, j int) bool {
@@ -479,9 +576,9 @@ func initDbForTransactionList(t *testing.T) func() error {
 func initTransactionIntegrationTest(t *testing.T) (*isPkg.I, func()) {
 	test.IsIntegrationTest(t)
 	is := isPkg.New(t)
-	_transactionModel.accounts = accountMock
+	_transactionModel = NewTransactionRepository(_conn, accountMock, nil)
 	return is, func() {
-		_transactionModel.accounts = nil
+		_transactionModel = NewTransactionRepository(_conn, nil, nil)
 	}
 }
 
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/go-sql-driver/mysql"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/jheimbach/nfc-cash-system/api"
 	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
@@ -31,12 +30,8 @@ func (u *UserRepository) Create(ctx context.Context, name, email, password strin
 
 	insertSql := `INSERT INTO users (name,email,hashed_password,created) VALUES(?,?,?,UTC_TIMESTAMP())`
 	_, err = u.db.ExecContext(ctx, insertSql, name, email, string(hashedPassword))
-	if err != nil {
-		if mysqlErr, ok := err.(*mysql.MySQLError); ok {
-			if mysqlErr.Number == 1062 {
-				return repositories.ErrDuplicateEmail
-			}
-		}
+	if err != nil && isUniqueViolation(err) {
+		return repositories.ErrDuplicateEmail
 	}
 
 	return err
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// dialect is the sql of postgres for the repositories of sqlcommon
+var dialect = &sqlcommon.Dialect{
+	Placeholder: func(n int) string {
+		return "$" + strconv.Itoa(n)
+	},
+	// only the rows of table are locked, not the rows of the tables it is joined with
+	LockRows: func(table string) string {
+		return " FOR UPDATE OF " + table
+	},
+	ReturningId: true,
+	TimeBucket: func(column string, hour bool) string {
+		if hour {
+			return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:00:00')", column)
+		}
+		return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD 00:00:00')", column)
+	},
+	Like: func(column, placeholder string) string {
+		return column + " ILIKE " + placeholder
+	},
+	// chip uids have a unique index on lower(nfc_chip_uid)
+	FoldCase: func(expr string) string {
+		return "lower(" + expr + ")"
+	},
+	IsUniqueViolation:     isUniqueViolation,
+	IsForeignKeyViolation: isForeignKeyViolation,
+}
@@ -0,0 +1,2 @@
+// postgres provides repositories account, groups, transactions and users data storage with the postgres database
+package postgres
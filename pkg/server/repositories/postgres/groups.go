@@ -0,0 +1,16 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// GroupRepository provides API for the account_groups table
+type GroupRepository struct {
+	*sqlcommon.Groups
+}
+
+func NewGroupRepository(db *sql.DB) *GroupRepository {
+	return &GroupRepository{Groups: sqlcommon.NewGroups(db, dialect)}
+}
@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// TransactionRepository provides API for the transactions table
+type TransactionRepository struct {
+	*sqlcommon.Transactions
+}
+
+// NewTransactionRepository returns a TransactionRepository, every created transaction is sent to publisher.
+// publisher can be nil
+func NewTransactionRepository(db *sql.DB, accounts repositories.AccountStorager, publisher repositories.TransactionPublisher) *TransactionRepository {
+	return &TransactionRepository{Transactions: sqlcommon.NewTransactions(db, dialect, accounts, publisher)}
+}
@@ -3,6 +3,9 @@ package repositories
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jheimbach/nfc-cash-system/api"
 )
@@ -18,40 +21,182 @@ var (
 	ErrAccountNotFound    = errors.New("account for given id does not exist")
 	ErrUserNotFound       = errors.New("user for given id does not exist")
 	ErrUpdateSaldo        = errors.New("cannot update saldo with update, use UpdateSaldo instead")
+	ErrMissingField       = errors.New("required field is missing")
+	ErrAccountBlocked     = errors.New("account is blocked")
+	ErrTerminalNotFound   = errors.New("terminal for given id does not exist")
+	ErrDuplicateTerminal  = errors.New("duplicate terminal id")
 )
 
+// ImportError describes why a single row of an account import was rejected,
+// Row starts with 1 for the first account of the import
+type ImportError struct {
+	Row       int
+	NfcChipId string
+	Err       error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// ImportErrors is returned by AccountStorager.Import if at least one row is rejected
+type ImportErrors []*ImportError
+
+func (e ImportErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d rows rejected: %s", len(e), strings.Join(msgs, "; "))
+}
+
 type AccountStorager interface {
 	Create(ctx context.Context, name, description string, startSaldo float64, groupId int32, nfcChipId string) (*api.Account, error)
 
-	GetAll(ctx context.Context, groupId, limit, offset int32) ([]*api.Account, int, error)
+	// GetAll returns the accounts matching filter in the order of filter and the number of all matching accounts
+	GetAll(ctx context.Context, filter AccountFilter, limit, offset int32) ([]*api.Account, int, error)
+	// GetPage returns up to size accounts matching filter that come after the cursor in the order of filter,
+	// after nil starts with the first account
+	GetPage(ctx context.Context, filter AccountFilter, after *Cursor, size int32) ([]*api.Account, error)
+	// Count returns the number of accounts matching filter
+	Count(ctx context.Context, filter AccountFilter) (int, error)
 	GetAllByIds(ctx context.Context, ids []int32) (map[int32]*api.Account, error)
 
 	Read(ctx context.Context, id int32) (*api.Account, error)
+	// ReadByNfcChipId returns ErrNotFound if no account has the chip
+	ReadByNfcChipId(ctx context.Context, nfcChipId string) (*api.Account, error)
+	// Delete returns ErrNonEmptyDelete if the account still has transactions
 	Delete(ctx context.Context, id int32) error
 	Update(ctx context.Context, m *api.Account) (*api.Account, error)
 
 	UpdateSaldo(ctx context.Context, m *api.Account, newSaldo float64) error
+
+	// Import creates all given accounts in one database transaction, either all accounts are saved or none.
+	// If dryRun is true, the accounts are only validated and returned without ids.
+	// Rejected rows are returned as ImportErrors
+	Import(ctx context.Context, accounts []*api.CreateAccountRequest, dryRun bool) ([]*api.Account, error)
+
+	// IssueChipCounter returns the counter for the next balance blob that is written to the chip of the account.
+	// seen is the counter of the blob that was read from the chip, replayed is true if a newer blob was issued before.
+	// Returns ErrAccountNotFound if the account does not exist
+	IssueChipCounter(ctx context.Context, id int32, seen uint32) (issued uint32, replayed bool, err error)
 }
 
 type GroupStorager interface {
 	Create(ctx context.Context, name, description string, canOverdraw bool) (*api.Group, error)
 
 	GetAll(ctx context.Context, limit, offset int32) ([]*api.Group, int, error)
+	// GetPage returns up to size groups ordered by id that come after the cursor, after nil starts with the first group
+	GetPage(ctx context.Context, after *Cursor, size int32) ([]*api.Group, error)
+	Count(ctx context.Context) (int, error)
 	GetAllByIds(ctx context.Context, ids []int32) (map[int32]*api.Group, error)
 
 	Read(ctx context.Context, id int32) (*api.Group, error)
 	Update(ctx context.Context, group *api.Group) (*api.Group, error)
+	// Delete returns ErrNonEmptyDelete if accounts are associated with the group
 	Delete(ctx context.Context, id int32) error
 }
 
 type TransactionStorager interface {
-	Create(ctx context.Context, amount float64, accountId int32) (*api.Transaction, error)
+	Create(ctx context.Context, amount float64, accountId int32, terminalId string) (*api.Transaction, error)
 
-	GetAll(ctx context.Context, accountId int32, order string, limit, offset int32) ([]*api.Transaction, int, error)
+	// GetAll returns the transactions matching filter ordered by created in the order of filter
+	// and the number of all matching transactions
+	GetAll(ctx context.Context, filter TransactionFilter, limit, offset int32) ([]*api.Transaction, int, error)
+	// GetPage returns up to size transactions matching filter ordered by created and id that come after the cursor
+	// in the order of filter, after nil starts with the first transaction
+	GetPage(ctx context.Context, filter TransactionFilter, after *Cursor, size int32) ([]*api.Transaction, error)
+	// Count returns the number of transactions matching filter
+	Count(ctx context.Context, filter TransactionFilter) (int, error)
 
 	Read(ctx context.Context, id int32) (*api.Transaction, error)
 
 	DeleteAllByAccount(ctx context.Context, accountId int32) error
+
+	// Export calls fn for every transaction that matches filter, the transactions are read in batches
+	// and not loaded into memory at once. Export stops with the error returned by fn
+	Export(ctx context.Context, filter TransactionFilter, fn func(*api.Transaction) error) error
+
+	// ApplyOffline applies the offline transactions of the terminal in the given order, the signatures must already be verified.
+	// Transactions with a sequence that was already processed for the terminal are reported as DUPLICATE_SEQUENCE conflicts,
+	// all other conflicts use up their sequence. Returns ErrTerminalNotFound if the terminal does not exist
+	ApplyOffline(ctx context.Context, terminalId string, transactions []*api.OfflineTransaction) (*api.SyncOfflineTransactionsResponse, error)
+}
+
+// TerminalStorager stores the terminals that are allowed to sync offline transactions
+type TerminalStorager interface {
+	// Register saves a new terminal, returns ErrDuplicateTerminal if a terminal with id already exists
+	Register(ctx context.Context, id, name string, publicKey []byte) (*api.Terminal, error)
+	// Read returns ErrTerminalNotFound if no terminal with id exists
+	Read(ctx context.Context, id string) (*api.Terminal, error)
+}
+
+// Cursor is the position of an entry in a list, the next page starts after it.
+// Transactions are ordered by Created and Id, groups only by Id.
+// Accounts are ordered by Id or by Name or Saldo and Id, depending on AccountFilter.SortBy
+type Cursor struct {
+	Created time.Time
+	Name    string
+	Saldo   float64
+	Id      int32
+}
+
+// AccountFilter limits and orders the accounts returned by AccountStorager, zero values are ignored.
+// Search matches a part of name or description and NfcChipIdPrefix the start of the nfc chip id, both ignore case.
+// MinSaldo and MaxSaldo are inclusive, an empty Status matches accounts of every status.
+// Accounts with the same name or saldo are ordered by id
+type AccountFilter struct {
+	GroupId         int32
+	Search          string
+	NfcChipIdPrefix string
+	MinSaldo        *float64
+	MaxSaldo        *float64
+	Status          []api.Account_Status
+	SortBy          api.ListAccountsRequest_SortBy
+	Descending      bool
+}
+
+// TransactionPublisher is informed about every transaction after it is saved,
+// it must not keep transaction, the storage returns it to the caller that may change it
+type TransactionPublisher interface {
+	Publish(transaction *api.Transaction)
+}
+
+// TransactionFilter limits the transactions returned by TransactionStorager, zero values are ignored.
+// From is inclusive, To is exclusive, MinAmount and MaxAmount are inclusive.
+// Order ASC or asc sorts ascending by created, all other orders descending
+type TransactionFilter struct {
+	AccountId  int32
+	GroupId    int32
+	TerminalId string
+	From       time.Time
+	To         time.Time
+	MinAmount  *float64
+	MaxAmount  *float64
+	Order      string
+}
+
+// LedgerVerifier checks the transaction chain of the accounts against their saldo
+type LedgerVerifier interface {
+	// VerifyLedger checks all accounts or only the account with accountId if it is greater than 0.
+	// If fix is true, every saldo mismatch is corrected with an ADJUSTMENT transaction or marked as resolved if the saldos
+	// match again by then, chain breaks are only reported
+	VerifyLedger(ctx context.Context, accountId int32, fix bool) (*api.LedgerReport, error)
+}
+
+// ReportStorager computes aggregates over transactions and accounts, ADJUSTMENT transactions are left out.
+// A zero from or to time leaves that side of the time range open
+type ReportStorager interface {
+	// Revenue sums up purchases (positive amounts) per bucket of groupBy
+	Revenue(ctx context.Context, from, to time.Time, groupBy api.RevenueRequest_Grouping) ([]*api.RevenueBucket, error)
+	CashFlow(ctx context.Context, from, to time.Time) (*api.CashFlowReport, error)
+	// OutstandingBalance returns the balance of all accounts as it was at time at, zero means now
+	OutstandingBalance(ctx context.Context, at time.Time) (*api.BalanceReport, error)
+	AverageSpend(ctx context.Context, from, to time.Time) (*api.AverageSpendReport, error)
 }
 
 type Authenticator interface {
@@ -0,0 +1,607 @@
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// accountSelect selects the columns of an account and its group, the group is joined so an account is read with one query
+const accountSelect = `SELECT a.id, a.name, a.description, a.saldo, a.nfc_chip_uid, a.status, g.id, g.name, g.description, g.can_overdraw
+	FROM accounts a JOIN account_groups g ON g.id = a.group_id`
+
+// Accounts provides API for the accounts table, the account repositories of the sql databases embed it
+type Accounts struct {
+	db      *sql.DB
+	dialect *Dialect
+	groups  repositories.GroupStorager
+}
+
+func NewAccounts(db *sql.DB, dialect *Dialect, groups repositories.GroupStorager) *Accounts {
+	return &Accounts{
+		db:      db,
+		dialect: dialect,
+		groups:  groups,
+	}
+}
+
+// Create inserts new account it returns error repositories.ErrGroupNotFound if the groupId is not associated with a group
+// it returns repositories.ErrDuplicateNfcChipId if the provided nfcchipid is already in the database present
+func (a *Accounts) Create(ctx context.Context, name, description string, startSaldo float64, groupId int32, nfcChipId string) (*api.Account, error) {
+	group, err := a.groups.Read(ctx, groupId)
+	if err != nil {
+		if err == repositories.ErrNotFound {
+			return nil, repositories.ErrGroupNotFound
+		}
+		return nil, err
+	}
+
+	args := a.dialect.args()
+	createStmt := `INSERT INTO accounts (name, description, saldo, group_id, nfc_chip_uid) VALUES (` +
+		args.add(name) + `,` + args.add(createNullableString(description)) + `,` + args.add(startSaldo) + `,` +
+		args.add(group.Id) + `,` + args.add(nfcChipId) + `)`
+	id, err := a.dialect.insert(ctx, a.db, createStmt, args)
+	if err != nil {
+		if a.dialect.IsUniqueViolation(err) {
+			return nil, repositories.ErrDuplicateNfcChipId
+		}
+		return nil, err
+	}
+
+	return &api.Account{
+		Id:          id,
+		Name:        name,
+		Description: description,
+		Saldo:       startSaldo,
+		NfcChipId:   nfcChipId,
+		Group:       group,
+	}, nil
+}
+
+// Read returns account struct for given id
+func (a *Accounts) Read(ctx context.Context, id int32) (*api.Account, error) {
+	args := a.dialect.args()
+	return a.readWhere(ctx, "a.id="+args.add(id), args)
+}
+
+// ReadByNfcChipId returns the account of the nfc chip, chip ids are compared case insensitive
+func (a *Accounts) ReadByNfcChipId(ctx context.Context, nfcChipId string) (*api.Account, error) {
+	args := a.dialect.args()
+	return a.readWhere(ctx, a.dialect.FoldCase("a.nfc_chip_uid")+"="+a.dialect.FoldCase(args.add(nfcChipId)), args)
+}
+
+// readWhere returns the account that matches the where condition, the columns of accounts have the alias a
+func (a *Accounts) readWhere(ctx context.Context, where string, args *args) (*api.Account, error) {
+	m, err := scanAccount(a.db.QueryRowContext(ctx, accountSelect+` WHERE `+where, args.values...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Update saves the (changed) model in the database will return repositories.ErrGroupNotFound if group id is not associated with a group
+func (a *Accounts) Update(ctx context.Context, m *api.Account) (*api.Account, error) {
+	acc, err := a.Read(ctx, m.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Saldo != 0 && m.Saldo != acc.Saldo {
+		return nil, repositories.ErrUpdateSaldo
+	}
+
+	g, err := a.groups.Read(ctx, m.Group.Id)
+	if err != nil {
+		return nil, repositories.ErrGroupNotFound
+	}
+
+	args := a.dialect.args()
+	updateStmt := `UPDATE accounts SET name=` + args.add(m.Name) + `, description=` + args.add(m.Description) +
+		`, group_id=` + args.add(m.Group.Id) + `, nfc_chip_uid=` + args.add(m.NfcChipId) +
+		`, status=` + args.add(m.Status.String()) + ` WHERE id=` + args.add(m.Id)
+	_, err = a.db.ExecContext(ctx, updateStmt, args.values...)
+	if err != nil {
+		if a.dialect.IsUniqueViolation(err) {
+			return nil, repositories.ErrDuplicateNfcChipId
+		}
+		return nil, err
+	}
+
+	acc.Name = m.Name
+	acc.Description = m.Description
+	acc.NfcChipId = m.NfcChipId
+	acc.Group = g
+	acc.Status = m.Status
+
+	return acc, nil
+}
+
+// Delete deletes a account, returns repositories.ErrNonEmptyDelete if the account still has transactions
+func (a *Accounts) Delete(ctx context.Context, id int32) error {
+	args := a.dialect.args()
+	_, err := a.db.ExecContext(ctx, `DELETE FROM accounts WHERE id=`+args.add(id), args.values...)
+	if err != nil {
+		if a.dialect.IsForeignKeyViolation(err) {
+			return repositories.ErrNonEmptyDelete
+		}
+		return err
+	}
+
+	return nil
+}
+
+// UpdateSaldo provides update method for the saldo field
+func (a *Accounts) UpdateSaldo(ctx context.Context, m *api.Account, newSaldo float64) error {
+	args := a.dialect.args()
+	_, err := a.db.ExecContext(ctx, `UPDATE accounts SET saldo=`+args.add(newSaldo)+` WHERE id=`+args.add(m.Id), args.values...)
+
+	return err
+}
+
+// GetAll returns slice with the accounts matching filter and the number of all matching accounts
+func (a *Accounts) GetAll(ctx context.Context, filter repositories.AccountFilter, limit int32, offset int32) ([]*api.Account, int, error) {
+	args := a.dialect.args()
+	stmt := fmt.Sprintf("%s%s ORDER BY %s", accountSelect, whereClause(a.accountConditions(filter, args)), accountOrderBy(filter))
+
+	// if limit is set
+	// add LIMIT clause to select query
+	if limit > 0 {
+		stmt = fmt.Sprintf("%s LIMIT %s", stmt, args.add(limit))
+
+		// if limit and offset is set
+		// add OFFSET clause to select query
+		if offset > 0 {
+			stmt = fmt.Sprintf("%s OFFSET %s", stmt, args.add(offset))
+		}
+	}
+
+	// get rows from database
+	rows, err := a.db.QueryContext(ctx, stmt, args.values...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	// scan rows to account objects
+	accounts, err := scanRowsToAccounts(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// set totalCount to length of accounts slice
+	totalCount := len(accounts)
+
+	// if limit is set, ask the database for the total amount
+	// we don't have to ask the database if no limit is set, because all matching accounts are in the account slice
+	if limit > 0 {
+		totalCount, err = a.Count(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return accounts, totalCount, nil
+}
+
+// GetPage returns up to size accounts matching filter that come after the cursor in the order of filter,
+// after nil starts with the first account
+func (a *Accounts) GetPage(ctx context.Context, filter repositories.AccountFilter, after *repositories.Cursor, size int32) ([]*api.Account, error) {
+	args := a.dialect.args()
+	conditions := a.accountConditions(filter, args)
+	if after != nil {
+		conditions = append(conditions, accountCursorCondition(filter, after, args))
+	}
+	stmt := fmt.Sprintf("%s%s ORDER BY %s LIMIT %s", accountSelect, whereClause(conditions), accountOrderBy(filter), args.add(size))
+
+	rows, err := a.db.QueryContext(ctx, stmt, args.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanRowsToAccounts(rows)
+}
+
+// Count counts the account rows in the database that match filter
+func (a *Accounts) Count(ctx context.Context, filter repositories.AccountFilter) (int, error) {
+	args := a.dialect.args()
+	countStmt := `SELECT COUNT(a.id) FROM accounts a` + whereClause(a.accountConditions(filter, args))
+
+	var totalCount int
+	err := a.db.QueryRowContext(ctx, countStmt, args.values...).Scan(&totalCount)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalCount, nil
+}
+
+// whereClause returns the WHERE clause of conditions with a leading space, empty if there are no conditions
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// accountConditions returns the conditions of filter and adds their values to args,
+// the texts are matched with the case insensitive LIKE of the dialect
+func (a *Accounts) accountConditions(filter repositories.AccountFilter, args *args) []string {
+	var conditions []string
+
+	if filter.GroupId > 0 {
+		conditions = append(conditions, "a.group_id = "+args.add(filter.GroupId))
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		conditions = append(conditions, fmt.Sprintf("(%s OR %s)",
+			a.dialect.Like("a.name", args.add(pattern)), a.dialect.Like("a.description", args.add(pattern))))
+	}
+	if filter.NfcChipIdPrefix != "" {
+		conditions = append(conditions, a.dialect.Like("a.nfc_chip_uid", args.add(escapeLike(filter.NfcChipIdPrefix)+"%")))
+	}
+	if filter.MinSaldo != nil {
+		conditions = append(conditions, "a.saldo >= "+args.add(*filter.MinSaldo))
+	}
+	if filter.MaxSaldo != nil {
+		conditions = append(conditions, "a.saldo <= "+args.add(*filter.MaxSaldo))
+	}
+	if len(filter.Status) > 0 {
+		statuses := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			statuses[i] = args.add(status.String())
+		}
+		conditions = append(conditions, "a.status IN ("+strings.Join(statuses, ",")+")")
+	}
+
+	return conditions
+}
+
+// accountSortColumn returns the column the accounts are sorted by before the id
+func accountSortColumn(filter repositories.AccountFilter) string {
+	switch filter.SortBy {
+	case api.ListAccountsRequest_NAME:
+		return "a.name"
+	case api.ListAccountsRequest_SALDO:
+		return "a.saldo"
+	}
+	return ""
+}
+
+// accountOrderBy returns the ORDER BY expression of filter, accounts with equal sort column are ordered by id
+func accountOrderBy(filter repositories.AccountFilter) string {
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	column := accountSortColumn(filter)
+	if column == "" {
+		return "a.id " + direction
+	}
+	return fmt.Sprintf("%s %s, a.id %s", column, direction, direction)
+}
+
+// accountCursorCondition returns the condition that matches all accounts after the cursor in the order of filter
+// and adds its values to args
+func accountCursorCondition(filter repositories.AccountFilter, after *repositories.Cursor, args *args) string {
+	comparison := ">"
+	if filter.Descending {
+		comparison = "<"
+	}
+
+	column := accountSortColumn(filter)
+	if column == "" {
+		return "a.id " + comparison + " " + args.add(after.Id)
+	}
+
+	var value interface{} = after.Name
+	if filter.SortBy == api.ListAccountsRequest_SALDO {
+		value = after.Saldo
+	}
+	return fmt.Sprintf("(%[1]s %[2]s %[3]s OR (%[1]s = %[4]s AND a.id %[2]s %[5]s))",
+		column, comparison, args.add(value), args.add(value), args.add(after.Id))
+}
+
+// escapeLike escapes the wildcards of LIKE in s with backslash, the escape character of Dialect.Like
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// GetAllByIds returns map of accounts, is used by to complete objects that are dependent on accounts.
+// The ids are read in chunks of at most MaxInClauseIds, an empty ids returns an empty map without a query
+func (a *Accounts) GetAllByIds(ctx context.Context, ids []int32) (map[int32]*api.Account, error) {
+	m := make(map[int32]*api.Account, len(ids))
+
+	err := a.dialect.inChunks(ids, func(in string, args *args) error {
+		rows, err := a.db.QueryContext(ctx, accountSelect+` WHERE a.id IN (`+in+`)`, args.values...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		accounts, err := scanRowsToAccounts(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, account := range accounts {
+			m[account.Id] = account
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// scanRowsToAccounts returns slice of Accounts from given sql.Rows of accountSelect
+func scanRowsToAccounts(rows *sql.Rows) ([]*api.Account, error) {
+	var accounts []*api.Account
+
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+// rowScanner is implemented by sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAccount scans the columns of accountSelect into an account with its group
+func scanAccount(row rowScanner) (*api.Account, error) {
+	account := &api.Account{Group: &api.Group{}}
+	var nullDesc, nullGroupDesc sql.NullString
+	var accountStatus string
+
+	err := row.Scan(
+		&account.Id, &account.Name, &nullDesc, &account.Saldo, &account.NfcChipId, &accountStatus,
+		&account.Group.Id, &account.Group.Name, &nullGroupDesc, &account.Group.CanOverdraw,
+	)
+	if err != nil {
+		return nil, err
+	}
+	account.Description = decodeNullableString(nullDesc)
+	account.Status = decodeAccountStatus(accountStatus)
+	account.Group.Description = decodeNullableString(nullGroupDesc)
+
+	return account, nil
+}
+
+// importChunkSize limits the number of nfc chip ids that are checked with one query while importing accounts
+const importChunkSize = 500
+
+// Import validates and creates all given accounts in a single database transaction.
+// Rows without name or nfc chip id, with an unknown group or with an nfc chip id that is already
+// in use (in the database or in an earlier row) are rejected with repositories.ImportErrors, in that case nothing is saved.
+// With dryRun the accounts are only validated, returned accounts have no id.
+func (a *Accounts) Import(ctx context.Context, rows []*api.CreateAccountRequest, dryRun bool) ([]*api.Account, error) {
+	var importErrs repositories.ImportErrors
+	rejectRow := func(row int, nfcChipId string, err error) {
+		importErrs = append(importErrs, &repositories.ImportError{Row: row + 1, NfcChipId: nfcChipId, Err: err})
+	}
+
+	// check required fields and duplicates within the import itself
+	chipRows := make(map[string]int, len(rows))
+	var groupIds []int32
+	groupIdLookup := make(map[int32]bool)
+	for i, row := range rows {
+		if row.Name == "" || row.NfcChipId == "" {
+			rejectRow(i, row.NfcChipId, repositories.ErrMissingField)
+			continue
+		}
+		if _, ok := chipRows[row.NfcChipId]; ok {
+			rejectRow(i, row.NfcChipId, repositories.ErrDuplicateNfcChipId)
+			continue
+		}
+		chipRows[row.NfcChipId] = i
+
+		if !groupIdLookup[row.GroupId] {
+			groupIdLookup[row.GroupId] = true
+			groupIds = append(groupIds, row.GroupId)
+		}
+	}
+
+	groups := make(map[int32]*api.Group)
+	if len(groupIds) > 0 {
+		var err error
+		groups, err = a.groups.GetAllByIds(ctx, groupIds)
+		if err != nil && err != repositories.ErrNotFound {
+			return nil, err
+		}
+	}
+
+	existingChips, err := a.existingNfcChipIds(ctx, chipRows)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*api.Account, 0, len(rows))
+	for i, row := range rows {
+		if idx, ok := chipRows[row.NfcChipId]; !ok || idx != i {
+			// row was already rejected
+			continue
+		}
+		if existingChips[strings.ToLower(row.NfcChipId)] {
+			rejectRow(i, row.NfcChipId, repositories.ErrDuplicateNfcChipId)
+			continue
+		}
+		group, ok := groups[row.GroupId]
+		if !ok {
+			rejectRow(i, row.NfcChipId, repositories.ErrGroupNotFound)
+			continue
+		}
+
+		accounts = append(accounts, &api.Account{
+			Name:        row.Name,
+			Description: row.Description,
+			Saldo:       row.Saldo,
+			NfcChipId:   row.NfcChipId,
+			Group:       group,
+		})
+	}
+
+	if len(importErrs) > 0 {
+		sort.Slice(importErrs, func(i, j int) bool { return importErrs[i].Row < importErrs[j].Row })
+		return nil, importErrs
+	}
+
+	if dryRun {
+		return accounts, nil
+	}
+
+	err = a.insertAccounts(ctx, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// insertAccounts saves all accounts within one database transaction and sets the ids on the given accounts
+func (a *Accounts) insertAccounts(ctx context.Context, accounts []*api.Account) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	p := a.dialect.Placeholder
+	stmt, err := a.dialect.prepareInsert(ctx, tx, `INSERT INTO accounts (name, description, saldo, group_id, nfc_chip_uid) VALUES (`+
+		p(1)+`,`+p(2)+`,`+p(3)+`,`+p(4)+`,`+p(5)+`)`)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for i, account := range accounts {
+		id, err := stmt.insert(ctx, account.Name, createNullableString(account.Description), account.Saldo, account.Group.Id, account.NfcChipId)
+		if err != nil {
+			_ = tx.Rollback()
+			// someone else could have used the nfc chip id after the validation
+			if a.dialect.IsUniqueViolation(err) {
+				return repositories.ImportErrors{
+					{Row: i + 1, NfcChipId: account.NfcChipId, Err: repositories.ErrDuplicateNfcChipId},
+				}
+			}
+			return err
+		}
+		account.Id = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		for _, account := range accounts {
+			account.Id = 0
+		}
+		return err
+	}
+
+	return nil
+}
+
+// existingNfcChipIds returns all of the given nfc chip ids that are already used by an account in lower case,
+// chip ids are compared case insensitive
+func (a *Accounts) existingNfcChipIds(ctx context.Context, chips map[string]int) (map[string]bool, error) {
+	existing := make(map[string]bool)
+
+	args := a.dialect.args()
+	var placeholders []string
+	query := func() error {
+		if len(placeholders) == 0 {
+			return nil
+		}
+		stmt := `SELECT nfc_chip_uid FROM accounts WHERE ` + a.dialect.FoldCase("nfc_chip_uid") + ` IN (` + strings.Join(placeholders, ",") + `)`
+		rows, err := a.db.QueryContext(ctx, stmt, args.values...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var chip string
+			if err := rows.Scan(&chip); err != nil {
+				return err
+			}
+			existing[strings.ToLower(chip)] = true
+		}
+		args = a.dialect.args()
+		placeholders = placeholders[:0]
+		return rows.Err()
+	}
+
+	for chip := range chips {
+		placeholders = append(placeholders, a.dialect.FoldCase(args.add(chip)))
+		if len(placeholders) == importChunkSize {
+			if err := query(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := query(); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// IssueChipCounter increases the chip counter of the account past seen and returns it.
+// The counter is read and written in one database transaction that locks the account, so two terminals never get the same counter
+func (a *Accounts) IssueChipCounter(ctx context.Context, id int32, seen uint32) (uint32, bool, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	issued, replayed, err := a.issueChipCounterTx(ctx, tx, id, seen)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, false, err
+	}
+
+	return issued, replayed, tx.Commit()
+}
+
+func (a *Accounts) issueChipCounterTx(ctx context.Context, tx *sql.Tx, id int32, seen uint32) (uint32, bool, error) {
+	args := a.dialect.args()
+	selectStmt := `SELECT chip_counter FROM accounts WHERE id = ` + args.add(id) + a.dialect.LockRows("accounts")
+
+	var stored uint32
+	err := tx.QueryRowContext(ctx, selectStmt, args.values...).Scan(&stored)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, repositories.ErrAccountNotFound
+		}
+		return 0, false, err
+	}
+
+	replayed := stored > seen
+	issued := seen + 1
+	if replayed {
+		issued = stored + 1
+	}
+
+	args = a.dialect.args()
+	_, err = tx.ExecContext(ctx, `UPDATE accounts SET chip_counter = `+args.add(issued)+` WHERE id = `+args.add(id), args.values...)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return issued, replayed, nil
+}
@@ -0,0 +1,154 @@
+// Package sqlcommon contains the repository logic that is the same for all sql databases,
+// a Dialect describes the statements that differ between them
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// MaxInClauseIds limits the number of placeholders in one IN clause, short IN clauses keep statements and their packets small
+const MaxInClauseIds = 500
+
+// Dialect describes the sql of a database driver
+type Dialect struct {
+	// Placeholder returns the placeholder of the nth argument of a statement, n starts with 1
+	Placeholder func(n int) string
+	// LockRows returns the clause that locks the rows of table a SELECT reads until the database transaction ends,
+	// it is empty for databases whose transactions lock on their own
+	LockRows func(table string) string
+	// ReturningId is true if an INSERT returns the new id with RETURNING id instead of the last insert id of the result
+	ReturningId bool
+	// TimeBucket returns the expression that truncates the time in column to the hour or the day,
+	// formatted as 2006-01-02 15:04:05
+	TimeBucket func(column string, hour bool) string
+	// Like returns the condition that column matches the LIKE pattern in placeholder without regard to case,
+	// backslash escapes the wildcards of the pattern
+	Like func(column, placeholder string) string
+	// FoldCase returns expr so that comparing it with = ignores the case,
+	// it is expr itself for databases whose columns compare with a case insensitive collation
+	FoldCase func(expr string) string
+	// IsUniqueViolation returns true if err of the driver is a violated unique constraint
+	IsUniqueViolation func(err error) bool
+	// IsForeignKeyViolation returns true if err of the driver is a violated foreign key constraint
+	IsForeignKeyViolation func(err error) bool
+}
+
+// QuestionMark returns the ? placeholder of mysql and sqlite
+func QuestionMark(int) string {
+	return "?"
+}
+
+// args collects the arguments of a statement that is built step by step
+type args struct {
+	dialect *Dialect
+	values  []interface{}
+}
+
+func (d *Dialect) args() *args {
+	return &args{dialect: d}
+}
+
+// add appends v to the arguments and returns its placeholder, times are passed in UTC
+func (a *args) add(v interface{}) string {
+	if t, ok := v.(time.Time); ok {
+		v = t.UTC()
+	}
+	a.values = append(a.values, v)
+	return a.dialect.Placeholder(len(a.values))
+}
+
+// in adds every id to the arguments and returns their placeholders separated by commas for an IN clause
+func (a *args) in(ids []int32) string {
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		placeholders[i] = a.add(id)
+	}
+	return strings.Join(placeholders, ",")
+}
+
+// inChunks calls fn for every chunk of at most MaxInClauseIds ids with the args that hold the chunk
+// and the placeholders of the IN clause
+func (d *Dialect) inChunks(ids []int32, fn func(in string, a *args) error) error {
+	for start := 0; start < len(ids); start += MaxInClauseIds {
+		end := start + MaxInClauseIds
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		a := d.args()
+		if err := fn(a.in(ids[start:end]), a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryer is a *sql.DB or a *sql.Tx
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// insert runs insertStmt and returns the id of the inserted row
+func (d *Dialect) insert(ctx context.Context, db queryer, insertStmt string, a *args) (int32, error) {
+	if d.ReturningId {
+		var id int32
+		err := db.QueryRowContext(ctx, insertStmt+" RETURNING id", a.values...).Scan(&id)
+		return id, err
+	}
+
+	res, err := db.ExecContext(ctx, insertStmt, a.values...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int32(id), nil
+}
+
+// preparedInsert is an insert statement that is prepared once and run for many rows
+type preparedInsert struct {
+	stmt        *sql.Stmt
+	returningId bool
+}
+
+// prepareInsert prepares insertStmt on tx, the statement is closed with tx
+func (d *Dialect) prepareInsert(ctx context.Context, tx *sql.Tx, insertStmt string) (*preparedInsert, error) {
+	if d.ReturningId {
+		insertStmt += " RETURNING id"
+	}
+	stmt, err := tx.PrepareContext(ctx, insertStmt)
+	if err != nil {
+		return nil, err
+	}
+	return &preparedInsert{stmt: stmt, returningId: d.ReturningId}, nil
+}
+
+// insert runs the statement with values and returns the id of the inserted row
+func (p *preparedInsert) insert(ctx context.Context, values ...interface{}) (int32, error) {
+	if p.returningId {
+		var id int32
+		err := p.stmt.QueryRowContext(ctx, values...).Scan(&id)
+		return id, err
+	}
+
+	res, err := p.stmt.ExecContext(ctx, values...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int32(id), nil
+}
+
+// now returns the current time in UTC, sqlite compares times as text, so all databases save times in UTC
+func now() time.Time {
+	return time.Now().UTC()
+}
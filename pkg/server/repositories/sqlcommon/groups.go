@@ -0,0 +1,216 @@
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// groupSelect selects the columns of a group
+const groupSelect = `SELECT id, name, description, can_overdraw FROM account_groups`
+
+// Groups provides API for the account_groups table, the group repositories of the sql databases embed it
+type Groups struct {
+	db      *sql.DB
+	dialect *Dialect
+}
+
+func NewGroups(db *sql.DB, dialect *Dialect) *Groups {
+	return &Groups{db: db, dialect: dialect}
+}
+
+// Create inserts new group with given fields
+func (g *Groups) Create(ctx context.Context, name, description string, canOverdraw bool) (*api.Group, error) {
+	a := g.dialect.args()
+	createStmt := `INSERT INTO account_groups (name, description, can_overdraw) VALUES (` +
+		a.add(name) + `,` + a.add(createNullableString(description)) + `,` + a.add(canOverdraw) + `)`
+	id, err := g.dialect.insert(ctx, g.db, createStmt, a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Group{
+		Id:          id,
+		Name:        name,
+		Description: description,
+		CanOverdraw: canOverdraw,
+	}, nil
+}
+
+// Read returns the group for given id, will return repositories.ErrNotFound if no group is found
+func (g *Groups) Read(ctx context.Context, id int32) (*api.Group, error) {
+	a := g.dialect.args()
+	group, err := scanGroup(g.db.QueryRowContext(ctx, groupSelect+` WHERE id = `+a.add(id), a.values...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// Update saves given (changed) group to the database
+// NOTE: every field will be overwritten with given value
+func (g *Groups) Update(ctx context.Context, group *api.Group) (*api.Group, error) {
+	if group.Id == 0 {
+		return nil, repositories.ErrModelNotSaved
+	}
+
+	a := g.dialect.args()
+	updateStmt := `UPDATE account_groups SET name=` + a.add(group.Name) + `, description=` + a.add(group.Description) +
+		`, can_overdraw=` + a.add(group.CanOverdraw) + ` WHERE id=` + a.add(group.Id)
+	_, err := g.db.ExecContext(ctx, updateStmt, a.values...)
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// Delete removes group with given id from the database
+// returns repositories.ErrNonEmptyDelete if accounts are associated with group
+func (g *Groups) Delete(ctx context.Context, id int32) error {
+	a := g.dialect.args()
+	_, err := g.db.ExecContext(ctx, `DELETE FROM account_groups WHERE id=`+a.add(id), a.values...)
+	if err != nil {
+		if g.dialect.IsForeignKeyViolation(err) {
+			return repositories.ErrNonEmptyDelete
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetAll returns the groups ordered by id and the number of all groups
+func (g *Groups) GetAll(ctx context.Context, limit, offset int32) ([]*api.Group, int, error) {
+	stmt := groupSelect + ` ORDER BY id`
+
+	a := g.dialect.args()
+	if limit > 0 {
+		stmt = fmt.Sprintf("%s LIMIT %s", stmt, a.add(limit))
+		if offset > 0 {
+			stmt = fmt.Sprintf("%s OFFSET %s", stmt, a.add(offset))
+		}
+	}
+
+	rows, err := g.db.QueryContext(ctx, stmt, a.values...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	groups, err := scanGroups(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := len(groups)
+	if limit > 0 {
+		totalCount, err = g.Count(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return groups, totalCount, nil
+}
+
+// GetPage returns up to size groups ordered by id that come after the cursor, after nil starts with the first group
+func (g *Groups) GetPage(ctx context.Context, after *repositories.Cursor, size int32) ([]*api.Group, error) {
+	stmt := groupSelect
+
+	a := g.dialect.args()
+	if after != nil {
+		stmt = fmt.Sprintf("%s WHERE id > %s", stmt, a.add(after.Id))
+	}
+	stmt = fmt.Sprintf("%s ORDER BY id LIMIT %s", stmt, a.add(size))
+
+	rows, err := g.db.QueryContext(ctx, stmt, a.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanGroups(rows)
+}
+
+// Count counts the group rows in the database
+func (g *Groups) Count(ctx context.Context) (int, error) {
+	var totalCount int
+	err := g.db.QueryRowContext(ctx, `SELECT COUNT(id) FROM account_groups`).Scan(&totalCount)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalCount, nil
+}
+
+// GetAllByIds returns the groups of ids by their id, it returns repositories.ErrNotFound if ids is empty
+// and nil if none of the groups exist. The ids are read in chunks of at most MaxInClauseIds
+func (g *Groups) GetAllByIds(ctx context.Context, ids []int32) (map[int32]*api.Group, error) {
+	if len(ids) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+
+	m := make(map[int32]*api.Group, len(ids))
+	err := g.dialect.inChunks(ids, func(in string, a *args) error {
+		rows, err := g.db.QueryContext(ctx, groupSelect+` WHERE id IN (`+in+`)`, a.values...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		groups, err := scanGroups(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range groups {
+			m[group.Id] = group
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	return m, nil
+}
+
+// scanGroups returns slice of groups from given sql.Rows of groupSelect
+func scanGroups(rows *sql.Rows) ([]*api.Group, error) {
+	var groups []*api.Group
+
+	for rows.Next() {
+		group, err := scanGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// scanGroup scans the columns of groupSelect into a group
+func scanGroup(row rowScanner) (*api.Group, error) {
+	group := &api.Group{}
+	var nullDesc sql.NullString
+
+	err := row.Scan(&group.Id, &group.Name, &nullDesc, &group.CanOverdraw)
+	if err != nil {
+		return nil, err
+	}
+	group.Description = decodeNullableString(nullDesc)
+
+	return group, nil
+}
@@ -0,0 +1,396 @@
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// saldoTolerance is the maximal difference of two saldos that are considered equal, saldos are stored with 2 decimals
+const saldoTolerance = 0.005
+
+// Ledger writes the transactions that change the saldo of accounts and verifies their saldo chain,
+// the transaction repositories of the sql databases embed it
+type Ledger struct {
+	db        *sql.DB
+	dialect   *Dialect
+	accounts  repositories.AccountStorager
+	publisher repositories.TransactionPublisher
+}
+
+// NewLedger returns a Ledger, every applied transaction is sent to publisher. publisher can be nil
+func NewLedger(db *sql.DB, dialect *Dialect, accounts repositories.AccountStorager, publisher repositories.TransactionPublisher) *Ledger {
+	return &Ledger{
+		db:        db,
+		dialect:   dialect,
+		accounts:  accounts,
+		publisher: publisher,
+	}
+}
+
+// ApplyOffline applies the offline transactions of the terminal one after another, each in its own database transaction.
+// The terminal and the account are locked while a transaction is applied, sqlite locks the whole database,
+// so the saldo chain of the account stays continuous even if online payments happen at the same time. The saved transactions are created at sync time.
+// A conflict uses up the sequence of the offline transaction, a repeated sync reports it as DUPLICATE_SEQUENCE.
+func (l *Ledger) ApplyOffline(ctx context.Context, terminalId string, transactions []*api.OfflineTransaction) (*api.SyncOfflineTransactionsResponse, error) {
+	res := &api.SyncOfflineTransactionsResponse{}
+
+	a := l.dialect.args()
+	selectStmt := `SELECT last_sequence FROM terminals WHERE id = ` + a.add(terminalId)
+	err := l.db.QueryRowContext(ctx, selectStmt, a.values...).Scan(&res.LastSequence)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repositories.ErrTerminalNotFound
+		}
+		return nil, err
+	}
+
+	for _, offline := range transactions {
+		transaction, reason, err := l.applyOffline(ctx, terminalId, offline)
+		if err != nil {
+			return nil, err
+		}
+
+		if reason != api.OfflineConflict_UNKNOWN {
+			res.Conflicts = append(res.Conflicts, &api.OfflineConflict{
+				Sequence:  offline.Sequence,
+				AccountId: offline.AccountId,
+				Amount:    offline.Amount,
+				Reason:    reason,
+			})
+		} else {
+			transaction.Account, err = l.accounts.Read(ctx, offline.AccountId)
+			if err != nil {
+				return nil, err
+			}
+			if l.publisher != nil {
+				l.publisher.Publish(transaction)
+			}
+			res.Applied = append(res.Applied, transaction)
+		}
+
+		if offline.Sequence > res.LastSequence {
+			res.LastSequence = offline.Sequence
+		}
+	}
+
+	return res, nil
+}
+
+// applyOffline applies a single offline transaction, it returns either the saved transaction or the reason of the conflict
+func (l *Ledger) applyOffline(ctx context.Context, terminalId string, offline *api.OfflineTransaction) (*api.Transaction, api.OfflineConflict_Reason, error) {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, api.OfflineConflict_UNKNOWN, err
+	}
+
+	transaction, reason, err := l.applyOfflineTx(ctx, tx, terminalId, offline)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, api.OfflineConflict_UNKNOWN, err
+	}
+
+	return transaction, reason, tx.Commit()
+}
+
+func (l *Ledger) applyOfflineTx(ctx context.Context, tx *sql.Tx, terminalId string, offline *api.OfflineTransaction) (*api.Transaction, api.OfflineConflict_Reason, error) {
+	a := l.dialect.args()
+	selectStmt := `SELECT last_sequence FROM terminals WHERE id = ` + a.add(terminalId) + l.dialect.LockRows("terminals")
+
+	var lastSequence uint64
+	err := tx.QueryRowContext(ctx, selectStmt, a.values...).Scan(&lastSequence)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.OfflineConflict_UNKNOWN, repositories.ErrTerminalNotFound
+		}
+		return nil, api.OfflineConflict_UNKNOWN, err
+	}
+	if offline.Sequence <= lastSequence {
+		return nil, api.OfflineConflict_DUPLICATE_SEQUENCE, nil
+	}
+
+	transaction, reason, err := l.chargeOfflineTx(ctx, tx, terminalId, offline)
+	if err != nil {
+		return nil, api.OfflineConflict_UNKNOWN, err
+	}
+
+	a = l.dialect.args()
+	updateStmt := `UPDATE terminals SET last_sequence = ` + a.add(offline.Sequence) + ` WHERE id = ` + a.add(terminalId)
+	_, err = tx.ExecContext(ctx, updateStmt, a.values...)
+	if err != nil {
+		return nil, api.OfflineConflict_UNKNOWN, err
+	}
+
+	return transaction, reason, nil
+}
+
+// chargeOfflineTx checks the account of the offline transaction and charges it, if there is no conflict
+func (l *Ledger) chargeOfflineTx(ctx context.Context, tx *sql.Tx, terminalId string, offline *api.OfflineTransaction) (*api.Transaction, api.OfflineConflict_Reason, error) {
+	oldSaldo, status, canOverdraw, err := l.lockAccount(ctx, tx, offline.AccountId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, api.OfflineConflict_ACCOUNT_NOT_FOUND, nil
+		}
+		return nil, api.OfflineConflict_UNKNOWN, err
+	}
+
+	newSaldo := oldSaldo - offline.Amount
+	switch {
+	case status == api.Account_BLOCKED:
+		return nil, api.OfflineConflict_ACCOUNT_BLOCKED, nil
+	case offline.Amount > 0 && newSaldo < 0 && !canOverdraw:
+		return nil, api.OfflineConflict_INSUFFICIENT_BALANCE, nil
+	}
+
+	transaction, err := l.chargeTx(ctx, tx, oldSaldo, offline.Amount, offline.AccountId, terminalId)
+	if err != nil {
+		return nil, api.OfflineConflict_UNKNOWN, err
+	}
+	return transaction, api.OfflineConflict_UNKNOWN, nil
+}
+
+// Create inserts a new transaction of amount for the account, the fields OldSaldo and NewSaldo are calculated
+// from the saldo of the account. The account is locked in a database transaction while its saldo is read and changed,
+// so the saldo chain of the account stays continuous with concurrent payments and offline syncs.
+// It returns repositories.ErrAccountNotFound if the account does not exist and repositories.ErrAccountBlocked
+// if the account is blocked. terminalId is optional and identifies the terminal the transaction was made on
+func (l *Ledger) Create(ctx context.Context, amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := l.createTx(ctx, tx, amount, accountId, terminalId)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	transaction.Account, err = l.accounts.Read(ctx, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.publisher != nil {
+		l.publisher.Publish(transaction)
+	}
+
+	return transaction, nil
+}
+
+func (l *Ledger) createTx(ctx context.Context, tx *sql.Tx, amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+	oldSaldo, status, _, err := l.lockAccount(ctx, tx, accountId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repositories.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	if status == api.Account_BLOCKED {
+		return nil, repositories.ErrAccountBlocked
+	}
+
+	return l.chargeTx(ctx, tx, oldSaldo, amount, accountId, terminalId)
+}
+
+// lockAccount returns saldo and status of the account and if its group can overdraw,
+// the account is locked until tx ends. Returns sql.ErrNoRows if the account does not exist
+func (l *Ledger) lockAccount(ctx context.Context, tx *sql.Tx, accountId int32) (float64, api.Account_Status, bool, error) {
+	a := l.dialect.args()
+	selectStmt := `SELECT a.saldo, a.status, g.can_overdraw FROM accounts a
+		JOIN account_groups g ON g.id = a.group_id WHERE a.id = ` + a.add(accountId) + l.dialect.LockRows("a")
+
+	var saldo float64
+	var status string
+	var canOverdraw bool
+	err := tx.QueryRowContext(ctx, selectStmt, a.values...).Scan(&saldo, &status, &canOverdraw)
+	if err != nil {
+		return 0, api.Account_ACTIVE, false, err
+	}
+	return saldo, decodeAccountStatus(status), canOverdraw, nil
+}
+
+// chargeTx inserts the transaction of amount and changes the saldo of the locked account from oldSaldo,
+// an empty terminalId is saved as NULL
+func (l *Ledger) chargeTx(ctx context.Context, tx *sql.Tx, oldSaldo, amount float64, accountId int32, terminalId string) (*api.Transaction, error) {
+	newSaldo := oldSaldo - amount
+
+	var terminal interface{}
+	if terminalId != "" {
+		terminal = terminalId
+	}
+
+	created := now()
+	a := l.dialect.args()
+	insertStmt := `INSERT INTO transactions (new_saldo, old_saldo, amount, account_id, created, terminal_id) VALUES (` +
+		a.add(newSaldo) + `,` + a.add(oldSaldo) + `,` + a.add(amount) + `,` + a.add(accountId) + `,` +
+		a.add(created) + `,` + a.add(terminal) + `)`
+	id, err := l.dialect.insert(ctx, tx, insertStmt, a)
+	if err != nil {
+		return nil, err
+	}
+
+	a = l.dialect.args()
+	updateStmt := `UPDATE accounts SET saldo = ` + a.add(newSaldo) + ` WHERE id = ` + a.add(accountId)
+	_, err = tx.ExecContext(ctx, updateStmt, a.values...)
+	if err != nil {
+		return nil, err
+	}
+
+	createdProto, _ := ptypes.TimestampProto(created)
+
+	return &api.Transaction{
+		Id:         id,
+		OldSaldo:   oldSaldo,
+		NewSaldo:   newSaldo,
+		Amount:     amount,
+		Created:    createdProto,
+		TerminalId: terminalId,
+	}, nil
+}
+
+// VerifyLedger walks the transactions of every account in the order they were created.
+// It reports a CHAIN_BREAK for every transaction whose old_saldo is not the new_saldo of the transaction before it,
+// and a SALDO_MISMATCH if the new_saldo of the last transaction is not the saldo of the account.
+// Accounts without transactions are not checked, their start saldo is not recorded anywhere.
+func (l *Ledger) VerifyLedger(ctx context.Context, accountId int32, fix bool) (*api.LedgerReport, error) {
+	a := l.dialect.args()
+	selectStmt := `SELECT t.id, t.account_id, t.old_saldo, t.new_saldo, a.saldo FROM transactions t
+		JOIN accounts a ON a.id = t.account_id`
+	if accountId > 0 {
+		selectStmt += ` WHERE t.account_id = ` + a.add(accountId)
+	}
+	selectStmt += ` ORDER BY t.account_id, t.created, t.id`
+
+	rows, err := l.db.QueryContext(ctx, selectStmt, a.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &api.LedgerReport{}
+	var mismatches []*api.LedgerDiscrepancy
+
+	var current, lastId int32
+	var lastNewSaldo, accountSaldo float64
+	checkSaldo := func() {
+		if current == 0 || saldosEqual(lastNewSaldo, accountSaldo) {
+			return
+		}
+		mismatches = append(mismatches, &api.LedgerDiscrepancy{
+			Kind:          api.LedgerDiscrepancy_SALDO_MISMATCH,
+			AccountId:     current,
+			TransactionId: lastId,
+			Expected:      accountSaldo,
+			Actual:        lastNewSaldo,
+		})
+	}
+
+	for rows.Next() {
+		var id, account int32
+		var oldSaldo, newSaldo, saldo float64
+		if err := rows.Scan(&id, &account, &oldSaldo, &newSaldo, &saldo); err != nil {
+			return nil, err
+		}
+		report.CheckedTransactions++
+
+		if account != current {
+			checkSaldo()
+			current = account
+			accountSaldo = saldo
+			report.CheckedAccounts++
+		} else if !saldosEqual(oldSaldo, lastNewSaldo) {
+			report.Discrepancies = append(report.Discrepancies, &api.LedgerDiscrepancy{
+				Kind:          api.LedgerDiscrepancy_CHAIN_BREAK,
+				AccountId:     account,
+				TransactionId: id,
+				Expected:      lastNewSaldo,
+				Actual:        oldSaldo,
+			})
+		}
+
+		lastId = id
+		lastNewSaldo = newSaldo
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	checkSaldo()
+
+	if fix {
+		for _, mismatch := range mismatches {
+			mismatch.AdjustmentId, err = l.adjustSaldo(ctx, mismatch.AccountId)
+			if err != nil {
+				return nil, err
+			}
+			mismatch.Resolved = mismatch.AdjustmentId == 0
+		}
+	}
+	report.Discrepancies = append(report.Discrepancies, mismatches...)
+
+	return report, nil
+}
+
+// adjustSaldo writes an ADJUSTMENT transaction that moves the ledger of the account from the new_saldo of its last
+// transaction to the saldo of the account. The saldo of the account is not changed.
+// Both values are read again in a database transaction, so concurrent payments are taken into account.
+// Returns 0 if the ledger does not need an adjustment anymore
+func (l *Ledger) adjustSaldo(ctx context.Context, accountId int32) (int32, error) {
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	adjustmentId, err := l.adjustSaldoTx(ctx, tx, accountId)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return adjustmentId, tx.Commit()
+}
+
+func (l *Ledger) adjustSaldoTx(ctx context.Context, tx *sql.Tx, accountId int32) (int32, error) {
+	a := l.dialect.args()
+	selectStmt := `SELECT saldo FROM accounts WHERE id = ` + a.add(accountId) + l.dialect.LockRows("accounts")
+
+	var saldo float64
+	err := tx.QueryRowContext(ctx, selectStmt, a.values...).Scan(&saldo)
+	if err != nil {
+		return 0, err
+	}
+
+	a = l.dialect.args()
+	selectStmt = `SELECT new_saldo FROM transactions WHERE account_id = ` + a.add(accountId) + ` ORDER BY created DESC, id DESC LIMIT 1`
+
+	var lastNewSaldo float64
+	err = tx.QueryRowContext(ctx, selectStmt, a.values...).Scan(&lastNewSaldo)
+	if err != nil {
+		return 0, err
+	}
+
+	if saldosEqual(lastNewSaldo, saldo) {
+		return 0, nil
+	}
+
+	a = l.dialect.args()
+	insertStmt := `INSERT INTO transactions (new_saldo, old_saldo, amount, account_id, created, type) VALUES (` +
+		a.add(saldo) + `,` + a.add(lastNewSaldo) + `,` + a.add(lastNewSaldo-saldo) + `,` + a.add(accountId) + `,` +
+		a.add(now()) + `,` + a.add(api.Transaction_ADJUSTMENT.String()) + `)`
+	return l.dialect.insert(ctx, tx, insertStmt, a)
+}
+
+func saldosEqual(a, b float64) bool {
+	return math.Abs(a-b) < saldoTolerance
+}
+
+func decodeAccountStatus(accountStatus string) api.Account_Status {
+	return api.Account_Status(api.Account_Status_value[accountStatus])
+}
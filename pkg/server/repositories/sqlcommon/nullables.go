@@ -1,4 +1,4 @@
-package mysql
+package sqlcommon
 
 import "database/sql"
 
@@ -0,0 +1,178 @@
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/jheimbach/nfc-cash-system/api"
+)
+
+// reportBucketLayout is the format of the time buckets returned by the revenue queries
+const reportBucketLayout = "2006-01-02 15:04:05"
+
+// notAdjustment leaves out the ADJUSTMENT transactions of VerifyLedger, they only repair the saldo chain
+// and are neither revenue nor cash flow
+const notAdjustment = "t.type <> 'ADJUSTMENT'"
+
+// Reports computes reports with sql aggregates over the transactions and accounts tables,
+// ADJUSTMENT transactions are left out. The report repositories of the sql databases embed it
+type Reports struct {
+	db      *sql.DB
+	dialect *Dialect
+}
+
+func NewReports(db *sql.DB, dialect *Dialect) *Reports {
+	return &Reports{db: db, dialect: dialect}
+}
+
+// Revenue returns the sum and count of purchases in the time range per bucket, ordered by bucket.
+// Buckets without purchases are not returned
+func (r *Reports) Revenue(ctx context.Context, from, to time.Time, groupBy api.RevenueRequest_Grouping) ([]*api.RevenueBucket, error) {
+	a := r.dialect.args()
+	where := timeRangeClause(a, "t.created", from, to)
+
+	var selectStmt string
+	switch groupBy {
+	case api.RevenueRequest_HOUR, api.RevenueRequest_DAY:
+		bucket := r.dialect.TimeBucket("t.created", groupBy == api.RevenueRequest_HOUR)
+		selectStmt = fmt.Sprintf(`SELECT %s AS bucket, SUM(t.amount), COUNT(t.id) FROM transactions t
+			WHERE t.amount > 0 AND %s%s GROUP BY bucket ORDER BY bucket`, bucket, notAdjustment, where)
+	case api.RevenueRequest_GROUP:
+		selectStmt = fmt.Sprintf(`SELECT g.id, g.name, SUM(t.amount), COUNT(t.id) FROM transactions t
+			JOIN accounts a ON a.id = t.account_id JOIN account_groups g ON g.id = a.group_id
+			WHERE t.amount > 0 AND %s%s GROUP BY g.id, g.name ORDER BY g.id`, notAdjustment, where)
+	case api.RevenueRequest_TERMINAL:
+		selectStmt = fmt.Sprintf(`SELECT COALESCE(t.terminal_id, ''), SUM(t.amount), COUNT(t.id) FROM transactions t
+			WHERE t.amount > 0 AND %s%s GROUP BY t.terminal_id ORDER BY t.terminal_id`, notAdjustment, where)
+	default:
+		return nil, fmt.Errorf("unknown revenue grouping %v", groupBy)
+	}
+
+	rows, err := r.db.QueryContext(ctx, selectStmt, a.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var buckets []*api.RevenueBucket
+	for rows.Next() {
+		bucket := &api.RevenueBucket{}
+
+		switch groupBy {
+		case api.RevenueRequest_GROUP:
+			err = rows.Scan(&bucket.GroupId, &bucket.GroupName, &bucket.Revenue, &bucket.TransactionCount)
+		case api.RevenueRequest_TERMINAL:
+			err = rows.Scan(&bucket.TerminalId, &bucket.Revenue, &bucket.TransactionCount)
+		default:
+			var start string
+			err = rows.Scan(&start, &bucket.Revenue, &bucket.TransactionCount)
+			if err == nil {
+				bucket.Start, err = bucketTimestamp(start)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, bucket)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return buckets, nil
+}
+
+// CashFlow returns sum and count of top-ups (negative amounts) and purchases (positive amounts) in the time range,
+// both sums are positive
+func (r *Reports) CashFlow(ctx context.Context, from, to time.Time) (*api.CashFlowReport, error) {
+	a := r.dialect.args()
+	selectStmt := `SELECT
+		COALESCE(-SUM(CASE WHEN t.amount < 0 THEN t.amount END), 0), COUNT(CASE WHEN t.amount < 0 THEN 1 END),
+		COALESCE(SUM(CASE WHEN t.amount > 0 THEN t.amount END), 0), COUNT(CASE WHEN t.amount > 0 THEN 1 END)
+		FROM transactions t WHERE ` + notAdjustment + timeRangeClause(a, "t.created", from, to)
+
+	report := &api.CashFlowReport{}
+	err := r.db.QueryRowContext(ctx, selectStmt, a.values...).Scan(
+		&report.TopUps, &report.TopUpCount, &report.Purchases, &report.PurchaseCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// OutstandingBalance returns the total saldo of all accounts at time at.
+// The saldo at a point in time is the current saldo plus the amounts of all later transactions
+func (r *Reports) OutstandingBalance(ctx context.Context, at time.Time) (*api.BalanceReport, error) {
+	a := r.dialect.args()
+	balances := `SELECT a.saldo AS saldo FROM accounts a`
+	if !at.IsZero() {
+		balances = `SELECT a.saldo + COALESCE(SUM(t.amount), 0) AS saldo FROM accounts a
+			LEFT JOIN transactions t ON t.account_id = a.id AND ` + notAdjustment + ` AND t.created >= ` + a.add(at) + `
+			GROUP BY a.id, a.saldo`
+	}
+
+	selectStmt := fmt.Sprintf(`SELECT
+		COALESCE(SUM(b.saldo), 0), COUNT(*),
+		COALESCE(SUM(CASE WHEN b.saldo < 0 THEN b.saldo END), 0), COUNT(CASE WHEN b.saldo < 0 THEN 1 END)
+		FROM (%s) b`, balances)
+
+	report := &api.BalanceReport{}
+	err := r.db.QueryRowContext(ctx, selectStmt, a.values...).Scan(
+		&report.Total, &report.AccountCount, &report.OverdrawnTotal, &report.OverdrawnCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// AverageSpend returns the average purchase sum per account for all accounts with purchases in the time range
+func (r *Reports) AverageSpend(ctx context.Context, from, to time.Time) (*api.AverageSpendReport, error) {
+	a := r.dialect.args()
+	selectStmt := `SELECT COALESCE(SUM(t.amount), 0), COUNT(DISTINCT t.account_id) FROM transactions t
+		WHERE t.amount > 0 AND ` + notAdjustment + timeRangeClause(a, "t.created", from, to)
+
+	report := &api.AverageSpendReport{}
+	err := r.db.QueryRowContext(ctx, selectStmt, a.values...).Scan(&report.Total, &report.AccountCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.AccountCount > 0 {
+		report.Average = report.Total / float64(report.AccountCount)
+	}
+
+	return report, nil
+}
+
+// timeRangeClause returns the conditions for column in [from, to) prefixed with AND and adds the times to a,
+// zero times are left out
+func timeRangeClause(a *args, column string, from, to time.Time) string {
+	var conditions []string
+	if !from.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(" AND %s >= %s", column, a.add(from)))
+	}
+	if !to.IsZero() {
+		conditions = append(conditions, fmt.Sprintf(" AND %s < %s", column, a.add(to)))
+	}
+	return strings.Join(conditions, "")
+}
+
+// bucketTimestamp parses a time bucket of the revenue query
+func bucketTimestamp(bucket string) (*timestamp.Timestamp, error) {
+	start, err := time.Parse(reportBucketLayout, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return ptypes.TimestampProto(start)
+}
@@ -0,0 +1,321 @@
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// transactionSelect selects the columns of a transaction, the transactions table has the alias t
+const transactionSelect = `SELECT t.id, t.new_saldo, t.old_saldo, t.amount, t.account_id, t.created, t.terminal_id, t.type FROM transactions t`
+
+// Transactions provides API for the transactions table, the transaction repositories of the sql databases embed it
+type Transactions struct {
+	db       *sql.DB
+	dialect  *Dialect
+	accounts repositories.AccountStorager
+
+	// Ledger creates transactions, applies offline transactions and verifies the ledger
+	*Ledger
+}
+
+// NewTransactions returns Transactions, every created transaction is sent to publisher. publisher can be nil
+func NewTransactions(db *sql.DB, dialect *Dialect, accounts repositories.AccountStorager, publisher repositories.TransactionPublisher) *Transactions {
+	return &Transactions{
+		db:       db,
+		dialect:  dialect,
+		accounts: accounts,
+		Ledger:   NewLedger(db, dialect, accounts, publisher),
+	}
+}
+
+// Read returns Transaction with given id, returns repositories.ErrNotFound if transaction with id does not exist
+func (t *Transactions) Read(ctx context.Context, id int32) (*api.Transaction, error) {
+	a := t.dialect.args()
+	transaction, err := scanTransaction(t.db.QueryRowContext(ctx, transactionSelect+` WHERE t.id=`+a.add(id), a.values...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repositories.ErrNotFound
+		}
+		return nil, err
+	}
+
+	account, err := t.accounts.Read(ctx, transaction.Account.Id)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Account = account
+
+	return transaction, nil
+}
+
+// GetAll returns the transactions matching filter ordered by create date, the order of filter can change it (default DESC)
+// CAUTION: due to the nature of Transactions, this could be a lot, use Export to read all transactions
+func (t *Transactions) GetAll(ctx context.Context, filter repositories.TransactionFilter, limit, offset int32) ([]*api.Transaction, int, error) {
+	a := t.dialect.args()
+	selectStmt := orderByClause(filter.Order, transactionSelect+whereClause(transactionConditions(filter, a)))
+	if limit > 0 {
+		selectStmt = fmt.Sprintf("%s LIMIT %s", selectStmt, a.add(limit))
+		if offset > 0 {
+			selectStmt = fmt.Sprintf("%s OFFSET %s", selectStmt, a.add(offset))
+		}
+	}
+
+	rows, err := t.db.QueryContext(ctx, selectStmt, a.values...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	transactions, err := t.loadTransactions(ctx, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount := len(transactions)
+	if limit > 0 {
+		totalCount, err = t.Count(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return transactions, totalCount, nil
+}
+
+// GetPage returns up to size transactions matching filter ordered by created and id that come after the cursor
+// in the order of filter, after nil starts with the first transaction
+func (t *Transactions) GetPage(ctx context.Context, filter repositories.TransactionFilter, after *repositories.Cursor, size int32) ([]*api.Transaction, error) {
+	direction := sortDirection(filter.Order)
+
+	a := t.dialect.args()
+	conditions := transactionConditions(filter, a)
+	if after != nil {
+		comparison := "<"
+		if direction == "ASC" {
+			comparison = ">"
+		}
+		conditions = append(conditions, fmt.Sprintf("(t.created %[1]s %[2]s OR (t.created = %[3]s AND t.id %[1]s %[4]s))",
+			comparison, a.add(after.Created), a.add(after.Created), a.add(after.Id)))
+	}
+	selectStmt := fmt.Sprintf("%s%s ORDER BY t.created %s, t.id %s LIMIT %s",
+		transactionSelect, whereClause(conditions), direction, direction, a.add(size))
+
+	rows, err := t.db.QueryContext(ctx, selectStmt, a.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return t.loadTransactions(ctx, rows)
+}
+
+// transactionConditions returns the conditions of filter for the transactions table with alias t and adds their values to a.
+// The time range compares the created column directly, so it can be read from idx_created
+func transactionConditions(filter repositories.TransactionFilter, a *args) []string {
+	var conditions []string
+
+	if filter.AccountId > 0 {
+		conditions = append(conditions, "t.account_id = "+a.add(filter.AccountId))
+	}
+	if filter.GroupId > 0 {
+		conditions = append(conditions, "t.account_id IN (SELECT id FROM accounts WHERE group_id = "+a.add(filter.GroupId)+")")
+	}
+	if filter.TerminalId != "" {
+		conditions = append(conditions, "t.terminal_id = "+a.add(filter.TerminalId))
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "t.created >= "+a.add(filter.From))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "t.created < "+a.add(filter.To))
+	}
+	if filter.MinAmount != nil {
+		conditions = append(conditions, "t.amount >= "+a.add(*filter.MinAmount))
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, "t.amount <= "+a.add(*filter.MaxAmount))
+	}
+
+	return conditions
+}
+
+// DeleteAllByAccount deletes all transactions for given account id
+func (t *Transactions) DeleteAllByAccount(ctx context.Context, accountId int32) error {
+	a := t.dialect.args()
+	_, err := t.db.ExecContext(ctx, "DELETE FROM transactions WHERE account_id="+a.add(accountId), a.values...)
+
+	return err
+}
+
+// exportBatchSize is the number of transactions that are read before the accounts for them are loaded
+const exportBatchSize = 500
+
+// Export calls fn for every transaction matching filter.
+// Transactions are read from one open cursor in batches of exportBatchSize, the accounts are loaded once per batch
+// and cached for the rest of the export.
+func (t *Transactions) Export(ctx context.Context, filter repositories.TransactionFilter, fn func(*api.Transaction) error) error {
+	a := t.dialect.args()
+	selectStmt := orderByClause(filter.Order, transactionSelect+whereClause(transactionConditions(filter, a)))
+
+	rows, err := t.db.QueryContext(ctx, selectStmt, a.values...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	accounts := make(map[int32]*api.Account)
+	batch := make([]*api.Transaction, 0, exportBatchSize)
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return err
+		}
+		batch = append(batch, transaction)
+
+		if len(batch) == exportBatchSize {
+			if err := t.sendBatch(ctx, batch, accounts, fn); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+
+	return t.sendBatch(ctx, batch, accounts, fn)
+}
+
+// sendBatch loads the accounts of the transactions in batch that are not yet in accounts and calls fn for every transaction
+func (t *Transactions) sendBatch(ctx context.Context, batch []*api.Transaction, accounts map[int32]*api.Account, fn func(*api.Transaction) error) error {
+	var missingIds []int32
+	for _, transaction := range batch {
+		if _, ok := accounts[transaction.Account.Id]; !ok {
+			accounts[transaction.Account.Id] = nil
+			missingIds = append(missingIds, transaction.Account.Id)
+		}
+	}
+
+	if len(missingIds) > 0 {
+		loaded, err := t.accounts.GetAllByIds(ctx, missingIds)
+		if err != nil {
+			return err
+		}
+		for id, account := range loaded {
+			accounts[id] = account
+		}
+	}
+
+	for _, transaction := range batch {
+		if account := accounts[transaction.Account.Id]; account != nil {
+			transaction.Account = account
+		}
+		if err := fn(transaction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderByClause returns selectStmt with order by created attached.
+// If order is ASC or asc returns ORDER BY created ASC, otherwise DESC, transactions created at the same time are ordered by id
+func orderByClause(order string, selectStmt string) string {
+	direction := sortDirection(order)
+	return fmt.Sprintf("%s ORDER BY t.created %s, t.id %s", selectStmt, direction, direction)
+}
+
+// sortDirection returns ASC if order is ASC or asc, otherwise DESC
+func sortDirection(order string) string {
+	if strings.ToLower(order) == "asc" {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// Count counts the transaction rows in the database that match filter
+func (t *Transactions) Count(ctx context.Context, filter repositories.TransactionFilter) (int, error) {
+	a := t.dialect.args()
+	countStmt := `SELECT COUNT(t.id) FROM transactions t` + whereClause(transactionConditions(filter, a))
+
+	var totalCount int
+	err := t.db.QueryRowContext(ctx, countStmt, a.values...).Scan(&totalCount)
+	if err != nil {
+		return 0, err
+	}
+
+	return totalCount, nil
+}
+
+// loadTransactions returns the transactions of rows with their accounts, the accounts are read with one query
+func (t *Transactions) loadTransactions(ctx context.Context, rows *sql.Rows) ([]*api.Transaction, error) {
+	var transactions []*api.Transaction
+	var accountIdsLookup = make(map[int32]bool)
+	var accountIds []int32
+
+	for rows.Next() {
+		s, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, s)
+
+		if _, ok := accountIdsLookup[s.Account.Id]; !ok {
+			accountIdsLookup[s.Account.Id] = true
+			accountIds = append(accountIds, s.Account.Id)
+		}
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	if len(transactions) == 0 {
+		return nil, nil
+	}
+
+	accounts, err := t.accounts.GetAllByIds(ctx, accountIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, transaction := range transactions {
+		transaction.Account = accounts[transaction.Account.Id]
+	}
+
+	return transactions, nil
+}
+
+// scanTransaction scans the columns of transactionSelect into a Transaction, the account only has its id set
+func scanTransaction(row rowScanner) (*api.Transaction, error) {
+	s := &api.Transaction{Account: &api.Account{}}
+	var created time.Time
+	var terminalId sql.NullString
+	var transactionType string
+
+	err := row.Scan(&s.Id, &s.NewSaldo, &s.OldSaldo, &s.Amount, &s.Account.Id, &created, &terminalId, &transactionType)
+	if err != nil {
+		return nil, err
+	}
+	s.TerminalId = decodeNullableString(terminalId)
+	s.Type = decodeTransactionType(transactionType)
+
+	s.Created, err = ptypes.TimestampProto(created)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// decodeTransactionType returns the api.Transaction_Type for the type column, unknown types are payments
+func decodeTransactionType(transactionType string) api.Transaction_Type {
+	return api.Transaction_Type(api.Transaction_Type_value[transactionType])
+}
@@ -0,0 +1,96 @@
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test/mock"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+func TestTransactionConditions_UseCreatedIndex(t *testing.T) {
+	from := time.Date(2019, 1, 17, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC)
+	maxAmount := 10.0
+
+	tests := []struct {
+		name   string
+		filter repositories.TransactionFilter
+	}{
+		{name: "created from", filter: repositories.TransactionFilter{From: from}},
+		{name: "created to", filter: repositories.TransactionFilter{To: to}},
+		{name: "created range", filter: repositories.TransactionFilter{From: from, To: to}},
+		{name: "created range and amount", filter: repositories.TransactionFilter{From: from, To: to, MaxAmount: &maxAmount}},
+	}
+
+	// the query plan of sqlite shows if the index is used
+	db, teardown := openSqlite(t)
+	defer teardown()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+
+			a := (&Dialect{Placeholder: QuestionMark}).args()
+			query := "EXPLAIN QUERY PLAN SELECT t.id FROM transactions t" + whereClause(transactionConditions(tt.filter, a))
+			rows, err := db.QueryContext(context.Background(), query, a.values...)
+			is.NoErr(err) // could not explain query
+			defer rows.Close()
+
+			var plan []string
+			for rows.Next() {
+				var id, parent, notUsed int
+				var detail string
+				is.NoErr(rows.Scan(&id, &parent, &notUsed, &detail))
+				plan = append(plan, detail)
+			}
+			is.NoErr(rows.Err())
+			is.True(strings.Contains(strings.Join(plan, "\n"), "idx_created")) // query does not use idx_created
+		})
+	}
+}
+
+func TestTransactions_GetAllAccountError(t *testing.T) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	db, teardown := openSqlite(t)
+	defer teardown()
+
+	sqlite := &Dialect{Placeholder: QuestionMark, LockRows: func(string) string { return "" }}
+	groups := NewGroups(db, sqlite)
+	accounts := NewAccounts(db, sqlite, groups)
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := accounts.Create(ctx, "guest", "", 10, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	_, err = NewLedger(db, sqlite, accounts, nil).Create(ctx, 1, account.Id, "")
+	is.NoErr(err) // could not create transaction
+
+	accountErr := errors.New("accounts unavailable")
+	transactions := NewTransactions(db, sqlite, &mock.AccountRepository{
+		GetAllByIdsFunc: func([]int32) (map[int32]*api.Account, error) {
+			return nil, accountErr
+		},
+	}, nil)
+
+	// the count after the transactions must not hide that their accounts could not be loaded
+	_, _, err = transactions.GetAll(ctx, repositories.TransactionFilter{}, 10, 0)
+	is.Equal(err, accountErr)
+}
+
+// openSqlite opens a new migrated sqlite database file
+func openSqlite(t *testing.T) (*sql.DB, func()) {
+	db, teardown, err := test.SqliteConnection("../../../../migrations/sqlite")
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	return db, teardown
+}
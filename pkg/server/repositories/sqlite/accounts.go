@@ -0,0 +1,17 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// AccountRepository provides API for the accounts table
+type AccountRepository struct {
+	*sqlcommon.Accounts
+}
+
+func NewAccountRepository(db *sql.DB, groups repositories.GroupStorager) *AccountRepository {
+	return &AccountRepository{Accounts: sqlcommon.NewAccounts(db, dialect, groups)}
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// dialect is the sql of sqlite for the repositories of sqlcommon
+var dialect = &sqlcommon.Dialect{
+	Placeholder: sqlcommon.QuestionMark,
+	// sqlite has no row locks, the immediate database transactions lock the whole database
+	LockRows: func(string) string {
+		return ""
+	},
+	TimeBucket: func(column string, hour bool) string {
+		if hour {
+			return fmt.Sprintf("strftime('%%Y-%%m-%%d %%H:00:00', %s)", column)
+		}
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d 00:00:00', %s)", column)
+	},
+	// LIKE of sqlite ignores the case of ascii characters, it has no default escape character
+	Like: func(column, placeholder string) string {
+		return column + " LIKE " + placeholder + ` ESCAPE '\'`
+	},
+	// nfc_chip_uid has the collation NOCASE
+	FoldCase: func(expr string) string {
+		return expr
+	},
+	IsUniqueViolation:     isUniqueViolation,
+	IsForeignKeyViolation: isForeignKeyViolation,
+}
@@ -0,0 +1,4 @@
+// sqlite provides repositories account, groups, transactions and users data storage with a sqlite database file.
+// It is meant for single laptop installations and fast tests, the database has to be opened with database.OpenDatabase
+// which enables foreign keys and immediate write transactions
+package sqlite
@@ -0,0 +1,18 @@
+//go:build cgo
+// +build cgo
+
+package sqlite
+
+import "github.com/mattn/go-sqlite3"
+
+// isUniqueViolation returns true if err is a violated unique or primary key constraint
+func isUniqueViolation(err error) bool {
+	sqliteErr, ok := err.(sqlite3.Error)
+	return ok && (sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
+
+// isForeignKeyViolation returns true if err is a violated foreign key constraint
+func isForeignKeyViolation(err error) bool {
+	sqliteErr, ok := err.(sqlite3.Error)
+	return ok && sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey
+}
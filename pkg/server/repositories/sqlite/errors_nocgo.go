@@ -0,0 +1,14 @@
+//go:build !cgo
+// +build !cgo
+
+package sqlite
+
+// the sqlite driver needs cgo, without it no database can be opened and no constraint is violated
+
+func isUniqueViolation(err error) bool {
+	return false
+}
+
+func isForeignKeyViolation(err error) bool {
+	return false
+}
@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// queryCall is a repository call and the number of statements it sends to the database
+type queryCall struct {
+	name    string
+	queries int
+	call    func(ctx context.Context) error
+}
+
+// queryCalls returns the repository calls whose statements are counted,
+// the number of statements must not grow with the number of rows they return
+func queryCalls(t testing.TB) (*test.QueryCounter, []queryCall, func()) {
+	db, counter, teardown, err := test.CountingSqliteConnection(migrationDir)
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+
+	ctx := context.Background()
+	groups := NewGroupRepository(db)
+	accounts := NewAccountRepository(db, groups)
+	transactions := NewTransactionRepository(db, accounts, nil)
+
+	// 100 accounts in 2 groups with 2 transactions each
+	var accountIds []int32
+	for g := 0; g < 2; g++ {
+		group, err := groups.Create(ctx, fmt.Sprintf("group %d", g), "", false)
+		if err != nil {
+			teardown()
+			t.Fatalf("could not create group: %v", err)
+		}
+		for a := 0; a < 50; a++ {
+			account, err := accounts.Create(ctx, fmt.Sprintf("account %d-%d", g, a), "", 100, group.Id, fmt.Sprintf("chip-%d-%d", g, a))
+			if err != nil {
+				teardown()
+				t.Fatalf("could not create account: %v", err)
+			}
+			accountIds = append(accountIds, account.Id)
+		}
+	}
+	for _, accountId := range append(accountIds, accountIds...) {
+		if _, err := transactions.Create(ctx, 1, accountId, ""); err != nil {
+			teardown()
+			t.Fatalf("could not create transaction: %v", err)
+		}
+	}
+
+	// more ids than fit into one IN clause
+	var manyIds []int32
+	for id := int32(1); id <= 2*sqlcommon.MaxInClauseIds+1; id++ {
+		manyIds = append(manyIds, id)
+	}
+
+	noErr := func(_ interface{}, err error) error {
+		return err
+	}
+	calls := []queryCall{
+		{"AccountRead", 1, func(ctx context.Context) error {
+			return noErr(accounts.Read(ctx, accountIds[0]))
+		}},
+		{"AccountReadByNfcChipId", 1, func(ctx context.Context) error {
+			return noErr(accounts.ReadByNfcChipId(ctx, "chip-1-7"))
+		}},
+		{"AccountGetAll", 1, func(ctx context.Context) error {
+			_, _, err := accounts.GetAll(ctx, repositories.AccountFilter{}, 0, 0)
+			return err
+		}},
+		{"AccountGetAllWithLimit", 2, func(ctx context.Context) error {
+			_, _, err := accounts.GetAll(ctx, repositories.AccountFilter{}, 20, 10)
+			return err
+		}},
+		{"AccountGetPage", 1, func(ctx context.Context) error {
+			return noErr(accounts.GetPage(ctx, repositories.AccountFilter{}, nil, 100))
+		}},
+		{"AccountGetAllByIds", 1, func(ctx context.Context) error {
+			return noErr(accounts.GetAllByIds(ctx, accountIds))
+		}},
+		{"AccountGetAllByIdsChunked", 3, func(ctx context.Context) error {
+			return noErr(accounts.GetAllByIds(ctx, manyIds))
+		}},
+		{"AccountGetAllByIdsEmpty", 0, func(ctx context.Context) error {
+			return noErr(accounts.GetAllByIds(ctx, nil))
+		}},
+		{"TransactionRead", 2, func(ctx context.Context) error {
+			return noErr(transactions.Read(ctx, 1))
+		}},
+		{"TransactionGetPage", 2, func(ctx context.Context) error {
+			return noErr(transactions.GetPage(ctx, repositories.TransactionFilter{}, nil, 200))
+		}},
+		{"TransactionGetAllWithLimit", 3, func(ctx context.Context) error {
+			_, _, err := transactions.GetAll(ctx, repositories.TransactionFilter{}, 100, 50)
+			return err
+		}},
+		{"TransactionGetPageWithoutMatches", 1, func(ctx context.Context) error {
+			return noErr(transactions.GetPage(ctx, repositories.TransactionFilter{TerminalId: "none"}, nil, 200))
+		}},
+		{"TransactionExport", 2, func(ctx context.Context) error {
+			return transactions.Export(ctx, repositories.TransactionFilter{}, func(*api.Transaction) error { return nil })
+		}},
+		// the account is locked and charged in a database transaction and read again after it is committed
+		{"TransactionCreate", 4, func(ctx context.Context) error {
+			return noErr(transactions.Create(ctx, 1, accountIds[0], ""))
+		}},
+	}
+
+	return counter, calls, teardown
+}
+
+func TestQueryCount(t *testing.T) {
+	counter, calls, teardown := queryCalls(t)
+	defer teardown()
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			counter.Reset()
+			if err := tt.call(context.Background()); err != nil {
+				t.Fatalf("got err %v, did not expect one", err)
+			}
+			if got := counter.Count(); got != tt.queries {
+				t.Errorf("got %d queries, expected %d", got, tt.queries)
+			}
+		})
+	}
+}
+
+// BenchmarkQueries reports the statements every repository call sends to the database as queries/op
+func BenchmarkQueries(b *testing.B) {
+	counter, calls, teardown := queryCalls(b)
+	defer teardown()
+
+	for _, bb := range calls {
+		b.Run(bb.name, func(b *testing.B) {
+			ctx := context.Background()
+			counter.Reset()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := bb.call(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(counter.Count())/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlcommon"
+)
+
+// ReportRepository computes reports with sql aggregates over the transactions and accounts tables
+type ReportRepository struct {
+	*sqlcommon.Reports
+}
+
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{Reports: sqlcommon.NewReports(db, dialect)}
+}
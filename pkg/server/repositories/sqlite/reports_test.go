@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test"
+	isPkg "github.com/matryer/is"
+)
+
+func TestReportModel_Revenue(t *testing.T) {
+	bucketStart := func(year, month, day, hour int) *api.RevenueBucket {
+		start, _ := ptypes.TimestampProto(time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC))
+		return &api.RevenueBucket{Start: start}
+	}
+	withRevenue := func(bucket *api.RevenueBucket, revenue float64, count int32) *api.RevenueBucket {
+		bucket.Revenue = revenue
+		bucket.TransactionCount = count
+		return bucket
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		groupBy  api.RevenueRequest_Grouping
+		want     []*api.RevenueBucket
+	}{
+		{
+			name:    "revenue per day",
+			groupBy: api.RevenueRequest_DAY,
+			want: []*api.RevenueBucket{
+				withRevenue(bucketStart(2019, 1, 17, 0), 17.5, 3),
+				withRevenue(bucketStart(2019, 1, 18, 0), 7.5, 1),
+			},
+		},
+		{
+			name:    "revenue per hour",
+			groupBy: api.RevenueRequest_HOUR,
+			want: []*api.RevenueBucket{
+				withRevenue(bucketStart(2019, 1, 17, 10), 7.5, 2),
+				withRevenue(bucketStart(2019, 1, 17, 12), 10, 1),
+				withRevenue(bucketStart(2019, 1, 18, 13), 7.5, 1),
+			},
+		},
+		{
+			name:    "revenue per terminal",
+			groupBy: api.RevenueRequest_TERMINAL,
+			want: []*api.RevenueBucket{
+				{TerminalId: "kiosk-1", Revenue: 15, TransactionCount: 2},
+				{TerminalId: "kiosk-2", Revenue: 10, TransactionCount: 2},
+			},
+		},
+		{
+			name: "revenue per day in time range of other time zone",
+			// 2019-01-18 00:00 UTC
+			from:    time.Date(2019, 1, 18, 1, 0, 0, 0, time.FixedZone("CET", 3600)),
+			to:      time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+			groupBy: api.RevenueRequest_DAY,
+			want: []*api.RevenueBucket{
+				withRevenue(bucketStart(2019, 1, 18, 0), 7.5, 1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := isPkg.New(t)
+			db, td := initDbForReports(t)
+			defer td()
+
+			got, err := NewReportRepository(db).Revenue(context.Background(), tt.from, tt.to, tt.groupBy)
+			is.NoErr(err)
+			is.Equal(got, tt.want)
+		})
+	}
+}
+
+func TestReportModel_CashFlow(t *testing.T) {
+	is := isPkg.New(t)
+	db, td := initDbForReports(t)
+	defer td()
+
+	got, err := NewReportRepository(db).CashFlow(context.Background(), time.Time{}, time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.Equal(got, &api.CashFlowReport{Purchases: 17.5, PurchaseCount: 3})
+}
+
+func TestReportModel_OutstandingBalance(t *testing.T) {
+	is := isPkg.New(t)
+	db, td := initDbForReports(t)
+	defer td()
+
+	got, err := NewReportRepository(db).OutstandingBalance(context.Background(), time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC))
+	is.NoErr(err)
+	is.Equal(got, &api.BalanceReport{Total: 10.5, AccountCount: 3})
+}
+
+func initDbForReports(t *testing.T) (*sql.DB, func()) {
+	t.Helper()
+	db, teardown, err := test.SqliteConnection(migrationDir)
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+
+	if err := test.SetupDB(db, "./testdata/report.sql"); err != nil {
+		teardown()
+		t.Fatal(err)
+	}
+	return db, teardown
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/test"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/storagetest"
+)
+
+const migrationDir = "../../../../migrations/sqlite"
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, storagetest.Factories{
+		Groups: func(t *testing.T) (repositories.GroupStorager, func()) {
+			db, teardown := openStorage(t)
+			return NewGroupRepository(db), teardown
+		},
+		Accounts: func(t *testing.T) (repositories.AccountStorager, repositories.GroupStorager, func()) {
+			db, teardown := openStorage(t)
+			groups := NewGroupRepository(db)
+			return NewAccountRepository(db, groups), groups, teardown
+		},
+		Transactions: func(t *testing.T) (repositories.TransactionStorager, repositories.AccountStorager, repositories.GroupStorager, func()) {
+			db, teardown := openStorage(t)
+			groups := NewGroupRepository(db)
+			accounts := NewAccountRepository(db, groups)
+			return NewTransactionRepository(db, accounts, nil), accounts, groups, teardown
+		},
+		Users: func(t *testing.T) (repositories.UserStorager, func(ctx context.Context, name, email, password string) error, func()) {
+			db, teardown := openStorage(t)
+			users := NewUserModel(db)
+			return users, users.Create, teardown
+		},
+		Ledger: func(t *testing.T) (*storagetest.LedgerStorage, func()) {
+			db, teardown := openStorage(t)
+			groups := NewGroupRepository(db)
+			accounts := NewAccountRepository(db, groups)
+			return &storagetest.LedgerStorage{
+				Transactions: NewTransactionRepository(db, accounts, nil),
+				Accounts:     accounts,
+				Groups:       groups,
+				Terminals:    NewTerminalRepository(db),
+				Reports:      NewReportRepository(db),
+			}, teardown
+		},
+	})
+}
+
+// openStorage opens a new migrated database file for a conformance test
+func openStorage(t *testing.T) (*sql.DB, func()) {
+	db, teardown, err := test.SqliteConnection(migrationDir)
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	return db, teardown
+}
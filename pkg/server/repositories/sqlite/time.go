@@ -0,0 +1,8 @@
+package sqlite
+
+import "time"
+
+// now returns the current time in UTC, sqlite compares times as text, so all times are saved in UTC
+func now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,465 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+// RunAccountStorager runs the conformance tests of repositories.AccountStorager
+func RunAccountStorager(t *testing.T, newAccounts AccountFactory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager)
+	}{
+		{"Create", testAccountCreate},
+		{"ReadByNfcChipId", testAccountReadByNfcChipId},
+		{"Update", testAccountUpdate},
+		{"Paging", testAccountPaging},
+		{"CursorPaging", testAccountCursorPaging},
+		{"Filter", testAccountFilter},
+		{"Sort", testAccountSort},
+		{"GetAllByIds", testAccountGetAllByIds},
+		{"ImportDuplicateNfcChipId", testAccountImportDuplicateNfcChipId},
+		{"IssueChipCounterNotFound", testAccountIssueChipCounterNotFound},
+		{"GroupDeleteProtection", testGroupDeleteProtection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts, groups, teardown := newAccounts(t)
+			defer teardown()
+
+			tt.test(t, accounts, groups)
+		})
+	}
+}
+
+func testAccountCreate(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+
+	created, err := accounts.Create(ctx, "guest", "wristband", 12.5, group.Id, "04a1b2c3")
+	is.NoErr(err)           // could not create account
+	is.True(created.Id > 0) // created account has no id
+
+	got, err := accounts.Read(ctx, created.Id)
+	is.NoErr(err) // could not read account
+	is.Equal(got.Name, "guest")
+	is.Equal(got.Description, "wristband")
+	is.Equal(got.Saldo, 12.5)
+	is.Equal(got.NfcChipId, "04a1b2c3")
+	is.Equal(got.Status, api.Account_ACTIVE)
+	is.Equal(got.Group, group)
+
+	_, err = accounts.Create(ctx, "other guest", "", 0, group.Id, "04a1b2c3")
+	is.Equal(err, repositories.ErrDuplicateNfcChipId)
+
+	_, err = accounts.Create(ctx, "lost guest", "", 0, group.Id+100, "04d5e6f7")
+	is.Equal(err, repositories.ErrGroupNotFound)
+
+	_, err = accounts.Read(ctx, created.Id+100)
+	is.Equal(err, repositories.ErrNotFound)
+}
+
+func testAccountReadByNfcChipId(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	created, err := accounts.Create(ctx, "guest", "", 0, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+
+	got, err := accounts.ReadByNfcChipId(ctx, "04A1B2C3")
+	is.NoErr(err) // chip ids are not case sensitive
+	is.Equal(got.Id, created.Id)
+
+	_, err = accounts.ReadByNfcChipId(ctx, "deadbeef")
+	is.Equal(err, repositories.ErrNotFound)
+}
+
+func testAccountUpdate(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	guests, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	staff, err := groups.Create(ctx, "staff", "", true)
+	is.NoErr(err) // could not create group
+	account, err := accounts.Create(ctx, "guest", "", 10, guests.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	_, err = accounts.Create(ctx, "other guest", "", 10, guests.Id, "04d5e6f7")
+	is.NoErr(err) // could not create account
+
+	account.Name = "artist"
+	account.Group = staff
+	account.Status = api.Account_BLOCKED
+	_, err = accounts.Update(ctx, account)
+	is.NoErr(err) // could not update account
+
+	got, err := accounts.Read(ctx, account.Id)
+	is.NoErr(err) // could not read account
+	is.Equal(got.Name, "artist")
+	is.Equal(got.Group, staff)
+	is.Equal(got.Status, api.Account_BLOCKED)
+	is.Equal(got.Saldo, 10.0)
+
+	_, err = accounts.Update(ctx, &api.Account{Id: account.Id, Saldo: 99, Group: staff, NfcChipId: "04a1b2c3"})
+	is.Equal(err, repositories.ErrUpdateSaldo)
+
+	_, err = accounts.Update(ctx, &api.Account{Id: account.Id, Group: &api.Group{Id: staff.Id + 100}, NfcChipId: "04a1b2c3"})
+	is.Equal(err, repositories.ErrGroupNotFound)
+
+	_, err = accounts.Update(ctx, &api.Account{Id: account.Id, Group: staff, NfcChipId: "04d5e6f7"})
+	is.Equal(err, repositories.ErrDuplicateNfcChipId)
+
+	_, err = accounts.Update(ctx, &api.Account{Id: account.Id + 100, Group: staff, NfcChipId: "04f8f9fa"})
+	is.Equal(err, repositories.ErrNotFound)
+}
+
+func testAccountPaging(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	guests, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	staff, err := groups.Create(ctx, "staff", "", true)
+	is.NoErr(err) // could not create group
+
+	names := []string{"a", "b", "c", "d", "e"}
+	groupOf := map[string]int32{"a": guests.Id, "b": staff.Id, "c": guests.Id, "d": guests.Id, "e": staff.Id}
+	for _, name := range names {
+		_, err := accounts.Create(ctx, name, "", 0, groupOf[name], "04a1b2c3"+name)
+		is.NoErr(err) // could not create account
+	}
+
+	tests := []struct {
+		name          string
+		groupId       int32
+		limit, offset int32
+		want          []string
+		wantTotal     int
+	}{
+		{name: "all", want: names, wantTotal: 5},
+		{name: "offset without limit is ignored", offset: 3, want: names, wantTotal: 5},
+		{name: "limit and offset", limit: 2, offset: 3, want: []string{"d", "e"}, wantTotal: 5},
+		{name: "offset past the end", limit: 2, offset: 10, wantTotal: 5},
+		{name: "group", groupId: guests.Id, want: []string{"a", "c", "d"}, wantTotal: 3},
+		{name: "group with limit and offset", groupId: guests.Id, limit: 1, offset: 1, want: []string{"c"}, wantTotal: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			page, total, err := accounts.GetAll(ctx, repositories.AccountFilter{GroupId: tt.groupId}, tt.limit, tt.offset)
+			is.NoErr(err) // could not list accounts
+			is.Equal(total, tt.wantTotal)
+			is.Equal(len(page), len(tt.want)) // unexpected page size
+			for i, account := range page {
+				is.Equal(account.Name, tt.want[i])                // accounts are not ordered by id
+				is.Equal(account.Group.Id, groupOf[account.Name]) // account has wrong group
+			}
+		})
+	}
+}
+
+func testAccountCursorPaging(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	guests, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	staff, err := groups.Create(ctx, "staff", "", true)
+	is.NoErr(err) // could not create group
+
+	names := []string{"a", "b", "c", "d", "e"}
+	groupOf := map[string]int32{"a": guests.Id, "b": staff.Id, "c": guests.Id, "d": guests.Id, "e": staff.Id}
+	for _, name := range names {
+		_, err := accounts.Create(ctx, name, "", 0, groupOf[name], "04a1b2c3"+name)
+		is.NoErr(err) // could not create account
+	}
+
+	tests := []struct {
+		name    string
+		groupId int32
+		want    []string
+	}{
+		{name: "all", want: names},
+		{name: "group", groupId: guests.Id, want: []string{"a", "c", "d"}},
+		{name: "group without accounts", groupId: staff.Id + 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var got []string
+			var after *repositories.Cursor
+			for pages := 0; ; pages++ {
+				is.True(pages <= len(names)) // paging does not end
+
+				page, err := accounts.GetPage(ctx, repositories.AccountFilter{GroupId: tt.groupId}, after, 2)
+				is.NoErr(err)           // could not get page
+				is.True(len(page) <= 2) // page is larger than size
+				if len(page) == 0 {
+					break
+				}
+				for _, account := range page {
+					is.Equal(account.Group.Id, groupOf[account.Name]) // account has wrong group
+					got = append(got, account.Name)
+				}
+				after = &repositories.Cursor{Id: page[len(page)-1].Id}
+			}
+			is.Equal(got, tt.want) // pages do not contain every account once, ordered by id
+
+			count, err := accounts.Count(ctx, repositories.AccountFilter{GroupId: tt.groupId})
+			is.NoErr(err) // could not count accounts
+			is.Equal(count, len(tt.want))
+		})
+	}
+}
+
+func testAccountFilter(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	guests, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	staff, err := groups.Create(ctx, "staff", "", true)
+	is.NoErr(err) // could not create group
+
+	rows := []struct {
+		name, description string
+		saldo             float64
+		groupId           int32
+		nfcChipId         string
+	}{
+		{"Anna", "crew", 10, guests.Id, "04AABB01"},
+		{"bob", "", -5, staff.Id, "04aabb02"},
+		{"Carla 50%", "Anna's friend", 0, guests.Id, "05ccdd03"},
+		{"dave_x", "", 10, guests.Id, "05ccdd04"},
+		{"eve", "bar staff", 25.5, staff.Id, "04aacc05"},
+	}
+	for _, row := range rows {
+		account, err := accounts.Create(ctx, row.name, row.description, row.saldo, row.groupId, row.nfcChipId)
+		is.NoErr(err) // could not create account
+
+		if row.name == "bob" {
+			account.Status = api.Account_BLOCKED
+			_, err = accounts.Update(ctx, account)
+			is.NoErr(err) // could not block account
+		}
+	}
+
+	saldo := func(v float64) *float64 {
+		return &v
+	}
+
+	tests := []struct {
+		name   string
+		filter repositories.AccountFilter
+		want   []string
+	}{
+		{name: "no filter", want: []string{"Anna", "bob", "Carla 50%", "dave_x", "eve"}},
+		{name: "search name ignores case", filter: repositories.AccountFilter{Search: "ANNA"}, want: []string{"Anna", "Carla 50%"}},
+		{name: "search description", filter: repositories.AccountFilter{Search: "staff"}, want: []string{"eve"}},
+		{name: "search percent sign is no wildcard", filter: repositories.AccountFilter{Search: "%"}, want: []string{"Carla 50%"}},
+		{name: "search underscore is no wildcard", filter: repositories.AccountFilter{Search: "_"}, want: []string{"dave_x"}},
+		{name: "search without match", filter: repositories.AccountFilter{Search: "zzz"}},
+		{name: "nfc chip id prefix ignores case", filter: repositories.AccountFilter{NfcChipIdPrefix: "04aa"}, want: []string{"Anna", "bob", "eve"}},
+		{name: "nfc chip id prefix is no substring", filter: repositories.AccountFilter{NfcChipIdPrefix: "aabb"}},
+		{name: "min saldo is inclusive", filter: repositories.AccountFilter{MinSaldo: saldo(10)}, want: []string{"Anna", "dave_x", "eve"}},
+		{name: "max saldo is inclusive", filter: repositories.AccountFilter{MaxSaldo: saldo(0)}, want: []string{"bob", "Carla 50%"}},
+		{name: "saldo range", filter: repositories.AccountFilter{MinSaldo: saldo(0), MaxSaldo: saldo(10)}, want: []string{"Anna", "Carla 50%", "dave_x"}},
+		{name: "status", filter: repositories.AccountFilter{Status: []api.Account_Status{api.Account_BLOCKED}}, want: []string{"bob"}},
+		{name: "statuses", filter: repositories.AccountFilter{Status: []api.Account_Status{api.Account_ACTIVE, api.Account_BLOCKED}}, want: []string{"Anna", "bob", "Carla 50%", "dave_x", "eve"}},
+		{name: "group and search", filter: repositories.AccountFilter{GroupId: staff.Id, Search: "e"}, want: []string{"eve"}},
+		{name: "nfc chip id prefix and min saldo", filter: repositories.AccountFilter{NfcChipIdPrefix: "05", MinSaldo: saldo(5)}, want: []string{"dave_x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			all, total, err := accounts.GetAll(ctx, tt.filter, 0, 0)
+			is.NoErr(err) // could not list accounts
+			is.Equal(total, len(tt.want))
+			is.Equal(accountNames(all), tt.want)
+
+			page, err := accounts.GetPage(ctx, tt.filter, nil, 10)
+			is.NoErr(err) // could not get page
+			is.Equal(accountNames(page), tt.want)
+
+			count, err := accounts.Count(ctx, tt.filter)
+			is.NoErr(err) // could not count accounts
+			is.Equal(count, len(tt.want))
+		})
+	}
+}
+
+func testAccountSort(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+
+	var ids []int32
+	for i, row := range []struct {
+		name  string
+		saldo float64
+	}{{"c", 5}, {"a", 10}, {"b", 5}, {"a", -1}, {"d", 10}} {
+		account, err := accounts.Create(ctx, row.name, "", row.saldo, group.Id, fmt.Sprintf("04a1b2c3%02d", i))
+		is.NoErr(err) // could not create account
+		ids = append(ids, account.Id)
+	}
+
+	tests := []struct {
+		name   string
+		filter repositories.AccountFilter
+		want   []int // index of the created account
+	}{
+		{name: "id", want: []int{0, 1, 2, 3, 4}},
+		{name: "id descending", filter: repositories.AccountFilter{Descending: true}, want: []int{4, 3, 2, 1, 0}},
+		{name: "name", filter: repositories.AccountFilter{SortBy: api.ListAccountsRequest_NAME}, want: []int{1, 3, 2, 0, 4}},
+		{name: "name descending", filter: repositories.AccountFilter{SortBy: api.ListAccountsRequest_NAME, Descending: true}, want: []int{4, 0, 2, 3, 1}},
+		{name: "saldo", filter: repositories.AccountFilter{SortBy: api.ListAccountsRequest_SALDO}, want: []int{3, 0, 2, 1, 4}},
+		{name: "saldo descending", filter: repositories.AccountFilter{SortBy: api.ListAccountsRequest_SALDO, Descending: true}, want: []int{4, 1, 2, 0, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var want []int32
+			for _, i := range tt.want {
+				want = append(want, ids[i])
+			}
+
+			all, _, err := accounts.GetAll(ctx, tt.filter, 0, 0)
+			is.NoErr(err)                   // could not list accounts
+			is.Equal(accountIds(all), want) // GetAll is not sorted
+
+			var paged []*api.Account
+			var after *repositories.Cursor
+			for pages := 0; ; pages++ {
+				is.True(pages <= len(want)) // paging does not end
+
+				page, err := accounts.GetPage(ctx, tt.filter, after, 2)
+				is.NoErr(err) // could not get page
+				if len(page) == 0 {
+					break
+				}
+				paged = append(paged, page...)
+
+				last := page[len(page)-1]
+				after = &repositories.Cursor{Name: last.Name, Saldo: last.Saldo, Id: last.Id}
+			}
+			is.Equal(accountIds(paged), want) // pages are not sorted or contain accounts twice
+		})
+	}
+}
+
+// accountNames returns the names of accounts
+func accountNames(accounts []*api.Account) []string {
+	var names []string
+	for _, account := range accounts {
+		names = append(names, account.Name)
+	}
+	return names
+}
+
+// accountIds returns the ids of accounts
+func accountIds(accounts []*api.Account) []int32 {
+	var ids []int32
+	for _, account := range accounts {
+		ids = append(ids, account.Id)
+	}
+	return ids
+}
+
+func testAccountGetAllByIds(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	first, err := accounts.Create(ctx, "first", "", 1, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	_, err = accounts.Create(ctx, "second", "", 2, group.Id, "04d5e6f7")
+	is.NoErr(err) // could not create account
+	third, err := accounts.Create(ctx, "third", "", 3, group.Id, "04f8f9fa")
+	is.NoErr(err) // could not create account
+
+	got, err := accounts.GetAllByIds(ctx, []int32{first.Id, third.Id, third.Id + 100})
+	is.NoErr(err)         // could not get accounts by ids
+	is.Equal(len(got), 2) // unknown ids are left out
+	is.Equal(got[first.Id].Name, "first")
+	is.Equal(got[first.Id].Group, group)
+	is.Equal(got[third.Id].Saldo, 3.0)
+
+	got, err = accounts.GetAllByIds(ctx, nil)
+	is.NoErr(err)         // empty ids are no error
+	is.Equal(len(got), 0) // no accounts for empty ids
+}
+
+func testAccountImportDuplicateNfcChipId(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	_, err = accounts.Create(ctx, "guest", "", 0, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+
+	_, err = accounts.Import(ctx, []*api.CreateAccountRequest{
+		{Name: "new", GroupId: group.Id, NfcChipId: "04d5e6f7"},
+		{Name: "twice in import", GroupId: group.Id, NfcChipId: "04d5e6f7"},
+		{Name: "already saved", GroupId: group.Id, NfcChipId: "04a1b2c3"},
+	}, false)
+
+	var importErrs repositories.ImportErrors
+	is.True(errors.As(err, &importErrs)) // rows are not rejected with ImportErrors
+	is.Equal(len(importErrs), 2)
+	is.Equal(importErrs[0].Row, 2)
+	is.Equal(importErrs[1].Row, 3)
+	for _, importErr := range importErrs {
+		is.Equal(importErr.Err, repositories.ErrDuplicateNfcChipId)
+	}
+
+	_, err = accounts.ReadByNfcChipId(ctx, "04d5e6f7")
+	is.Equal(err, repositories.ErrNotFound) // import with rejected rows saved accounts
+}
+
+func testAccountIssueChipCounterNotFound(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+
+	_, _, err := accounts.IssueChipCounter(context.Background(), 100, 0)
+	is.Equal(err, repositories.ErrAccountNotFound)
+}
+
+func testGroupDeleteProtection(t *testing.T, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	_, err = accounts.Create(ctx, "guest", "", 10, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+
+	err = groups.Delete(ctx, group.Id)
+	is.Equal(err, repositories.ErrNonEmptyDelete)
+
+	_, err = groups.Read(ctx, group.Id)
+	is.NoErr(err) // group was deleted
+}
@@ -0,0 +1,162 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+// RunGroupStorager runs the conformance tests of repositories.GroupStorager
+func RunGroupStorager(t *testing.T, newGroups GroupFactory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, groups repositories.GroupStorager)
+	}{
+		{"Crud", testGroupCrud},
+		{"ReadNotFound", testGroupReadNotFound},
+		{"Paging", testGroupPaging},
+		{"GetAllByIds", testGroupGetAllByIds},
+		{"CursorPaging", testGroupCursorPaging},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, teardown := newGroups(t)
+			defer teardown()
+
+			tt.test(t, groups)
+		})
+	}
+}
+
+func testGroupCrud(t *testing.T, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	created, err := groups.Create(ctx, "staff", "crew and artists", true)
+	is.NoErr(err)           // could not create group
+	is.True(created.Id > 0) // created group has no id
+
+	got, err := groups.Read(ctx, created.Id)
+	is.NoErr(err) // could not read group
+	is.Equal(got, created)
+
+	got.Name = "crew"
+	got.CanOverdraw = false
+	_, err = groups.Update(ctx, got)
+	is.NoErr(err) // could not update group
+
+	updated, err := groups.Read(ctx, created.Id)
+	is.NoErr(err) // could not read updated group
+	is.Equal(updated, got)
+
+	_, err = groups.Update(ctx, &api.Group{Name: "not saved"})
+	is.Equal(err, repositories.ErrModelNotSaved)
+
+	is.NoErr(groups.Delete(ctx, created.Id)) // could not delete group
+	_, err = groups.Read(ctx, created.Id)
+	is.Equal(err, repositories.ErrNotFound)
+}
+
+func testGroupReadNotFound(t *testing.T, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	created, err := groups.Create(ctx, "staff", "", false)
+	is.NoErr(err) // could not create group
+
+	_, err = groups.Read(ctx, created.Id+100)
+	is.Equal(err, repositories.ErrNotFound)
+}
+
+func testGroupPaging(t *testing.T, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	names := []string{"a", "b", "c", "d", "e"}
+	for _, name := range names {
+		_, err := groups.Create(ctx, name, "", false)
+		is.NoErr(err) // could not create group
+	}
+
+	tests := []struct {
+		name          string
+		limit, offset int32
+		want          []string
+	}{
+		{name: "all", want: names},
+		{name: "offset without limit is ignored", offset: 3, want: names},
+		{name: "limit", limit: 2, want: names[:2]},
+		{name: "limit and offset", limit: 2, offset: 3, want: names[3:]},
+		{name: "last page is not full", limit: 2, offset: 4, want: names[4:]},
+		{name: "offset past the end", limit: 2, offset: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			page, total, err := groups.GetAll(ctx, tt.limit, tt.offset)
+			is.NoErr(err)                     // could not list groups
+			is.Equal(total, len(names))       // total count is the number of all groups
+			is.Equal(len(page), len(tt.want)) // unexpected page size
+			for i, group := range page {
+				is.Equal(group.Name, tt.want[i]) // groups are not ordered by id
+			}
+		})
+	}
+}
+
+func testGroupGetAllByIds(t *testing.T, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	var created []*api.Group
+	for _, name := range []string{"a", "b", "c"} {
+		group, err := groups.Create(ctx, name, "", false)
+		is.NoErr(err) // could not create group
+		created = append(created, group)
+	}
+
+	got, err := groups.GetAllByIds(ctx, []int32{created[0].Id, created[2].Id, created[2].Id + 100})
+	is.NoErr(err)         // could not get groups by ids
+	is.Equal(len(got), 2) // unknown ids are left out
+	is.Equal(got[created[0].Id], created[0])
+	is.Equal(got[created[2].Id], created[2])
+}
+
+func testGroupCursorPaging(t *testing.T, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	names := []string{"a", "b", "c", "d", "e"}
+	for _, name := range names {
+		_, err := groups.Create(ctx, name, "", false)
+		is.NoErr(err) // could not create group
+	}
+
+	var got []string
+	var after *repositories.Cursor
+	for pages := 0; ; pages++ {
+		is.True(pages < len(names)) // paging does not end
+
+		page, err := groups.GetPage(ctx, after, 2)
+		is.NoErr(err)           // could not get page
+		is.True(len(page) <= 2) // page is larger than size
+		if len(page) == 0 {
+			break
+		}
+		for _, group := range page {
+			got = append(got, group.Name)
+		}
+		after = &repositories.Cursor{Id: page[len(page)-1].Id}
+	}
+	is.Equal(got, names) // pages do not contain every group once, ordered by id
+
+	count, err := groups.Count(ctx)
+	is.NoErr(err) // could not count groups
+	is.Equal(count, len(names))
+}
@@ -0,0 +1,183 @@
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+// LedgerTransactions creates transactions and verifies the saldo chain they form
+type LedgerTransactions interface {
+	repositories.TransactionStorager
+	repositories.LedgerVerifier
+}
+
+// LedgerStorage are the storagers of a backend that change and check the saldo of accounts, all use the same database
+type LedgerStorage struct {
+	Transactions LedgerTransactions
+	Accounts     repositories.AccountStorager
+	Groups       repositories.GroupStorager
+	Terminals    repositories.TerminalStorager
+	Reports      repositories.ReportStorager
+}
+
+// LedgerFactory returns the LedgerStorage of the backend on an empty database, teardown is called after every test
+type LedgerFactory func(t *testing.T) (storage *LedgerStorage, teardown func())
+
+// RunLedger runs the conformance tests of the saldo chain that online and offline transactions write together
+func RunLedger(t *testing.T, newStorage LedgerFactory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, storage *LedgerStorage)
+	}{
+		{"ConcurrentCreateAndApplyOffline", testLedgerConcurrentCreateAndApplyOffline},
+		{"FixKeepsReports", testLedgerFixKeepsReports},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, teardown := newStorage(t)
+			defer teardown()
+
+			tt.test(t, storage)
+		})
+	}
+}
+
+// testLedgerConcurrentCreateAndApplyOffline charges one account online and offline at the same time,
+// no charge may get lost and the saldo chain must stay unbroken
+func testLedgerConcurrentCreateAndApplyOffline(t *testing.T, storage *LedgerStorage) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	const (
+		creators          = 10
+		createsPerCreator = 10
+		offlineCharges    = 20
+	)
+
+	group, err := storage.Groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := storage.Accounts.Create(ctx, "guest", "", 1000, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	_, err = storage.Terminals.Register(ctx, "bar-1", "bar", []byte("key"))
+	is.NoErr(err) // could not register terminal
+
+	charges := make([]*api.OfflineTransaction, offlineCharges)
+	for i := range charges {
+		charges[i] = &api.OfflineTransaction{Sequence: uint64(i + 1), AccountId: account.Id, Amount: 1, Created: ptypes.TimestampNow()}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, creators+1)
+	for i := 0; i < creators; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < createsPerCreator; j++ {
+				if _, err := storage.Transactions.Create(ctx, 1, account.Id, ""); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// sync the charges one by one, so online charges can come in between them
+		for _, charge := range charges {
+			res, err := storage.Transactions.ApplyOffline(ctx, "bar-1", []*api.OfflineTransaction{charge})
+			if err == nil && len(res.Applied) != 1 {
+				err = fmt.Errorf("offline charge %d was not applied: %v", charge.Sequence, res.Conflicts)
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		is.NoErr(err) // could not charge account
+	}
+
+	got, err := storage.Accounts.Read(ctx, account.Id)
+	is.NoErr(err) // could not read account
+	is.Equal(got.Saldo, float64(1000-creators*createsPerCreator-offlineCharges))
+
+	report, err := storage.Transactions.VerifyLedger(ctx, account.Id, false)
+	is.NoErr(err) // could not verify ledger
+	is.Equal(report.CheckedTransactions, int32(creators*createsPerCreator+offlineCharges))
+	is.Equal(len(report.Discrepancies), 0) // saldo chain is broken
+}
+
+// testLedgerFixKeepsReports repairs a saldo with VerifyLedger, the ADJUSTMENT transaction must not show up in the reports
+func testLedgerFixKeepsReports(t *testing.T, storage *LedgerStorage) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	before := time.Now().Add(-time.Minute)
+
+	group, err := storage.Groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := storage.Accounts.Create(ctx, "guest", "", 50, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+
+	_, err = storage.Transactions.Create(ctx, 10, account.Id, "")
+	is.NoErr(err) // could not create payment
+	_, err = storage.Transactions.Create(ctx, -20, account.Id, "")
+	is.NoErr(err) // could not create top up
+
+	// lower the saldo without a transaction, the fix adjusts the ledger with a positive amount like a purchase
+	account, err = storage.Accounts.Read(ctx, account.Id)
+	is.NoErr(err) // could not read account
+	is.NoErr(storage.Accounts.UpdateSaldo(ctx, account, 25))
+
+	want := ledgerReports(t, storage.Reports, before)
+
+	report, err := storage.Transactions.VerifyLedger(ctx, account.Id, true)
+	is.NoErr(err) // could not fix ledger
+	is.Equal(len(report.Discrepancies), 1)
+	is.True(report.Discrepancies[0].AdjustmentId != 0) // saldo was not adjusted
+	is.True(!report.Discrepancies[0].Resolved)         // adjusted mismatch is not resolved on its own
+
+	got := ledgerReports(t, storage.Reports, before)
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("report changed by fix, got %v, expected %v", got[i], want[i])
+		}
+	}
+}
+
+// ledgerReports returns every report over the time since from
+func ledgerReports(t *testing.T, reports repositories.ReportStorager, from time.Time) []proto.Message {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	var messages []proto.Message
+	for _, groupBy := range []api.RevenueRequest_Grouping{api.RevenueRequest_DAY, api.RevenueRequest_GROUP, api.RevenueRequest_TERMINAL} {
+		buckets, err := reports.Revenue(ctx, from, time.Time{}, groupBy)
+		is.NoErr(err) // could not read revenue
+		messages = append(messages, &api.RevenueReport{Buckets: buckets})
+	}
+
+	cashFlow, err := reports.CashFlow(ctx, from, time.Time{})
+	is.NoErr(err) // could not read cash flow
+	averageSpend, err := reports.AverageSpend(ctx, from, time.Time{})
+	is.NoErr(err) // could not read average spend
+	balance, err := reports.OutstandingBalance(ctx, from)
+	is.NoErr(err) // could not read outstanding balance
+
+	return append(messages, cashFlow, averageSpend, balance)
+}
@@ -0,0 +1,64 @@
+// storagetest is the conformance test suite of the repositories, every storage backend has to pass it.
+// It checks the behaviour documented on the storager interfaces of the repositories package,
+// a backend runs it with a factory for each storager it implements.
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+)
+
+// GroupFactory returns the GroupStorager of the backend on an empty database, teardown is called after every test
+type GroupFactory func(t *testing.T) (groups repositories.GroupStorager, teardown func())
+
+// AccountFactory returns the AccountStorager of the backend and the GroupStorager of the same database.
+// The database is empty, teardown is called after every test
+type AccountFactory func(t *testing.T) (accounts repositories.AccountStorager, groups repositories.GroupStorager, teardown func())
+
+// TransactionFactory returns the TransactionStorager of the backend and the Account- and GroupStorager of the same database.
+// The database is empty, teardown is called after every test
+type TransactionFactory func(t *testing.T) (transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager, teardown func())
+
+// UserFactory returns the UserStorager of the backend on an empty database and create to save new users,
+// the UserStorager can only authenticate users. teardown is called after every test
+type UserFactory func(t *testing.T) (users repositories.UserStorager, create func(ctx context.Context, name, email, password string) error, teardown func())
+
+// Factories are the factories of a backend, the suites of nil factories are skipped
+type Factories struct {
+	Groups       GroupFactory
+	Accounts     AccountFactory
+	Transactions TransactionFactory
+	Users        UserFactory
+	Ledger       LedgerFactory
+}
+
+// Run runs the conformance suites of all given factories
+func Run(t *testing.T, factories Factories) {
+	if factories.Groups != nil {
+		t.Run("GroupStorager", func(t *testing.T) {
+			RunGroupStorager(t, factories.Groups)
+		})
+	}
+	if factories.Accounts != nil {
+		t.Run("AccountStorager", func(t *testing.T) {
+			RunAccountStorager(t, factories.Accounts)
+		})
+	}
+	if factories.Transactions != nil {
+		t.Run("TransactionStorager", func(t *testing.T) {
+			RunTransactionStorager(t, factories.Transactions)
+		})
+	}
+	if factories.Users != nil {
+		t.Run("UserStorager", func(t *testing.T) {
+			RunUserStorager(t, factories.Users)
+		})
+	}
+	if factories.Ledger != nil {
+		t.Run("Ledger", func(t *testing.T) {
+			RunLedger(t, factories.Ledger)
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/jheimbach/nfc-cash-system/api"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+// RunTransactionStorager runs the conformance tests of repositories.TransactionStorager
+func RunTransactionStorager(t *testing.T, newTransactions TransactionFactory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager)
+	}{
+		{"Create", testTransactionCreate},
+		{"ReadNotFound", testTransactionReadNotFound},
+		{"Paging", testTransactionPaging},
+		{"Order", testTransactionOrder},
+		{"CursorPaging", testTransactionCursorPaging},
+		{"Filter", testTransactionFilter},
+		{"AccountDeleteProtection", testAccountDeleteProtection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactions, accounts, groups, teardown := newTransactions(t)
+			defer teardown()
+
+			tt.test(t, transactions, accounts, groups)
+		})
+	}
+}
+
+func testTransactionCreate(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := accounts.Create(ctx, "guest", "", 20, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+
+	payment, err := transactions.Create(ctx, 7.5, account.Id, "bar-1")
+	is.NoErr(err) // could not create payment
+	is.Equal(payment.OldSaldo, 20.0)
+	is.Equal(payment.NewSaldo, 12.5)
+	is.Equal(payment.TerminalId, "bar-1")
+
+	topUp, err := transactions.Create(ctx, -10, account.Id, "")
+	is.NoErr(err) // could not create top up
+	is.Equal(topUp.OldSaldo, 12.5)
+	is.Equal(topUp.NewSaldo, 22.5)
+
+	got, err := accounts.Read(ctx, account.Id)
+	is.NoErr(err) // could not read account
+	is.Equal(got.Saldo, 22.5)
+
+	read, err := transactions.Read(ctx, payment.Id)
+	is.NoErr(err) // could not read transaction
+	is.Equal(read.Amount, 7.5)
+	is.Equal(read.Account.Id, account.Id)
+	is.Equal(read.TerminalId, "bar-1")
+
+	_, err = transactions.Create(ctx, 1, account.Id+100, "")
+	is.Equal(err, repositories.ErrAccountNotFound)
+
+	got.Status = api.Account_BLOCKED
+	_, err = accounts.Update(ctx, got)
+	is.NoErr(err) // could not block account
+	_, err = transactions.Create(ctx, 1, account.Id, "")
+	is.Equal(err, repositories.ErrAccountBlocked)
+}
+
+func testTransactionReadNotFound(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+
+	_, err := transactions.Read(context.Background(), 100)
+	is.Equal(err, repositories.ErrNotFound)
+}
+
+func testTransactionPaging(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	first, err := accounts.Create(ctx, "first", "", 50, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	second, err := accounts.Create(ctx, "second", "", 50, group.Id, "04d5e6f7")
+	is.NoErr(err) // could not create account
+
+	for _, accountId := range []int32{first.Id, second.Id, first.Id, first.Id} {
+		_, err := transactions.Create(ctx, 1, accountId, "")
+		is.NoErr(err) // could not create transaction
+	}
+
+	tests := []struct {
+		name          string
+		accountId     int32
+		limit, offset int32
+		wantLen       int
+		wantTotal     int
+	}{
+		{name: "all", wantLen: 4, wantTotal: 4},
+		{name: "offset without limit is ignored", offset: 2, wantLen: 4, wantTotal: 4},
+		{name: "limit and offset", limit: 3, offset: 2, wantLen: 2, wantTotal: 4},
+		{name: "offset past the end", limit: 3, offset: 10, wantTotal: 4},
+		{name: "account", accountId: first.Id, wantLen: 3, wantTotal: 3},
+		{name: "account with limit", accountId: first.Id, limit: 2, wantLen: 2, wantTotal: 3},
+		{name: "account without transactions", accountId: second.Id + 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			page, total, err := transactions.GetAll(ctx, repositories.TransactionFilter{AccountId: tt.accountId}, tt.limit, tt.offset)
+			is.NoErr(err) // could not list transactions
+			is.Equal(total, tt.wantTotal)
+			is.Equal(len(page), tt.wantLen) // unexpected page size
+			for _, transaction := range page {
+				if tt.accountId > 0 {
+					is.Equal(transaction.Account.Id, tt.accountId) // transaction of other account
+				}
+			}
+		})
+	}
+}
+
+func testTransactionOrder(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := accounts.Create(ctx, "guest", "", 50, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+
+	for i := 0; i < 4; i++ {
+		_, err := transactions.Create(ctx, 1, account.Id, "")
+		is.NoErr(err) // could not create transaction
+	}
+
+	tests := []struct {
+		order     string
+		ascending bool
+	}{
+		{order: "asc", ascending: true},
+		{order: "ASC", ascending: true},
+		{order: "desc"},
+		{order: "DESC"},
+		{order: ""},
+		{order: "created; DROP TABLE transactions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			is := is.New(t)
+
+			got, _, err := transactions.GetAll(ctx, repositories.TransactionFilter{AccountId: account.Id, Order: tt.order}, 0, 0)
+			is.NoErr(err) // could not list transactions
+			is.Equal(len(got), 4)
+			is.True(createdInOrder(t, got, tt.ascending)) // transactions are not in expected order
+		})
+	}
+}
+
+func testTransactionCursorPaging(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	first, err := accounts.Create(ctx, "first", "", 50, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	second, err := accounts.Create(ctx, "second", "", 50, group.Id, "04d5e6f7")
+	is.NoErr(err) // could not create account
+
+	// transactions created in the same second share their created time in some backends, the id orders them
+	var ids []int32
+	for _, accountId := range []int32{first.Id, second.Id, first.Id, first.Id, second.Id} {
+		transaction, err := transactions.Create(ctx, 1, accountId, "")
+		is.NoErr(err) // could not create transaction
+		ids = append(ids, transaction.Id)
+	}
+
+	tests := []struct {
+		name      string
+		accountId int32
+		order     string
+		wantLen   int
+	}{
+		{name: "all ascending", order: "asc", wantLen: 5},
+		{name: "all descending", order: "desc", wantLen: 5},
+		{name: "account ascending", accountId: first.Id, order: "asc", wantLen: 3},
+		{name: "account descending", accountId: first.Id, wantLen: 3},
+		{name: "account without transactions", accountId: second.Id + 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+
+			filter := repositories.TransactionFilter{AccountId: tt.accountId, Order: tt.order}
+			var got []*api.Transaction
+			seen := make(map[int32]bool)
+			var after *repositories.Cursor
+			for pages := 0; ; pages++ {
+				is.True(pages <= len(ids)) // paging does not end
+
+				page, err := transactions.GetPage(ctx, filter, after, 2)
+				is.NoErr(err)           // could not get page
+				is.True(len(page) <= 2) // page is larger than size
+				if len(page) == 0 {
+					break
+				}
+				for _, transaction := range page {
+					is.True(!seen[transaction.Id]) // transaction is on more than one page
+					seen[transaction.Id] = true
+					if tt.accountId > 0 {
+						is.Equal(transaction.Account.Id, tt.accountId) // transaction of other account
+					}
+				}
+				got = append(got, page...)
+
+				last := page[len(page)-1]
+				created, err := ptypes.Timestamp(last.Created)
+				is.NoErr(err) // invalid created
+				after = &repositories.Cursor{Created: created, Id: last.Id}
+			}
+			is.Equal(len(got), tt.wantLen)                     // pages do not contain every transaction
+			is.True(createdInOrder(t, got, tt.order == "asc")) // transactions are not in expected order
+
+			count, err := transactions.Count(ctx, filter)
+			is.NoErr(err) // could not count transactions
+			is.Equal(count, tt.wantLen)
+		})
+	}
+}
+
+func testTransactionFilter(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	guests, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	staff, err := groups.Create(ctx, "staff", "", true)
+	is.NoErr(err) // could not create group
+	first, err := accounts.Create(ctx, "first", "", 50, guests.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	second, err := accounts.Create(ctx, "second", "", 50, staff.Id, "04d5e6f7")
+	is.NoErr(err) // could not create account
+
+	rows := []struct {
+		amount     float64
+		accountId  int32
+		terminalId string
+	}{
+		{7.5, first.Id, "bar-1"},
+		{-10, first.Id, ""},
+		{2, second.Id, "bar-1"},
+		{20, second.Id, "kiosk-1"},
+	}
+	for _, row := range rows {
+		_, err := transactions.Create(ctx, row.amount, row.accountId, row.terminalId)
+		is.NoErr(err) // could not create transaction
+	}
+
+	amount := func(v float64) *float64 {
+		return &v
+	}
+	// created is set by the storage, a day around now covers every clock and time zone of the backends
+	past, future := time.Now().Add(-24*time.Hour), time.Now().Add(24*time.Hour)
+
+	tests := []struct {
+		name   string
+		filter repositories.TransactionFilter
+		want   []float64
+	}{
+		{name: "no filter", want: []float64{7.5, -10, 2, 20}},
+		{name: "terminal", filter: repositories.TransactionFilter{TerminalId: "bar-1"}, want: []float64{7.5, 2}},
+		{name: "terminal without transactions", filter: repositories.TransactionFilter{TerminalId: "bar-2"}},
+		{name: "group", filter: repositories.TransactionFilter{GroupId: staff.Id}, want: []float64{2, 20}},
+		{name: "group without accounts", filter: repositories.TransactionFilter{GroupId: staff.Id + 100}},
+		{name: "min amount is inclusive", filter: repositories.TransactionFilter{MinAmount: amount(7.5)}, want: []float64{7.5, 20}},
+		{name: "max amount is inclusive", filter: repositories.TransactionFilter{MaxAmount: amount(2)}, want: []float64{-10, 2}},
+		{name: "amount range", filter: repositories.TransactionFilter{MinAmount: amount(0), MaxAmount: amount(10)}, want: []float64{7.5, 2}},
+		{name: "created from", filter: repositories.TransactionFilter{From: past}, want: []float64{7.5, -10, 2, 20}},
+		{name: "created from in the future", filter: repositories.TransactionFilter{From: future}},
+		{name: "created to", filter: repositories.TransactionFilter{To: future}, want: []float64{7.5, -10, 2, 20}},
+		{name: "created to in the past", filter: repositories.TransactionFilter{To: past}},
+		{name: "created range", filter: repositories.TransactionFilter{From: past, To: future}, want: []float64{7.5, -10, 2, 20}},
+		{name: "account and terminal", filter: repositories.TransactionFilter{AccountId: first.Id, TerminalId: "bar-1"}, want: []float64{7.5}},
+		{name: "group and amount", filter: repositories.TransactionFilter{GroupId: guests.Id, MaxAmount: amount(0)}, want: []float64{-10}},
+		{name: "all combined", filter: repositories.TransactionFilter{AccountId: second.Id, GroupId: staff.Id, TerminalId: "kiosk-1", From: past, To: future, MinAmount: amount(5), MaxAmount: amount(50)}, want: []float64{20}},
+		{name: "account of other group", filter: repositories.TransactionFilter{AccountId: first.Id, GroupId: staff.Id}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			tt.filter.Order = "asc"
+
+			all, total, err := transactions.GetAll(ctx, tt.filter, 0, 0)
+			is.NoErr(err) // could not list transactions
+			is.Equal(total, len(tt.want))
+			is.Equal(transactionAmounts(all), tt.want)
+
+			page, err := transactions.GetPage(ctx, tt.filter, nil, 10)
+			is.NoErr(err) // could not get page
+			is.Equal(transactionAmounts(page), tt.want)
+
+			count, err := transactions.Count(ctx, tt.filter)
+			is.NoErr(err) // could not count transactions
+			is.Equal(count, len(tt.want))
+		})
+	}
+}
+
+// transactionAmounts returns the amounts of transactions, nil if there are none
+func transactionAmounts(transactions []*api.Transaction) []float64 {
+	var amounts []float64
+	for _, transaction := range transactions {
+		amounts = append(amounts, transaction.Amount)
+	}
+	return amounts
+}
+
+// createdInOrder returns true if the transactions are sorted by created, ascending or descending
+func createdInOrder(t *testing.T, transactions []*api.Transaction, ascending bool) bool {
+	for i := 1; i < len(transactions); i++ {
+		prev, err := ptypes.Timestamp(transactions[i-1].Created)
+		if err != nil {
+			t.Fatalf("invalid created: %v", err)
+		}
+		cur, err := ptypes.Timestamp(transactions[i].Created)
+		if err != nil {
+			t.Fatalf("invalid created: %v", err)
+		}
+		if (ascending && cur.Before(prev)) || (!ascending && cur.After(prev)) {
+			return false
+		}
+	}
+	return true
+}
+
+func testAccountDeleteProtection(t *testing.T, transactions repositories.TransactionStorager, accounts repositories.AccountStorager, groups repositories.GroupStorager) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	group, err := groups.Create(ctx, "guests", "", false)
+	is.NoErr(err) // could not create group
+	account, err := accounts.Create(ctx, "guest", "", 10, group.Id, "04a1b2c3")
+	is.NoErr(err) // could not create account
+	_, err = transactions.Create(ctx, 1, account.Id, "")
+	is.NoErr(err) // could not create transaction
+
+	err = accounts.Delete(ctx, account.Id)
+	is.Equal(err, repositories.ErrNonEmptyDelete)
+
+	is.NoErr(transactions.DeleteAllByAccount(ctx, account.Id)) // could not delete transactions
+	is.NoErr(accounts.Delete(ctx, account.Id))                 // could not delete account without transactions
+
+	_, err = accounts.Read(ctx, account.Id)
+	is.Equal(err, repositories.ErrNotFound)
+}
@@ -0,0 +1,40 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	isPkg "github.com/matryer/is"
+)
+
+// RunUserStorager runs the conformance tests of repositories.UserStorager
+func RunUserStorager(t *testing.T, newUsers UserFactory) {
+	t.Run("Authenticate", func(t *testing.T) {
+		users, create, teardown := newUsers(t)
+		defer teardown()
+
+		testUserAuthenticate(t, users, create)
+	})
+}
+
+func testUserAuthenticate(t *testing.T, users repositories.UserStorager, create func(ctx context.Context, name, email, password string) error) {
+	is := isPkg.New(t)
+	ctx := context.Background()
+
+	is.NoErr(create(ctx, "admin", "admin@example.org", "secret")) // could not create user
+
+	err := create(ctx, "admin", "admin@example.org", "other")
+	is.Equal(err, repositories.ErrDuplicateEmail)
+
+	user, err := users.Authenticate(ctx, "admin@example.org", "secret")
+	is.NoErr(err) // could not authenticate
+	is.Equal(user.Email, "admin@example.org")
+	is.Equal(user.Name, "admin")
+
+	_, err = users.Authenticate(ctx, "admin@example.org", "wrong")
+	is.Equal(err, repositories.ErrInvalidCredentials)
+
+	_, err = users.Authenticate(ctx, "nobody@example.org", "secret")
+	is.Equal(err, repositories.ErrInvalidCredentials)
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/jheimbach/nfc-cash-system/pkg/server/internals/database"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/cache"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/memory"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/mysql"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/postgres"
+	"github.com/jheimbach/nfc-cash-system/pkg/server/repositories/sqlite"
+)
+
+// TransactionStorage stores the transactions and verifies their ledger
+type TransactionStorage interface {
+	repositories.TransactionStorager
+	repositories.LedgerVerifier
+}
+
+// Storage holds the repositories the grpc services work with
+type Storage struct {
+	Users        repositories.UserStorager
+	Groups       repositories.GroupStorager
+	Accounts     repositories.AccountStorager
+	Transactions TransactionStorage
+	Terminals    repositories.TerminalStorager
+	Reports      repositories.ReportStorager
+
+	// CacheStats are the hits and misses of the caches by name, nil if the storage is not cached
+	CacheStats map[string]*cache.Stats
+}
+
+// NewSQLStorage returns the repositories for db, driver is one of the drivers supported by database.OpenDatabase.
+// Every created transaction is sent to publisher, publisher can be nil
+func NewSQLStorage(driver string, db *sql.DB, publisher repositories.TransactionPublisher) (*Storage, error) {
+	switch driver {
+	case database.MySQL:
+		groups := mysql.NewGroupRepository(db)
+		accounts := mysql.NewAccountRepository(db, groups)
+		return &Storage{
+			Users:        mysql.NewUserModel(db),
+			Groups:       groups,
+			Accounts:     accounts,
+			Transactions: mysql.NewTransactionRepository(db, accounts, publisher),
+			Terminals:    mysql.NewTerminalRepository(db),
+			Reports:      mysql.NewReportRepository(db),
+		}, nil
+	case database.Postgres:
+		groups := postgres.NewGroupRepository(db)
+		accounts := postgres.NewAccountRepository(db, groups)
+		return &Storage{
+			Users:        postgres.NewUserModel(db),
+			Groups:       groups,
+			Accounts:     accounts,
+			Transactions: postgres.NewTransactionRepository(db, accounts, publisher),
+			Terminals:    postgres.NewTerminalRepository(db),
+			Reports:      postgres.NewReportRepository(db),
+		}, nil
+	case database.SQLite:
+		groups := sqlite.NewGroupRepository(db)
+		accounts := sqlite.NewAccountRepository(db, groups)
+		return &Storage{
+			Users:        sqlite.NewUserModel(db),
+			Groups:       groups,
+			Accounts:     accounts,
+			Transactions: sqlite.NewTransactionRepository(db, accounts, publisher),
+			Terminals:    sqlite.NewTerminalRepository(db),
+			Reports:      sqlite.NewReportRepository(db),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", driver)
+	}
+}
+
+// NewMemoryStorage returns repositories that keep all data in memory, nothing is persisted.
+// Every created transaction is sent to publisher, publisher can be nil
+func NewMemoryStorage(publisher repositories.TransactionPublisher) *Storage {
+	db := memory.NewDatabase()
+	return &Storage{
+		Users:        memory.NewUserModel(db),
+		Groups:       memory.NewGroupRepository(db),
+		Accounts:     memory.NewAccountRepository(db),
+		Transactions: memory.NewTransactionRepository(db, publisher),
+		Terminals:    memory.NewTerminalRepository(db),
+		Reports:      memory.NewReportRepository(db),
+	}
+}
+
+// Cached returns the storage with read-through caches for the groups and accounts of s, config limits every cache.
+// The transactions of s keep reading their accounts without cache, so transactions are always created with the current saldo
+func (s *Storage) Cached(config cache.Config) *Storage {
+	groups := cache.NewGroupRepository(s.Groups, config)
+	accounts := cache.NewAccountRepository(s.Accounts, groups, config)
+
+	cached := *s
+	cached.Groups = groups
+	cached.Accounts = accounts
+	cached.Transactions = cache.NewTransactionRepository(s.Transactions, accounts)
+	cached.CacheStats = map[string]*cache.Stats{
+		"groups":   groups.Stats(),
+		"accounts": accounts.Stats(),
+	}
+	return &cached
+}
@@ -0,0 +1,21 @@
+import axios, { AxiosRequestConfig } from 'axios'
+
+// the server returns at most 500 entries per request, so lists that are searched in the browser
+// are loaded page by page until next_page_token is empty
+export async function getAllPages<T>(url: string, listKey: string, config: AxiosRequestConfig): Promise<T[]> {
+  const items: T[] = []
+  let pageToken = ''
+
+  do {
+    const params: any = { ...config.params, 'paging.skip_total_count': true }
+    if (pageToken) {
+      params['paging.page_token'] = pageToken
+    }
+
+    const response = await axios.get(url, { ...config, params })
+    items.push(...(response.data[listKey] || []))
+    pageToken = response.data.next_page_token || ''
+  } while (pageToken)
+
+  return items
+}
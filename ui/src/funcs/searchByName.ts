@@ -1,5 +1,6 @@
 import { Named } from '@/data/globals'
 
+// searchByName only searches the given items, lists from the server have to be loaded completely with getAllPages
 export function searchByName(items: Named[], term: string) {
   if (term) {
     return items.filter((item: Named) => {